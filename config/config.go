@@ -62,10 +62,11 @@ type Payment struct {
 }
 
 type Whatsapp struct {
-	WhatsappToken     string `yaml:"whatsapptoken"`
-	MssgOtpTemplateId string `yaml:"mssgotptemplateid"`
-	ChannelId         string `yaml:"channelid"`
-	WhatsappUrl       string `yaml:"whatsappurl"`
+	WhatsappToken         string `yaml:"whatsapptoken"`
+	MssgOtpTemplateId     string `yaml:"mssgotptemplateid"`
+	MssgRestockTemplateId string `yaml:"mssgrestocktemplateid"`
+	ChannelId             string `yaml:"channelid"`
+	WhatsappUrl           string `yaml:"whatsappurl"`
 }
 
 type Email struct {
@@ -88,6 +89,12 @@ type Sms struct {
 	PassKey string `yaml:"passkey"`
 }
 
+type Media struct {
+	StoragePath   string `yaml:"storagepath"`
+	BaseUrl       string `yaml:"baseurl"`
+	MaxUploadSize int64  `yaml:"maxuploadsize"`
+}
+
 type ApplicationConfiguration struct {
 	Application Application
 	Webserver   Webserver
@@ -101,6 +108,7 @@ type ApplicationConfiguration struct {
 	Whatsapp    Whatsapp
 	Fcm         Fcm
 	Sms         Sms
+	Media       Media
 }
 
 var lock = sync.Mutex{}
@@ -122,6 +130,10 @@ func initConfig() *ApplicationConfiguration {
 	viper.SetConfigName("config-prod")
 	viper.AddConfigPath("./")
 
+	// Media disimpan di direktori uploads dan disajikan pada /media jika tidak diatur
+	viper.SetDefault("media.storagepath", "uploads")
+	viper.SetDefault("media.baseurl", "/media")
+
 	if err := viper.ReadInConfig(); err != nil {
 		panic(err)
 	}
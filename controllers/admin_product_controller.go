@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type AdminProductControllerInterface interface {
+	FindAdminProducts(c echo.Context) error
+	FindAdminProductById(c echo.Context) error
+	CreateProduct(c echo.Context) error
+	UpdateProduct(c echo.Context) error
+	PublishProduct(c echo.Context) error
+	UnpublishProduct(c echo.Context) error
+	ArchiveProduct(c echo.Context) error
+	RestoreProduct(c echo.Context) error
+	ExportProducts(c echo.Context) error
+	ImportProducts(c echo.Context) error
+}
+
+type AdminProductControllerImplementation struct {
+	ConfigWebserver              config.Webserver
+	Logger                       *logrus.Logger
+	AdminProductServiceInterface services.AdminProductServiceInterface
+}
+
+func NewAdminProductController(configWebserver config.Webserver, logger *logrus.Logger, adminProductServiceInterface services.AdminProductServiceInterface) AdminProductControllerInterface {
+	return &AdminProductControllerImplementation{
+		ConfigWebserver:              configWebserver,
+		Logger:                       logger,
+		AdminProductServiceInterface: adminProductServiceInterface,
+	}
+}
+
+func (controller *AdminProductControllerImplementation) FindAdminProducts(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductListRequestQuery(c, requestId, controller.Logger)
+	adminProductListResponse := controller.AdminProductServiceInterface.FindAdminProducts(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: adminProductListResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) FindAdminProductById(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductIdRequest(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.FindAdminProductById(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) CreateProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromAdminProductRequestBody(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.CreateProduct(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "product created", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) UpdateProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromAdminProductRequestBody(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.UpdateProduct(requestId, idUser, request)
+	responses := response.Response{Code: 200, Mssg: "product updated", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) PublishProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductIdRequest(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.PublishProduct(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product published", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) UnpublishProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductIdRequest(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.UnpublishProduct(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product unpublished", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) ArchiveProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductIdRequest(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.ArchiveProduct(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product archived", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) RestoreProduct(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductIdRequest(c, requestId, controller.Logger)
+	adminProductResponse := controller.AdminProductServiceInterface.RestoreProduct(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product restored", Data: adminProductResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *AdminProductControllerImplementation) ExportProducts(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromAdminProductExportRequestQuery(c, requestId, controller.Logger)
+	products := controller.AdminProductServiceInterface.ExportProducts(requestId, request)
+	if request.Format == "xlsx" {
+		c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=products.xlsx")
+		return c.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", products)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=products.csv")
+	return c.Blob(http.StatusOK, "text/csv", products)
+}
+
+// Import yang ditolak karena ada baris salah tetap mengembalikan laporan per baris
+func (controller *AdminProductControllerImplementation) ImportProducts(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromAdminProductImportRequestBody(c, requestId, controller.Logger)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		exceptions.PanicIfBadRequest(err, requestId, []string{"file is required"}, controller.Logger)
+	}
+	productImportResponse := controller.AdminProductServiceInterface.ImportProducts(requestId, idUser, request, fileHeader)
+	if len(productImportResponse.Errors) > 0 && !productImportResponse.DryRun {
+		responses := response.Response{Code: 400, Mssg: "import rejected, fix the rows in errors", Data: productImportResponse, Error: []string{}}
+		return c.JSON(http.StatusBadRequest, responses)
+	}
+	mssg := "products imported"
+	if productImportResponse.DryRun {
+		mssg = "dry run finished, no data saved"
+	}
+	responses := response.Response{Code: 200, Mssg: mssg, Data: productImportResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -16,6 +16,7 @@ type BalancePointControllerInterface interface {
 	FindBalancePointByIdUser(c echo.Context) error
 	BalancePointCheckAmount(c echo.Context) error
 	BalancePointCheckOrderTx(c echo.Context) error
+	BalancePointCheckRedemption(c echo.Context) error
 }
 
 type BalancePointControllerImplementation struct {
@@ -59,3 +60,13 @@ func (controller *BalancePointControllerImplementation) BalancePointCheckOrderTx
 	response := response.Response{Code: 200, Mssg: "success", Data: balancePointCheckResponse, Error: []string{}}
 	return c.JSON(http.StatusOK, response)
 }
+
+func (controller *BalancePointControllerImplementation) BalancePointCheckRedemption(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	amount, _ := strconv.ParseFloat(c.QueryParam("amount"), 64)
+	totalBill, _ := strconv.ParseFloat(c.QueryParam("total_bill"), 64)
+	pointRedemptionResponse := controller.BalancePointServiceInterface.BalancePointCheckRedemption(requestId, idUser, amount, totalBill)
+	response := response.Response{Code: 200, Mssg: "success", Data: pointRedemptionResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
@@ -7,12 +7,16 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
 )
 
 type BalancePointTxControllerInterface interface {
 	FindBalancePointTxByIdBalancePoint(c echo.Context) error
+	FindBalancePointStatement(c echo.Context) error
+	ExportBalancePointStatementCsv(c echo.Context) error
+	ExportBalancePointStatementPdf(c echo.Context) error
 }
 
 type BalancePointTxControllerImplementation struct {
@@ -39,3 +43,30 @@ func (controller *BalancePointTxControllerImplementation) FindBalancePointTxById
 	response := response.Response{Code: 200, Mssg: "success", Data: balancePointWithTxResponse, Error: []string{}}
 	return c.JSON(http.StatusOK, response)
 }
+
+func (controller *BalancePointTxControllerImplementation) FindBalancePointStatement(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromBalancePointStatementRequestQuery(c, requestId, controller.Logger)
+	balancePointStatementResponse := controller.BalancePointTxServiceInterface.FindBalancePointStatement(requestId, idUser, request)
+	response := response.Response{Code: 200, Mssg: "success", Data: balancePointStatementResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *BalancePointTxControllerImplementation) ExportBalancePointStatementCsv(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromBalancePointStatementRequestQuery(c, requestId, controller.Logger)
+	statement := controller.BalancePointTxServiceInterface.ExportBalancePointStatementCsv(requestId, idUser, request)
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=statement-point.csv")
+	return c.Blob(http.StatusOK, "text/csv", statement)
+}
+
+func (controller *BalancePointTxControllerImplementation) ExportBalancePointStatementPdf(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromBalancePointStatementRequestQuery(c, requestId, controller.Logger)
+	statement := controller.BalancePointTxServiceInterface.ExportBalancePointStatementPdf(requestId, idUser, request)
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=statement-point.pdf")
+	return c.Blob(http.StatusOK, "application/pdf", statement)
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type FamilyControllerInterface interface {
+	FindFamily(c echo.Context) error
+	InviteFamilyMember(c echo.Context) error
+	FindFamilyInvitation(c echo.Context) error
+	AcceptFamilyInvitation(c echo.Context) error
+	RejectFamilyInvitation(c echo.Context) error
+	UpdateFamilySharedWallet(c echo.Context) error
+	TransferFamilyPoint(c echo.Context) error
+}
+
+type FamilyControllerImplementation struct {
+	ConfigurationWebserver config.Webserver
+	Logger                 *logrus.Logger
+	FamilyServiceInterface services.FamilyServiceInterface
+}
+
+func NewFamilyController(configurationWebserver config.Webserver,
+	logger *logrus.Logger,
+	familyServiceInterface services.FamilyServiceInterface) FamilyControllerInterface {
+	return &FamilyControllerImplementation{
+		ConfigurationWebserver: configurationWebserver,
+		Logger:                 logger,
+		FamilyServiceInterface: familyServiceInterface,
+	}
+}
+
+func (controller *FamilyControllerImplementation) FindFamily(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	familyResponse := controller.FamilyServiceInterface.FindFamily(requestId, idUser)
+	response := response.Response{Code: 200, Mssg: "success", Data: familyResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) InviteFamilyMember(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromCreateFamilyInvitationRequestBody(c, requestId, controller.Logger)
+	controller.FamilyServiceInterface.InviteFamilyMember(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "invitation sent", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) FindFamilyInvitation(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	familyInvitationResponses := controller.FamilyServiceInterface.FindFamilyInvitation(requestId, idUser)
+	response := response.Response{Code: 200, Mssg: "success", Data: familyInvitationResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) AcceptFamilyInvitation(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromRespondFamilyInvitationRequestBody(c, requestId, controller.Logger)
+	controller.FamilyServiceInterface.AcceptFamilyInvitation(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "invitation accepted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) RejectFamilyInvitation(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromRespondFamilyInvitationRequestBody(c, requestId, controller.Logger)
+	controller.FamilyServiceInterface.RejectFamilyInvitation(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "invitation rejected", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) UpdateFamilySharedWallet(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromUpdateFamilySharedWalletRequestBody(c, requestId, controller.Logger)
+	controller.FamilyServiceInterface.UpdateFamilySharedWallet(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "shared wallet updated", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *FamilyControllerImplementation) TransferFamilyPoint(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromFamilyPointTransferRequestBody(c, requestId, controller.Logger)
+	familyPointTransferResponse := controller.FamilyServiceInterface.TransferFamilyPoint(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "point transferred", Data: familyPointTransferResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type FlashSaleControllerInterface interface {
+	FindFlashSales(c echo.Context) error
+	FindAdminFlashSales(c echo.Context) error
+	FindFlashSaleById(c echo.Context) error
+	CreateFlashSale(c echo.Context) error
+	DeactivateFlashSale(c echo.Context) error
+}
+
+type FlashSaleControllerImplementation struct {
+	ConfigWebserver           config.Webserver
+	Logger                    *logrus.Logger
+	FlashSaleServiceInterface services.FlashSaleServiceInterface
+}
+
+func NewFlashSaleController(configWebserver config.Webserver, logger *logrus.Logger, flashSaleServiceInterface services.FlashSaleServiceInterface) FlashSaleControllerInterface {
+	return &FlashSaleControllerImplementation{
+		ConfigWebserver:           configWebserver,
+		Logger:                    logger,
+		FlashSaleServiceInterface: flashSaleServiceInterface,
+	}
+}
+
+func (controller *FlashSaleControllerImplementation) FindFlashSales(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	flashSaleListResponse := controller.FlashSaleServiceInterface.FindFlashSales(requestId)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: flashSaleListResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *FlashSaleControllerImplementation) FindAdminFlashSales(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromFlashSaleListRequestQuery(c, requestId, controller.Logger)
+	flashSaleListResponse := controller.FlashSaleServiceInterface.FindAdminFlashSales(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: flashSaleListResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *FlashSaleControllerImplementation) FindFlashSaleById(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromFlashSaleIdRequest(c, requestId, controller.Logger)
+	flashSaleResponse := controller.FlashSaleServiceInterface.FindFlashSaleById(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: flashSaleResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *FlashSaleControllerImplementation) CreateFlashSale(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromFlashSaleCreateRequestBody(c, requestId, controller.Logger)
+	flashSaleResponse := controller.FlashSaleServiceInterface.CreateFlashSale(requestId, request)
+	responses := response.Response{Code: 201, Mssg: "flash sale created", Data: flashSaleResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *FlashSaleControllerImplementation) DeactivateFlashSale(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromFlashSaleIdRequest(c, requestId, controller.Logger)
+	flashSaleResponse := controller.FlashSaleServiceInterface.DeactivateFlashSale(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "flash sale deactivated", Data: flashSaleResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
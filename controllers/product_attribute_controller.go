@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductAttributeControllerInterface interface {
+	FindFilterableProductAttributes(c echo.Context) error
+	FindAllProductAttributes(c echo.Context) error
+	CreateProductAttribute(c echo.Context) error
+	UpdateProductAttribute(c echo.Context) error
+	DeleteProductAttribute(c echo.Context) error
+	FindProductAttributeValues(c echo.Context) error
+	UpdateProductAttributeValues(c echo.Context) error
+}
+
+type ProductAttributeControllerImplementation struct {
+	ConfigWebserver                  config.Webserver
+	Logger                           *logrus.Logger
+	ProductAttributeServiceInterface services.ProductAttributeServiceInterface
+}
+
+func NewProductAttributeController(configWebserver config.Webserver, logger *logrus.Logger, productAttributeServiceInterface services.ProductAttributeServiceInterface) ProductAttributeControllerInterface {
+	return &ProductAttributeControllerImplementation{
+		ConfigWebserver:                  configWebserver,
+		Logger:                           logger,
+		ProductAttributeServiceInterface: productAttributeServiceInterface,
+	}
+}
+
+func (controller *ProductAttributeControllerImplementation) FindFilterableProductAttributes(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	productAttributeResponses := controller.ProductAttributeServiceInterface.FindFilterableProductAttributes(requestId)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productAttributeResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) FindAllProductAttributes(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	productAttributeResponses := controller.ProductAttributeServiceInterface.FindAllProductAttributes(requestId)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productAttributeResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) CreateProductAttribute(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductAttributeRequestBody(c, requestId, controller.Logger)
+	productAttributeResponse := controller.ProductAttributeServiceInterface.CreateProductAttribute(requestId, request)
+	responses := response.Response{Code: 201, Mssg: "attribute created", Data: productAttributeResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) UpdateProductAttribute(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductAttributeRequestBody(c, requestId, controller.Logger)
+	productAttributeResponse := controller.ProductAttributeServiceInterface.UpdateProductAttribute(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "attribute updated", Data: productAttributeResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) DeleteProductAttribute(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductAttributeIdRequestQuery(c, requestId, controller.Logger)
+	controller.ProductAttributeServiceInterface.DeleteProductAttribute(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "attribute deleted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) FindProductAttributeValues(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductAttributeValueFindRequestQuery(c, requestId, controller.Logger)
+	productAttributeValueResponses := controller.ProductAttributeServiceInterface.FindProductAttributeValues(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productAttributeValueResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductAttributeControllerImplementation) UpdateProductAttributeValues(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductAttributeValueRequestBody(c, requestId, controller.Logger)
+	productAttributeValueResponses := controller.ProductAttributeServiceInterface.UpdateProductAttributeValues(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product attributes updated", Data: productAttributeValueResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
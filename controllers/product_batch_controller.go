@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductBatchControllerInterface interface {
+	FindProductBatches(c echo.Context) error
+	FindNearExpiryProductBatches(c echo.Context) error
+	FindProductBatchRecall(c echo.Context) error
+}
+
+type ProductBatchControllerImplementation struct {
+	ConfigWebserver              config.Webserver
+	Logger                       *logrus.Logger
+	ProductBatchServiceInterface services.ProductBatchServiceInterface
+}
+
+func NewProductBatchController(configWebserver config.Webserver, logger *logrus.Logger, productBatchServiceInterface services.ProductBatchServiceInterface) ProductBatchControllerInterface {
+	return &ProductBatchControllerImplementation{
+		ConfigWebserver:              configWebserver,
+		Logger:                       logger,
+		ProductBatchServiceInterface: productBatchServiceInterface,
+	}
+}
+
+func (controller *ProductBatchControllerImplementation) FindProductBatches(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductBatchListRequestQuery(c, requestId, controller.Logger)
+	productBatchResponses := controller.ProductBatchServiceInterface.FindProductBatches(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productBatchResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductBatchControllerImplementation) FindNearExpiryProductBatches(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductBatchNearExpiryRequestQuery(c, requestId, controller.Logger)
+	productBatchResponses := controller.ProductBatchServiceInterface.FindNearExpiryProductBatches(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productBatchResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductBatchControllerImplementation) FindProductBatchRecall(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductBatchRecallRequestQuery(c, requestId, controller.Logger)
+	productBatchRecallResponse := controller.ProductBatchServiceInterface.FindProductBatchRecall(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productBatchRecallResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductCategoryControllerInterface interface {
+	FindCategoryTree(c echo.Context) error
+	FindAllCategories(c echo.Context) error
+	CreateCategory(c echo.Context) error
+	UpdateCategory(c echo.Context) error
+	DeleteCategory(c echo.Context) error
+	CreateSubCategory(c echo.Context) error
+	UpdateSubCategory(c echo.Context) error
+	DeleteSubCategory(c echo.Context) error
+}
+
+type ProductCategoryControllerImplementation struct {
+	ConfigWebserver                 config.Webserver
+	Logger                          *logrus.Logger
+	ProductCategoryServiceInterface services.ProductCategoryServiceInterface
+}
+
+func NewProductCategoryController(configWebserver config.Webserver, logger *logrus.Logger, productCategoryServiceInterface services.ProductCategoryServiceInterface) ProductCategoryControllerInterface {
+	return &ProductCategoryControllerImplementation{
+		ConfigWebserver:                 configWebserver,
+		Logger:                          logger,
+		ProductCategoryServiceInterface: productCategoryServiceInterface,
+	}
+}
+
+func (controller *ProductCategoryControllerImplementation) FindCategoryTree(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	categoryResponses := controller.ProductCategoryServiceInterface.FindCategoryTree(requestId)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: categoryResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) FindAllCategories(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	categoryResponses := controller.ProductCategoryServiceInterface.FindAllCategories(requestId)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: categoryResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) CreateCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductCategoryRequestBody(c, requestId, controller.Logger)
+	categoryResponse := controller.ProductCategoryServiceInterface.CreateCategory(requestId, request)
+	responses := response.Response{Code: 201, Mssg: "category created", Data: categoryResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) UpdateCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductCategoryRequestBody(c, requestId, controller.Logger)
+	categoryResponse := controller.ProductCategoryServiceInterface.UpdateCategory(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "category updated", Data: categoryResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) DeleteCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductCategoryDeleteRequestQuery(c, requestId, controller.Logger)
+	controller.ProductCategoryServiceInterface.DeleteCategory(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "category deleted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) CreateSubCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductSubCategoryRequestBody(c, requestId, controller.Logger)
+	subCategoryResponse := controller.ProductCategoryServiceInterface.CreateSubCategory(requestId, request)
+	responses := response.Response{Code: 201, Mssg: "sub category created", Data: subCategoryResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) UpdateSubCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductSubCategoryRequestBody(c, requestId, controller.Logger)
+	subCategoryResponse := controller.ProductCategoryServiceInterface.UpdateSubCategory(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "sub category updated", Data: subCategoryResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductCategoryControllerImplementation) DeleteSubCategory(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductCategoryDeleteRequestQuery(c, requestId, controller.Logger)
+	controller.ProductCategoryServiceInterface.DeleteSubCategory(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "sub category deleted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -2,11 +2,12 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
 )
@@ -26,58 +27,68 @@ type ProductControllerImplementation struct {
 	ProductServiceInterface services.ProductServiceInterface
 }
 
-func NewProductController(configWebserver config.Webserver, productServiceInterface services.ProductServiceInterface) ProductControllerInterface {
+func NewProductController(configWebserver config.Webserver, logger *logrus.Logger, productServiceInterface services.ProductServiceInterface) ProductControllerInterface {
 	return &ProductControllerImplementation{
 		ConfigWebserver:         configWebserver,
+		Logger:                  logger,
 		ProductServiceInterface: productServiceInterface,
 	}
 }
 
 func (controller *ProductControllerImplementation) FindAllProducts(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	productResponses := controller.ProductServiceInterface.FindAllProducts(requestId, limit, page)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
+	request := request.ReadFromPaginationRequestQuery(c, requestId, controller.Logger)
+	productResponses := controller.ProductServiceInterface.FindAllProducts(requestId, idUser, request)
 	responses := response.Response{Code: 200, Mssg: "success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
 
 func (controller *ProductControllerImplementation) FindProductsBySearch(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
-	product := c.QueryParam("product")
-	productResponses := controller.ProductServiceInterface.FindProductsBySearch(requestId, product)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
+	request := request.ReadFromProductSearchRequestQuery(c, requestId, controller.Logger)
+	productResponses := controller.ProductServiceInterface.FindProductsBySearch(requestId, idUser, request)
 	responses := response.Response{Code: 200, Mssg: "Success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
 
 func (controller *ProductControllerImplementation) FindProductById(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
 	id := c.QueryParam("id_product")
-	productResponses := controller.ProductServiceInterface.FindProductById(requestId, id)
+	idSearchLog := c.QueryParam("search_id")
+	productResponses := controller.ProductServiceInterface.FindProductById(requestId, idUser, id, idSearchLog)
 	responses := response.Response{Code: 200, Mssg: "Success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
 
 func (controller *ProductControllerImplementation) FindProductByIdCategory(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
 	id := c.QueryParam("id_category")
-	productResponses := controller.ProductServiceInterface.FindProductByIdCategory(requestId, id)
+	request := request.ReadFromPaginationRequestQuery(c, requestId, controller.Logger)
+	productResponses := controller.ProductServiceInterface.FindProductByIdCategory(requestId, idUser, id, request)
 	responses := response.Response{Code: 200, Mssg: "Success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
 
 func (controller *ProductControllerImplementation) FindProductByIdSubCategory(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
 	id := c.QueryParam("id_sub_category")
-	productResponses := controller.ProductServiceInterface.FindProductByIdSubCategory(requestId, id)
+	request := request.ReadFromPaginationRequestQuery(c, requestId, controller.Logger)
+	productResponses := controller.ProductServiceInterface.FindProductByIdSubCategory(requestId, idUser, id, request)
 	responses := response.Response{Code: 200, Mssg: "Success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
 
 func (controller *ProductControllerImplementation) FindProductByIdBrand(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
 	id := c.QueryParam("id_brand")
-	productResponses := controller.ProductServiceInterface.FindProductByIdBrand(requestId, id)
+	request := request.ReadFromPaginationRequestQuery(c, requestId, controller.Logger)
+	productResponses := controller.ProductServiceInterface.FindProductByIdBrand(requestId, idUser, id, request)
 	responses := response.Response{Code: 200, Mssg: "Success", Data: productResponses, Error: []string{}}
 	return c.JSON(http.StatusOK, responses)
 }
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductImageControllerInterface interface {
+	UploadProductImage(c echo.Context) error
+	ReorderProductImages(c echo.Context) error
+	DeleteProductImage(c echo.Context) error
+}
+
+type ProductImageControllerImplementation struct {
+	ConfigWebserver              config.Webserver
+	Logger                       *logrus.Logger
+	ProductImageServiceInterface services.ProductImageServiceInterface
+}
+
+func NewProductImageController(configWebserver config.Webserver, logger *logrus.Logger, productImageServiceInterface services.ProductImageServiceInterface) ProductImageControllerInterface {
+	return &ProductImageControllerImplementation{
+		ConfigWebserver:              configWebserver,
+		Logger:                       logger,
+		ProductImageServiceInterface: productImageServiceInterface,
+	}
+}
+
+func (controller *ProductImageControllerImplementation) UploadProductImage(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductImageUploadRequestBody(c, requestId, controller.Logger)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		exceptions.PanicIfBadRequest(err, requestId, []string{"image is required"}, controller.Logger)
+	}
+	productImageResponse := controller.ProductImageServiceInterface.UploadProductImage(requestId, request, fileHeader)
+	responses := response.Response{Code: 201, Mssg: "product image uploaded", Data: productImageResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductImageControllerImplementation) ReorderProductImages(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductImageReorderRequestBody(c, requestId, controller.Logger)
+	productImageResponses := controller.ProductImageServiceInterface.ReorderProductImages(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product images reordered", Data: productImageResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductImageControllerImplementation) DeleteProductImage(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductImageDeleteRequestQuery(c, requestId, controller.Logger)
+	controller.ProductImageServiceInterface.DeleteProductImage(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "product image deleted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductPriceHistoryControllerInterface interface {
+	FindProductPriceHistories(c echo.Context) error
+}
+
+type ProductPriceHistoryControllerImplementation struct {
+	ConfigWebserver                     config.Webserver
+	Logger                              *logrus.Logger
+	ProductPriceHistoryServiceInterface services.ProductPriceHistoryServiceInterface
+}
+
+func NewProductPriceHistoryController(configWebserver config.Webserver, logger *logrus.Logger, productPriceHistoryServiceInterface services.ProductPriceHistoryServiceInterface) ProductPriceHistoryControllerInterface {
+	return &ProductPriceHistoryControllerImplementation{
+		ConfigWebserver:                     configWebserver,
+		Logger:                              logger,
+		ProductPriceHistoryServiceInterface: productPriceHistoryServiceInterface,
+	}
+}
+
+func (controller *ProductPriceHistoryControllerImplementation) FindProductPriceHistories(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromProductPriceHistoryRequestQuery(c, requestId, controller.Logger)
+	productPriceHistoryListResponse := controller.ProductPriceHistoryServiceInterface.FindProductPriceHistories(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: productPriceHistoryListResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
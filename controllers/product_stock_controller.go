@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ProductStockControllerInterface interface {
+	StockIn(c echo.Context) error
+	ReceiveGoods(c echo.Context) error
+	AdjustStock(c echo.Context) error
+	FindStockCard(c echo.Context) error
+}
+
+type ProductStockControllerImplementation struct {
+	ConfigWebserver              config.Webserver
+	Logger                       *logrus.Logger
+	ProductStockServiceInterface services.ProductStockServiceInterface
+}
+
+func NewProductStockController(configWebserver config.Webserver, logger *logrus.Logger, productStockServiceInterface services.ProductStockServiceInterface) ProductStockControllerInterface {
+	return &ProductStockControllerImplementation{
+		ConfigWebserver:              configWebserver,
+		Logger:                       logger,
+		ProductStockServiceInterface: productStockServiceInterface,
+	}
+}
+
+func (controller *ProductStockControllerImplementation) StockIn(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromProductStockInRequestBody(c, requestId, controller.Logger)
+	productStockMovementResponse := controller.ProductStockServiceInterface.StockIn(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "stock in success", Data: productStockMovementResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductStockControllerImplementation) ReceiveGoods(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromGoodsReceivingRequestBody(c, requestId, controller.Logger)
+	goodsReceivingResponse := controller.ProductStockServiceInterface.ReceiveGoods(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "goods received", Data: goodsReceivingResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductStockControllerImplementation) AdjustStock(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromStockAdjustmentRequestBody(c, requestId, controller.Logger)
+	productStockMovementResponse := controller.ProductStockServiceInterface.AdjustStock(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "stock adjusted", Data: productStockMovementResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *ProductStockControllerImplementation) FindStockCard(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromStockCardRequestQuery(c, requestId, controller.Logger)
+	stockCardResponse := controller.ProductStockServiceInterface.FindStockCard(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: stockCardResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type RecommendationControllerInterface interface {
+	FindProductRecommendations(c echo.Context) error
+	FindHomeFeed(c echo.Context) error
+}
+
+type RecommendationControllerImplementation struct {
+	ConfigWebserver                config.Webserver
+	Logger                         *logrus.Logger
+	RecommendationServiceInterface services.RecommendationServiceInterface
+}
+
+func NewRecommendationController(configWebserver config.Webserver, logger *logrus.Logger, recommendationServiceInterface services.RecommendationServiceInterface) RecommendationControllerInterface {
+	return &RecommendationControllerImplementation{
+		ConfigWebserver:                configWebserver,
+		Logger:                         logger,
+		RecommendationServiceInterface: recommendationServiceInterface,
+	}
+}
+
+func (controller *RecommendationControllerImplementation) FindProductRecommendations(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.OptionalTokenClaimsIdUser(c)
+	id := c.QueryParam("id_product")
+	recommendationResponses := controller.RecommendationServiceInterface.FindProductRecommendations(requestId, idUser, id)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: recommendationResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *RecommendationControllerImplementation) FindHomeFeed(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	homeFeedResponses := controller.RecommendationServiceInterface.FindHomeFeed(requestId, idUser)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: homeFeedResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
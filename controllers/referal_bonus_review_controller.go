@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type ReferalBonusReviewControllerInterface interface {
+	FindReferalBonusReview(c echo.Context) error
+	ApproveReferalBonusReview(c echo.Context) error
+	RejectReferalBonusReview(c echo.Context) error
+}
+
+type ReferalBonusReviewControllerImplementation struct {
+	ConfigurationWebserver       config.Webserver
+	Logger                       *logrus.Logger
+	ReferalFraudServiceInterface services.ReferalFraudServiceInterface
+}
+
+func NewReferalBonusReviewController(configurationWebserver config.Webserver,
+	logger *logrus.Logger,
+	referalFraudServiceInterface services.ReferalFraudServiceInterface) ReferalBonusReviewControllerInterface {
+	return &ReferalBonusReviewControllerImplementation{
+		ConfigurationWebserver:       configurationWebserver,
+		Logger:                       logger,
+		ReferalFraudServiceInterface: referalFraudServiceInterface,
+	}
+}
+
+func (controller *ReferalBonusReviewControllerImplementation) FindReferalBonusReview(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromFindReferalBonusReviewRequestQuery(c, requestId, controller.Logger)
+	referalBonusReviewResponse := controller.ReferalFraudServiceInterface.FindReferalBonusReview(requestId, request)
+	response := response.Response{Code: 200, Mssg: "success", Data: referalBonusReviewResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *ReferalBonusReviewControllerImplementation) ApproveReferalBonusReview(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromReviewReferalBonusRequestBody(c, requestId, controller.Logger)
+	controller.ReferalFraudServiceInterface.ApproveReferalBonusReview(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "referal bonus approved", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *ReferalBonusReviewControllerImplementation) RejectReferalBonusReview(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromReviewReferalBonusRequestBody(c, requestId, controller.Logger)
+	controller.ReferalFraudServiceInterface.RejectReferalBonusReview(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "referal bonus rejected", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type RestockSubscriptionControllerInterface interface {
+	FindRestockSubscriptionsByIdUser(c echo.Context) error
+	SubscribeRestock(c echo.Context) error
+	UnsubscribeRestock(c echo.Context) error
+}
+
+type RestockSubscriptionControllerImplementation struct {
+	ConfigWebserver                     config.Webserver
+	Logger                              *logrus.Logger
+	RestockSubscriptionServiceInterface services.RestockSubscriptionServiceInterface
+}
+
+func NewRestockSubscriptionController(configWebserver config.Webserver, logger *logrus.Logger, restockSubscriptionServiceInterface services.RestockSubscriptionServiceInterface) RestockSubscriptionControllerInterface {
+	return &RestockSubscriptionControllerImplementation{
+		ConfigWebserver:                     configWebserver,
+		Logger:                              logger,
+		RestockSubscriptionServiceInterface: restockSubscriptionServiceInterface,
+	}
+}
+
+func (controller *RestockSubscriptionControllerImplementation) FindRestockSubscriptionsByIdUser(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	restockSubscriptionResponses := controller.RestockSubscriptionServiceInterface.FindRestockSubscriptionsByIdUser(requestId, idUser)
+	responses := response.Response{Code: 200, Mssg: "success", Data: restockSubscriptionResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *RestockSubscriptionControllerImplementation) SubscribeRestock(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromRestockSubscriptionRequestBody(c, requestId, controller.Logger)
+	restockSubscriptionResponse := controller.RestockSubscriptionServiceInterface.SubscribeRestock(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "restock subscription created", Data: restockSubscriptionResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *RestockSubscriptionControllerImplementation) UnsubscribeRestock(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromRestockSubscriptionRequestBody(c, requestId, controller.Logger)
+	controller.RestockSubscriptionServiceInterface.UnsubscribeRestock(requestId, idUser, request)
+	responses := response.Response{Code: 200, Mssg: "restock subscription deleted", Data: nil, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type SearchAnalyticsControllerInterface interface {
+	FindTopSearches(c echo.Context) error
+	FindZeroResultSearches(c echo.Context) error
+	FindSearchConversion(c echo.Context) error
+}
+
+type SearchAnalyticsControllerImplementation struct {
+	ConfigWebserver                 config.Webserver
+	Logger                          *logrus.Logger
+	SearchAnalyticsServiceInterface services.SearchAnalyticsServiceInterface
+}
+
+func NewSearchAnalyticsController(configWebserver config.Webserver, logger *logrus.Logger, searchAnalyticsServiceInterface services.SearchAnalyticsServiceInterface) SearchAnalyticsControllerInterface {
+	return &SearchAnalyticsControllerImplementation{
+		ConfigWebserver:                 configWebserver,
+		Logger:                          logger,
+		SearchAnalyticsServiceInterface: searchAnalyticsServiceInterface,
+	}
+}
+
+func (controller *SearchAnalyticsControllerImplementation) FindTopSearches(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromSearchAnalyticsRequestQuery(c, requestId, controller.Logger)
+	searchTermReportResponse := controller.SearchAnalyticsServiceInterface.FindTopSearches(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: searchTermReportResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *SearchAnalyticsControllerImplementation) FindZeroResultSearches(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromSearchAnalyticsRequestQuery(c, requestId, controller.Logger)
+	searchTermReportResponse := controller.SearchAnalyticsServiceInterface.FindZeroResultSearches(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: searchTermReportResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *SearchAnalyticsControllerImplementation) FindSearchConversion(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromSearchAnalyticsRequestQuery(c, requestId, controller.Logger)
+	searchConversionReportResponse := controller.SearchAnalyticsServiceInterface.FindSearchConversion(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: searchConversionReportResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type StockOpnameControllerInterface interface {
+	FindStockOpnames(c echo.Context) error
+	FindStockOpnameById(c echo.Context) error
+	CreateStockOpname(c echo.Context) error
+	CountStockOpname(c echo.Context) error
+	PostStockOpname(c echo.Context) error
+	CancelStockOpname(c echo.Context) error
+}
+
+type StockOpnameControllerImplementation struct {
+	ConfigWebserver             config.Webserver
+	Logger                      *logrus.Logger
+	StockOpnameServiceInterface services.StockOpnameServiceInterface
+}
+
+func NewStockOpnameController(configWebserver config.Webserver, logger *logrus.Logger, stockOpnameServiceInterface services.StockOpnameServiceInterface) StockOpnameControllerInterface {
+	return &StockOpnameControllerImplementation{
+		ConfigWebserver:             configWebserver,
+		Logger:                      logger,
+		StockOpnameServiceInterface: stockOpnameServiceInterface,
+	}
+}
+
+func (controller *StockOpnameControllerImplementation) FindStockOpnames(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromStockOpnameListRequestQuery(c, requestId, controller.Logger)
+	stockOpnameListResponse := controller.StockOpnameServiceInterface.FindStockOpnames(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: stockOpnameListResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *StockOpnameControllerImplementation) FindStockOpnameById(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromStockOpnameIdRequest(c, requestId, controller.Logger)
+	stockOpnameResponse := controller.StockOpnameServiceInterface.FindStockOpnameById(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "Success", Data: stockOpnameResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *StockOpnameControllerImplementation) CreateStockOpname(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromStockOpnameCreateRequestBody(c, requestId, controller.Logger)
+	stockOpnameResponse := controller.StockOpnameServiceInterface.CreateStockOpname(requestId, idUser, request)
+	responses := response.Response{Code: 201, Mssg: "stock opname created", Data: stockOpnameResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *StockOpnameControllerImplementation) CountStockOpname(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromStockOpnameCountRequestBody(c, requestId, controller.Logger)
+	stockOpnameResponse := controller.StockOpnameServiceInterface.CountStockOpname(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "stock opname counted", Data: stockOpnameResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *StockOpnameControllerImplementation) PostStockOpname(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromStockOpnameIdRequest(c, requestId, controller.Logger)
+	stockOpnameResponse := controller.StockOpnameServiceInterface.PostStockOpname(requestId, idUser, request)
+	responses := response.Response{Code: 200, Mssg: "stock opname posted", Data: stockOpnameResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (controller *StockOpnameControllerImplementation) CancelStockOpname(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	request := request.ReadFromStockOpnameIdRequest(c, requestId, controller.Logger)
+	stockOpnameResponse := controller.StockOpnameServiceInterface.CancelStockOpname(requestId, request)
+	responses := response.Response{Code: 200, Mssg: "stock opname cancelled", Data: stockOpnameResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -53,7 +53,7 @@ func (controller *UserControllerImplementation) DeleteAccount(c echo.Context) er
 func (controller *UserControllerImplementation) CreateUser(c echo.Context) error {
 	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
 	request := request.ReadFromCreateUserRequestBody(c, requestId, controller.Logger)
-	userResponse := controller.UserServiceInterface.CreateUser(requestId, request)
+	userResponse := controller.UserServiceInterface.CreateUser(requestId, request, c.RealIP())
 	response := response.Response{Code: 201, Mssg: "user created", Data: userResponse, Error: []string{}}
 	return c.JSON(http.StatusOK, response)
 }
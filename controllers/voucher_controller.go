@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type VoucherControllerInterface interface {
+	ValidateVoucher(c echo.Context) error
+}
+
+type VoucherControllerImplementation struct {
+	ConfigWebserver         config.Webserver
+	Logger                  *logrus.Logger
+	VoucherServiceInterface services.VoucherServiceInterface
+}
+
+func NewVoucherController(configWebserver config.Webserver,
+	logger *logrus.Logger,
+	voucherServiceInterface services.VoucherServiceInterface) VoucherControllerInterface {
+	return &VoucherControllerImplementation{
+		ConfigWebserver:         configWebserver,
+		Logger:                  logger,
+		VoucherServiceInterface: voucherServiceInterface,
+	}
+}
+
+func (controller *VoucherControllerImplementation) ValidateVoucher(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromVoucherValidateRequestQuery(c, requestId, controller.Logger)
+	voucherValidateResponse := controller.VoucherServiceInterface.ValidateVoucher(requestId, idUser, request)
+	response := response.Response{Code: 200, Mssg: "success", Data: voucherValidateResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
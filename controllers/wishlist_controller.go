@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/middleware"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
+)
+
+type WishlistControllerInterface interface {
+	FindWishlistByIdUser(c echo.Context) error
+	AddProductToWishlist(c echo.Context) error
+	DeleteProductInWishlist(c echo.Context) error
+	MoveWishlistToCart(c echo.Context) error
+}
+
+type WishlistControllerImplementation struct {
+	ConfigWebserver          config.Webserver
+	Logger                   *logrus.Logger
+	WishlistServiceInterface services.WishlistServiceInterface
+}
+
+func NewWishlistController(configWebserver config.Webserver,
+	logger *logrus.Logger,
+	wishlistServiceInterface services.WishlistServiceInterface) WishlistControllerInterface {
+	return &WishlistControllerImplementation{
+		ConfigWebserver:          configWebserver,
+		Logger:                   logger,
+		WishlistServiceInterface: wishlistServiceInterface,
+	}
+}
+
+func (controller *WishlistControllerImplementation) FindWishlistByIdUser(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	wishlistResponses := controller.WishlistServiceInterface.FindWishlistByIdUser(requestId, idUser)
+	response := response.Response{Code: 200, Mssg: "success", Data: wishlistResponses, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *WishlistControllerImplementation) AddProductToWishlist(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromWishlistRequestBody(c, requestId, controller.Logger)
+	wishlistResponse := controller.WishlistServiceInterface.AddProductToWishlist(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "success add product to wishlist", Data: wishlistResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *WishlistControllerImplementation) DeleteProductInWishlist(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromWishlistRequestBody(c, requestId, controller.Logger)
+	controller.WishlistServiceInterface.DeleteProductInWishlist(requestId, idUser, request)
+	response := response.Response{Code: 200, Mssg: "success", Data: "", Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (controller *WishlistControllerImplementation) MoveWishlistToCart(c echo.Context) error {
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	idUser := middleware.TokenClaimsIdUser(c)
+	request := request.ReadFromWishlistRequestBody(c, requestId, controller.Logger)
+	cartResponse := controller.WishlistServiceInterface.MoveWishlistToCart(requestId, idUser, request)
+	response := response.Response{Code: 201, Mssg: "success move product to cart", Data: cartResponse, Error: []string{}}
+	return c.JSON(http.StatusOK, response)
+}
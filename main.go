@@ -15,6 +15,7 @@ import (
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/controllers"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/media"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/routes"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/services"
@@ -82,6 +83,12 @@ func main() {
 	// Family Members Repository
 	familyMembersRepository := mysql.NewFamilyMembersRepository(&appConfig.Database)
 
+	// Family Invitation Repository
+	familyInvitationRepository := mysql.NewFamilyInvitationRepository(&appConfig.Database)
+
+	// Family Point Transfer Repository
+	familyPointTransferRepository := mysql.NewFamilyPointTransferRepository(&appConfig.Database)
+
 	// Shipping Repository
 	shippingRepository := mysql.NewShippingRepository(&appConfig.Database)
 
@@ -124,9 +131,65 @@ func main() {
 	// Setting Repository
 	bannerRepository := mysql.NewBannerRepository(&appConfig.Database)
 
+	// Product Variant Repository
+	productVariantRepository := mysql.NewProductVariantRepository(&appConfig.Database)
+
 	// Product Brand Repository
 	productBrandRepository := mysql.NewProductBrandRepository(&appConfig.Database)
 
+	// Registration Campaign Repository
+	registrationCampaignRepository := mysql.NewRegistrationCampaignRepository(&appConfig.Database)
+
+	// Point Redemption Rule Repository
+	pointRedemptionRuleRepository := mysql.NewPointRedemptionRuleRepository(&appConfig.Database)
+
+	// Referal Bonus Review Repository
+	referalBonusReviewRepository := mysql.NewReferalBonusReviewRepository(&appConfig.Database)
+
+	// Voucher Repository
+	voucherRepository := mysql.NewVoucherRepository(&appConfig.Database)
+	voucherUsageRepository := mysql.NewVoucherUsageRepository(&appConfig.Database)
+
+	// Flash Sale Repository
+	flashSaleRepository := mysql.NewFlashSaleRepository(&appConfig.Database)
+	flashSaleUsageRepository := mysql.NewFlashSaleUsageRepository(&appConfig.Database)
+
+	// Product Category Repository
+	productCategoryRepository := mysql.NewProductCategoryRepository(&appConfig.Database)
+
+	// Product Price History Repository
+	productPriceHistoryRepository := mysql.NewProductPriceHistoryRepository(&appConfig.Database)
+
+	// Search Log Repository
+	searchLogRepository := mysql.NewSearchLogRepository(&appConfig.Database)
+
+	// Product Attribute Repository
+	productAttributeRepository := mysql.NewProductAttributeRepository(&appConfig.Database)
+
+	// Product Image Repository
+	productImageRepository := mysql.NewProductImageRepository(&appConfig.Database)
+
+	// Product Discount Repository
+	productDiscountRepository := mysql.NewProductDiscountRepository(&appConfig.Database)
+
+	// Product Batch Repository
+	productBatchRepository := mysql.NewProductBatchRepository(&appConfig.Database)
+
+	// Stock Opname Repository
+	stockOpnameRepository := mysql.NewStockOpnameRepository(&appConfig.Database)
+
+	// Restock Subscription Repository
+	restockSubscriptionRepository := mysql.NewRestockSubscriptionRepository(&appConfig.Database)
+
+	// Recommendation Repository
+	recommendationRepository := mysql.NewRecommendationRepository(&appConfig.Database)
+
+	// Wishlist Repository
+	wishlistRepository := mysql.NewWishlistRepository(&appConfig.Database)
+
+	// Promotion Repository
+	promotionRepository := mysql.NewPromotionRepository(&appConfig.Database)
+
 	// Setting Service
 	settingService := services.NewSettingService(
 		appConfig.Webserver,
@@ -178,6 +241,41 @@ func main() {
 		logrusLogger,
 		shippingRepository)
 
+	// Promotion Service
+	promotionService := services.NewPromotionService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		logrusLogger,
+		promotionRepository)
+
+	// Flash Sale Service
+	flashSaleService := services.NewFlashSaleService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		flashSaleRepository,
+		flashSaleUsageRepository,
+		productRepository)
+
+	// Purchase Limit Service
+	purchaseLimitService := services.NewPurchaseLimitService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		logrusLogger,
+		cartRepository,
+		orderItemRepository,
+		settingsRepository,
+		userRepository)
+
+	// Search Analytics Service
+	searchAnalyticsService := services.NewSearchAnalyticsService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		searchLogRepository)
+
 	// Cart Service
 	cartService := services.NewCartService(
 		appConfig.Webserver,
@@ -188,8 +286,35 @@ func main() {
 		shippingRepository,
 		productRepository,
 		settingsRepository,
+		promotionService,
+		flashSaleService,
+		purchaseLimitService,
+		searchAnalyticsService,
 	)
 
+	// Wishlist Service
+	wishlistService := services.NewWishlistService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		wishlistRepository,
+		productRepository,
+		cartService)
+
+	// Point Redemption Service
+	pointRedemptionService := services.NewPointRedemptionService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		logrusLogger,
+		pointRedemptionRuleRepository,
+		orderRepository,
+		cartRepository,
+		userRepository,
+		settingsRepository,
+		promotionService,
+		flashSaleService)
+
 	// Balance Point Service
 	balancePointService := services.NewBalancePointService(
 		appConfig.Webserver,
@@ -197,16 +322,30 @@ func main() {
 		logrusLogger,
 		balancePointRepository,
 		settingsRepository,
-		orderRepository)
+		pointRedemptionService)
 
 	// Balance Point Tx Service
 	balancePointTxService := services.NewBalancePointTxService(
 		appConfig.Webserver,
 		mysqlDBConnection,
+		validate,
 		logrusLogger,
 		balancePointTxRepository,
 		balancePointRepository)
 
+	// Referal Fraud Service
+	referalFraudService := services.NewReferalFraudService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		userRepository,
+		userShippingAddressRepository,
+		balancePointRepository,
+		balancePointTxRepository,
+		referalBonusReviewRepository,
+		settingsRepository)
+
 	// User Service
 	userService := services.NewUserService(
 		appConfig.Webserver,
@@ -221,7 +360,24 @@ func main() {
 		familyMembersRepository,
 		balancePointRepository,
 		balancePointTxRepository,
-		userShippingAddressRepository)
+		userShippingAddressRepository,
+		registrationCampaignRepository,
+		referalFraudService)
+
+	// Family Service
+	familyService := services.NewFamilyService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		userRepository,
+		familyRepository,
+		familyMembersRepository,
+		familyInvitationRepository,
+		familyPointTransferRepository,
+		balancePointRepository,
+		balancePointTxRepository,
+		settingsRepository)
 
 	// Auth Service
 	authService := services.NewAuthService(
@@ -234,13 +390,159 @@ func main() {
 		settingsRepository,
 		otpManagerRepository)
 
+	// Product Search Service
+	productSearchService := services.NewProductSearchService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		logrusLogger,
+		productRepository)
+
+	// Product Price History Service
+	productPriceHistoryService := services.NewProductPriceHistoryService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productPriceHistoryRepository,
+		productRepository)
+
+	// Restock Subscription Service
+	restockSubscriptionService := services.NewRestockSubscriptionService(
+		appConfig.Webserver,
+		appConfig.Whatsapp,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		restockSubscriptionRepository,
+		productRepository)
+
+	// Stock Monitor Service
+	stockMonitorService := services.NewStockMonitorService(
+		appConfig.Telegram,
+		mysqlDBConnection,
+		logrusLogger,
+		productRepository)
+
+	// Product Stock Service
+	productStockService := services.NewProductStockService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productRepository,
+		productVariantRepository,
+		productStockHistoryRepository,
+		productBatchRepository,
+		restockSubscriptionService,
+		stockMonitorService,
+		productSearchService)
+
+	// Product Batch Service
+	productBatchService := services.NewProductBatchService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productBatchRepository,
+		productStockService,
+		stockMonitorService,
+		productSearchService)
+
+	// Stock Opname Service
+	stockOpnameService := services.NewStockOpnameService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		stockOpnameRepository,
+		productRepository,
+		productStockService,
+		restockSubscriptionService,
+		stockMonitorService,
+		productSearchService)
+
 	// Product Service
 	productService := services.NewProductService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productRepository,
+		wishlistRepository,
+		appConfig.Payment,
+		productSearchService,
+		productPriceHistoryService,
+		searchAnalyticsService)
+
+	// Product Category Service
+	productCategoryService := services.NewProductCategoryService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productCategoryRepository,
+		productSearchService)
+
+	// Product Attribute Service
+	productAttributeService := services.NewProductAttributeService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productAttributeRepository,
+		productRepository,
+		productSearchService)
+
+	// Product Image Service
+	productImageService := services.NewProductImageService(
+		appConfig.Webserver,
+		appConfig.Media,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		media.NewLocalStorage(appConfig.Media.StoragePath, appConfig.Media.BaseUrl),
+		productImageRepository,
+		productRepository,
+		productSearchService)
+
+	// Admin Product Service
+	adminProductService := services.NewAdminProductService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		productRepository,
+		productDiscountRepository,
+		productCategoryRepository,
+		productBrandRepository,
+		productStockService,
+		productCategoryService,
+		restockSubscriptionService,
+		productPriceHistoryService)
+
+	// Recommendation Service
+	recommendationService := services.NewRecommendationService(
 		appConfig.Webserver,
 		mysqlDBConnection,
 		logrusLogger,
+		recommendationRepository,
 		productRepository,
-		appConfig.Payment)
+		productService,
+		productPriceHistoryService)
+
+	// Voucher Service
+	voucherService := services.NewVoucherService(
+		appConfig.Webserver,
+		mysqlDBConnection,
+		validate,
+		logrusLogger,
+		voucherRepository,
+		voucherUsageRepository,
+		cartRepository,
+		userRepository,
+		settingsRepository,
+		promotionService,
+		flashSaleService)
 
 	// Order Service
 	orderService := services.NewOrderService(
@@ -258,12 +560,19 @@ func main() {
 		paymentLogRepository,
 		bankTransferRepository,
 		bankVaRepository,
-		productRepository,
-		productStockHistoryRepository,
 		balancePointRepository,
 		balancePointTxRepository,
 		userLevelMemberRepository,
-		settingsRepository)
+		settingsRepository,
+		referalFraudService,
+		pointRedemptionService,
+		productSearchService,
+		productStockService,
+		voucherService,
+		promotionService,
+		stockMonitorService,
+		flashSaleService,
+		purchaseLimitService)
 
 	// Payment Channel Service
 	paymentChannelService := services.NewPaymentChannelService(
@@ -296,9 +605,10 @@ func main() {
 		appConfig.Payment,
 		orderRepository,
 		orderItemRepository,
-		productRepository,
-		productStockHistoryRepository,
-		paymentLogRepository)
+		paymentLogRepository,
+		productSearchService,
+		productStockService,
+		stockMonitorService)
 
 	// Setting Controller
 	settingController := controllers.NewSettingController(appConfig.Webserver, settingService)
@@ -331,6 +641,10 @@ func main() {
 	cartController := controllers.NewCartController(appConfig.Webserver, cartService)
 	routes.CartRoute(e, appConfig.Webserver, appConfig.Jwt, cartController)
 
+	// Wishlist Controller
+	wishlistController := controllers.NewWishlistController(appConfig.Webserver, logrusLogger, wishlistService)
+	routes.WishlistRoute(e, appConfig.Webserver, appConfig.Jwt, wishlistController)
+
 	// Balance Point Controller
 	balancePointController := controllers.NewBalancePointController(appConfig.Webserver, logrusLogger, balancePointService)
 	routes.BalancePointRoute(e, appConfig.Webserver, appConfig.Jwt, balancePointController)
@@ -349,13 +663,65 @@ func main() {
 	routes.AuthRoute(e, appConfig.Webserver, appConfig.Jwt, authController)
 
 	// Product Controller
-	productController := controllers.NewProductController(appConfig.Webserver, productService)
+	productController := controllers.NewProductController(appConfig.Webserver, logrusLogger, productService)
 	routes.ProductRoute(e, appConfig.Webserver, appConfig.Jwt, productController)
 
+	// Product Category Controller
+	productCategoryController := controllers.NewProductCategoryController(appConfig.Webserver, logrusLogger, productCategoryService)
+	routes.ProductCategoryRoute(e, appConfig.Webserver, appConfig.Jwt, productCategoryController)
+
+	// Product Attribute Controller
+	productAttributeController := controllers.NewProductAttributeController(appConfig.Webserver, logrusLogger, productAttributeService)
+	routes.ProductAttributeRoute(e, appConfig.Webserver, appConfig.Jwt, productAttributeController)
+
+	// Product Image Controller
+	productImageController := controllers.NewProductImageController(appConfig.Webserver, logrusLogger, productImageService)
+	routes.ProductImageRoute(e, appConfig.Webserver, appConfig.Jwt, appConfig.Media, productImageController)
+
+	// Product Price History Controller
+	productPriceHistoryController := controllers.NewProductPriceHistoryController(appConfig.Webserver, logrusLogger, productPriceHistoryService)
+	routes.ProductPriceHistoryRoute(e, appConfig.Webserver, appConfig.Jwt, productPriceHistoryController)
+
+	// Search Analytics Controller
+	searchAnalyticsController := controllers.NewSearchAnalyticsController(appConfig.Webserver, logrusLogger, searchAnalyticsService)
+	routes.SearchAnalyticsRoute(e, appConfig.Webserver, appConfig.Jwt, searchAnalyticsController)
+
+	// Admin Product Controller
+	adminProductController := controllers.NewAdminProductController(appConfig.Webserver, logrusLogger, adminProductService)
+	routes.AdminProductRoute(e, appConfig.Webserver, appConfig.Jwt, adminProductController)
+
+	// Restock Subscription Controller
+	restockSubscriptionController := controllers.NewRestockSubscriptionController(appConfig.Webserver, logrusLogger, restockSubscriptionService)
+	routes.RestockSubscriptionRoute(e, appConfig.Webserver, appConfig.Jwt, restockSubscriptionController)
+
+	// Product Stock Controller
+	productStockController := controllers.NewProductStockController(appConfig.Webserver, logrusLogger, productStockService)
+	routes.ProductStockRoute(e, appConfig.Webserver, appConfig.Jwt, productStockController)
+
+	// Product Batch Controller
+	productBatchController := controllers.NewProductBatchController(appConfig.Webserver, logrusLogger, productBatchService)
+	routes.ProductBatchRoute(e, appConfig.Webserver, appConfig.Jwt, productBatchController)
+
+	// Stock Opname Controller
+	stockOpnameController := controllers.NewStockOpnameController(appConfig.Webserver, logrusLogger, stockOpnameService)
+	routes.StockOpnameRoute(e, appConfig.Webserver, appConfig.Jwt, stockOpnameController)
+
+	// Recommendation Controller
+	recommendationController := controllers.NewRecommendationController(appConfig.Webserver, logrusLogger, recommendationService)
+	routes.RecommendationRoute(e, appConfig.Webserver, appConfig.Jwt, recommendationController)
+
 	// Order Controller
 	orderController := controllers.NewOrderController(appConfig.Webserver, logrusLogger, orderService)
 	routes.OrderRoute(e, appConfig.Webserver, appConfig.Jwt, orderController)
 
+	// Voucher Controller
+	voucherController := controllers.NewVoucherController(appConfig.Webserver, logrusLogger, voucherService)
+	routes.VoucherRoute(e, appConfig.Webserver, appConfig.Jwt, voucherController)
+
+	// Flash Sale Controller
+	flashSaleController := controllers.NewFlashSaleController(appConfig.Webserver, logrusLogger, flashSaleService)
+	routes.FlashSaleRoute(e, appConfig.Webserver, appConfig.Jwt, flashSaleController)
+
 	// Payment Channel Controller
 	paymentChannelController := controllers.NewPaymentChannelController(appConfig.Webserver, logrusLogger, paymentChannelService)
 	routes.PaymentChannelRoute(e, appConfig.Webserver, appConfig.Jwt, paymentChannelController)
@@ -368,6 +734,14 @@ func main() {
 	bannerController := controllers.NewBannerController(appConfig.Webserver, bannerService)
 	routes.BannerRoute(e, appConfig.Webserver, appConfig.Jwt, bannerController)
 
+	// Family Controller
+	familyController := controllers.NewFamilyController(appConfig.Webserver, logrusLogger, familyService)
+	routes.FamilyRoute(e, appConfig.Webserver, appConfig.Jwt, familyController)
+
+	// Referal Bonus Review Controller
+	referalBonusReviewController := controllers.NewReferalBonusReviewController(appConfig.Webserver, logrusLogger, referalFraudService)
+	routes.ReferalBonusReviewRoute(e, appConfig.Webserver, appConfig.Jwt, referalBonusReviewController)
+
 	// Product Brand Controller
 	productBrandController := controllers.NewProductBrandController(appConfig.Webserver, productBrandService)
 	routes.ProductBrandRoute(e, appConfig.Webserver, appConfig.Jwt, productBrandController)
@@ -376,6 +750,49 @@ func main() {
 	mainController := controllers.NewMainController(appConfig.Webserver)
 	routes.MainRoute(e, appConfig.Webserver, mainController)
 
+	// Wishlist Notification Job
+	go func() {
+		for range time.Tick(time.Hour) {
+			wishlistService.NotifyWishlistPriceChanges()
+		}
+	}()
+
+	// Recommendation Refresh Job
+	go func() {
+		recommendationService.RefreshRecommendations()
+		for range time.Tick(6 * time.Hour) {
+			recommendationService.RefreshRecommendations()
+		}
+	}()
+
+	// Restock Notification Job, menangkap perubahan stok di luar jalur stok masuk
+	go func() {
+		for range time.Tick(15 * time.Minute) {
+			restockSubscriptionService.NotifyRestockSubscribers()
+		}
+	}()
+
+	// Expired Batch Job, sisa batch kadaluarsa dikeluarkan dari stok
+	go func() {
+		productBatchService.ExpireProductBatches()
+		for range time.Tick(time.Hour) {
+			productBatchService.ExpireProductBatches()
+		}
+	}()
+
+	// Low Stock Digest Job, ringkasan stok dikirim setiap hari jam 07.00
+	go func() {
+		for {
+			now := time.Now()
+			nextDigest := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, now.Location())
+			if !nextDigest.After(now) {
+				nextDigest = nextDigest.AddDate(0, 0, 1)
+			}
+			time.Sleep(time.Until(nextDigest))
+			stockMonitorService.SendLowStockDigest()
+		}
+	}()
+
 	// Careful shutdown
 	go func() {
 		if err := e.Start(":" + strconv.Itoa(int(appConfig.Webserver.Port))); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,180 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"math"
+)
+
+// Ukuran sisi terpanjang setiap varian gambar
+const (
+	VariantThumbnail = "thumbnail"
+	VariantMedium    = "medium"
+	VariantLarge     = "large"
+)
+
+var variantSizes = []struct {
+	name string
+	size int
+}{
+	{VariantThumbnail, 200},
+	{VariantMedium, 600},
+	{VariantLarge, 1200},
+}
+
+// Batas piksel gambar asli agar upload tidak menghabiskan memori saat di-decode
+const maxSourcePixels = 40000000
+
+const jpegQuality = 85
+
+var ErrUnsupportedImage = errors.New("unsupported image")
+var ErrImageTooLarge = errors.New("image dimension too large")
+
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Mengubah gambar jpeg, png atau gif menjadi varian jpeg thumbnail, medium dan large.
+// Gambar yang lebih kecil dari ukuran varian tidak diperbesar
+func ProcessImage(data []byte) (width int, height int, variants []ImageVariant, err error) {
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, ErrUnsupportedImage
+	}
+	if imageConfig.Width*imageConfig.Height > maxSourcePixels {
+		return 0, 0, nil, ErrImageTooLarge
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, ErrUnsupportedImage
+	}
+	flattened := flatten(source)
+	width = flattened.Bounds().Dx()
+	height = flattened.Bounds().Dy()
+
+	for _, variantSize := range variantSizes {
+		resized := Resize(flattened, variantSize.size)
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, resized, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return 0, 0, nil, err
+		}
+		variants = append(variants, ImageVariant{
+			Name:   variantSize.name,
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+			Data:   buffer.Bytes(),
+		})
+	}
+	return width, height, variants, nil
+}
+
+// Jpeg tidak punya transparansi, area transparan diberi latar putih
+func flatten(source image.Image) *image.RGBA {
+	bounds := source.Bounds()
+	flattened := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flattened, flattened.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), source, bounds.Min, draw.Over)
+	return flattened
+}
+
+// Memperkecil gambar agar sisi terpanjang tidak melebihi maxSize dengan rata-rata area piksel
+func Resize(source *image.RGBA, maxSize int) *image.RGBA {
+	srcWidth := source.Bounds().Dx()
+	srcHeight := source.Bounds().Dy()
+	dstWidth, dstHeight := srcWidth, srcHeight
+	if srcWidth > maxSize || srcHeight > maxSize {
+		if srcWidth >= srcHeight {
+			dstWidth = maxSize
+			dstHeight = int(math.Max(1, math.Round(float64(srcHeight)*float64(maxSize)/float64(srcWidth))))
+		} else {
+			dstHeight = maxSize
+			dstWidth = int(math.Max(1, math.Round(float64(srcWidth)*float64(maxSize)/float64(srcHeight))))
+		}
+	}
+	if dstWidth == srcWidth && dstHeight == srcHeight {
+		return source
+	}
+
+	xWeights := areaWeights(srcWidth, dstWidth)
+	yWeights := areaWeights(srcHeight, dstHeight)
+
+	// Tahap horizontal lalu vertikal, nilai sementara disimpan per kanal rgb
+	horizontal := make([]float64, srcHeight*dstWidth*3)
+	for y := 0; y < srcHeight; y++ {
+		row := source.Pix[y*source.Stride:]
+		for x, weights := range xWeights {
+			var r, g, b float64
+			for _, weight := range weights {
+				offset := weight.index * 4
+				r += float64(row[offset]) * weight.value
+				g += float64(row[offset+1]) * weight.value
+				b += float64(row[offset+2]) * weight.value
+			}
+			offset := (y*dstWidth + x) * 3
+			horizontal[offset] = r
+			horizontal[offset+1] = g
+			horizontal[offset+2] = b
+		}
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y, weights := range yWeights {
+		for x := 0; x < dstWidth; x++ {
+			var r, g, b float64
+			for _, weight := range weights {
+				offset := (weight.index*dstWidth + x) * 3
+				r += horizontal[offset] * weight.value
+				g += horizontal[offset+1] * weight.value
+				b += horizontal[offset+2] * weight.value
+			}
+			offset := y*resized.Stride + x*4
+			resized.Pix[offset] = clampColor(r)
+			resized.Pix[offset+1] = clampColor(g)
+			resized.Pix[offset+2] = clampColor(b)
+			resized.Pix[offset+3] = 255
+		}
+	}
+	return resized
+}
+
+type areaWeight struct {
+	index int
+	value float64
+}
+
+// Bobot setiap piksel asli terhadap piksel tujuan sesuai luas irisannya
+func areaWeights(srcLength int, dstLength int) [][]areaWeight {
+	scale := float64(srcLength) / float64(dstLength)
+	weights := make([][]areaWeight, dstLength)
+	for i := 0; i < dstLength; i++ {
+		start := float64(i) * scale
+		end := start + scale
+		for index := int(start); index < srcLength && float64(index) < end; index++ {
+			value := math.Min(end, float64(index+1)) - math.Max(start, float64(index))
+			if value > 0 {
+				weights[i] = append(weights[i], areaWeight{index: index, value: value / scale})
+			}
+		}
+	}
+	return weights
+}
+
+func clampColor(value float64) uint8 {
+	if value <= 0 {
+		return 0
+	}
+	if value >= 255 {
+		return 255
+	}
+	return uint8(math.Round(value))
+}
@@ -0,0 +1,50 @@
+package media
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Penyimpanan file media, key memakai pemisah "/" dan url yang dikembalikan bisa langsung dipakai aplikasi
+type Storage interface {
+	Save(key string, data []byte) (url string, err error)
+	Delete(key string) error
+}
+
+// Menyimpan file di direktori lokal yang disajikan webserver pada baseUrl
+type LocalStorage struct {
+	Directory string
+	BaseUrl   string
+}
+
+func NewLocalStorage(directory string, baseUrl string) Storage {
+	return &LocalStorage{
+		Directory: directory,
+		BaseUrl:   strings.TrimRight(baseUrl, "/"),
+	}
+}
+
+func (storage *LocalStorage) Save(key string, data []byte) (string, error) {
+	path := storage.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return storage.BaseUrl + "/" + key, nil
+}
+
+// File yang sudah tidak ada dianggap berhasil dihapus
+func (storage *LocalStorage) Delete(key string) error {
+	err := os.Remove(storage.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (storage *LocalStorage) path(key string) string {
+	return filepath.Join(storage.Directory, filepath.FromSlash(filepath.Clean("/"+key)))
+}
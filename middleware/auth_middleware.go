@@ -2,14 +2,18 @@ package middleware
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
 	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
 )
 
+const RoleAdmin = "admin"
+
 func Authentication(configurationJWT config.Jwt) echo.MiddlewareFunc {
 	return middleware.JWTWithConfig(middleware.JWTConfig{
 		Claims:       &modelService.TokenClaims{},
@@ -18,6 +22,17 @@ func Authentication(configurationJWT config.Jwt) echo.MiddlewareFunc {
 	})
 }
 
+// Hanya untuk user dengan role admin, dipasang setelah Authentication
+func AdminAuthorization(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if TokenClaimsIdRole(c) != RoleAdmin {
+			response := response.Response{Code: http.StatusForbidden, Mssg: "Forbidden", Data: []string{}, Error: []string{"forbidden"}}
+			return c.JSON(http.StatusForbidden, response)
+		}
+		return next(c)
+	}
+}
+
 func ErrorHandler(err error) error {
 	fmt.Println(err)
 	return err
@@ -30,6 +45,26 @@ func TokenClaimsIdUser(c echo.Context) (id string) {
 	return idUser
 }
 
+// Id user dari token jika ada, kosong untuk route tanpa token
+func OptionalTokenClaimsIdUser(c echo.Context) (id string) {
+	user, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+	claims, ok := user.Claims.(*modelService.TokenClaims)
+	if !ok {
+		return ""
+	}
+	return claims.Id
+}
+
+func TokenClaimsIdRole(c echo.Context) (idRole string) {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*modelService.TokenClaims)
+	idRole = claims.IdRole
+	return idRole
+}
+
 func TokenClaimsIdKelurahan(c echo.Context) (id int) {
 	user := c.Get("user").(*jwt.Token)
 	claims := user.Claims.(*modelService.TokenClaims)
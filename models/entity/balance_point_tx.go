@@ -8,6 +8,7 @@ type BalancePointTx struct {
 	Id               string    `gorm:"primaryKey;column:id;"`
 	IdBalancePoint   string    `gorm:"column:id_balance_point;"`
 	NoOrder          string    `gorm:"column:no_order;"`
+	IdCampaign       string    `gorm:"column:id_campaign;"`
 	TxType           string    `gorm:"column:tx_type;"`
 	TxDate           time.Time `gorm:"column:tx_date;"`
 	TxNominal        float64   `gorm:"column:tx_nominal;"`
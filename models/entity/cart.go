@@ -3,12 +3,16 @@ package entity
 import "time"
 
 type Cart struct {
-	Id        string    `gorm:"primaryKey;column:id;"`
-	IdUser    string    `gorm:"column:id_user;"`
-	IdProduct string    `gorm:"column:id_product;"`
-	Product   Product   `gorm:"foreignKey:IdProduct"`
-	Qty       int       `gorm:"column:qty;"`
-	CreatedAt time.Time `gorm:"column:created_at;"`
+	Id               string         `gorm:"primaryKey;column:id;"`
+	IdUser           string         `gorm:"column:id_user;"`
+	IdProduct        string         `gorm:"column:id_product;"`
+	Product          Product        `gorm:"foreignKey:IdProduct"`
+	IdProductVariant string         `gorm:"column:id_product_variant;"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:IdProductVariant"`
+	Qty              int            `gorm:"column:qty;"`
+	CreatedAt        time.Time      `gorm:"column:created_at;"`
+	// Diisi service flash sale jika item keranjang mendapat harga flash sale
+	FlashSaleItem *FlashSaleItem `gorm:"-"`
 }
 
 func (Cart) TableName() string {
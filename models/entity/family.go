@@ -1,11 +1,13 @@
 package entity
 
 type Family struct {
-	Id          string `gorm:"primaryKey;column:id;"`
-	IdProvinsi  int    `gorm:"column:id_provinsi;"`
-	IdKabupaten int    `gorm:"column:id_kabupaten;"`
-	IdKecamatan int    `gorm:"column:id_kecamatan;"`
-	IdKelurahan int    `gorm:"column:id_kelurahan;"`
+	Id           string `gorm:"primaryKey;column:id;"`
+	IdUserHead   string `gorm:"column:id_user_head;"`
+	SharedWallet int    `gorm:"column:shared_wallet;"`
+	IdProvinsi   int    `gorm:"column:id_provinsi;"`
+	IdKabupaten  int    `gorm:"column:id_kabupaten;"`
+	IdKecamatan  int    `gorm:"column:id_kecamatan;"`
+	IdKelurahan  int    `gorm:"column:id_kelurahan;"`
 }
 
 func (Family) TableName() string {
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type FamilyInvitation struct {
+	Id            string    `gorm:"primaryKey;column:id;"`
+	IdFamily      string    `gorm:"column:id_family;"`
+	IdUserInviter string    `gorm:"column:id_user_inviter;"`
+	UserInviter   User      `gorm:"foreignKey:IdUserInviter"`
+	IdUserInvitee string    `gorm:"column:id_user_invitee;"`
+	Status        string    `gorm:"column:status;"`
+	RespondedAt   null.Time `gorm:"column:responded_at;"`
+	CreatedDate   time.Time `gorm:"column:created_at;"`
+}
+
+func (FamilyInvitation) TableName() string {
+	return "family_invitation"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type FamilyPointTransfer struct {
+	Id          string    `gorm:"primaryKey;column:id;"`
+	IdFamily    string    `gorm:"column:id_family;"`
+	IdUserFrom  string    `gorm:"column:id_user_from;"`
+	IdUserTo    string    `gorm:"column:id_user_to;"`
+	Nominal     float64   `gorm:"column:nominal;"`
+	Note        string    `gorm:"column:note;"`
+	CreatedDate time.Time `gorm:"column:created_at;"`
+}
+
+func (FamilyPointTransfer) TableName() string {
+	return "family_point_transfer"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type FlashSale struct {
+	Id             string          `gorm:"primaryKey;column:id;"`
+	FlashSaleName  string          `gorm:"column:flash_sale_name;"`
+	StartAt        time.Time       `gorm:"column:start_at;"`
+	EndAt          time.Time       `gorm:"column:end_at;"`
+	IsActive       int             `gorm:"column:is_active;"`
+	CreatedAt      time.Time       `gorm:"column:created_at;"`
+	FlashSaleItems []FlashSaleItem `gorm:"foreignKey:IdFlashSale"`
+}
+
+func (FlashSale) TableName() string {
+	return "flash_sale"
+}
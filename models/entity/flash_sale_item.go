@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type FlashSaleItem struct {
+	Id               string         `gorm:"primaryKey;column:id;"`
+	IdFlashSale      string         `gorm:"column:id_flash_sale;"`
+	FlashSale        FlashSale      `gorm:"foreignKey:IdFlashSale"`
+	IdProduct        string         `gorm:"column:id_product;"`
+	Product          Product        `gorm:"foreignKey:IdProduct"`
+	IdProductVariant string         `gorm:"column:id_product_variant;"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:IdProductVariant"`
+	SalePrice        float64        `gorm:"column:sale_price;"`
+	Quota            int            `gorm:"column:quota;"`
+	SoldQty          int            `gorm:"column:sold_qty;"`
+	LimitPerUser     int            `gorm:"column:limit_per_user;"`
+	CreatedAt        time.Time      `gorm:"column:created_at;"`
+}
+
+func (FlashSaleItem) TableName() string {
+	return "flash_sale_item"
+}
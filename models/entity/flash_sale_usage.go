@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type FlashSaleUsage struct {
+	Id              string    `gorm:"primaryKey;column:id;"`
+	IdFlashSaleItem string    `gorm:"column:id_flash_sale_item;"`
+	IdUser          string    `gorm:"column:id_user;"`
+	IdOrder         string    `gorm:"column:id_order;"`
+	NumberOrder     string    `gorm:"column:number_order;"`
+	Qty             int       `gorm:"column:qty;"`
+	Status          string    `gorm:"column:status;"`
+	UsedAt          time.Time `gorm:"column:used_at;"`
+	ReleasedAt      null.Time `gorm:"column:released_at;"`
+}
+
+func (FlashSaleUsage) TableName() string {
+	return "flash_sale_usage"
+}
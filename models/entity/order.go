@@ -29,6 +29,9 @@ type Order struct {
 	PaymentByPoint          float64   `gorm:"column:payment_by_point;"`
 	PaymentByCash           float64   `gorm:"column:payment_by_cash;"`
 	PaymentFee              float64   `gorm:"column:payment_fee;"`
+	IdVoucher               string    `gorm:"column:id_voucher;"`
+	VoucherCode             string    `gorm:"column:voucher_code;"`
+	VoucherDiscount         float64   `gorm:"column:voucher_discount;"`
 	ShippingMethod          string    `gorm:"column:shipping_method;"`
 	ShippingCost            float64   `gorm:"column:shipping_cost;"`
 	ShippingStatus          string    `gorm:"column:shipping_status;"`
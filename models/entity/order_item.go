@@ -6,6 +6,10 @@ type OrderItem struct {
 	Id                  string    `gorm:"primaryKey;column:id;"`
 	IdOrder             string    `gorm:"column:id_order;"`
 	IdProduct           string    `gorm:"column:id_product;"`
+	IdProductVariant    string    `gorm:"column:id_product_variant;"`
+	IdPromotion         string    `gorm:"column:id_promotion;"`
+	IdFlashSaleItem     string    `gorm:"column:id_flash_sale_item;"`
+	VariantName         string    `gorm:"column:variant_name;"`
 	NoSku               string    `gorm:"column:no_sku;"`
 	ProductName         string    `gorm:"column:product_name;"`
 	PictureUrl          string    `gorm:"column:picture_url;"`
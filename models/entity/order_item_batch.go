@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type OrderItemBatch struct {
+	Id             string       `gorm:"primaryKey;column:id;"`
+	IdOrder        string       `gorm:"column:id_order;"`
+	Order          Order        `gorm:"foreignKey:IdOrder"`
+	IdOrderItem    string       `gorm:"column:id_order_item;"`
+	IdProductBatch string       `gorm:"column:id_product_batch;"`
+	ProductBatch   ProductBatch `gorm:"foreignKey:IdProductBatch"`
+	Qty            int          `gorm:"column:qty;"`
+	CreatedAt      time.Time    `gorm:"column:created_at;"`
+}
+
+func (OrderItemBatch) TableName() string {
+	return "orders_items_batch"
+}
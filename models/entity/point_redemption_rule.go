@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type PointRedemptionRule struct {
+	Id            string    `gorm:"primaryKey;column:id;"`
+	RuleName      string    `gorm:"column:rule_name;"`
+	RuleType      string    `gorm:"column:rule_type;"`
+	IdLevelMember int       `gorm:"column:id_level_member;"`
+	RefId         string    `gorm:"column:ref_id;"`
+	Value         float64   `gorm:"column:value;"`
+	Period        string    `gorm:"column:period;"`
+	IsActive      int       `gorm:"column:is_active;"`
+	CreatedDate   time.Time `gorm:"column:created_at;"`
+}
+
+func (PointRedemptionRule) TableName() string {
+	return "point_redemption_rule"
+}
@@ -1,20 +1,41 @@
 package entity
 
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
 type Product struct {
-	Id              string          `gorm:"primaryKey;column:id;"`
-	NoSku           string          `gorm:"column:no_sku;"`
-	ProductName     string          `gorm:"column:product_name;"`
-	Price           float64         `gorm:"column:price;"`
-	Description     string          `gorm:"column:description;"`
-	Weight          float64         `gorm:"column:weight;"`
-	Volume          float64         `gorm:"column:volume;"`
-	PictureUrl      string          `gorm:"column:picture_url;"`
-	Thumbnail       string          `gorm:"column:thumbnail;"`
-	Stock           int             `gorm:"column:stock;"`
-	IdCategory      int             `gorm:"column:id_category;"`
-	IdSubCategory   int             `gorm:"column:id_sub_category;"`
-	ProductCategory ProductCategory `gorm:"foreignKey:IdCategory"`
-	ProductDiscount ProductDiscount `gorm:"foreignKey:IdProduct"`
+	Id                     string                  `gorm:"primaryKey;column:id;"`
+	NoSku                  string                  `gorm:"column:no_sku;"`
+	ProductName            string                  `gorm:"column:product_name;"`
+	Price                  float64                 `gorm:"column:price;"`
+	Description            string                  `gorm:"column:description;"`
+	Weight                 float64                 `gorm:"column:weight;"`
+	Volume                 float64                 `gorm:"column:volume;"`
+	PictureUrl             string                  `gorm:"column:picture_url;"`
+	Thumbnail              string                  `gorm:"column:thumbnail;"`
+	Stock                  int                     `gorm:"column:stock;"`
+	ReorderPoint           int                     `gorm:"column:reorder_point;"`
+	StockAlertLevel        string                  `gorm:"column:stock_alert_level;"`
+	MaxQtyPerOrder         int                     `gorm:"column:max_qty_per_order;"`
+	MaxQtyPerUser          int                     `gorm:"column:max_qty_per_user;"`
+	MaxQtyPeriodDays       int                     `gorm:"column:max_qty_period_days;"`
+	IdCategory             int                     `gorm:"column:id_category;"`
+	IdSubCategory          int                     `gorm:"column:id_sub_category;"`
+	IdBrand                string                  `gorm:"column:id_brand;"`
+	Published              string                  `gorm:"column:published;"`
+	ArchivedAt             null.Time               `gorm:"column:archived_at;"`
+	CreatedAt              time.Time               `gorm:"column:created_at;"`
+	ProductCategory        ProductCategory         `gorm:"foreignKey:IdCategory"`
+	ProductSubCategory     ProductSubCategory      `gorm:"foreignKey:IdSubCategory"`
+	ProductDiscounts       []ProductDiscount       `gorm:"foreignKey:IdProduct"`
+	ProductBrand           ProductBrand            `gorm:"foreignKey:IdBrand"`
+	ProductOptions         []ProductOption         `gorm:"foreignKey:IdProduct"`
+	ProductVariants        []ProductVariant        `gorm:"foreignKey:IdProduct"`
+	ProductImages          []ProductImage          `gorm:"foreignKey:IdProduct"`
+	ProductAttributeValues []ProductAttributeValue `gorm:"foreignKey:IdProduct"`
 }
 
 func (Product) TableName() string {
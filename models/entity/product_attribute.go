@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type ProductAttribute struct {
+	Id                      string                   `gorm:"primaryKey;column:id;"`
+	AttributeCode           string                   `gorm:"column:attribute_code;"`
+	AttributeName           string                   `gorm:"column:attribute_name;"`
+	AttributeType           string                   `gorm:"column:attribute_type;"`
+	Unit                    string                   `gorm:"column:unit;"`
+	IsFilterable            int                      `gorm:"column:is_filterable;"`
+	Position                int                      `gorm:"column:position;"`
+	CreatedAt               time.Time                `gorm:"column:created_at;"`
+	ProductAttributeOptions []ProductAttributeOption `gorm:"foreignKey:IdProductAttribute"`
+}
+
+func (ProductAttribute) TableName() string {
+	return "products_attribute"
+}
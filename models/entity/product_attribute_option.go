@@ -0,0 +1,12 @@
+package entity
+
+type ProductAttributeOption struct {
+	Id                 string `gorm:"primaryKey;column:id;"`
+	IdProductAttribute string `gorm:"column:id_product_attribute;"`
+	Value              string `gorm:"column:value;"`
+	Position           int    `gorm:"column:position;"`
+}
+
+func (ProductAttributeOption) TableName() string {
+	return "products_attribute_option"
+}
@@ -0,0 +1,15 @@
+package entity
+
+// Satu baris per nilai, atribut multi_select bisa punya beberapa baris untuk produk yang sama
+type ProductAttributeValue struct {
+	Id                 string           `gorm:"primaryKey;column:id;"`
+	IdProduct          string           `gorm:"column:id_product;"`
+	IdProductAttribute string           `gorm:"column:id_product_attribute;"`
+	ProductAttribute   ProductAttribute `gorm:"foreignKey:IdProductAttribute"`
+	Value              string           `gorm:"column:value;"`
+	Position           int              `gorm:"column:position;"`
+}
+
+func (ProductAttributeValue) TableName() string {
+	return "products_attribute_value"
+}
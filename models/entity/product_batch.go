@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// Lot barang dengan tanggal kadaluarsa, RemainingQty berkurang saat item order dialokasikan ke batch
+type ProductBatch struct {
+	Id               string         `gorm:"primaryKey;column:id;"`
+	IdProduct        string         `gorm:"column:id_product;"`
+	Product          Product        `gorm:"foreignKey:IdProduct"`
+	IdProductVariant string         `gorm:"column:id_product_variant;"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:IdProductVariant"`
+	BatchNumber      string         `gorm:"column:batch_number;"`
+	ExpiryDate       time.Time      `gorm:"column:expiry_date;"`
+	ReceivedQty      int            `gorm:"column:received_qty;"`
+	RemainingQty     int            `gorm:"column:remaining_qty;"`
+	Supplier         string         `gorm:"column:supplier;"`
+	Reference        string         `gorm:"column:reference;"`
+	ExpiredAt        null.Time      `gorm:"column:expired_at;"`
+	CreatedAt        time.Time      `gorm:"column:created_at;"`
+}
+
+func (ProductBatch) TableName() string {
+	return "products_batch"
+}
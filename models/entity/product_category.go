@@ -1,8 +1,15 @@
 package entity
 
+import "time"
+
 type ProductCategory struct {
-	Id           string `gorm:"primaryKey;column:id;"`
-	CategoryName string `gorm:"column:category_name;"`
+	Id                   int                  `gorm:"primaryKey;autoIncrement;column:id;"`
+	CategoryName         string               `gorm:"column:category_name;"`
+	Icon                 string               `gorm:"column:icon;"`
+	SortOrder            int                  `gorm:"column:sort_order;"`
+	IsActive             int                  `gorm:"column:is_active;"`
+	CreatedAt            time.Time            `gorm:"column:created_at;"`
+	ProductSubCategories []ProductSubCategory `gorm:"foreignKey:IdCategory"`
 }
 
 func (ProductCategory) TableName() string {
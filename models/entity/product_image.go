@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type ProductImage struct {
+	Id           string    `gorm:"primaryKey;column:id;"`
+	IdProduct    string    `gorm:"column:id_product;"`
+	FileKey      string    `gorm:"column:file_key;"`
+	ThumbnailUrl string    `gorm:"column:thumbnail_url;"`
+	MediumUrl    string    `gorm:"column:medium_url;"`
+	LargeUrl     string    `gorm:"column:large_url;"`
+	Width        int       `gorm:"column:width;"`
+	Height       int       `gorm:"column:height;"`
+	Position     int       `gorm:"column:position;"`
+	CreatedAt    time.Time `gorm:"column:created_at;"`
+}
+
+func (ProductImage) TableName() string {
+	return "products_image"
+}
@@ -0,0 +1,13 @@
+package entity
+
+type ProductOption struct {
+	Id                  string               `gorm:"primaryKey;column:id;"`
+	IdProduct           string               `gorm:"column:id_product;"`
+	OptionName          string               `gorm:"column:option_name;"`
+	Position            int                  `gorm:"column:position;"`
+	ProductOptionValues []ProductOptionValue `gorm:"foreignKey:IdProductOption"`
+}
+
+func (ProductOption) TableName() string {
+	return "products_option"
+}
@@ -0,0 +1,12 @@
+package entity
+
+type ProductOptionValue struct {
+	Id              string `gorm:"primaryKey;column:id;"`
+	IdProductOption string `gorm:"column:id_product_option;"`
+	Value           string `gorm:"column:value;"`
+	Position        int    `gorm:"column:position;"`
+}
+
+func (ProductOptionValue) TableName() string {
+	return "products_option_value"
+}
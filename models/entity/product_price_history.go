@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Harga jual adalah harga setelah diskon yang berlaku saat perubahan dicatat
+type ProductPriceHistory struct {
+	Id              string    `gorm:"primaryKey;column:id;"`
+	IdProduct       string    `gorm:"column:id_product;"`
+	OldPrice        float64   `gorm:"column:old_price;"`
+	NewPrice        float64   `gorm:"column:new_price;"`
+	OldSellingPrice float64   `gorm:"column:old_selling_price;"`
+	NewSellingPrice float64   `gorm:"column:new_selling_price;"`
+	OldDiscount     string    `gorm:"column:old_discount;"`
+	NewDiscount     string    `gorm:"column:new_discount;"`
+	Source          string    `gorm:"column:source;"`
+	CreatedBy       string    `gorm:"column:created_by;"`
+	CreatedAt       time.Time `gorm:"column:created_at;"`
+}
+
+func (ProductPriceHistory) TableName() string {
+	return "products_price_history"
+}
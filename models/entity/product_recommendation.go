@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type ProductRecommendation struct {
+	Id                   string    `gorm:"primaryKey;column:id;"`
+	IdProduct            string    `gorm:"column:id_product;"`
+	IdProductRecommended string    `gorm:"column:id_product_recommended;"`
+	RecommendationType   string    `gorm:"column:recommendation_type;"`
+	Score                float64   `gorm:"column:score;"`
+	CreatedAt            time.Time `gorm:"column:created_at;"`
+}
+
+func (ProductRecommendation) TableName() string {
+	return "product_recommendation"
+}
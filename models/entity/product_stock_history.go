@@ -3,14 +3,21 @@ package entity
 import "time"
 
 type ProductStockHistory struct {
-	IdProduct   string    `gorm:"column:id_product;"`
-	TxDate      time.Time `gorm:"column:tx_date;"`
-	StockInQty  int       `gorm:"column:stock_in_qty;"`
-	StockOutQty int       `gorm:"column:stock_out_qty;"`
-	StockOpname int       `gorm:"column:stock_opname;"`
-	StockFinal  int       `gorm:"column:stock_final;"`
-	Description string    `gorm:"column:description;"`
-	CreatedAt   time.Time `gorm:"column:created_at;"`
+	IdProduct        string    `gorm:"column:id_product;"`
+	IdProductVariant string    `gorm:"column:id_product_variant;"`
+	TxDate           time.Time `gorm:"column:tx_date;"`
+	StockInQty       int       `gorm:"column:stock_in_qty;"`
+	StockOutQty      int       `gorm:"column:stock_out_qty;"`
+	StockOpname      int       `gorm:"column:stock_opname;"`
+	StockFinal       int       `gorm:"column:stock_final;"`
+	Description      string    `gorm:"column:description;"`
+	TxType           string    `gorm:"column:tx_type;"`
+	ReasonCode       string    `gorm:"column:reason_code;"`
+	Supplier         string    `gorm:"column:supplier;"`
+	Reference        string    `gorm:"column:reference;"`
+	BatchNumber      string    `gorm:"column:batch_number;"`
+	CreatedBy        string    `gorm:"column:created_by;"`
+	CreatedAt        time.Time `gorm:"column:created_at;"`
 }
 
 func (ProductStockHistory) TableName() string {
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type ProductSubCategory struct {
+	Id              int       `gorm:"primaryKey;autoIncrement;column:id;"`
+	IdCategory      int       `gorm:"column:id_category;"`
+	SubCategoryName string    `gorm:"column:sub_category_name;"`
+	Icon            string    `gorm:"column:icon;"`
+	SortOrder       int       `gorm:"column:sort_order;"`
+	IsActive        int       `gorm:"column:is_active;"`
+	CreatedAt       time.Time `gorm:"column:created_at;"`
+}
+
+func (ProductSubCategory) TableName() string {
+	return "products_sub_category"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type ProductVariant struct {
+	Id                  string               `gorm:"primaryKey;column:id;"`
+	IdProduct           string               `gorm:"column:id_product;"`
+	NoSku               string               `gorm:"column:no_sku;"`
+	VariantName         string               `gorm:"column:variant_name;"`
+	Price               float64              `gorm:"column:price;"`
+	Stock               int                  `gorm:"column:stock;"`
+	Weight              float64              `gorm:"column:weight;"`
+	Volume              float64              `gorm:"column:volume;"`
+	PictureUrl          string               `gorm:"column:picture_url;"`
+	Thumbnail           string               `gorm:"column:thumbnail;"`
+	IsActive            int                  `gorm:"column:is_active;"`
+	CreatedAt           time.Time            `gorm:"column:created_at;"`
+	ProductOptionValues []ProductOptionValue `gorm:"many2many:products_variant_option_value;joinForeignKey:IdProductVariant;joinReferences:IdProductOptionValue"`
+}
+
+func (ProductVariant) TableName() string {
+	return "products_variant"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type Promotion struct {
+	Id             string          `gorm:"primaryKey;column:id;"`
+	PromotionName  string          `gorm:"column:promotion_name;"`
+	PromotionType  string          `gorm:"column:promotion_type;"`
+	BuyQty         int             `gorm:"column:buy_qty;"`
+	GetQty         int             `gorm:"column:get_qty;"`
+	IdProductGet   string          `gorm:"column:id_product_get;"`
+	BundlePrice    float64         `gorm:"column:bundle_price;"`
+	Priority       int             `gorm:"column:priority;"`
+	StartDate      time.Time       `gorm:"column:start_date;"`
+	EndDate        time.Time       `gorm:"column:end_date;"`
+	IsActive       int             `gorm:"column:is_active;"`
+	CreatedAt      time.Time       `gorm:"column:created_at;"`
+	PromotionItems []PromotionItem `gorm:"foreignKey:IdPromotion"`
+	PromotionTiers []PromotionTier `gorm:"foreignKey:IdPromotion"`
+}
+
+func (Promotion) TableName() string {
+	return "promotion"
+}
@@ -0,0 +1,12 @@
+package entity
+
+type PromotionItem struct {
+	Id          string `gorm:"primaryKey;column:id;"`
+	IdPromotion string `gorm:"column:id_promotion;"`
+	IdProduct   string `gorm:"column:id_product;"`
+	Qty         int    `gorm:"column:qty;"`
+}
+
+func (PromotionItem) TableName() string {
+	return "promotion_item"
+}
@@ -0,0 +1,12 @@
+package entity
+
+type PromotionTier struct {
+	Id          string  `gorm:"primaryKey;column:id;"`
+	IdPromotion string  `gorm:"column:id_promotion;"`
+	MinQty      int     `gorm:"column:min_qty;"`
+	Percentage  float64 `gorm:"column:percentage;"`
+}
+
+func (PromotionTier) TableName() string {
+	return "promotion_tier"
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type ReferalBonusReview struct {
+	Id            string    `gorm:"primaryKey;column:id;"`
+	IdUser        string    `gorm:"column:id_user;"`
+	User          User      `gorm:"foreignKey:IdUser"`
+	IdUserReferal string    `gorm:"column:id_user_referal;"`
+	UserReferal   User      `gorm:"foreignKey:IdUserReferal"`
+	Event         string    `gorm:"column:event;"`
+	NoOrder       string    `gorm:"column:no_order;"`
+	IdCampaign    string    `gorm:"column:id_campaign;"`
+	BonusUser     float64   `gorm:"column:bonus_user;"`
+	BonusReferal  float64   `gorm:"column:bonus_referal;"`
+	FraudScore    int       `gorm:"column:fraud_score;"`
+	FraudReasons  string    `gorm:"column:fraud_reasons;"`
+	Status        string    `gorm:"column:status;"`
+	ReviewedBy    string    `gorm:"column:reviewed_by;"`
+	ReviewNote    string    `gorm:"column:review_note;"`
+	ReviewedAt    null.Time `gorm:"column:reviewed_at;"`
+	CreatedDate   time.Time `gorm:"column:created_at;"`
+}
+
+func (ReferalBonusReview) TableName() string {
+	return "referal_bonus_review"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type RegistrationCampaign struct {
+	Id                  string    `gorm:"primaryKey;column:id;"`
+	CampaignName        string    `gorm:"column:campaign_name;"`
+	StartDate           time.Time `gorm:"column:start_date;"`
+	EndDate             time.Time `gorm:"column:end_date;"`
+	BonusNewUser        float64   `gorm:"column:bonus_new_user;"`
+	BonusReferrer       float64   `gorm:"column:bonus_referrer;"`
+	ReferalCodes        string    `gorm:"column:referal_codes;"`
+	ReferalCodePrefixes string    `gorm:"column:referal_code_prefixes;"`
+	MaxRedemption       int       `gorm:"column:max_redemption;"`
+	TotalRedemption     int       `gorm:"column:total_redemption;"`
+	IsActive            int       `gorm:"column:is_active;"`
+	CreatedDate         time.Time `gorm:"column:created_at;"`
+}
+
+func (RegistrationCampaign) TableName() string {
+	return "registration_campaign"
+}
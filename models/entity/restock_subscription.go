@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type RestockSubscription struct {
+	Id               string         `gorm:"primaryKey;column:id;"`
+	IdUser           string         `gorm:"column:id_user;"`
+	User             User           `gorm:"foreignKey:IdUser"`
+	IdProduct        string         `gorm:"column:id_product;"`
+	Product          Product        `gorm:"foreignKey:IdProduct"`
+	IdProductVariant string         `gorm:"column:id_product_variant;"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:IdProductVariant"`
+	CreatedAt        time.Time      `gorm:"column:created_at;"`
+}
+
+func (RestockSubscription) TableName() string {
+	return "restock_subscription"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Satu pencarian produk, term sudah dinormalisasi agar pencarian yang sama terkumpul di laporan.
+// IdUser kosong untuk pencarian tanpa login
+type SearchLog struct {
+	Id          string    `gorm:"primaryKey;column:id;"`
+	IdUser      string    `gorm:"column:id_user;"`
+	Term        string    `gorm:"column:term;"`
+	Query       string    `gorm:"column:query;"`
+	ResultCount int64     `gorm:"column:result_count;"`
+	ClickCount  int       `gorm:"column:click_count;"`
+	AddedToCart int       `gorm:"column:added_to_cart;"`
+	CreatedAt   time.Time `gorm:"column:created_at;"`
+}
+
+func (SearchLog) TableName() string {
+	return "products_search_log"
+}
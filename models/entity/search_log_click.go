@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// Produk yang dibuka dari hasil pencarian, AddedToCartAt terisi saat produk tersebut masuk keranjang
+type SearchLogClick struct {
+	Id            string    `gorm:"primaryKey;column:id;"`
+	IdSearchLog   string    `gorm:"column:id_search_log;"`
+	IdUser        string    `gorm:"column:id_user;"`
+	IdProduct     string    `gorm:"column:id_product;"`
+	ClickedAt     time.Time `gorm:"column:clicked_at;"`
+	AddedToCartAt null.Time `gorm:"column:added_to_cart_at;"`
+}
+
+func (SearchLogClick) TableName() string {
+	return "products_search_click"
+}
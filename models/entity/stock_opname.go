@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type StockOpname struct {
+	Id               string            `gorm:"primaryKey;column:id;"`
+	NoOpname         string            `gorm:"column:no_opname;"`
+	Description      string            `gorm:"column:description;"`
+	IdCategory       int               `gorm:"column:id_category;"`
+	Status           string            `gorm:"column:status;"`
+	CreatedBy        string            `gorm:"column:created_by;"`
+	CreatedAt        time.Time         `gorm:"column:created_at;"`
+	PostedAt         null.Time         `gorm:"column:posted_at;"`
+	StockOpnameItems []StockOpnameItem `gorm:"foreignKey:IdStockOpname"`
+}
+
+func (StockOpname) TableName() string {
+	return "stock_opname"
+}
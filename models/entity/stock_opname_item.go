@@ -0,0 +1,20 @@
+package entity
+
+import "gopkg.in/guregu/null.v4"
+
+type StockOpnameItem struct {
+	Id               string         `gorm:"primaryKey;column:id;"`
+	IdStockOpname    string         `gorm:"column:id_stock_opname;"`
+	IdProduct        string         `gorm:"column:id_product;"`
+	Product          Product        `gorm:"foreignKey:IdProduct"`
+	IdProductVariant string         `gorm:"column:id_product_variant;"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:IdProductVariant"`
+	SystemQty        int            `gorm:"column:system_qty;"`
+	CountedQty       null.Int       `gorm:"column:counted_qty;"`
+	DifferenceQty    int            `gorm:"column:difference_qty;"`
+	CountedAt        null.Time      `gorm:"column:counted_at;"`
+}
+
+func (StockOpnameItem) TableName() string {
+	return "stock_opname_item"
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type UserRecommendation struct {
+	Id                 string    `gorm:"primaryKey;column:id;"`
+	IdUser             string    `gorm:"column:id_user;"`
+	IdProduct          string    `gorm:"column:id_product;"`
+	RecommendationType string    `gorm:"column:recommendation_type;"`
+	Score              float64   `gorm:"column:score;"`
+	LastOrderedAt      time.Time `gorm:"column:last_ordered_at;"`
+	CreatedAt          time.Time `gorm:"column:created_at;"`
+}
+
+func (UserRecommendation) TableName() string {
+	return "user_recommendation"
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type Voucher struct {
+	Id                string        `gorm:"primaryKey;column:id;"`
+	VoucherCode       string        `gorm:"column:voucher_code;"`
+	VoucherName       string        `gorm:"column:voucher_name;"`
+	Description       string        `gorm:"column:description;"`
+	VoucherType       string        `gorm:"column:voucher_type;"`
+	Value             float64       `gorm:"column:value;"`
+	MinSpend          float64       `gorm:"column:min_spend;"`
+	MaxDiscount       float64       `gorm:"column:max_discount;"`
+	UsageLimit        int           `gorm:"column:usage_limit;"`
+	UsageLimitPerUser int           `gorm:"column:usage_limit_per_user;"`
+	UsageCount        int           `gorm:"column:usage_count;"`
+	StartDate         time.Time     `gorm:"column:start_date;"`
+	EndDate           time.Time     `gorm:"column:end_date;"`
+	IsActive          int           `gorm:"column:is_active;"`
+	CreatedAt         time.Time     `gorm:"column:created_at;"`
+	VoucherRules      []VoucherRule `gorm:"foreignKey:IdVoucher"`
+}
+
+func (Voucher) TableName() string {
+	return "voucher"
+}
@@ -0,0 +1,12 @@
+package entity
+
+type VoucherRule struct {
+	Id        string `gorm:"primaryKey;column:id;"`
+	IdVoucher string `gorm:"column:id_voucher;"`
+	RuleType  string `gorm:"column:rule_type;"`
+	RefId     string `gorm:"column:ref_id;"`
+}
+
+func (VoucherRule) TableName() string {
+	return "voucher_rule"
+}
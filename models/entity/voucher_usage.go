@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type VoucherUsage struct {
+	Id              string    `gorm:"primaryKey;column:id;"`
+	IdVoucher       string    `gorm:"column:id_voucher;"`
+	IdUser          string    `gorm:"column:id_user;"`
+	IdOrder         string    `gorm:"column:id_order;"`
+	NumberOrder     string    `gorm:"column:number_order;"`
+	VoucherDiscount float64   `gorm:"column:voucher_discount;"`
+	Status          string    `gorm:"column:status;"`
+	UsedAt          time.Time `gorm:"column:used_at;"`
+	ReleasedAt      null.Time `gorm:"column:released_at;"`
+}
+
+func (VoucherUsage) TableName() string {
+	return "voucher_usage"
+}
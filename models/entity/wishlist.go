@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type Wishlist struct {
+	Id            string    `gorm:"primaryKey;column:id;"`
+	IdUser        string    `gorm:"column:id_user;"`
+	User          User      `gorm:"foreignKey:IdUser"`
+	IdProduct     string    `gorm:"column:id_product;"`
+	Product       Product   `gorm:"foreignKey:IdProduct"`
+	ProductName   string    `gorm:"column:product_name;"`
+	Price         float64   `gorm:"column:price;"`
+	PictureUrl    string    `gorm:"column:picture_url;"`
+	Thumbnail     string    `gorm:"column:thumbnail;"`
+	NotifiedPrice float64   `gorm:"column:notified_price;"`
+	NotifiedPromo int       `gorm:"column:notified_promo;"`
+	CreatedAt     time.Time `gorm:"column:created_at;"`
+}
+
+func (Wishlist) TableName() string {
+	return "wishlist"
+}
@@ -0,0 +1,108 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Id diisi saat update. Stok hanya dipakai saat create, setelah itu stok berubah lewat stok masuk.
+// Batas pembelian 0 berarti tanpa batas, periode 0 berarti batas per user dihitung dari semua order
+type AdminProductRequest struct {
+	Id               string  `json:"id" form:"id"`
+	NoSku            string  `json:"no_sku" form:"no_sku" validate:"required,max=50"`
+	ProductName      string  `json:"product_name" form:"product_name" validate:"required,max=200"`
+	Price            float64 `json:"price" form:"price" validate:"gt=0"`
+	Description      string  `json:"description" form:"description"`
+	Weight           float64 `json:"weight" form:"weight" validate:"min=0"`
+	Volume           float64 `json:"volume" form:"volume" validate:"min=0"`
+	Stock            int     `json:"stock" form:"stock" validate:"min=0"`
+	ReorderPoint     int     `json:"reorder_point" form:"reorder_point" validate:"min=0"`
+	MaxQtyPerOrder   int     `json:"max_qty_per_order" form:"max_qty_per_order" validate:"min=0"`
+	MaxQtyPerUser    int     `json:"max_qty_per_user" form:"max_qty_per_user" validate:"min=0"`
+	MaxQtyPeriodDays int     `json:"max_qty_period_days" form:"max_qty_period_days" validate:"min=0,max=365"`
+	IdCategory       int     `json:"id_category" form:"id_category" validate:"required"`
+	IdSubCategory    int     `json:"id_sub_category" form:"id_sub_category"`
+	IdBrand          string  `json:"id_brand" form:"id_brand"`
+}
+
+type AdminProductIdRequest struct {
+	Id string `json:"id" query:"id" form:"id" validate:"required"`
+}
+
+// Status kosong berarti semua produk termasuk yang diarsipkan
+type AdminProductListRequest struct {
+	Status  string `json:"status" query:"status" validate:"omitempty,oneof=published unpublished archived"`
+	Keyword string `json:"keyword" query:"keyword" validate:"omitempty,max=100"`
+	Page    int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit   int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type AdminProductExportRequest struct {
+	Status string `json:"status" query:"status" validate:"omitempty,oneof=published unpublished archived"`
+	Format string `json:"format" query:"format" validate:"omitempty,oneof=csv xlsx"`
+}
+
+// File csv atau xlsx dikirim sebagai multipart dengan field file
+type AdminProductImportRequest struct {
+	DryRun bool `json:"dry_run" form:"dry_run"`
+}
+
+func ReadFromAdminProductRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (adminProduct *AdminProductRequest) {
+	adminProductRequest := new(AdminProductRequest)
+	if err := c.Bind(adminProductRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	adminProduct = adminProductRequest
+	return adminProduct
+}
+
+func ReadFromAdminProductIdRequest(c echo.Context, requestId string, logger *logrus.Logger) (adminProductId *AdminProductIdRequest) {
+	adminProductIdRequest := new(AdminProductIdRequest)
+	if err := c.Bind(adminProductIdRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	adminProductId = adminProductIdRequest
+	return adminProductId
+}
+
+func ReadFromAdminProductListRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (adminProductList *AdminProductListRequest) {
+	adminProductListRequest := new(AdminProductListRequest)
+	if err := c.Bind(adminProductListRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	adminProductList = adminProductListRequest
+	return adminProductList
+}
+
+func ReadFromAdminProductExportRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (adminProductExport *AdminProductExportRequest) {
+	adminProductExportRequest := new(AdminProductExportRequest)
+	if err := c.Bind(adminProductExportRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	adminProductExport = adminProductExportRequest
+	return adminProductExport
+}
+
+func ReadFromAdminProductImportRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (adminProductImport *AdminProductImportRequest) {
+	adminProductImportRequest := new(AdminProductImportRequest)
+	if err := c.Bind(adminProductImportRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	adminProductImport = adminProductImportRequest
+	return adminProductImport
+}
+
+func ValidateAdminProductRequest(validate *validator.Validate, adminProduct interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(adminProduct)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,38 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type BalancePointStatementRequest struct {
+	DateFrom string `json:"date_from" query:"date_from"`
+	DateTo   string `json:"date_to" query:"date_to"`
+	TxType   string `json:"tx_type" query:"tx_type" validate:"omitempty,oneof=debit credit referal"`
+	Page     int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit    int    `json:"limit" query:"limit" validate:"omitempty,min=1"`
+}
+
+func ReadFromBalancePointStatementRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (balancePointStatement *BalancePointStatementRequest) {
+	balancePointStatementRequest := new(BalancePointStatementRequest)
+	if err := c.Bind(balancePointStatementRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	balancePointStatement = balancePointStatementRequest
+	return balancePointStatement
+}
+
+func ValidateBalancePointStatementRequest(validate *validator.Validate, balancePointStatement *BalancePointStatementRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(balancePointStatement)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
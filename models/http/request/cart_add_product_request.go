@@ -8,8 +8,9 @@ import (
 )
 
 type AddProductToCartRequest struct {
-	IdProduct string `json:"full_name" form:"id_product" validate:"required"`
-	Qty       int    `json:"email" form:"qty" validate:"required"`
+	IdProduct        string `json:"full_name" form:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant"`
+	Qty              int    `json:"email" form:"qty" validate:"required"`
 }
 
 func ReadFromAddProductToCartRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (addProductToCart *AddProductToCartRequest) {
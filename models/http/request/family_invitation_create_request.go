@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type CreateFamilyInvitationRequest struct {
+	Credential string `json:"credential" form:"credential" validate:"required"`
+}
+
+func ReadFromCreateFamilyInvitationRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (createFamilyInvitation *CreateFamilyInvitationRequest) {
+	createFamilyInvitationRequest := new(CreateFamilyInvitationRequest)
+	if err := c.Bind(createFamilyInvitationRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	createFamilyInvitation = createFamilyInvitationRequest
+	return createFamilyInvitation
+}
+
+func ValidateCreateFamilyInvitationRequest(validate *validator.Validate, createFamilyInvitation *CreateFamilyInvitationRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(createFamilyInvitation)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type RespondFamilyInvitationRequest struct {
+	IdInvitation string `json:"id_invitation" form:"id_invitation" validate:"required"`
+}
+
+func ReadFromRespondFamilyInvitationRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (respondFamilyInvitation *RespondFamilyInvitationRequest) {
+	respondFamilyInvitationRequest := new(RespondFamilyInvitationRequest)
+	if err := c.Bind(respondFamilyInvitationRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	respondFamilyInvitation = respondFamilyInvitationRequest
+	return respondFamilyInvitation
+}
+
+func ValidateRespondFamilyInvitationRequest(validate *validator.Validate, respondFamilyInvitation *RespondFamilyInvitationRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(respondFamilyInvitation)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type FamilyPointTransferRequest struct {
+	IdUserTo string  `json:"id_user_to" form:"id_user_to" validate:"required"`
+	Nominal  float64 `json:"nominal" form:"nominal" validate:"required,gt=0"`
+	Note     string  `json:"note" form:"note" validate:"omitempty,max=100"`
+}
+
+func ReadFromFamilyPointTransferRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (familyPointTransfer *FamilyPointTransferRequest) {
+	familyPointTransferRequest := new(FamilyPointTransferRequest)
+	if err := c.Bind(familyPointTransferRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	familyPointTransfer = familyPointTransferRequest
+	return familyPointTransfer
+}
+
+func ValidateFamilyPointTransferRequest(validate *validator.Validate, familyPointTransfer *FamilyPointTransferRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(familyPointTransfer)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
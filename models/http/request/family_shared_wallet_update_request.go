@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type UpdateFamilySharedWalletRequest struct {
+	SharedWallet int `json:"shared_wallet" form:"shared_wallet" validate:"oneof=0 1"`
+}
+
+func ReadFromUpdateFamilySharedWalletRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (updateFamilySharedWallet *UpdateFamilySharedWalletRequest) {
+	updateFamilySharedWalletRequest := new(UpdateFamilySharedWalletRequest)
+	if err := c.Bind(updateFamilySharedWalletRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	updateFamilySharedWallet = updateFamilySharedWalletRequest
+	return updateFamilySharedWallet
+}
+
+func ValidateUpdateFamilySharedWalletRequest(validate *validator.Validate, updateFamilySharedWallet *UpdateFamilySharedWalletRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(updateFamilySharedWallet)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Waktu mulai dan berakhir dengan format 2006-01-02 15:04:05
+type FlashSaleCreateRequest struct {
+	FlashSaleName string                 `json:"flash_sale_name" form:"flash_sale_name" validate:"required,max=100"`
+	StartAt       string                 `json:"start_at" form:"start_at" validate:"required"`
+	EndAt         string                 `json:"end_at" form:"end_at" validate:"required"`
+	Items         []FlashSaleItemRequest `json:"items" form:"items" validate:"required,min=1,dive"`
+}
+
+// LimitPerUser 0 berarti tanpa batas per user
+type FlashSaleItemRequest struct {
+	IdProduct        string  `json:"id_product" form:"id_product" validate:"required"`
+	IdProductVariant string  `json:"id_product_variant" form:"id_product_variant"`
+	SalePrice        float64 `json:"sale_price" form:"sale_price" validate:"gt=0"`
+	Quota            int     `json:"quota" form:"quota" validate:"min=1"`
+	LimitPerUser     int     `json:"limit_per_user" form:"limit_per_user" validate:"min=0"`
+}
+
+type FlashSaleIdRequest struct {
+	Id string `json:"id" query:"id" form:"id" validate:"required"`
+}
+
+type FlashSaleListRequest struct {
+	Page  int `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit int `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func ReadFromFlashSaleCreateRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (flashSaleCreate *FlashSaleCreateRequest) {
+	flashSaleCreateRequest := new(FlashSaleCreateRequest)
+	if err := c.Bind(flashSaleCreateRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	flashSaleCreate = flashSaleCreateRequest
+	return flashSaleCreate
+}
+
+func ReadFromFlashSaleIdRequest(c echo.Context, requestId string, logger *logrus.Logger) (flashSaleId *FlashSaleIdRequest) {
+	flashSaleIdRequest := new(FlashSaleIdRequest)
+	if err := c.Bind(flashSaleIdRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	flashSaleId = flashSaleIdRequest
+	return flashSaleId
+}
+
+func ReadFromFlashSaleListRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (flashSaleList *FlashSaleListRequest) {
+	flashSaleListRequest := new(FlashSaleListRequest)
+	if err := c.Bind(flashSaleListRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	flashSaleList = flashSaleListRequest
+	return flashSaleList
+}
+
+func ValidateFlashSaleRequest(validate *validator.Validate, flashSale interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(flashSale)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
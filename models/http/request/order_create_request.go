@@ -17,6 +17,7 @@ type CreateOrderRequest struct {
 	PaymentMethod  string  `json:"payment_method" form:"payment_method" validate:"required"`
 	PaymentChannel string  `json:"payment_channel" form:"payment_channel" validate:"required"`
 	PaymentFee     float64 `json:"payment_fee" form:"payment_fee"`
+	VoucherCode    string  `json:"voucher_code" form:"voucher_code"`
 }
 
 func ReadFromCreateOrderRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (createOrder *CreateOrderRequest) {
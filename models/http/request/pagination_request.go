@@ -0,0 +1,40 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Kontrak paginasi daftar produk, cursor dari response menggantikan page jika diisi
+// Attributes berformat kode:nilai dipisah koma, contoh usia:0-6 bulan,halal:true
+type PaginationRequest struct {
+	Page       int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit      int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor     string `json:"cursor" query:"cursor"`
+	Sort       string `json:"sort" query:"sort" validate:"omitempty,oneof=relevance price_asc price_desc name_asc name_desc newest bestselling"`
+	Attributes string `json:"attributes" query:"attributes" validate:"omitempty,max=500"`
+}
+
+func ReadFromPaginationRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (pagination *PaginationRequest) {
+	paginationRequest := new(PaginationRequest)
+	if err := c.Bind(paginationRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	pagination = paginationRequest
+	return pagination
+}
+
+func ValidatePaginationRequest(validate *validator.Validate, pagination *PaginationRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(pagination)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
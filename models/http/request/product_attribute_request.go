@@ -0,0 +1,89 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Id diisi saat update, kode dan tipe hanya dipakai saat create
+// Options wajib untuk tipe select dan multi_select, urutannya menjadi urutan tampil
+type ProductAttributeRequest struct {
+	Id            string   `json:"id" form:"id"`
+	AttributeCode string   `json:"attribute_code" form:"attribute_code" validate:"omitempty,max=50"`
+	AttributeName string   `json:"attribute_name" form:"attribute_name" validate:"required,max=100"`
+	AttributeType string   `json:"attribute_type" form:"attribute_type" validate:"omitempty,oneof=select multi_select boolean number"`
+	Unit          string   `json:"unit" form:"unit" validate:"omitempty,max=20"`
+	IsFilterable  int      `json:"is_filterable" form:"is_filterable" validate:"oneof=0 1"`
+	Position      int      `json:"position" form:"position" validate:"min=0"`
+	Options       []string `json:"options" form:"options" validate:"omitempty,max=100,dive,required,max=100"`
+}
+
+type ProductAttributeIdRequest struct {
+	Id string `json:"id" query:"id" validate:"required"`
+}
+
+// Semua nilai atribut produk diganti dengan isi request, atribut yang tidak dikirim dihapus dari produk
+type ProductAttributeValueRequest struct {
+	IdProduct  string                             `json:"id_product" validate:"required"`
+	Attributes []ProductAttributeValueItemRequest `json:"attributes" validate:"dive"`
+}
+
+type ProductAttributeValueItemRequest struct {
+	IdProductAttribute string   `json:"id_product_attribute" validate:"required"`
+	Values             []string `json:"values" validate:"required,min=1,dive,required,max=100"`
+}
+
+type ProductAttributeValueFindRequest struct {
+	IdProduct string `json:"id_product" query:"id_product" validate:"required"`
+}
+
+func ReadFromProductAttributeRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productAttribute *ProductAttributeRequest) {
+	productAttributeRequest := new(ProductAttributeRequest)
+	if err := c.Bind(productAttributeRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productAttribute = productAttributeRequest
+	return productAttribute
+}
+
+func ReadFromProductAttributeIdRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productAttributeId *ProductAttributeIdRequest) {
+	productAttributeIdRequest := new(ProductAttributeIdRequest)
+	if err := c.Bind(productAttributeIdRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productAttributeId = productAttributeIdRequest
+	return productAttributeId
+}
+
+func ReadFromProductAttributeValueRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productAttributeValue *ProductAttributeValueRequest) {
+	productAttributeValueRequest := new(ProductAttributeValueRequest)
+	if err := c.Bind(productAttributeValueRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productAttributeValue = productAttributeValueRequest
+	return productAttributeValue
+}
+
+func ReadFromProductAttributeValueFindRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productAttributeValueFind *ProductAttributeValueFindRequest) {
+	productAttributeValueFindRequest := new(ProductAttributeValueFindRequest)
+	if err := c.Bind(productAttributeValueFindRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productAttributeValueFind = productAttributeValueFindRequest
+	return productAttributeValueFind
+}
+
+func ValidateProductAttributeRequest(validate *validator.Validate, productAttribute interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productAttribute)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,66 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Status kosong berarti semua batch produk
+type ProductBatchListRequest struct {
+	IdProduct        string `json:"id_product" query:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" query:"id_product_variant"`
+	Status           string `json:"status" query:"status" validate:"omitempty,oneof=active expired empty"`
+}
+
+// Days default 30 hari ke depan
+type ProductBatchNearExpiryRequest struct {
+	Days int `json:"days" query:"days" validate:"omitempty,min=1,max=365"`
+}
+
+// Recall bisa dicari dengan id batch atau nomor batch
+type ProductBatchRecallRequest struct {
+	IdProductBatch string `json:"id_product_batch" query:"id_product_batch"`
+	BatchNumber    string `json:"batch_number" query:"batch_number" validate:"omitempty,max=50"`
+}
+
+func ReadFromProductBatchListRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productBatchList *ProductBatchListRequest) {
+	productBatchListRequest := new(ProductBatchListRequest)
+	if err := c.Bind(productBatchListRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productBatchList = productBatchListRequest
+	return productBatchList
+}
+
+func ReadFromProductBatchNearExpiryRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productBatchNearExpiry *ProductBatchNearExpiryRequest) {
+	productBatchNearExpiryRequest := new(ProductBatchNearExpiryRequest)
+	if err := c.Bind(productBatchNearExpiryRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productBatchNearExpiry = productBatchNearExpiryRequest
+	return productBatchNearExpiry
+}
+
+func ReadFromProductBatchRecallRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productBatchRecall *ProductBatchRecallRequest) {
+	productBatchRecallRequest := new(ProductBatchRecallRequest)
+	if err := c.Bind(productBatchRecallRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productBatchRecall = productBatchRecallRequest
+	return productBatchRecall
+}
+
+func ValidateProductBatchRequest(validate *validator.Validate, productBatch interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productBatch)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,70 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Id diisi saat update, kosong saat create
+type ProductCategoryRequest struct {
+	Id           int    `json:"id" form:"id"`
+	CategoryName string `json:"category_name" form:"category_name" validate:"required,max=100"`
+	Icon         string `json:"icon" form:"icon" validate:"omitempty,max=255"`
+	SortOrder    int    `json:"sort_order" form:"sort_order" validate:"min=0"`
+	IsActive     int    `json:"is_active" form:"is_active" validate:"oneof=0 1"`
+}
+
+type ProductSubCategoryRequest struct {
+	Id              int    `json:"id" form:"id"`
+	IdCategory      int    `json:"id_category" form:"id_category" validate:"required"`
+	SubCategoryName string `json:"sub_category_name" form:"sub_category_name" validate:"required,max=100"`
+	Icon            string `json:"icon" form:"icon" validate:"omitempty,max=255"`
+	SortOrder       int    `json:"sort_order" form:"sort_order" validate:"min=0"`
+	IsActive        int    `json:"is_active" form:"is_active" validate:"oneof=0 1"`
+}
+
+type ProductCategoryDeleteRequest struct {
+	Id int `json:"id" query:"id" validate:"required"`
+}
+
+func ReadFromProductCategoryRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productCategory *ProductCategoryRequest) {
+	productCategoryRequest := new(ProductCategoryRequest)
+	if err := c.Bind(productCategoryRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productCategory = productCategoryRequest
+	return productCategory
+}
+
+func ReadFromProductSubCategoryRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productSubCategory *ProductSubCategoryRequest) {
+	productSubCategoryRequest := new(ProductSubCategoryRequest)
+	if err := c.Bind(productSubCategoryRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productSubCategory = productSubCategoryRequest
+	return productSubCategory
+}
+
+func ReadFromProductCategoryDeleteRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productCategoryDelete *ProductCategoryDeleteRequest) {
+	productCategoryDeleteRequest := new(ProductCategoryDeleteRequest)
+	if err := c.Bind(productCategoryDeleteRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productCategoryDelete = productCategoryDeleteRequest
+	return productCategoryDelete
+}
+
+func ValidateProductCategoryRequest(validate *validator.Validate, productCategory interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productCategory)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// File gambar dikirim sebagai multipart dengan field image
+type ProductImageUploadRequest struct {
+	IdProduct string `json:"id_product" form:"id_product" validate:"required"`
+}
+
+// Urutan baru galeri, semua gambar produk harus disebutkan
+type ProductImageReorderRequest struct {
+	IdProduct string   `json:"id_product" form:"id_product" validate:"required"`
+	IdImages  []string `json:"id_images" form:"id_images" validate:"required,min=1"`
+}
+
+type ProductImageDeleteRequest struct {
+	Id string `json:"id" query:"id" validate:"required"`
+}
+
+func ReadFromProductImageUploadRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productImageUpload *ProductImageUploadRequest) {
+	productImageUploadRequest := new(ProductImageUploadRequest)
+	if err := c.Bind(productImageUploadRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productImageUpload = productImageUploadRequest
+	return productImageUpload
+}
+
+func ReadFromProductImageReorderRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productImageReorder *ProductImageReorderRequest) {
+	productImageReorderRequest := new(ProductImageReorderRequest)
+	if err := c.Bind(productImageReorderRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productImageReorder = productImageReorderRequest
+	return productImageReorder
+}
+
+func ReadFromProductImageDeleteRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productImageDelete *ProductImageDeleteRequest) {
+	productImageDeleteRequest := new(ProductImageDeleteRequest)
+	if err := c.Bind(productImageDeleteRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productImageDelete = productImageDeleteRequest
+	return productImageDelete
+}
+
+func ValidateProductImageRequest(validate *validator.Validate, productImage interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productImage)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type ProductPriceHistoryRequest struct {
+	IdProduct string `json:"id_product" query:"id_product" validate:"required"`
+	Page      int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit     int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func ReadFromProductPriceHistoryRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productPriceHistory *ProductPriceHistoryRequest) {
+	productPriceHistoryRequest := new(ProductPriceHistoryRequest)
+	if err := c.Bind(productPriceHistoryRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productPriceHistory = productPriceHistoryRequest
+	return productPriceHistory
+}
+
+func ValidateProductPriceHistoryRequest(validate *validator.Validate, productPriceHistory *ProductPriceHistoryRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productPriceHistory)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
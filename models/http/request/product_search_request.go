@@ -0,0 +1,42 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type ProductSearchRequest struct {
+	Product       string  `json:"product" query:"product"`
+	IdCategory    int     `json:"id_category" query:"id_category"`
+	IdSubCategory int     `json:"id_sub_category" query:"id_sub_category"`
+	IdBrand       string  `json:"id_brand" query:"id_brand"`
+	PriceMin      float64 `json:"price_min" query:"price_min" validate:"omitempty,min=0"`
+	PriceMax      float64 `json:"price_max" query:"price_max" validate:"omitempty,min=0"`
+	InStock       bool    `json:"in_stock" query:"in_stock"`
+	OnPromo       bool    `json:"on_promo" query:"on_promo"`
+	PaginationRequest
+}
+
+func ReadFromProductSearchRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (productSearch *ProductSearchRequest) {
+	productSearchRequest := new(ProductSearchRequest)
+	if err := c.Bind(productSearchRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productSearch = productSearchRequest
+	return productSearch
+}
+
+func ValidateProductSearchRequest(validate *validator.Validate, productSearch *ProductSearchRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productSearch)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,37 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type ProductStockInRequest struct {
+	IdProduct        string `json:"id_product" form:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant"`
+	Qty              int    `json:"qty" form:"qty" validate:"required,min=1"`
+	Description      string `json:"description" form:"description" validate:"omitempty,max=255"`
+}
+
+func ReadFromProductStockInRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (productStockIn *ProductStockInRequest) {
+	productStockInRequest := new(ProductStockInRequest)
+	if err := c.Bind(productStockInRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	productStockIn = productStockInRequest
+	return productStockIn
+}
+
+func ValidateProductStockInRequest(validate *validator.Validate, productStockIn *ProductStockInRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productStockIn)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Penerimaan barang dari supplier, satu reference hanya bisa diterima sekali per supplier
+type GoodsReceivingRequest struct {
+	Supplier    string                      `json:"supplier" form:"supplier" validate:"required,max=100"`
+	Reference   string                      `json:"reference" form:"reference" validate:"required,max=100"`
+	Description string                      `json:"description" form:"description" validate:"omitempty,max=255"`
+	Items       []GoodsReceivingItemRequest `json:"items" form:"items" validate:"required,min=1,dive"`
+}
+
+// Batch dan tanggal kadaluarsa (YYYY-MM-DD) diisi bersamaan untuk produk yang dilacak per batch
+type GoodsReceivingItemRequest struct {
+	IdProduct        string `json:"id_product" form:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant"`
+	Qty              int    `json:"qty" form:"qty" validate:"required,min=1"`
+	BatchNumber      string `json:"batch_number" form:"batch_number" validate:"omitempty,max=50"`
+	ExpiryDate       string `json:"expiry_date" form:"expiry_date"`
+}
+
+// Qty negatif untuk barang rusak, kadaluarsa dan hilang, positif untuk barang ditemukan, koreksi boleh keduanya
+type StockAdjustmentRequest struct {
+	IdProduct        string `json:"id_product" form:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant"`
+	ReasonCode       string `json:"reason_code" form:"reason_code" validate:"required,oneof=damaged expired lost found correction"`
+	IdProductBatch   string `json:"id_product_batch" form:"id_product_batch"`
+	Qty              int    `json:"qty" form:"qty" validate:"required"`
+	Description      string `json:"description" form:"description" validate:"omitempty,max=255"`
+}
+
+type StockCardRequest struct {
+	IdProduct        string `json:"id_product" query:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" query:"id_product_variant"`
+	DateFrom         string `json:"date_from" query:"date_from"`
+	DateTo           string `json:"date_to" query:"date_to"`
+}
+
+func ReadFromGoodsReceivingRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (goodsReceiving *GoodsReceivingRequest) {
+	goodsReceivingRequest := new(GoodsReceivingRequest)
+	if err := c.Bind(goodsReceivingRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	goodsReceiving = goodsReceivingRequest
+	return goodsReceiving
+}
+
+func ReadFromStockAdjustmentRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (stockAdjustment *StockAdjustmentRequest) {
+	stockAdjustmentRequest := new(StockAdjustmentRequest)
+	if err := c.Bind(stockAdjustmentRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockAdjustment = stockAdjustmentRequest
+	return stockAdjustment
+}
+
+func ReadFromStockCardRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (stockCard *StockCardRequest) {
+	stockCardRequest := new(StockCardRequest)
+	if err := c.Bind(stockCardRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockCard = stockCardRequest
+	return stockCard
+}
+
+func ValidateProductStockRequest(validate *validator.Validate, productStock interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(productStock)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
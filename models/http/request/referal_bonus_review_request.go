@@ -0,0 +1,63 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type FindReferalBonusReviewRequest struct {
+	Status string `json:"status" query:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Page   int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit  int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type ReviewReferalBonusRequest struct {
+	IdReview string `json:"id_review" form:"id_review" validate:"required"`
+	Note     string `json:"note" form:"note" validate:"omitempty,max=255"`
+}
+
+func ReadFromFindReferalBonusReviewRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (findReferalBonusReview *FindReferalBonusReviewRequest) {
+	findReferalBonusReviewRequest := new(FindReferalBonusReviewRequest)
+	if err := c.Bind(findReferalBonusReviewRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	findReferalBonusReview = findReferalBonusReviewRequest
+	return findReferalBonusReview
+}
+
+func ValidateFindReferalBonusReviewRequest(validate *validator.Validate, findReferalBonusReview *FindReferalBonusReviewRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(findReferalBonusReview)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
+
+func ReadFromReviewReferalBonusRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (reviewReferalBonus *ReviewReferalBonusRequest) {
+	reviewReferalBonusRequest := new(ReviewReferalBonusRequest)
+	if err := c.Bind(reviewReferalBonusRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	reviewReferalBonus = reviewReferalBonusRequest
+	return reviewReferalBonus
+}
+
+func ValidateReviewReferalBonusRequest(validate *validator.Validate, reviewReferalBonus *ReviewReferalBonusRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(reviewReferalBonus)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type RestockSubscriptionRequest struct {
+	IdProduct        string `json:"id_product" form:"id_product" query:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant" query:"id_product_variant"`
+}
+
+func ReadFromRestockSubscriptionRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (restockSubscription *RestockSubscriptionRequest) {
+	restockSubscriptionRequest := new(RestockSubscriptionRequest)
+	if err := c.Bind(restockSubscriptionRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	restockSubscription = restockSubscriptionRequest
+	return restockSubscription
+}
+
+func ValidateRestockSubscriptionRequest(validate *validator.Validate, restockSubscription *RestockSubscriptionRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(restockSubscription)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
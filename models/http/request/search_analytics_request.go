@@ -0,0 +1,37 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// Periode laporan dalam format YYYY-MM-DD
+type SearchAnalyticsRequest struct {
+	DateFrom string `json:"date_from" query:"date_from"`
+	DateTo   string `json:"date_to" query:"date_to"`
+	Limit    int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func ReadFromSearchAnalyticsRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (searchAnalytics *SearchAnalyticsRequest) {
+	searchAnalyticsRequest := new(SearchAnalyticsRequest)
+	if err := c.Bind(searchAnalyticsRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	searchAnalytics = searchAnalyticsRequest
+	return searchAnalytics
+}
+
+func ValidateSearchAnalyticsRequest(validate *validator.Validate, searchAnalytics *SearchAnalyticsRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(searchAnalytics)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
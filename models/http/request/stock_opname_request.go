@@ -0,0 +1,83 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+// IdCategory kosong berarti semua produk yang tidak diarsipkan ikut dihitung
+type StockOpnameCreateRequest struct {
+	Description string `json:"description" form:"description" validate:"omitempty,max=255"`
+	IdCategory  int    `json:"id_category" form:"id_category" validate:"min=0"`
+}
+
+type StockOpnameIdRequest struct {
+	Id string `json:"id" query:"id" form:"id" validate:"required"`
+}
+
+type StockOpnameListRequest struct {
+	Page  int `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit int `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Hasil hitung fisik, item yang sama boleh dikirim ulang untuk mengoreksi hitungan
+type StockOpnameCountRequest struct {
+	Id    string                        `json:"id" form:"id" validate:"required"`
+	Items []StockOpnameCountItemRequest `json:"items" form:"items" validate:"required,min=1,dive"`
+}
+
+type StockOpnameCountItemRequest struct {
+	IdItem     string `json:"id_item" form:"id_item" validate:"required"`
+	CountedQty int    `json:"counted_qty" form:"counted_qty" validate:"min=0"`
+}
+
+func ReadFromStockOpnameCreateRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (stockOpnameCreate *StockOpnameCreateRequest) {
+	stockOpnameCreateRequest := new(StockOpnameCreateRequest)
+	if err := c.Bind(stockOpnameCreateRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockOpnameCreate = stockOpnameCreateRequest
+	return stockOpnameCreate
+}
+
+func ReadFromStockOpnameIdRequest(c echo.Context, requestId string, logger *logrus.Logger) (stockOpnameId *StockOpnameIdRequest) {
+	stockOpnameIdRequest := new(StockOpnameIdRequest)
+	if err := c.Bind(stockOpnameIdRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockOpnameId = stockOpnameIdRequest
+	return stockOpnameId
+}
+
+func ReadFromStockOpnameListRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (stockOpnameList *StockOpnameListRequest) {
+	stockOpnameListRequest := new(StockOpnameListRequest)
+	if err := c.Bind(stockOpnameListRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockOpnameList = stockOpnameListRequest
+	return stockOpnameList
+}
+
+func ReadFromStockOpnameCountRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (stockOpnameCount *StockOpnameCountRequest) {
+	stockOpnameCountRequest := new(StockOpnameCountRequest)
+	if err := c.Bind(stockOpnameCountRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	stockOpnameCount = stockOpnameCountRequest
+	return stockOpnameCount
+}
+
+func ValidateStockOpnameRequest(validate *validator.Validate, stockOpname interface{}, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(stockOpname)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -14,6 +14,7 @@ type CreateUserRequest struct {
 	Password                string `json:"password" form:"password" validate:"required"`
 	RegistrationReferalCode string `json:"registration_referal_code" form:"registration_referal_code"`
 	FormToken               string `json:"form_token" form:"form_token"`
+	TokenDevice             string `json:"token_device" form:"token_device"`
 }
 
 func ReadFromCreateUserRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (createUser *CreateUserRequest) {
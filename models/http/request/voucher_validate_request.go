@@ -0,0 +1,35 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type VoucherValidateRequest struct {
+	VoucherCode  string  `json:"voucher_code" query:"voucher_code" validate:"required"`
+	ShippingCost float64 `json:"shipping_cost" query:"shipping_cost"`
+}
+
+func ReadFromVoucherValidateRequestQuery(c echo.Context, requestId string, logger *logrus.Logger) (voucherValidate *VoucherValidateRequest) {
+	voucherValidateRequest := new(VoucherValidateRequest)
+	if err := c.Bind(voucherValidateRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	voucherValidate = voucherValidateRequest
+	return voucherValidate
+}
+
+func ValidateVoucherValidateRequest(validate *validator.Validate, voucherValidate *VoucherValidateRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(voucherValidate)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
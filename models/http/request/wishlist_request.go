@@ -0,0 +1,36 @@
+package request
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+)
+
+type WishlistRequest struct {
+	IdProduct        string `json:"id_product" form:"id_product" query:"id_product" validate:"required"`
+	IdProductVariant string `json:"id_product_variant" form:"id_product_variant"`
+	Qty              int    `json:"qty" form:"qty" validate:"omitempty,min=1"`
+}
+
+func ReadFromWishlistRequestBody(c echo.Context, requestId string, logger *logrus.Logger) (wishlist *WishlistRequest) {
+	wishlistRequest := new(WishlistRequest)
+	if err := c.Bind(wishlistRequest); err != nil {
+		exceptions.PanicIfError(err, requestId, logger)
+	}
+	wishlist = wishlistRequest
+	return wishlist
+}
+
+func ValidateWishlistRequest(validate *validator.Validate, wishlist *WishlistRequest, requestId string, logger *logrus.Logger) {
+	var errorStrings []string
+	var errorString string
+	err := validate.Struct(wishlist)
+	if err != nil {
+		for _, errorValidation := range err.(validator.ValidationErrors) {
+			errorString = errorValidation.Field() + " is " + errorValidation.Tag()
+			errorStrings = append(errorStrings, errorString)
+		}
+		exceptions.PanicIfBadRequest(err, requestId, errorStrings, logger)
+	}
+}
@@ -0,0 +1,141 @@
+package response
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type AdminProductResponse struct {
+	Id               string                         `json:"id"`
+	NoSku            string                         `json:"no_sku"`
+	ProductName      string                         `json:"product_name"`
+	Price            float64                        `json:"price"`
+	Description      string                         `json:"description"`
+	Weight           float64                        `json:"weight"`
+	Volume           float64                        `json:"volume"`
+	Stock            int                            `json:"stock"`
+	ReorderPoint     int                            `json:"reorder_point"`
+	MaxQtyPerOrder   int                            `json:"max_qty_per_order"`
+	MaxQtyPerUser    int                            `json:"max_qty_per_user"`
+	MaxQtyPeriodDays int                            `json:"max_qty_period_days"`
+	IdCategory       int                            `json:"id_category"`
+	CategoryName     string                         `json:"category_name"`
+	IdSubCategory    int                            `json:"id_sub_category"`
+	IdBrand          string                         `json:"id_brand"`
+	BrandName        string                         `json:"brand_name"`
+	Status           string                         `json:"status"`
+	ArchivedAt       string                         `json:"archived_at"`
+	CreatedAt        string                         `json:"created_at"`
+	VariantCount     int                            `json:"variant_count"`
+	Discounts        []AdminProductDiscountResponse `json:"discounts"`
+	PictureUrl       string                         `json:"picture_url"`
+	Thumbnail        string                         `json:"thumbnail"`
+}
+
+type AdminProductDiscountResponse struct {
+	Id           string  `json:"id"`
+	DiscountType string  `json:"discount_type"`
+	Percentage   float64 `json:"percentage"`
+	Nominal      float64 `json:"nominal"`
+	FlagPromo    string  `json:"flag_promo"`
+	Priority     int     `json:"priority"`
+	Stackable    int     `json:"stackable"`
+	StartDate    string  `json:"start_date"`
+	EndDate      string  `json:"end_date"`
+}
+
+type FindAdminProductListResponse struct {
+	Products   []AdminProductResponse `json:"products"`
+	Pagination PaginationResponse     `json:"pagination"`
+}
+
+// Row sesuai nomor baris di file, header adalah baris 1
+type ProductImportRowErrorResponse struct {
+	Row    int      `json:"row"`
+	NoSku  string   `json:"no_sku"`
+	Errors []string `json:"errors"`
+}
+
+type ProductImportResponse struct {
+	DryRun    bool                            `json:"dry_run"`
+	Applied   bool                            `json:"applied"`
+	TotalRows int                             `json:"total_rows"`
+	Created   int                             `json:"created"`
+	Updated   int                             `json:"updated"`
+	Errors    []ProductImportRowErrorResponse `json:"errors"`
+}
+
+// Produk arsip tidak tayang dan tidak bisa diterbitkan sebelum dipulihkan
+func AdminProductStatus(product entity.Product) string {
+	if product.ArchivedAt.Valid {
+		return "archived"
+	}
+	if product.Published == "1" {
+		return "published"
+	}
+	return "unpublished"
+}
+
+func ToAdminProductResponses(products []entity.Product) (adminProductResponses []AdminProductResponse) {
+	adminProductResponses = []AdminProductResponse{}
+	for _, product := range products {
+		adminProductResponses = append(adminProductResponses, ToAdminProductResponse(product))
+	}
+	return adminProductResponses
+}
+
+func ToAdminProductResponse(product entity.Product) (adminProductResponse AdminProductResponse) {
+	adminProductResponse.Id = product.Id
+	adminProductResponse.NoSku = product.NoSku
+	adminProductResponse.ProductName = product.ProductName
+	adminProductResponse.Price = product.Price
+	adminProductResponse.Description = product.Description
+	adminProductResponse.Weight = product.Weight
+	adminProductResponse.Volume = product.Volume
+	adminProductResponse.Stock = product.Stock
+	adminProductResponse.ReorderPoint = product.ReorderPoint
+	adminProductResponse.MaxQtyPerOrder = product.MaxQtyPerOrder
+	adminProductResponse.MaxQtyPerUser = product.MaxQtyPerUser
+	adminProductResponse.MaxQtyPeriodDays = product.MaxQtyPeriodDays
+	adminProductResponse.IdCategory = product.IdCategory
+	adminProductResponse.CategoryName = product.ProductCategory.CategoryName
+	adminProductResponse.IdSubCategory = product.IdSubCategory
+	adminProductResponse.IdBrand = product.IdBrand
+	adminProductResponse.BrandName = product.ProductBrand.BrandName
+	adminProductResponse.Status = AdminProductStatus(product)
+	if product.ArchivedAt.Valid {
+		adminProductResponse.ArchivedAt = product.ArchivedAt.Time.Format("2006-01-02 15:04:05")
+	}
+	if !product.CreatedAt.IsZero() {
+		adminProductResponse.CreatedAt = product.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	adminProductResponse.VariantCount = len(product.ProductVariants)
+	adminProductResponse.PictureUrl = product.PictureUrl
+	adminProductResponse.Thumbnail = product.Thumbnail
+
+	adminProductResponse.Discounts = []AdminProductDiscountResponse{}
+	for _, productDiscount := range product.ProductDiscounts {
+		var adminProductDiscountResponse AdminProductDiscountResponse
+		adminProductDiscountResponse.Id = productDiscount.Id
+		adminProductDiscountResponse.DiscountType = productDiscount.DiscountType
+		adminProductDiscountResponse.Percentage = productDiscount.Percentage
+		adminProductDiscountResponse.Nominal = productDiscount.Nominal
+		adminProductDiscountResponse.FlagPromo = productDiscount.FlagPromo
+		adminProductDiscountResponse.Priority = productDiscount.Priority
+		adminProductDiscountResponse.Stackable = productDiscount.Stackable
+		if !productDiscount.StartDate.IsZero() {
+			adminProductDiscountResponse.StartDate = productDiscount.StartDate.Format("2006-01-02 15:04:05")
+		}
+		if !productDiscount.EndDate.IsZero() {
+			adminProductDiscountResponse.EndDate = productDiscount.EndDate.Format("2006-01-02 15:04:05")
+		}
+		adminProductResponse.Discounts = append(adminProductResponse.Discounts, adminProductDiscountResponse)
+	}
+	return adminProductResponse
+}
+
+func ToFindAdminProductListResponse(products []entity.Product, pagination modelService.Pagination, totalData int64) (adminProductListResponse FindAdminProductListResponse) {
+	adminProductListResponse.Products = ToAdminProductResponses(products)
+	adminProductListResponse.Pagination = ToPaginationResponse(pagination, totalData)
+	return adminProductListResponse
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type BalancePointStatementResponse struct {
+	DateFrom       string                               `json:"date_from"`
+	DateTo         string                               `json:"date_to"`
+	TxType         string                               `json:"tx_type"`
+	OpeningBalance float64                              `json:"opening_balance"`
+	ClosingBalance float64                              `json:"closing_balance"`
+	Page           int                                  `json:"page"`
+	Limit          int                                  `json:"limit"`
+	TotalData      int64                                `json:"total_data"`
+	TotalPage      int                                  `json:"total_page"`
+	Transactions   []FindBalancePointTxByIdBalancePoint `json:"transactions"`
+}
+
+func ToBalancePointStatementResponse(dateFrom time.Time, dateTo time.Time, txType string, openingBalance float64, closingBalance float64, page int, limit int, totalData int64, balancePointTxs []entity.BalancePointTx) (balancePointStatementResponse BalancePointStatementResponse) {
+	balancePointStatementResponse.DateFrom = dateFrom.Format("2006-01-02")
+	balancePointStatementResponse.DateTo = dateTo.Format("2006-01-02")
+	balancePointStatementResponse.TxType = txType
+	balancePointStatementResponse.OpeningBalance = openingBalance
+	balancePointStatementResponse.ClosingBalance = closingBalance
+	balancePointStatementResponse.Page = page
+	balancePointStatementResponse.Limit = limit
+	balancePointStatementResponse.TotalData = totalData
+	balancePointStatementResponse.TotalPage = int((totalData + int64(limit) - 1) / int64(limit))
+	balancePointStatementResponse.Transactions = ToFindBalancePointTxByIdBalancePoint(balancePointTxs)
+	if balancePointStatementResponse.Transactions == nil {
+		balancePointStatementResponse.Transactions = []FindBalancePointTxByIdBalancePoint{}
+	}
+	return balancePointStatementResponse
+}
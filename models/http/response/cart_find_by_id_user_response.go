@@ -1,44 +1,74 @@
 package response
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
 )
 
 type FindCartByIdUserResponse struct {
-	SubTotal     float64    `json:"sub_total"`
-	ShippingCost float64    `json:"shipping_cost"`
-	TotalBill    float64    `json:"total_bill"`
-	CartItems    []CartItem `json:"cart_items"`
+	SubTotal          float64                 `json:"sub_total"`
+	PromotionDiscount float64                 `json:"promotion_discount"`
+	ShippingCost      float64                 `json:"shipping_cost"`
+	TotalBill         float64                 `json:"total_bill"`
+	CartItems         []CartItem              `json:"cart_items"`
+	Promotions        []CartPromotionResponse `json:"promotions"`
+	MinOrderValue     float64                 `json:"min_order_value"`
+	Violations        []string                `json:"violations"`
 }
 
 type CartItem struct {
-	Id          string  `json:"id"`
-	IdProduct   string  `json:"id_product"`
-	Price       float64 `json:"price"`
-	ProductName string  `json:"product_name"`
-	Description string  `json:"description"`
-	Stock       int     `json:"stock"`
-	PictureUrl  string  `json:"picture_url"`
-	Thumbnail   string  `json:"thumbnail"`
-	Qty         int     `json:"qty"`
-	FlagPromo   string  `json:"flag_promo"`
+	Id                string  `json:"id"`
+	IdProduct         string  `json:"id_product"`
+	IdProductVariant  string  `json:"id_product_variant"`
+	VariantName       string  `json:"variant_name"`
+	NoSku             string  `json:"no_sku"`
+	Price             float64 `json:"price"`
+	ProductName       string  `json:"product_name"`
+	Description       string  `json:"description"`
+	Stock             int     `json:"stock"`
+	PictureUrl        string  `json:"picture_url"`
+	Thumbnail         string  `json:"thumbnail"`
+	Qty               int     `json:"qty"`
+	FlagPromo         string  `json:"flag_promo"`
+	PromoEndAt        string  `json:"promo_end_at"`
+	PromotionDiscount float64 `json:"promotion_discount"`
+	IdFlashSaleItem   string  `json:"id_flash_sale_item"`
+}
+
+type CartPromotionResponse struct {
+	IdPromotion   string                      `json:"id_promotion"`
+	PromotionName string                      `json:"promotion_name"`
+	PromotionType string                      `json:"promotion_type"`
+	Discount      float64                     `json:"discount"`
+	CartItems     []CartPromotionItemResponse `json:"cart_items"`
+}
+
+type CartPromotionItemResponse struct {
+	IdCart   string  `json:"id_cart"`
+	Qty      int     `json:"qty"`
+	Discount float64 `json:"discount"`
 }
 
-func ToFindCartByIdUserResponse(carts []entity.Cart, shippingCost float64) (cartResponse FindCartByIdUserResponse) {
+func ToFindCartByIdUserResponse(carts []entity.Cart, cartPromotions []modelService.CartPromotion, shippingCost float64) (cartResponse FindCartByIdUserResponse) {
+	lineDiscounts := pricing.CartPromotionDiscounts(cartPromotions)
 
 	var cartItems []CartItem
 	var totalPricePerItem float64
 	var subTotal float64
 	for _, cart := range carts {
 		var cartItem CartItem
-		if cart.Product.ProductDiscount.FlagPromo == "true" {
-			totalPricePerItem = cart.Product.ProductDiscount.Nominal
-		} else {
-			totalPricePerItem = cart.Product.Price
-		}
+		productPricing := pricing.EvaluateCartItemPricing(cart, time.Now())
+		totalPricePerItem = productPricing.Price
 
 		cartItem.Id = cart.Id
 		cartItem.IdProduct = cart.IdProduct
+		cartItem.IdProductVariant = cart.IdProductVariant
+		cartItem.VariantName = cart.ProductVariant.VariantName
+		cartItem.NoSku = cart.Product.NoSku
 		totalPricePerItem = totalPricePerItem * (float64(cart.Qty))
 		cartItem.Price = totalPricePerItem
 		cartItem.ProductName = cart.Product.ProductName
@@ -47,16 +77,43 @@ func ToFindCartByIdUserResponse(carts []entity.Cart, shippingCost float64) (cart
 		cartItem.PictureUrl = cart.Product.PictureUrl
 		cartItem.Thumbnail = cart.Product.Thumbnail
 		cartItem.Qty = cart.Qty
-		cartItem.FlagPromo = cart.Product.ProductDiscount.FlagPromo
+		cartItem.FlagPromo = strconv.FormatBool(productPricing.OnPromo)
+		cartItem.PromoEndAt = formatPromoEndAt(productPricing.PromoEndAt)
+		cartItem.PromotionDiscount = lineDiscounts[cart.Id]
+		cartItem.IdFlashSaleItem = productPricing.IdFlashSaleItem
+		if cart.ProductVariant.Id != "" {
+			cartItem.NoSku = cart.ProductVariant.NoSku
+			cartItem.Stock = cart.ProductVariant.Stock
+			if cart.ProductVariant.PictureUrl != "" {
+				cartItem.PictureUrl = cart.ProductVariant.PictureUrl
+				cartItem.Thumbnail = cart.ProductVariant.Thumbnail
+			}
+		}
 		subTotal = subTotal + totalPricePerItem
 
 		cartItems = append(cartItems, cartItem)
 	}
 
+	// Potongan promo keranjang mengurangi total tagihan
+	cartResponse.Promotions = []CartPromotionResponse{}
+	cartResponse.Violations = []string{}
+	for _, cartPromotion := range cartPromotions {
+		var cartPromotionResponse CartPromotionResponse
+		cartPromotionResponse.IdPromotion = cartPromotion.IdPromotion
+		cartPromotionResponse.PromotionName = cartPromotion.PromotionName
+		cartPromotionResponse.PromotionType = cartPromotion.PromotionType
+		cartPromotionResponse.Discount = cartPromotion.Discount
+		for _, cartLine := range cartPromotion.CartLines {
+			cartPromotionResponse.CartItems = append(cartPromotionResponse.CartItems, CartPromotionItemResponse{IdCart: cartLine.IdCart, Qty: cartLine.Qty, Discount: cartLine.Discount})
+		}
+		cartResponse.Promotions = append(cartResponse.Promotions, cartPromotionResponse)
+		cartResponse.PromotionDiscount = cartResponse.PromotionDiscount + cartPromotion.Discount
+	}
+
 	cartResponse.CartItems = cartItems
 	cartResponse.SubTotal = subTotal
 	cartResponse.ShippingCost = shippingCost
-	cartResponse.TotalBill = subTotal + shippingCost
+	cartResponse.TotalBill = subTotal - cartResponse.PromotionDiscount + shippingCost
 
 	return cartResponse
 }
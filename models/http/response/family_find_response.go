@@ -0,0 +1,39 @@
+package response
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type FindFamilyResponse struct {
+	Id            string                      `json:"id"`
+	IdUserHead    string                      `json:"id_user_head"`
+	SharedWallet  int                         `json:"shared_wallet"`
+	BalancePoints float64                     `json:"balance_points"`
+	Members       []FindFamilyMembersResponse `json:"members"`
+}
+
+type FindFamilyMembersResponse struct {
+	IdUser        string  `json:"id_user"`
+	FullName      string  `json:"full_name"`
+	Phone         string  `json:"phone"`
+	IsHead        bool    `json:"is_head"`
+	BalancePoints float64 `json:"balance_points"`
+}
+
+func ToFindFamilyResponse(family entity.Family, users []entity.User, balancePoint entity.BalancePoint) (familyResponse FindFamilyResponse) {
+	familyResponse.Id = family.Id
+	familyResponse.IdUserHead = family.IdUserHead
+	familyResponse.SharedWallet = family.SharedWallet
+	familyResponse.BalancePoints = balancePoint.BalancePoints
+	familyResponse.Members = []FindFamilyMembersResponse{}
+	for _, user := range users {
+		var familyMembersResponse FindFamilyMembersResponse
+		familyMembersResponse.IdUser = user.Id
+		familyMembersResponse.FullName = user.FamilyMembers.FullName
+		familyMembersResponse.Phone = user.FamilyMembers.Phone
+		familyMembersResponse.IsHead = user.Id == family.IdUserHead
+		familyMembersResponse.BalancePoints = user.BalancePoint.BalancePoints
+		familyResponse.Members = append(familyResponse.Members, familyMembersResponse)
+	}
+	return familyResponse
+}
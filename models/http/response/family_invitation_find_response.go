@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type FindFamilyInvitationResponse struct {
+	Id              string    `json:"id"`
+	IdFamily        string    `json:"id_family"`
+	InviterFullName string    `json:"inviter_full_name"`
+	InviterPhone    string    `json:"inviter_phone"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func ToFindFamilyInvitationResponse(familyInvitations []entity.FamilyInvitation) (familyInvitationResponses []FindFamilyInvitationResponse) {
+	familyInvitationResponses = []FindFamilyInvitationResponse{}
+	for _, familyInvitation := range familyInvitations {
+		var familyInvitationResponse FindFamilyInvitationResponse
+		familyInvitationResponse.Id = familyInvitation.Id
+		familyInvitationResponse.IdFamily = familyInvitation.IdFamily
+		familyInvitationResponse.InviterFullName = familyInvitation.UserInviter.FamilyMembers.FullName
+		familyInvitationResponse.InviterPhone = familyInvitation.UserInviter.FamilyMembers.Phone
+		familyInvitationResponse.Status = familyInvitation.Status
+		familyInvitationResponse.CreatedAt = familyInvitation.CreatedDate
+		familyInvitationResponses = append(familyInvitationResponses, familyInvitationResponse)
+	}
+	return familyInvitationResponses
+}
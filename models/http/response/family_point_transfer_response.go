@@ -0,0 +1,28 @@
+package response
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type FamilyPointTransferResponse struct {
+	Id            string    `json:"id"`
+	IdUserFrom    string    `json:"id_user_from"`
+	IdUserTo      string    `json:"id_user_to"`
+	Nominal       float64   `json:"nominal"`
+	Note          string    `json:"note"`
+	BalancePoints float64   `json:"balance_points"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func ToFamilyPointTransferResponse(familyPointTransfer entity.FamilyPointTransfer, balancePoints float64) (familyPointTransferResponse FamilyPointTransferResponse) {
+	familyPointTransferResponse.Id = familyPointTransfer.Id
+	familyPointTransferResponse.IdUserFrom = familyPointTransfer.IdUserFrom
+	familyPointTransferResponse.IdUserTo = familyPointTransfer.IdUserTo
+	familyPointTransferResponse.Nominal = familyPointTransfer.Nominal
+	familyPointTransferResponse.Note = familyPointTransfer.Note
+	familyPointTransferResponse.BalancePoints = balancePoints
+	familyPointTransferResponse.CreatedAt = familyPointTransfer.CreatedDate
+	return familyPointTransferResponse
+}
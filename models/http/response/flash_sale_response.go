@@ -0,0 +1,128 @@
+package response
+
+import (
+	"math"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+)
+
+// Waktu server dipakai aplikasi untuk menghitung mundur flash sale
+type FlashSaleListResponse struct {
+	ServerTime string              `json:"server_time"`
+	FlashSales []FlashSaleResponse `json:"flash_sales"`
+}
+
+type FindFlashSaleListResponse struct {
+	FlashSales []FlashSaleResponse `json:"flash_sales"`
+	TotalData  int64               `json:"total_data"`
+}
+
+// Status upcoming belum dimulai, active sedang berjalan, ended sudah selesai, inactive dinonaktifkan admin
+type FlashSaleResponse struct {
+	Id            string                  `json:"id"`
+	FlashSaleName string                  `json:"flash_sale_name"`
+	StartAt       string                  `json:"start_at"`
+	EndAt         string                  `json:"end_at"`
+	Status        string                  `json:"status"`
+	CreatedAt     string                  `json:"created_at"`
+	Items         []FlashSaleItemResponse `json:"items"`
+}
+
+type FlashSaleItemResponse struct {
+	Id               string  `json:"id"`
+	IdProduct        string  `json:"id_product"`
+	IdProductVariant string  `json:"id_product_variant"`
+	NoSku            string  `json:"no_sku"`
+	ProductName      string  `json:"product_name"`
+	VariantName      string  `json:"variant_name"`
+	PictureUrl       string  `json:"picture_url"`
+	Thumbnail        string  `json:"thumbnail"`
+	Price            float64 `json:"price"`
+	SalePrice        float64 `json:"sale_price"`
+	Percentage       float64 `json:"percentage"`
+	Quota            int     `json:"quota"`
+	SoldQty          int     `json:"sold_qty"`
+	RemainingQuota   int     `json:"remaining_quota"`
+	LimitPerUser     int     `json:"limit_per_user"`
+	SoldOut          bool    `json:"sold_out"`
+}
+
+func FlashSaleStatus(flashSale entity.FlashSale, now time.Time) string {
+	switch {
+	case flashSale.IsActive != 1:
+		return "inactive"
+	case now.Before(flashSale.StartAt):
+		return "upcoming"
+	case pricing.IsFlashSaleActive(flashSale, now):
+		return "active"
+	}
+	return "ended"
+}
+
+func ToFlashSaleResponse(flashSale entity.FlashSale, now time.Time) (flashSaleResponse FlashSaleResponse) {
+	flashSaleResponse.Id = flashSale.Id
+	flashSaleResponse.FlashSaleName = flashSale.FlashSaleName
+	flashSaleResponse.StartAt = flashSale.StartAt.Format("2006-01-02 15:04:05")
+	flashSaleResponse.EndAt = flashSale.EndAt.Format("2006-01-02 15:04:05")
+	flashSaleResponse.Status = FlashSaleStatus(flashSale, now)
+	flashSaleResponse.CreatedAt = flashSale.CreatedAt.Format("2006-01-02 15:04:05")
+	flashSaleResponse.Items = []FlashSaleItemResponse{}
+	for _, flashSaleItem := range flashSale.FlashSaleItems {
+		flashSaleResponse.Items = append(flashSaleResponse.Items, ToFlashSaleItemResponse(flashSaleItem))
+	}
+	return flashSaleResponse
+}
+
+// Harga normal adalah harga produk atau varian sebelum promo
+func ToFlashSaleItemResponse(flashSaleItem entity.FlashSaleItem) (flashSaleItemResponse FlashSaleItemResponse) {
+	flashSaleItemResponse.Id = flashSaleItem.Id
+	flashSaleItemResponse.IdProduct = flashSaleItem.IdProduct
+	flashSaleItemResponse.IdProductVariant = flashSaleItem.IdProductVariant
+	flashSaleItemResponse.NoSku = flashSaleItem.Product.NoSku
+	flashSaleItemResponse.ProductName = flashSaleItem.Product.ProductName
+	flashSaleItemResponse.PictureUrl = flashSaleItem.Product.PictureUrl
+	flashSaleItemResponse.Thumbnail = flashSaleItem.Product.Thumbnail
+	flashSaleItemResponse.Price = flashSaleItem.Product.Price
+	if flashSaleItem.ProductVariant.Id != "" {
+		flashSaleItemResponse.VariantName = flashSaleItem.ProductVariant.VariantName
+		flashSaleItemResponse.NoSku = flashSaleItem.ProductVariant.NoSku
+		flashSaleItemResponse.Price = flashSaleItem.ProductVariant.Price
+		if flashSaleItem.ProductVariant.PictureUrl != "" {
+			flashSaleItemResponse.PictureUrl = flashSaleItem.ProductVariant.PictureUrl
+			flashSaleItemResponse.Thumbnail = flashSaleItem.ProductVariant.Thumbnail
+		}
+	}
+	flashSaleItemResponse.SalePrice = flashSaleItem.SalePrice
+	if flashSaleItemResponse.Price > 0 {
+		flashSaleItemResponse.Percentage = math.Round((flashSaleItemResponse.Price - flashSaleItem.SalePrice) / flashSaleItemResponse.Price * 100)
+	}
+	flashSaleItemResponse.Quota = flashSaleItem.Quota
+	flashSaleItemResponse.SoldQty = flashSaleItem.SoldQty
+	flashSaleItemResponse.RemainingQuota = flashSaleItem.Quota - flashSaleItem.SoldQty
+	if flashSaleItemResponse.RemainingQuota < 0 {
+		flashSaleItemResponse.RemainingQuota = 0
+	}
+	flashSaleItemResponse.LimitPerUser = flashSaleItem.LimitPerUser
+	flashSaleItemResponse.SoldOut = flashSaleItemResponse.RemainingQuota == 0
+	return flashSaleItemResponse
+}
+
+func ToFlashSaleListResponse(flashSales []entity.FlashSale, now time.Time) (flashSaleListResponse FlashSaleListResponse) {
+	flashSaleListResponse.ServerTime = now.Format("2006-01-02 15:04:05")
+	flashSaleListResponse.FlashSales = []FlashSaleResponse{}
+	for _, flashSale := range flashSales {
+		flashSaleListResponse.FlashSales = append(flashSaleListResponse.FlashSales, ToFlashSaleResponse(flashSale, now))
+	}
+	return flashSaleListResponse
+}
+
+func ToFindFlashSaleListResponse(flashSales []entity.FlashSale, totalData int64, now time.Time) (flashSaleListResponse FindFlashSaleListResponse) {
+	flashSaleListResponse.FlashSales = []FlashSaleResponse{}
+	for _, flashSale := range flashSales {
+		flashSaleListResponse.FlashSales = append(flashSaleListResponse.FlashSales, ToFlashSaleResponse(flashSale, now))
+	}
+	flashSaleListResponse.TotalData = totalData
+	return flashSaleListResponse
+}
@@ -5,33 +5,38 @@ import (
 )
 
 type FindOrderByIdOrderResponse struct {
-	IdOrder        string              `json:"id_order"`
-	TrxId          int                 `json:"trx_id"`
-	ShippingCost   float64             `json:"shipping_cost"`
-	TotalBill      float64             `json:"total_bill"`
-	SubTotal       float64             `json:"sub_total"`
-	OrderStatus    string              `json:"order_status"`
-	PaymentByPoint float64             `json:"payment_by_point"`
-	PaymentByCash  float64             `json:"payment_by_cash"`
-	PaymentFee     float64             `json:"payment_fee"`
-	PaymentMethod  string              `json:"payment_method"`
-	PaymentChannel string              `json:"payment_channel"`
-	ProofOfPayment string              `json:"proof_of_payment"`
-	OrderItems     []OrderItemResponse `json:"order_items"`
-	PaymentDueDate string              `json:"payment_due_date"`
+	IdOrder         string              `json:"id_order"`
+	TrxId           int                 `json:"trx_id"`
+	ShippingCost    float64             `json:"shipping_cost"`
+	TotalBill       float64             `json:"total_bill"`
+	SubTotal        float64             `json:"sub_total"`
+	OrderStatus     string              `json:"order_status"`
+	PaymentByPoint  float64             `json:"payment_by_point"`
+	PaymentByCash   float64             `json:"payment_by_cash"`
+	PaymentFee      float64             `json:"payment_fee"`
+	VoucherCode     string              `json:"voucher_code"`
+	VoucherDiscount float64             `json:"voucher_discount"`
+	PaymentMethod   string              `json:"payment_method"`
+	PaymentChannel  string              `json:"payment_channel"`
+	ProofOfPayment  string              `json:"proof_of_payment"`
+	OrderItems      []OrderItemResponse `json:"order_items"`
+	PaymentDueDate  string              `json:"payment_due_date"`
 }
 
 type OrderItemResponse struct {
-	Id          string  `json:"id"`
-	IdProduct   string  `json:"id_product"`
-	Price       float64 `json:"price"`
-	ProductName string  `json:"product_name"`
-	Description string  `json:"description"`
-	Stock       int     `json:"stock"`
-	PictureUrl  string  `json:"picture_url"`
-	Thumbnail   string  `json:"thumbnail"`
-	Qty         int     `json:"qty"`
-	FlagPromo   string  `json:"flag_promo"`
+	Id               string  `json:"id"`
+	IdProduct        string  `json:"id_product"`
+	IdProductVariant string  `json:"id_product_variant"`
+	IdPromotion      string  `json:"id_promotion"`
+	VariantName      string  `json:"variant_name"`
+	Price            float64 `json:"price"`
+	ProductName      string  `json:"product_name"`
+	Description      string  `json:"description"`
+	Stock            int     `json:"stock"`
+	PictureUrl       string  `json:"picture_url"`
+	Thumbnail        string  `json:"thumbnail"`
+	Qty              int     `json:"qty"`
+	FlagPromo        string  `json:"flag_promo"`
 }
 
 func ToFindOrderByIdOrder(order entity.Order, orderItems []entity.OrderItem) (orderResponse FindOrderByIdOrderResponse) {
@@ -42,6 +47,9 @@ func ToFindOrderByIdOrder(order entity.Order, orderItems []entity.OrderItem) (or
 		var orderItemResponse OrderItemResponse
 		orderItemResponse.Id = orderItem.Id
 		orderItemResponse.IdProduct = orderItem.IdProduct
+		orderItemResponse.IdProductVariant = orderItem.IdProductVariant
+		orderItemResponse.IdPromotion = orderItem.IdPromotion
+		orderItemResponse.VariantName = orderItem.VariantName
 		orderItemResponse.Price = orderItem.Price * float64(orderItem.Qty)
 		orderItemResponse.ProductName = orderItem.ProductName
 		orderItemResponse.Description = orderItem.Description
@@ -67,6 +75,8 @@ func ToFindOrderByIdOrder(order entity.Order, orderItems []entity.OrderItem) (or
 	orderResponse.PaymentByPoint = order.PaymentByPoint
 	orderResponse.PaymentByCash = order.PaymentByCash
 	orderResponse.PaymentFee = order.PaymentFee
+	orderResponse.VoucherCode = order.VoucherCode
+	orderResponse.VoucherDiscount = order.VoucherDiscount
 	orderResponse.OrderStatus = order.OrderSatus
 	orderResponse.ShippingCost = order.ShippingCost
 	orderResponse.SubTotal = totalPricePerItem
@@ -0,0 +1,27 @@
+package response
+
+import (
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+)
+
+type PaginationResponse struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	TotalData  int64  `json:"total_data"`
+	TotalPage  int    `json:"total_page"`
+	NextCursor string `json:"next_cursor"`
+}
+
+func ToPaginationResponse(pagination modelService.Pagination, totalData int64) (paginationResponse PaginationResponse) {
+	paginationResponse.Page = pagination.Page
+	paginationResponse.Limit = pagination.Limit
+	paginationResponse.Sort = pagination.Sort
+	paginationResponse.TotalData = totalData
+	paginationResponse.TotalPage = int((totalData + int64(pagination.Limit) - 1) / int64(pagination.Limit))
+	if int64(pagination.Offset+pagination.Limit) < totalData {
+		paginationResponse.NextCursor = utilities.EncodeCursor(pagination.Sort, pagination.Offset+pagination.Limit)
+	}
+	return paginationResponse
+}
@@ -0,0 +1,25 @@
+package response
+
+import (
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type PointRedemptionCheckResponse struct {
+	Subtotal      float64  `json:"subtotal"`
+	EligibleTotal float64  `json:"eligible_total"`
+	MaxPoint      float64  `json:"max_point"`
+	CanUsePoint   bool     `json:"can_use_point"`
+	Violations    []string `json:"violations"`
+}
+
+func ToPointRedemptionCheckResponse(pointRedemption modelService.PointRedemption) (pointRedemptionResponse PointRedemptionCheckResponse) {
+	pointRedemptionResponse.Subtotal = pointRedemption.Subtotal
+	pointRedemptionResponse.EligibleTotal = pointRedemption.EligibleTotal
+	pointRedemptionResponse.MaxPoint = pointRedemption.MaxPoint
+	pointRedemptionResponse.CanUsePoint = len(pointRedemption.Violations) == 0
+	pointRedemptionResponse.Violations = pointRedemption.Violations
+	if pointRedemptionResponse.Violations == nil {
+		pointRedemptionResponse.Violations = []string{}
+	}
+	return pointRedemptionResponse
+}
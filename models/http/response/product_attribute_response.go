@@ -0,0 +1,37 @@
+package response
+
+import "github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+
+type ProductAttributeResponse struct {
+	Id            string   `json:"id"`
+	AttributeCode string   `json:"attribute_code"`
+	AttributeName string   `json:"attribute_name"`
+	AttributeType string   `json:"attribute_type"`
+	Unit          string   `json:"unit"`
+	IsFilterable  int      `json:"is_filterable"`
+	Position      int      `json:"position"`
+	Options       []string `json:"options"`
+}
+
+func ToProductAttributeResponses(productAttributes []entity.ProductAttribute) (productAttributeResponses []ProductAttributeResponse) {
+	productAttributeResponses = []ProductAttributeResponse{}
+	for _, productAttribute := range productAttributes {
+		productAttributeResponses = append(productAttributeResponses, ToProductAttributeResponse(productAttribute))
+	}
+	return productAttributeResponses
+}
+
+func ToProductAttributeResponse(productAttribute entity.ProductAttribute) (productAttributeResponse ProductAttributeResponse) {
+	productAttributeResponse.Id = productAttribute.Id
+	productAttributeResponse.AttributeCode = productAttribute.AttributeCode
+	productAttributeResponse.AttributeName = productAttribute.AttributeName
+	productAttributeResponse.AttributeType = productAttribute.AttributeType
+	productAttributeResponse.Unit = productAttribute.Unit
+	productAttributeResponse.IsFilterable = productAttribute.IsFilterable
+	productAttributeResponse.Position = productAttribute.Position
+	productAttributeResponse.Options = []string{}
+	for _, productAttributeOption := range productAttribute.ProductAttributeOptions {
+		productAttributeResponse.Options = append(productAttributeResponse.Options, productAttributeOption.Value)
+	}
+	return productAttributeResponse
+}
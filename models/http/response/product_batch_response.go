@@ -0,0 +1,113 @@
+package response
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+// Status active masih bisa dijual, expired sudah lewat tanggal kadaluarsa, empty sudah habis
+type ProductBatchResponse struct {
+	Id               string `json:"id"`
+	IdProduct        string `json:"id_product"`
+	NoSku            string `json:"no_sku"`
+	ProductName      string `json:"product_name"`
+	IdProductVariant string `json:"id_product_variant"`
+	VariantName      string `json:"variant_name"`
+	BatchNumber      string `json:"batch_number"`
+	ExpiryDate       string `json:"expiry_date"`
+	DaysToExpiry     int    `json:"days_to_expiry"`
+	ReceivedQty      int    `json:"received_qty"`
+	RemainingQty     int    `json:"remaining_qty"`
+	Supplier         string `json:"supplier"`
+	Reference        string `json:"reference"`
+	Status           string `json:"status"`
+	CreatedAt        string `json:"created_at"`
+}
+
+type ProductBatchRecallResponse struct {
+	Batches   []ProductBatchResponse            `json:"batches"`
+	Orders    []ProductBatchRecallOrderResponse `json:"orders"`
+	TotalQty  int                               `json:"total_qty"`
+	TotalUser int                               `json:"total_user"`
+}
+
+type ProductBatchRecallOrderResponse struct {
+	IdOrder          string `json:"id_order"`
+	NumberOrder      string `json:"number_order"`
+	IdUser           string `json:"id_user"`
+	FullName         string `json:"full_name"`
+	Phone            string `json:"phone"`
+	OrderStatus      string `json:"order_status"`
+	PaymentSuccessAt string `json:"payment_success_at"`
+	IdProductBatch   string `json:"id_product_batch"`
+	BatchNumber      string `json:"batch_number"`
+	Qty              int    `json:"qty"`
+}
+
+func ProductBatchStatus(productBatch entity.ProductBatch, today time.Time) string {
+	switch {
+	case productBatch.ExpiredAt.Valid || !productBatch.ExpiryDate.After(today):
+		return "expired"
+	case productBatch.RemainingQty <= 0:
+		return "empty"
+	}
+	return "active"
+}
+
+func ToProductBatchResponse(productBatch entity.ProductBatch, today time.Time) (productBatchResponse ProductBatchResponse) {
+	productBatchResponse.Id = productBatch.Id
+	productBatchResponse.IdProduct = productBatch.IdProduct
+	productBatchResponse.NoSku = productBatch.Product.NoSku
+	productBatchResponse.ProductName = productBatch.Product.ProductName
+	productBatchResponse.IdProductVariant = productBatch.IdProductVariant
+	productBatchResponse.VariantName = productBatch.ProductVariant.VariantName
+	if productBatch.ProductVariant.NoSku != "" {
+		productBatchResponse.NoSku = productBatch.ProductVariant.NoSku
+	}
+	productBatchResponse.BatchNumber = productBatch.BatchNumber
+	productBatchResponse.ExpiryDate = productBatch.ExpiryDate.Format("2006-01-02")
+	productBatchResponse.DaysToExpiry = int(productBatch.ExpiryDate.Sub(today).Hours() / 24)
+	productBatchResponse.ReceivedQty = productBatch.ReceivedQty
+	productBatchResponse.RemainingQty = productBatch.RemainingQty
+	productBatchResponse.Supplier = productBatch.Supplier
+	productBatchResponse.Reference = productBatch.Reference
+	productBatchResponse.Status = ProductBatchStatus(productBatch, today)
+	productBatchResponse.CreatedAt = productBatch.CreatedAt.Format("2006-01-02 15:04:05")
+	return productBatchResponse
+}
+
+func ToProductBatchResponses(productBatches []entity.ProductBatch, today time.Time) (productBatchResponses []ProductBatchResponse) {
+	productBatchResponses = []ProductBatchResponse{}
+	for _, productBatch := range productBatches {
+		productBatchResponses = append(productBatchResponses, ToProductBatchResponse(productBatch, today))
+	}
+	return productBatchResponses
+}
+
+func ToProductBatchRecallResponse(productBatches []entity.ProductBatch, orderItemBatches []entity.OrderItemBatch, today time.Time) (productBatchRecallResponse ProductBatchRecallResponse) {
+	productBatchRecallResponse.Batches = ToProductBatchResponses(productBatches, today)
+	productBatchRecallResponse.Orders = []ProductBatchRecallOrderResponse{}
+	users := make(map[string]bool)
+	for _, orderItemBatch := range orderItemBatches {
+		var productBatchRecallOrderResponse ProductBatchRecallOrderResponse
+		productBatchRecallOrderResponse.IdOrder = orderItemBatch.IdOrder
+		productBatchRecallOrderResponse.NumberOrder = orderItemBatch.Order.NumberOrder
+		productBatchRecallOrderResponse.IdUser = orderItemBatch.Order.IdUser
+		productBatchRecallOrderResponse.FullName = orderItemBatch.Order.FullName
+		productBatchRecallOrderResponse.Phone = orderItemBatch.Order.Phone
+		productBatchRecallOrderResponse.OrderStatus = orderItemBatch.Order.OrderSatus
+		if orderItemBatch.Order.PaymentSuccessAt.Valid {
+			productBatchRecallOrderResponse.PaymentSuccessAt = orderItemBatch.Order.PaymentSuccessAt.Time.Format("2006-01-02 15:04:05")
+		}
+		productBatchRecallOrderResponse.IdProductBatch = orderItemBatch.IdProductBatch
+		productBatchRecallOrderResponse.BatchNumber = orderItemBatch.ProductBatch.BatchNumber
+		productBatchRecallOrderResponse.Qty = orderItemBatch.Qty
+		productBatchRecallResponse.Orders = append(productBatchRecallResponse.Orders, productBatchRecallOrderResponse)
+
+		productBatchRecallResponse.TotalQty += orderItemBatch.Qty
+		users[orderItemBatch.Order.IdUser] = true
+	}
+	productBatchRecallResponse.TotalUser = len(users)
+	return productBatchRecallResponse
+}
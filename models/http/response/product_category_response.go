@@ -0,0 +1,95 @@
+package response
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type FindProductCategoryResponse struct {
+	Id            int                              `json:"id"`
+	CategoryName  string                           `json:"category_name"`
+	Icon          string                           `json:"icon"`
+	SortOrder     int                              `json:"sort_order"`
+	IsActive      int                              `json:"is_active"`
+	ProductCount  int                              `json:"product_count"`
+	InStockCount  int                              `json:"in_stock_count"`
+	SubCategories []FindProductSubCategoryResponse `json:"sub_categories"`
+}
+
+type FindProductSubCategoryResponse struct {
+	Id              int    `json:"id"`
+	IdCategory      int    `json:"id_category"`
+	SubCategoryName string `json:"sub_category_name"`
+	Icon            string `json:"icon"`
+	SortOrder       int    `json:"sort_order"`
+	IsActive        int    `json:"is_active"`
+	ProductCount    int    `json:"product_count"`
+	InStockCount    int    `json:"in_stock_count"`
+}
+
+// Pohon kategori, kategori dan sub kategori nonaktif hanya disertakan jika includeInactive
+func ToFindProductCategoryTreeResponses(categories []entity.ProductCategory, productCategoryCounts []modelService.ProductCategoryCount, includeInactive bool) (categoryResponses []FindProductCategoryResponse) {
+	categoryCounts := make(map[int]modelService.ProductCategoryCount)
+	subCategoryCounts := make(map[int]modelService.ProductCategoryCount)
+	for _, productCategoryCount := range productCategoryCounts {
+		categoryCount := categoryCounts[productCategoryCount.IdCategory]
+		categoryCount.Published = categoryCount.Published + productCategoryCount.Published
+		categoryCount.InStock = categoryCount.InStock + productCategoryCount.InStock
+		categoryCounts[productCategoryCount.IdCategory] = categoryCount
+
+		subCategoryCount := subCategoryCounts[productCategoryCount.IdSubCategory]
+		subCategoryCount.Published = subCategoryCount.Published + productCategoryCount.Published
+		subCategoryCount.InStock = subCategoryCount.InStock + productCategoryCount.InStock
+		subCategoryCounts[productCategoryCount.IdSubCategory] = subCategoryCount
+	}
+
+	categoryResponses = []FindProductCategoryResponse{}
+	for _, category := range categories {
+		if category.IsActive != 1 && !includeInactive {
+			continue
+		}
+		var categoryResponse FindProductCategoryResponse
+		categoryResponse.Id = category.Id
+		categoryResponse.CategoryName = category.CategoryName
+		categoryResponse.Icon = category.Icon
+		categoryResponse.SortOrder = category.SortOrder
+		categoryResponse.IsActive = category.IsActive
+		categoryResponse.ProductCount = categoryCounts[category.Id].Published
+		categoryResponse.InStockCount = categoryCounts[category.Id].InStock
+		categoryResponse.SubCategories = []FindProductSubCategoryResponse{}
+		for _, subCategory := range category.ProductSubCategories {
+			if subCategory.IsActive != 1 && !includeInactive {
+				continue
+			}
+			subCategoryResponse := ToFindProductSubCategoryResponse(subCategory)
+			subCategoryResponse.ProductCount = subCategoryCounts[subCategory.Id].Published
+			subCategoryResponse.InStockCount = subCategoryCounts[subCategory.Id].InStock
+			categoryResponse.SubCategories = append(categoryResponse.SubCategories, subCategoryResponse)
+		}
+		categoryResponses = append(categoryResponses, categoryResponse)
+	}
+	return categoryResponses
+}
+
+func ToFindProductCategoryResponse(category entity.ProductCategory) (categoryResponse FindProductCategoryResponse) {
+	categoryResponse.Id = category.Id
+	categoryResponse.CategoryName = category.CategoryName
+	categoryResponse.Icon = category.Icon
+	categoryResponse.SortOrder = category.SortOrder
+	categoryResponse.IsActive = category.IsActive
+	categoryResponse.SubCategories = []FindProductSubCategoryResponse{}
+	for _, subCategory := range category.ProductSubCategories {
+		categoryResponse.SubCategories = append(categoryResponse.SubCategories, ToFindProductSubCategoryResponse(subCategory))
+	}
+	return categoryResponse
+}
+
+func ToFindProductSubCategoryResponse(subCategory entity.ProductSubCategory) (subCategoryResponse FindProductSubCategoryResponse) {
+	subCategoryResponse.Id = subCategory.Id
+	subCategoryResponse.IdCategory = subCategory.IdCategory
+	subCategoryResponse.SubCategoryName = subCategory.SubCategoryName
+	subCategoryResponse.Icon = subCategory.Icon
+	subCategoryResponse.SortOrder = subCategory.SortOrder
+	subCategoryResponse.IsActive = subCategory.IsActive
+	return subCategoryResponse
+}
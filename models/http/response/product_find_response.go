@@ -1,40 +1,84 @@
 package response
 
 import (
+	"sort"
+	"strconv"
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
 )
 
 type FindProductResponse struct {
-	Id            string  `json:"id"`
-	IdCategory    int     `json:"id_category"`
-	IdSubCategory int     `json:"id_sub_category"`
-	ProductName   string  `json:"product_name"`
-	Price         float64 `json:"price"`
-	Description   string  `json:"description"`
-	PictureUrl    string  `json:"picture_url"`
-	Thumbnail     string  `json:"thumbnail"`
-	Stock         int     `json:"stock"`
-	FlagPromo     string  `json:"flag_promo"`
-	Percentage    float64 `json:"discount_percentage"`
-	Nominal       float64 `json:"discount_nominal"`
+	Id             string                          `json:"id"`
+	IdCategory     int                             `json:"id_category"`
+	IdSubCategory  int                             `json:"id_sub_category"`
+	ProductName    string                          `json:"product_name"`
+	Price          float64                         `json:"price"`
+	Description    string                          `json:"description"`
+	PictureUrl     string                          `json:"picture_url"`
+	Thumbnail      string                          `json:"thumbnail"`
+	Stock          int                             `json:"stock"`
+	FlagPromo      string                          `json:"flag_promo"`
+	Percentage     float64                         `json:"discount_percentage"`
+	Nominal        float64                         `json:"discount_nominal"`
+	PromoEndAt     string                          `json:"promo_end_at"`
+	IsWishlisted   bool                            `json:"is_wishlisted"`
+	PriceDropped   bool                            `json:"price_dropped"`
+	PriceDropFrom  float64                         `json:"price_drop_from"`
+	MaxQtyPerOrder int                             `json:"max_qty_per_order"`
+	Images         []ProductImageResponse          `json:"images"`
+	Attributes     []ProductAttributeValueResponse `json:"attributes"`
+	Options        []ProductOptionResponse         `json:"options"`
+	Variants       []ProductVariantResponse        `json:"variants"`
+}
+
+type ProductImageResponse struct {
+	Id        string `json:"id"`
+	Thumbnail string `json:"thumbnail"`
+	Medium    string `json:"medium"`
+	Large     string `json:"large"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Position  int    `json:"position"`
+}
+
+type ProductAttributeValueResponse struct {
+	AttributeCode string   `json:"attribute_code"`
+	AttributeName string   `json:"attribute_name"`
+	AttributeType string   `json:"attribute_type"`
+	Unit          string   `json:"unit"`
+	Values        []string `json:"values"`
+}
+
+type ProductOptionResponse struct {
+	Id         string                       `json:"id"`
+	OptionName string                       `json:"option_name"`
+	Values     []ProductOptionValueResponse `json:"values"`
+}
+
+type ProductOptionValueResponse struct {
+	Id    string `json:"id"`
+	Value string `json:"value"`
+}
+
+type ProductVariantResponse struct {
+	Id           string                       `json:"id"`
+	NoSku        string                       `json:"no_sku"`
+	VariantName  string                       `json:"variant_name"`
+	Price        float64                      `json:"price"`
+	PricePromo   float64                      `json:"price_promo"`
+	Stock        int                          `json:"stock"`
+	Weight       float64                      `json:"weight"`
+	PictureUrl   string                       `json:"picture_url"`
+	Thumbnail    string                       `json:"thumbnail"`
+	OptionValues []ProductOptionValueResponse `json:"option_values"`
 }
 
 func ToFindProductResponses(products []entity.Product) (productResponses []FindProductResponse) {
 	for _, product := range products {
-		var productResponse FindProductResponse
-		productResponse.Id = product.Id
-		productResponse.IdCategory = product.IdCategory
-		productResponse.IdSubCategory = product.IdSubCategory
-		productResponse.ProductName = product.ProductName
-		productResponse.Price = product.Price
-		productResponse.Description = product.Description
-		productResponse.PictureUrl = product.PictureUrl
-		productResponse.Thumbnail = product.Thumbnail
-		productResponse.Stock = product.Stock
-		productResponse.Percentage = product.ProductDiscount.Percentage
-		productResponse.FlagPromo = product.ProductDiscount.FlagPromo
-		productResponse.Nominal = product.ProductDiscount.Nominal
-		productResponses = append(productResponses, productResponse)
+		productResponses = append(productResponses, ToFindProductResponse(product))
 	}
 	return productResponses
 }
@@ -46,11 +90,130 @@ func ToFindProductResponse(product entity.Product) (productResponse FindProductR
 	productResponse.ProductName = product.ProductName
 	productResponse.Price = product.Price
 	productResponse.Description = product.Description
+	productResponse.MaxQtyPerOrder = product.MaxQtyPerOrder
 	productResponse.PictureUrl = product.PictureUrl
 	productResponse.Thumbnail = product.Thumbnail
 	productResponse.Stock = product.Stock
-	productResponse.FlagPromo = product.ProductDiscount.FlagPromo
-	productResponse.Percentage = product.ProductDiscount.Percentage
-	productResponse.Nominal = product.ProductDiscount.Nominal
+
+	// Harga promo dihitung dari promo yang sedang berlaku
+	productPricing := pricing.EvaluateProductPricing(product, entity.ProductVariant{}, time.Now())
+	productResponse.FlagPromo = strconv.FormatBool(productPricing.OnPromo)
+	if productPricing.OnPromo {
+		productResponse.Percentage = productPricing.Percentage
+		productResponse.Nominal = productPricing.Price
+		productResponse.PromoEndAt = formatPromoEndAt(productPricing.PromoEndAt)
+	}
+
+	productResponse.Images = ToProductImageResponses(product.ProductImages)
+	productResponse.Attributes = ToProductAttributeValueResponses(product.ProductAttributeValues)
+
+	productResponse.Options = []ProductOptionResponse{}
+	for _, productOption := range product.ProductOptions {
+		var productOptionResponse ProductOptionResponse
+		productOptionResponse.Id = productOption.Id
+		productOptionResponse.OptionName = productOption.OptionName
+		productOptionResponse.Values = ToProductOptionValueResponses(productOption.ProductOptionValues)
+		productResponse.Options = append(productResponse.Options, productOptionResponse)
+	}
+
+	productResponse.Variants = []ProductVariantResponse{}
+	for _, productVariant := range product.ProductVariants {
+		var productVariantResponse ProductVariantResponse
+		productVariantResponse.Id = productVariant.Id
+		productVariantResponse.NoSku = productVariant.NoSku
+		productVariantResponse.VariantName = productVariant.VariantName
+		productVariantPricing := pricing.EvaluateProductPricing(product, productVariant, time.Now())
+		productVariantResponse.Price = productVariantPricing.PriceBeforeDiscount
+		productVariantResponse.PricePromo = productVariantPricing.Price
+		productVariantResponse.Stock = productVariant.Stock
+		productVariantResponse.Weight = productVariant.Weight
+		productVariantResponse.PictureUrl = productVariant.PictureUrl
+		productVariantResponse.Thumbnail = productVariant.Thumbnail
+		productVariantResponse.OptionValues = ToProductOptionValueResponses(productVariant.ProductOptionValues)
+		productResponse.Variants = append(productResponse.Variants, productVariantResponse)
+	}
 	return productResponse
 }
+
+func ToProductImageResponses(productImages []entity.ProductImage) (productImageResponses []ProductImageResponse) {
+	productImageResponses = []ProductImageResponse{}
+	for _, productImage := range productImages {
+		productImageResponses = append(productImageResponses, ToProductImageResponse(productImage))
+	}
+	return productImageResponses
+}
+
+func ToProductImageResponse(productImage entity.ProductImage) (productImageResponse ProductImageResponse) {
+	productImageResponse.Id = productImage.Id
+	productImageResponse.Thumbnail = productImage.ThumbnailUrl
+	productImageResponse.Medium = productImage.MediumUrl
+	productImageResponse.Large = productImage.LargeUrl
+	productImageResponse.Width = productImage.Width
+	productImageResponse.Height = productImage.Height
+	productImageResponse.Position = productImage.Position
+	return productImageResponse
+}
+
+// Nilai multi_select digabung dalam satu atribut, atribut diurutkan sesuai posisi definisinya
+func ToProductAttributeValueResponses(productAttributeValues []entity.ProductAttributeValue) (productAttributeValueResponses []ProductAttributeValueResponse) {
+	productAttributeValueResponses = []ProductAttributeValueResponse{}
+	positions := make(map[string]int)
+	indexes := make(map[string]int)
+	for _, productAttributeValue := range productAttributeValues {
+		productAttribute := productAttributeValue.ProductAttribute
+		index, ok := indexes[productAttribute.Id]
+		if !ok {
+			index = len(productAttributeValueResponses)
+			indexes[productAttribute.Id] = index
+			positions[productAttribute.AttributeCode] = productAttribute.Position
+			var productAttributeValueResponse ProductAttributeValueResponse
+			productAttributeValueResponse.AttributeCode = productAttribute.AttributeCode
+			productAttributeValueResponse.AttributeName = productAttribute.AttributeName
+			productAttributeValueResponse.AttributeType = productAttribute.AttributeType
+			productAttributeValueResponse.Unit = productAttribute.Unit
+			productAttributeValueResponse.Values = []string{}
+			productAttributeValueResponses = append(productAttributeValueResponses, productAttributeValueResponse)
+		}
+		productAttributeValueResponses[index].Values = append(productAttributeValueResponses[index].Values, productAttributeValue.Value)
+	}
+	sort.SliceStable(productAttributeValueResponses, func(i, j int) bool {
+		if positions[productAttributeValueResponses[i].AttributeCode] != positions[productAttributeValueResponses[j].AttributeCode] {
+			return positions[productAttributeValueResponses[i].AttributeCode] < positions[productAttributeValueResponses[j].AttributeCode]
+		}
+		return productAttributeValueResponses[i].AttributeName < productAttributeValueResponses[j].AttributeName
+	})
+	return productAttributeValueResponses
+}
+
+func ToProductOptionValueResponses(productOptionValues []entity.ProductOptionValue) (productOptionValueResponses []ProductOptionValueResponse) {
+	productOptionValueResponses = []ProductOptionValueResponse{}
+	for _, productOptionValue := range productOptionValues {
+		var productOptionValueResponse ProductOptionValueResponse
+		productOptionValueResponse.Id = productOptionValue.Id
+		productOptionValueResponse.Value = productOptionValue.Value
+		productOptionValueResponses = append(productOptionValueResponses, productOptionValueResponse)
+	}
+	return productOptionValueResponses
+}
+
+type FindProductListResponse struct {
+	Products   []FindProductResponse `json:"products"`
+	Pagination PaginationResponse    `json:"pagination"`
+}
+
+func ToFindProductListResponse(products []entity.Product, pagination modelService.Pagination, totalData int64) (productListResponse FindProductListResponse) {
+	productListResponse.Products = ToFindProductResponses(products)
+	if productListResponse.Products == nil {
+		productListResponse.Products = []FindProductResponse{}
+	}
+	productListResponse.Pagination = ToPaginationResponse(pagination, totalData)
+	return productListResponse
+}
+
+// Waktu berakhir promo untuk countdown, kosong jika promo tidak punya batas waktu
+func formatPromoEndAt(promoEndAt time.Time) string {
+	if promoEndAt.IsZero() {
+		return ""
+	}
+	return promoEndAt.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type ProductPriceHistoryListResponse struct {
+	IdProduct    string                        `json:"id_product"`
+	ProductName  string                        `json:"product_name"`
+	Price        float64                       `json:"price"`
+	SellingPrice float64                       `json:"selling_price"`
+	Histories    []ProductPriceHistoryResponse `json:"histories"`
+	Pagination   PaginationResponse            `json:"pagination"`
+}
+
+type ProductPriceHistoryResponse struct {
+	Id              string  `json:"id"`
+	OldPrice        float64 `json:"old_price"`
+	NewPrice        float64 `json:"new_price"`
+	OldSellingPrice float64 `json:"old_selling_price"`
+	NewSellingPrice float64 `json:"new_selling_price"`
+	OldDiscount     string  `json:"old_discount"`
+	NewDiscount     string  `json:"new_discount"`
+	Source          string  `json:"source"`
+	CreatedBy       string  `json:"created_by"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+func ToProductPriceHistoryListResponse(product entity.Product, sellingPrice float64, productPriceHistories []entity.ProductPriceHistory, pagination modelService.Pagination, totalData int64) (productPriceHistoryListResponse ProductPriceHistoryListResponse) {
+	productPriceHistoryListResponse.IdProduct = product.Id
+	productPriceHistoryListResponse.ProductName = product.ProductName
+	productPriceHistoryListResponse.Price = product.Price
+	productPriceHistoryListResponse.SellingPrice = sellingPrice
+	productPriceHistoryListResponse.Histories = []ProductPriceHistoryResponse{}
+	for _, productPriceHistory := range productPriceHistories {
+		var productPriceHistoryResponse ProductPriceHistoryResponse
+		productPriceHistoryResponse.Id = productPriceHistory.Id
+		productPriceHistoryResponse.OldPrice = productPriceHistory.OldPrice
+		productPriceHistoryResponse.NewPrice = productPriceHistory.NewPrice
+		productPriceHistoryResponse.OldSellingPrice = productPriceHistory.OldSellingPrice
+		productPriceHistoryResponse.NewSellingPrice = productPriceHistory.NewSellingPrice
+		productPriceHistoryResponse.OldDiscount = productPriceHistory.OldDiscount
+		productPriceHistoryResponse.NewDiscount = productPriceHistory.NewDiscount
+		productPriceHistoryResponse.Source = productPriceHistory.Source
+		productPriceHistoryResponse.CreatedBy = productPriceHistory.CreatedBy
+		productPriceHistoryResponse.CreatedAt = productPriceHistory.CreatedAt.Format("2006-01-02 15:04:05")
+		productPriceHistoryListResponse.Histories = append(productPriceHistoryListResponse.Histories, productPriceHistoryResponse)
+	}
+	productPriceHistoryListResponse.Pagination = ToPaginationResponse(pagination, totalData)
+	return productPriceHistoryListResponse
+}
@@ -0,0 +1,30 @@
+package response
+
+import "github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+
+type FindProductRecommendationResponse struct {
+	FrequentlyBoughtTogether []FindProductResponse `json:"frequently_bought_together"`
+	CustomersAlsoBought      []FindProductResponse `json:"customers_also_bought"`
+}
+
+type FindHomeFeedResponse struct {
+	BuyAgain          []FindProductResponse `json:"buy_again"`
+	RecommendedForYou []FindProductResponse `json:"recommended_for_you"`
+}
+
+// Urutan response mengikuti urutan id rekomendasi, produk yang tidak lagi tayang dilewati
+func ToFindProductResponsesByIds(products []entity.Product, idProducts []string) (productResponses []FindProductResponse) {
+	productsById := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		productsById[product.Id] = product
+	}
+	productResponses = []FindProductResponse{}
+	for _, idProduct := range idProducts {
+		product, ok := productsById[idProduct]
+		if !ok {
+			continue
+		}
+		productResponses = append(productResponses, ToFindProductResponse(product))
+	}
+	return productResponses
+}
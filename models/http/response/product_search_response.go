@@ -0,0 +1,76 @@
+package response
+
+import (
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+// SearchId dikirim kembali ke /product/id saat produk dari hasil pencarian dibuka
+type ProductSearchResponse struct {
+	SearchId   string                      `json:"search_id"`
+	Query      string                      `json:"query"`
+	Products   []FindProductResponse       `json:"products"`
+	Pagination PaginationResponse          `json:"pagination"`
+	Facets     ProductSearchFacetsResponse `json:"facets"`
+}
+
+type ProductSearchFacetsResponse struct {
+	Category    []ProductSearchFacetResponse          `json:"category"`
+	SubCategory []ProductSearchFacetResponse          `json:"sub_category"`
+	Brand       []ProductSearchFacetResponse          `json:"brand"`
+	Attributes  []ProductSearchAttributeFacetResponse `json:"attributes"`
+	PriceMin    float64                               `json:"price_min"`
+	PriceMax    float64                               `json:"price_max"`
+	InStock     int                                   `json:"in_stock"`
+	OnPromo     int                                   `json:"on_promo"`
+}
+
+type ProductSearchAttributeFacetResponse struct {
+	AttributeCode string                       `json:"attribute_code"`
+	AttributeName string                       `json:"attribute_name"`
+	Unit          string                       `json:"unit"`
+	Values        []ProductSearchFacetResponse `json:"values"`
+}
+
+type ProductSearchFacetResponse struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func ToProductSearchResponse(query string, pagination modelService.Pagination, productSearch modelService.ProductSearch) (productSearchResponse ProductSearchResponse) {
+	productSearchResponse.Query = query
+	productSearchResponse.Products = ToFindProductResponses(productSearch.Products)
+	if productSearchResponse.Products == nil {
+		productSearchResponse.Products = []FindProductResponse{}
+	}
+	productSearchResponse.Pagination = ToPaginationResponse(pagination, productSearch.TotalData)
+	productSearchResponse.Facets.Category = ToProductSearchFacetResponses(productSearch.CategoryFacets)
+	productSearchResponse.Facets.SubCategory = ToProductSearchFacetResponses(productSearch.SubCategoryFacets)
+	productSearchResponse.Facets.Brand = ToProductSearchFacetResponses(productSearch.BrandFacets)
+	productSearchResponse.Facets.Attributes = []ProductSearchAttributeFacetResponse{}
+	for _, attributeFacet := range productSearch.AttributeFacets {
+		var attributeFacetResponse ProductSearchAttributeFacetResponse
+		attributeFacetResponse.AttributeCode = attributeFacet.AttributeCode
+		attributeFacetResponse.AttributeName = attributeFacet.AttributeName
+		attributeFacetResponse.Unit = attributeFacet.Unit
+		attributeFacetResponse.Values = ToProductSearchFacetResponses(attributeFacet.Facets)
+		productSearchResponse.Facets.Attributes = append(productSearchResponse.Facets.Attributes, attributeFacetResponse)
+	}
+	productSearchResponse.Facets.PriceMin = productSearch.PriceMin
+	productSearchResponse.Facets.PriceMax = productSearch.PriceMax
+	productSearchResponse.Facets.InStock = productSearch.InStockCount
+	productSearchResponse.Facets.OnPromo = productSearch.OnPromoCount
+	return productSearchResponse
+}
+
+func ToProductSearchFacetResponses(productSearchFacets []modelService.ProductSearchFacet) (productSearchFacetResponses []ProductSearchFacetResponse) {
+	productSearchFacetResponses = []ProductSearchFacetResponse{}
+	for _, productSearchFacet := range productSearchFacets {
+		var productSearchFacetResponse ProductSearchFacetResponse
+		productSearchFacetResponse.Id = productSearchFacet.Id
+		productSearchFacetResponse.Name = productSearchFacet.Name
+		productSearchFacetResponse.Count = productSearchFacet.Count
+		productSearchFacetResponses = append(productSearchFacetResponses, productSearchFacetResponse)
+	}
+	return productSearchFacetResponses
+}
@@ -0,0 +1,84 @@
+package response
+
+import "github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+
+type ProductStockMovementResponse struct {
+	IdProduct        string `json:"id_product"`
+	IdProductVariant string `json:"id_product_variant"`
+	TxType           string `json:"tx_type"`
+	ReasonCode       string `json:"reason_code"`
+	BatchNumber      string `json:"batch_number"`
+	StockOpname      int    `json:"stock_opname"`
+	StockInQty       int    `json:"stock_in_qty"`
+	StockOutQty      int    `json:"stock_out_qty"`
+	StockFinal       int    `json:"stock_final"`
+}
+
+type GoodsReceivingResponse struct {
+	Supplier  string                         `json:"supplier"`
+	Reference string                         `json:"reference"`
+	Items     []ProductStockMovementResponse `json:"items"`
+}
+
+type StockCardResponse struct {
+	IdProduct        string                      `json:"id_product"`
+	ProductName      string                      `json:"product_name"`
+	IdProductVariant string                      `json:"id_product_variant"`
+	VariantName      string                      `json:"variant_name"`
+	DateFrom         string                      `json:"date_from"`
+	DateTo           string                      `json:"date_to"`
+	OpeningStock     int                         `json:"opening_stock"`
+	TotalIn          int                         `json:"total_in"`
+	TotalOut         int                         `json:"total_out"`
+	ClosingStock     int                         `json:"closing_stock"`
+	Movements        []StockCardMovementResponse `json:"movements"`
+}
+
+type StockCardMovementResponse struct {
+	TxDate      string `json:"tx_date"`
+	TxType      string `json:"tx_type"`
+	ReasonCode  string `json:"reason_code"`
+	Supplier    string `json:"supplier"`
+	Reference   string `json:"reference"`
+	BatchNumber string `json:"batch_number"`
+	Description string `json:"description"`
+	StockOpname int    `json:"stock_opname"`
+	StockInQty  int    `json:"stock_in_qty"`
+	StockOutQty int    `json:"stock_out_qty"`
+	StockFinal  int    `json:"stock_final"`
+	CreatedBy   string `json:"created_by"`
+}
+
+func ToProductStockMovementResponse(productStockHistory entity.ProductStockHistory) (productStockMovementResponse ProductStockMovementResponse) {
+	productStockMovementResponse.IdProduct = productStockHistory.IdProduct
+	productStockMovementResponse.IdProductVariant = productStockHistory.IdProductVariant
+	productStockMovementResponse.TxType = productStockHistory.TxType
+	productStockMovementResponse.ReasonCode = productStockHistory.ReasonCode
+	productStockMovementResponse.BatchNumber = productStockHistory.BatchNumber
+	productStockMovementResponse.StockOpname = productStockHistory.StockOpname
+	productStockMovementResponse.StockInQty = productStockHistory.StockInQty
+	productStockMovementResponse.StockOutQty = productStockHistory.StockOutQty
+	productStockMovementResponse.StockFinal = productStockHistory.StockFinal
+	return productStockMovementResponse
+}
+
+func ToStockCardMovementResponses(productStockHistories []entity.ProductStockHistory) (stockCardMovementResponses []StockCardMovementResponse) {
+	stockCardMovementResponses = []StockCardMovementResponse{}
+	for _, productStockHistory := range productStockHistories {
+		var stockCardMovementResponse StockCardMovementResponse
+		stockCardMovementResponse.TxDate = productStockHistory.TxDate.Format("2006-01-02 15:04:05")
+		stockCardMovementResponse.TxType = productStockHistory.TxType
+		stockCardMovementResponse.ReasonCode = productStockHistory.ReasonCode
+		stockCardMovementResponse.Supplier = productStockHistory.Supplier
+		stockCardMovementResponse.Reference = productStockHistory.Reference
+		stockCardMovementResponse.BatchNumber = productStockHistory.BatchNumber
+		stockCardMovementResponse.Description = productStockHistory.Description
+		stockCardMovementResponse.StockOpname = productStockHistory.StockOpname
+		stockCardMovementResponse.StockInQty = productStockHistory.StockInQty
+		stockCardMovementResponse.StockOutQty = productStockHistory.StockOutQty
+		stockCardMovementResponse.StockFinal = productStockHistory.StockFinal
+		stockCardMovementResponse.CreatedBy = productStockHistory.CreatedBy
+		stockCardMovementResponses = append(stockCardMovementResponses, stockCardMovementResponse)
+	}
+	return stockCardMovementResponses
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type FindReferalBonusReviewResponse struct {
+	Page      int                          `json:"page"`
+	Limit     int                          `json:"limit"`
+	TotalData int64                        `json:"total_data"`
+	TotalPage int                          `json:"total_page"`
+	Reviews   []FindReferalBonusReviewItem `json:"reviews"`
+}
+
+type FindReferalBonusReviewItem struct {
+	Id                  string    `json:"id"`
+	IdUser              string    `json:"id_user"`
+	UserFullName        string    `json:"user_full_name"`
+	UserPhone           string    `json:"user_phone"`
+	IdUserReferal       string    `json:"id_user_referal"`
+	UserReferalFullName string    `json:"user_referal_full_name"`
+	UserReferalPhone    string    `json:"user_referal_phone"`
+	Event               string    `json:"event"`
+	NoOrder             string    `json:"no_order"`
+	BonusUser           float64   `json:"bonus_user"`
+	BonusReferal        float64   `json:"bonus_referal"`
+	FraudScore          int       `json:"fraud_score"`
+	FraudReasons        []string  `json:"fraud_reasons"`
+	Status              string    `json:"status"`
+	ReviewNote          string    `json:"review_note"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+func ToFindReferalBonusReviewResponse(page int, limit int, totalData int64, referalBonusReviews []entity.ReferalBonusReview) (referalBonusReviewResponse FindReferalBonusReviewResponse) {
+	referalBonusReviewResponse.Page = page
+	referalBonusReviewResponse.Limit = limit
+	referalBonusReviewResponse.TotalData = totalData
+	referalBonusReviewResponse.TotalPage = int((totalData + int64(limit) - 1) / int64(limit))
+	referalBonusReviewResponse.Reviews = []FindReferalBonusReviewItem{}
+	for _, referalBonusReview := range referalBonusReviews {
+		var referalBonusReviewItem FindReferalBonusReviewItem
+		referalBonusReviewItem.Id = referalBonusReview.Id
+		referalBonusReviewItem.IdUser = referalBonusReview.IdUser
+		referalBonusReviewItem.UserFullName = referalBonusReview.User.FamilyMembers.FullName
+		referalBonusReviewItem.UserPhone = referalBonusReview.User.FamilyMembers.Phone
+		referalBonusReviewItem.IdUserReferal = referalBonusReview.IdUserReferal
+		referalBonusReviewItem.UserReferalFullName = referalBonusReview.UserReferal.FamilyMembers.FullName
+		referalBonusReviewItem.UserReferalPhone = referalBonusReview.UserReferal.FamilyMembers.Phone
+		referalBonusReviewItem.Event = referalBonusReview.Event
+		referalBonusReviewItem.NoOrder = referalBonusReview.NoOrder
+		referalBonusReviewItem.BonusUser = referalBonusReview.BonusUser
+		referalBonusReviewItem.BonusReferal = referalBonusReview.BonusReferal
+		referalBonusReviewItem.FraudScore = referalBonusReview.FraudScore
+		referalBonusReviewItem.FraudReasons = []string{}
+		if referalBonusReview.FraudReasons != "" {
+			referalBonusReviewItem.FraudReasons = strings.Split(referalBonusReview.FraudReasons, ",")
+		}
+		referalBonusReviewItem.Status = referalBonusReview.Status
+		referalBonusReviewItem.ReviewNote = referalBonusReview.ReviewNote
+		referalBonusReviewItem.CreatedAt = referalBonusReview.CreatedDate
+		referalBonusReviewResponse.Reviews = append(referalBonusReviewResponse.Reviews, referalBonusReviewItem)
+	}
+	return referalBonusReviewResponse
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+type FindRestockSubscriptionResponse struct {
+	Id               string    `json:"id"`
+	IdProduct        string    `json:"id_product"`
+	IdProductVariant string    `json:"id_product_variant"`
+	ProductName      string    `json:"product_name"`
+	VariantName      string    `json:"variant_name"`
+	Thumbnail        string    `json:"thumbnail"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func ToFindRestockSubscriptionResponses(restockSubscriptions []entity.RestockSubscription) (restockSubscriptionResponses []FindRestockSubscriptionResponse) {
+	restockSubscriptionResponses = []FindRestockSubscriptionResponse{}
+	for _, restockSubscription := range restockSubscriptions {
+		restockSubscriptionResponses = append(restockSubscriptionResponses, ToFindRestockSubscriptionResponse(restockSubscription))
+	}
+	return restockSubscriptionResponses
+}
+
+func ToFindRestockSubscriptionResponse(restockSubscription entity.RestockSubscription) (restockSubscriptionResponse FindRestockSubscriptionResponse) {
+	restockSubscriptionResponse.Id = restockSubscription.Id
+	restockSubscriptionResponse.IdProduct = restockSubscription.IdProduct
+	restockSubscriptionResponse.IdProductVariant = restockSubscription.IdProductVariant
+	restockSubscriptionResponse.ProductName = restockSubscription.Product.ProductName
+	restockSubscriptionResponse.VariantName = restockSubscription.ProductVariant.VariantName
+	restockSubscriptionResponse.Thumbnail = restockSubscription.Product.Thumbnail
+	if restockSubscription.ProductVariant.Thumbnail != "" {
+		restockSubscriptionResponse.Thumbnail = restockSubscription.ProductVariant.Thumbnail
+	}
+	restockSubscriptionResponse.CreatedAt = restockSubscription.CreatedAt
+	return restockSubscriptionResponse
+}
@@ -0,0 +1,82 @@
+package response
+
+import (
+	"math"
+	"time"
+
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type SearchTermReportResponse struct {
+	DateFrom string                   `json:"date_from"`
+	DateTo   string                   `json:"date_to"`
+	Terms    []SearchTermStatResponse `json:"terms"`
+}
+
+type SearchConversionReportResponse struct {
+	DateFrom       string                   `json:"date_from"`
+	DateTo         string                   `json:"date_to"`
+	TotalSearch    int64                    `json:"total_search"`
+	TotalClick     int64                    `json:"total_click"`
+	TotalCart      int64                    `json:"total_cart"`
+	ClickRate      float64                  `json:"click_rate"`
+	ConversionRate float64                  `json:"conversion_rate"`
+	Terms          []SearchTermStatResponse `json:"terms"`
+}
+
+// TotalClick dan TotalCart dihitung per pencarian, rate dalam persen dari TotalSearch
+type SearchTermStatResponse struct {
+	Term           string  `json:"term"`
+	TotalSearch    int64   `json:"total_search"`
+	TotalUser      int64   `json:"total_user"`
+	AvgResult      float64 `json:"avg_result"`
+	TotalClick     int64   `json:"total_click"`
+	TotalCart      int64   `json:"total_cart"`
+	ClickRate      float64 `json:"click_rate"`
+	ConversionRate float64 `json:"conversion_rate"`
+	LastSearchedAt string  `json:"last_searched_at"`
+}
+
+func ToSearchTermReportResponse(dateFrom time.Time, dateTo time.Time, searchTermStats []modelService.SearchTermStat) (searchTermReportResponse SearchTermReportResponse) {
+	searchTermReportResponse.DateFrom = dateFrom.Format("2006-01-02")
+	searchTermReportResponse.DateTo = dateTo.Format("2006-01-02")
+	searchTermReportResponse.Terms = ToSearchTermStatResponses(searchTermStats)
+	return searchTermReportResponse
+}
+
+func ToSearchConversionReportResponse(dateFrom time.Time, dateTo time.Time, searchSummary modelService.SearchSummary, searchTermStats []modelService.SearchTermStat) (searchConversionReportResponse SearchConversionReportResponse) {
+	searchConversionReportResponse.DateFrom = dateFrom.Format("2006-01-02")
+	searchConversionReportResponse.DateTo = dateTo.Format("2006-01-02")
+	searchConversionReportResponse.TotalSearch = searchSummary.TotalSearch
+	searchConversionReportResponse.TotalClick = searchSummary.TotalClick
+	searchConversionReportResponse.TotalCart = searchSummary.TotalCart
+	searchConversionReportResponse.ClickRate = searchRate(searchSummary.TotalClick, searchSummary.TotalSearch)
+	searchConversionReportResponse.ConversionRate = searchRate(searchSummary.TotalCart, searchSummary.TotalSearch)
+	searchConversionReportResponse.Terms = ToSearchTermStatResponses(searchTermStats)
+	return searchConversionReportResponse
+}
+
+func ToSearchTermStatResponses(searchTermStats []modelService.SearchTermStat) (searchTermStatResponses []SearchTermStatResponse) {
+	searchTermStatResponses = []SearchTermStatResponse{}
+	for _, searchTermStat := range searchTermStats {
+		var searchTermStatResponse SearchTermStatResponse
+		searchTermStatResponse.Term = searchTermStat.Term
+		searchTermStatResponse.TotalSearch = searchTermStat.TotalSearch
+		searchTermStatResponse.TotalUser = searchTermStat.TotalUser
+		searchTermStatResponse.AvgResult = math.Round(searchTermStat.AvgResult*100) / 100
+		searchTermStatResponse.TotalClick = searchTermStat.TotalClick
+		searchTermStatResponse.TotalCart = searchTermStat.TotalCart
+		searchTermStatResponse.ClickRate = searchRate(searchTermStat.TotalClick, searchTermStat.TotalSearch)
+		searchTermStatResponse.ConversionRate = searchRate(searchTermStat.TotalCart, searchTermStat.TotalSearch)
+		searchTermStatResponse.LastSearchedAt = searchTermStat.LastSearchedAt.Format("2006-01-02 15:04:05")
+		searchTermStatResponses = append(searchTermStatResponses, searchTermStatResponse)
+	}
+	return searchTermStatResponses
+}
+
+func searchRate(total int64, totalSearch int64) float64 {
+	if totalSearch == 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(totalSearch)*10000) / 100
+}
@@ -0,0 +1,88 @@
+package response
+
+import "github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+
+type StockOpnameResponse struct {
+	Id              string                    `json:"id"`
+	NoOpname        string                    `json:"no_opname"`
+	Description     string                    `json:"description"`
+	IdCategory      int                       `json:"id_category"`
+	Status          string                    `json:"status"`
+	CreatedBy       string                    `json:"created_by"`
+	CreatedAt       string                    `json:"created_at"`
+	PostedAt        string                    `json:"posted_at"`
+	TotalItems      int                       `json:"total_items"`
+	CountedItems    int                       `json:"counted_items"`
+	DifferenceItems int                       `json:"difference_items"`
+	Items           []StockOpnameItemResponse `json:"items"`
+}
+
+// Counted bernilai false selama item belum dihitung, item seperti ini tidak diposting
+type StockOpnameItemResponse struct {
+	Id               string `json:"id"`
+	IdProduct        string `json:"id_product"`
+	NoSku            string `json:"no_sku"`
+	ProductName      string `json:"product_name"`
+	IdProductVariant string `json:"id_product_variant"`
+	VariantName      string `json:"variant_name"`
+	SystemQty        int    `json:"system_qty"`
+	Counted          bool   `json:"counted"`
+	CountedQty       int    `json:"counted_qty"`
+	DifferenceQty    int    `json:"difference_qty"`
+}
+
+type FindStockOpnameListResponse struct {
+	StockOpnames []StockOpnameResponse `json:"stock_opnames"`
+	TotalData    int64                 `json:"total_data"`
+}
+
+func ToStockOpnameResponse(stockOpname entity.StockOpname) (stockOpnameResponse StockOpnameResponse) {
+	stockOpnameResponse.Id = stockOpname.Id
+	stockOpnameResponse.NoOpname = stockOpname.NoOpname
+	stockOpnameResponse.Description = stockOpname.Description
+	stockOpnameResponse.IdCategory = stockOpname.IdCategory
+	stockOpnameResponse.Status = stockOpname.Status
+	stockOpnameResponse.CreatedBy = stockOpname.CreatedBy
+	stockOpnameResponse.CreatedAt = stockOpname.CreatedAt.Format("2006-01-02 15:04:05")
+	if stockOpname.PostedAt.Valid {
+		stockOpnameResponse.PostedAt = stockOpname.PostedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	stockOpnameResponse.Items = []StockOpnameItemResponse{}
+	for _, stockOpnameItem := range stockOpname.StockOpnameItems {
+		var stockOpnameItemResponse StockOpnameItemResponse
+		stockOpnameItemResponse.Id = stockOpnameItem.Id
+		stockOpnameItemResponse.IdProduct = stockOpnameItem.IdProduct
+		stockOpnameItemResponse.NoSku = stockOpnameItem.Product.NoSku
+		stockOpnameItemResponse.ProductName = stockOpnameItem.Product.ProductName
+		stockOpnameItemResponse.IdProductVariant = stockOpnameItem.IdProductVariant
+		stockOpnameItemResponse.VariantName = stockOpnameItem.ProductVariant.VariantName
+		if stockOpnameItem.ProductVariant.NoSku != "" {
+			stockOpnameItemResponse.NoSku = stockOpnameItem.ProductVariant.NoSku
+		}
+		stockOpnameItemResponse.SystemQty = stockOpnameItem.SystemQty
+		stockOpnameItemResponse.Counted = stockOpnameItem.CountedQty.Valid
+		stockOpnameItemResponse.CountedQty = int(stockOpnameItem.CountedQty.Int64)
+		stockOpnameItemResponse.DifferenceQty = stockOpnameItem.DifferenceQty
+		stockOpnameResponse.Items = append(stockOpnameResponse.Items, stockOpnameItemResponse)
+
+		stockOpnameResponse.TotalItems++
+		if stockOpnameItem.CountedQty.Valid {
+			stockOpnameResponse.CountedItems++
+			if stockOpnameItem.DifferenceQty != 0 {
+				stockOpnameResponse.DifferenceItems++
+			}
+		}
+	}
+	return stockOpnameResponse
+}
+
+// Daftar sesi tanpa rincian item
+func ToFindStockOpnameListResponse(stockOpnames []entity.StockOpname, totalData int64) (stockOpnameListResponse FindStockOpnameListResponse) {
+	stockOpnameListResponse.StockOpnames = []StockOpnameResponse{}
+	for _, stockOpname := range stockOpnames {
+		stockOpnameListResponse.StockOpnames = append(stockOpnameListResponse.StockOpnames, ToStockOpnameResponse(stockOpname))
+	}
+	stockOpnameListResponse.TotalData = totalData
+	return stockOpnameListResponse
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+type VoucherValidateResponse struct {
+	VoucherCode   string   `json:"voucher_code"`
+	VoucherName   string   `json:"voucher_name"`
+	VoucherType   string   `json:"voucher_type"`
+	Subtotal      float64  `json:"subtotal"`
+	EligibleTotal float64  `json:"eligible_total"`
+	ShippingCost  float64  `json:"shipping_cost"`
+	Discount      float64  `json:"discount"`
+	TotalBill     float64  `json:"total_bill"`
+	CanUseVoucher bool     `json:"can_use_voucher"`
+	Violations    []string `json:"violations"`
+}
+
+func ToVoucherValidateResponse(voucherRedemption modelService.VoucherRedemption) (voucherValidateResponse VoucherValidateResponse) {
+	voucherValidateResponse.VoucherCode = voucherRedemption.VoucherCode
+	voucherValidateResponse.VoucherName = voucherRedemption.VoucherName
+	voucherValidateResponse.VoucherType = voucherRedemption.VoucherType
+	voucherValidateResponse.Subtotal = voucherRedemption.Subtotal
+	voucherValidateResponse.EligibleTotal = voucherRedemption.EligibleTotal
+	voucherValidateResponse.ShippingCost = voucherRedemption.ShippingCost
+	voucherValidateResponse.CanUseVoucher = len(voucherRedemption.Violations) == 0
+	if voucherValidateResponse.CanUseVoucher {
+		voucherValidateResponse.Discount = voucherRedemption.Discount
+	}
+	voucherValidateResponse.TotalBill = voucherRedemption.Subtotal + voucherRedemption.ShippingCost - voucherValidateResponse.Discount
+	voucherValidateResponse.Violations = voucherRedemption.Violations
+	if voucherValidateResponse.Violations == nil {
+		voucherValidateResponse.Violations = []string{}
+	}
+	return voucherValidateResponse
+}
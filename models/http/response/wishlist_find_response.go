@@ -0,0 +1,61 @@
+package response
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+)
+
+type FindWishlistResponse struct {
+	Id             string  `json:"id"`
+	IdProduct      string  `json:"id_product"`
+	ProductName    string  `json:"product_name"`
+	PictureUrl     string  `json:"picture_url"`
+	Thumbnail      string  `json:"thumbnail"`
+	PriceWhenAdded float64 `json:"price_when_added"`
+	Price          float64 `json:"price"`
+	PricePromo     float64 `json:"price_promo"`
+	FlagPromo      string  `json:"flag_promo"`
+	PromoEndAt     string  `json:"promo_end_at"`
+	Stock          int     `json:"stock"`
+	IsAvailable    bool    `json:"is_available"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+func ToFindWishlistResponses(wishlists []entity.Wishlist) (wishlistResponses []FindWishlistResponse) {
+	wishlistResponses = []FindWishlistResponse{}
+	for _, wishlist := range wishlists {
+		wishlistResponses = append(wishlistResponses, ToFindWishlistResponse(wishlist))
+	}
+	return wishlistResponses
+}
+
+// Data produk yang sudah tidak tersedia memakai snapshot saat ditambahkan ke wishlist
+func ToFindWishlistResponse(wishlist entity.Wishlist) (wishlistResponse FindWishlistResponse) {
+	wishlistResponse.Id = wishlist.Id
+	wishlistResponse.IdProduct = wishlist.IdProduct
+	wishlistResponse.ProductName = wishlist.ProductName
+	wishlistResponse.PictureUrl = wishlist.PictureUrl
+	wishlistResponse.Thumbnail = wishlist.Thumbnail
+	wishlistResponse.PriceWhenAdded = wishlist.Price
+	wishlistResponse.Price = wishlist.Price
+	wishlistResponse.PricePromo = wishlist.Price
+	wishlistResponse.FlagPromo = "false"
+	wishlistResponse.CreatedAt = wishlist.CreatedAt.Format("2006-01-02 15:04:05")
+
+	if wishlist.Product.Id != "" {
+		productPricing := pricing.EvaluateProductPricing(wishlist.Product, entity.ProductVariant{}, time.Now())
+		wishlistResponse.ProductName = wishlist.Product.ProductName
+		wishlistResponse.PictureUrl = wishlist.Product.PictureUrl
+		wishlistResponse.Thumbnail = wishlist.Product.Thumbnail
+		wishlistResponse.Price = productPricing.PriceBeforeDiscount
+		wishlistResponse.PricePromo = productPricing.Price
+		wishlistResponse.FlagPromo = strconv.FormatBool(productPricing.OnPromo)
+		wishlistResponse.PromoEndAt = formatPromoEndAt(productPricing.PromoEndAt)
+		wishlistResponse.Stock = wishlist.Product.Stock
+		wishlistResponse.IsAvailable = wishlist.Product.Published == "1" && wishlist.Product.Stock > 0
+	}
+	return wishlistResponse
+}
@@ -13,5 +13,6 @@ type TokenClaims struct {
 	Id          string `json:"id"`
 	Username    string `json:"username"`
 	IdKelurahan int    `json:"id_kelurahan"`
+	IdRole      string `json:"id_role"`
 	jwt.StandardClaims
 }
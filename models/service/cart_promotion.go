@@ -0,0 +1,16 @@
+package service
+
+type CartPromotion struct {
+	IdPromotion   string
+	PromotionName string
+	PromotionType string
+	Discount      float64
+	CartLines     []CartPromotionLine
+}
+
+// Bagian potongan promo pada satu baris keranjang
+type CartPromotionLine struct {
+	IdCart   string
+	Qty      int
+	Discount float64
+}
@@ -0,0 +1,9 @@
+package service
+
+type Pagination struct {
+	Page       int
+	Limit      int
+	Offset     int
+	Sort       string
+	Attributes []ProductAttributeFilter
+}
@@ -0,0 +1,8 @@
+package service
+
+type PointRedemption struct {
+	Subtotal      float64
+	EligibleTotal float64
+	MaxPoint      float64
+	Violations    []string
+}
@@ -0,0 +1,6 @@
+package service
+
+type ProductAttributeFilter struct {
+	AttributeCode string
+	Values        []string
+}
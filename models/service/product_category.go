@@ -0,0 +1,9 @@
+package service
+
+// Jumlah produk tayang per kategori dan sub kategori
+type ProductCategoryCount struct {
+	IdCategory    int
+	IdSubCategory int
+	Published     int
+	InStock       int
+}
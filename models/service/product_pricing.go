@@ -0,0 +1,13 @@
+package service
+
+import "time"
+
+type ProductPricing struct {
+	PriceBeforeDiscount float64
+	Price               float64
+	Percentage          float64
+	OnPromo             bool
+	PromoEndAt          time.Time
+	IdProductDiscounts  []string
+	IdFlashSaleItem     string
+}
@@ -0,0 +1,11 @@
+package service
+
+import "time"
+
+// Satu produk dalam satu pesanan, bahan perhitungan rekomendasi
+type PurchasedProduct struct {
+	IdOrder   string
+	IdUser    string
+	IdProduct string
+	OrderedAt time.Time
+}
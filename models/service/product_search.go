@@ -0,0 +1,32 @@
+package service
+
+import "github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+
+type ProductSearchFacet struct {
+	Id    string
+	Name  string
+	Count int
+}
+
+// Values dipakai saat menghitung, Facets berisi hasil yang sudah diurutkan
+type ProductSearchAttributeFacet struct {
+	AttributeCode string
+	AttributeName string
+	Unit          string
+	Position      int
+	Values        map[string]*ProductSearchFacet
+	Facets        []ProductSearchFacet
+}
+
+type ProductSearch struct {
+	Products          []entity.Product
+	TotalData         int64
+	CategoryFacets    []ProductSearchFacet
+	SubCategoryFacets []ProductSearchFacet
+	BrandFacets       []ProductSearchFacet
+	AttributeFacets   []ProductSearchAttributeFacet
+	PriceMin          float64
+	PriceMax          float64
+	InStockCount      int
+	OnPromoCount      int
+}
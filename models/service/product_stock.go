@@ -0,0 +1,15 @@
+package service
+
+// Satu pergerakan stok produk atau varian, Qty positif untuk stok masuk dan negatif untuk stok keluar
+type StockMovement struct {
+	IdProduct        string
+	IdProductVariant string
+	Qty              int
+	TxType           string
+	ReasonCode       string
+	Supplier         string
+	Reference        string
+	BatchNumber      string
+	Description      string
+	CreatedBy        string
+}
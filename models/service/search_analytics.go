@@ -0,0 +1,20 @@
+package service
+
+import "time"
+
+// Agregat pencarian per term dalam satu periode
+type SearchTermStat struct {
+	Term           string
+	TotalSearch    int64
+	TotalUser      int64
+	AvgResult      float64
+	TotalClick     int64
+	TotalCart      int64
+	LastSearchedAt time.Time
+}
+
+type SearchSummary struct {
+	TotalSearch int64
+	TotalClick  int64
+	TotalCart   int64
+}
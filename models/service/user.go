@@ -7,6 +7,7 @@ type User struct {
 	Username     string
 	Password     string
 	IdKelurahan  int
+	IdRole       string
 	CreatedDate  time.Time
 	RefreshToken string
 }
@@ -0,0 +1,13 @@
+package service
+
+type VoucherRedemption struct {
+	IdVoucher     string
+	VoucherCode   string
+	VoucherName   string
+	VoucherType   string
+	Subtotal      float64
+	EligibleTotal float64
+	ShippingCost  float64
+	Discount      float64
+	Violations    []string
+}
@@ -0,0 +1,298 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+// Jenis promo keranjang
+const (
+	PromotionTypeBuyXGetY       = "buy_x_get_y"
+	PromotionTypeBundle         = "bundle"
+	PromotionTypeTieredQuantity = "tiered_quantity"
+)
+
+// Satu unit produk di keranjang beserta harga setelah promo produk
+type promotionUnit struct {
+	idCart string
+	price  float64
+}
+
+// Menghitung promo keranjang berurutan dari prioritas tertinggi.
+// Setiap unit di keranjang hanya bisa dipakai oleh satu promo
+func EvaluateCartPromotions(promotions []entity.Promotion, cartItems []entity.Cart, now time.Time) (cartPromotions []modelService.CartPromotion) {
+	remainingQty := make(map[string]int)
+	unitPrices := make(map[string]float64)
+	for _, cartItem := range cartItems {
+		remainingQty[cartItem.Id] = cartItem.Qty
+		unitPrices[cartItem.Id] = EvaluateCartItemPricing(cartItem, now).Price
+	}
+
+	activePromotions := make([]entity.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if IsPromotionActive(promotion, now) {
+			activePromotions = append(activePromotions, promotion)
+		}
+	}
+	sort.SliceStable(activePromotions, func(i, j int) bool {
+		return activePromotions[i].Priority > activePromotions[j].Priority
+	})
+
+	for _, promotion := range activePromotions {
+		productIds := make(map[string]bool)
+		for _, promotionItem := range promotion.PromotionItems {
+			productIds[promotionItem.IdProduct] = true
+		}
+
+		var freeUnits, consumedUnits []promotionUnit
+		var discount float64
+		switch promotion.PromotionType {
+		case PromotionTypeBuyXGetY:
+			freeUnits, consumedUnits = applyBuyXGetY(promotion, productIds, cartItems, remainingQty, unitPrices)
+			discount = sumPromotionUnits(freeUnits)
+		case PromotionTypeBundle:
+			consumedUnits, discount = applyBundle(promotion, cartItems, remainingQty, unitPrices)
+		case PromotionTypeTieredQuantity:
+			consumedUnits, discount = applyTieredQuantity(promotion, productIds, cartItems, remainingQty, unitPrices)
+		}
+		if discount <= 0 {
+			continue
+		}
+
+		for _, unit := range consumedUnits {
+			remainingQty[unit.idCart]--
+		}
+
+		// Potongan gratis melekat pada unit gratis, potongan lain dibagi proporsional ke unit yang dipakai
+		lineDiscounts := make(map[string]float64)
+		if promotion.PromotionType == PromotionTypeBuyXGetY {
+			for _, unit := range freeUnits {
+				lineDiscounts[unit.idCart] = lineDiscounts[unit.idCart] + unit.price
+			}
+		} else {
+			lineDiscounts = allocatePromotionDiscount(consumedUnits, discount)
+		}
+
+		cartPromotion := modelService.CartPromotion{
+			IdPromotion:   promotion.Id,
+			PromotionName: promotion.PromotionName,
+			PromotionType: promotion.PromotionType,
+			Discount:      discount,
+		}
+		lineQty := make(map[string]int)
+		for _, unit := range consumedUnits {
+			lineQty[unit.idCart]++
+		}
+		for _, cartItem := range cartItems {
+			if lineQty[cartItem.Id] == 0 {
+				continue
+			}
+			cartPromotion.CartLines = append(cartPromotion.CartLines, modelService.CartPromotionLine{
+				IdCart:   cartItem.Id,
+				Qty:      lineQty[cartItem.Id],
+				Discount: lineDiscounts[cartItem.Id],
+			})
+		}
+		cartPromotions = append(cartPromotions, cartPromotion)
+	}
+	return cartPromotions
+}
+
+// Promo keranjang aktif jika berada di antara tanggal mulai dan tanggal berakhir
+func IsPromotionActive(promotion entity.Promotion, now time.Time) bool {
+	if promotion.IsActive != 1 {
+		return false
+	}
+	if !promotion.StartDate.IsZero() && now.Before(promotion.StartDate) {
+		return false
+	}
+	endAt := ValidUntil(promotion.EndDate)
+	return endAt.IsZero() || now.Before(endAt)
+}
+
+// Total potongan promo per baris keranjang
+func CartPromotionDiscounts(cartPromotions []modelService.CartPromotion) map[string]float64 {
+	lineDiscounts := make(map[string]float64)
+	for _, cartPromotion := range cartPromotions {
+		for _, cartLine := range cartPromotion.CartLines {
+			lineDiscounts[cartLine.IdCart] = lineDiscounts[cartLine.IdCart] + cartLine.Discount
+		}
+	}
+	return lineDiscounts
+}
+
+// Tanpa produk gratis khusus, unit termurah dari setiap kelipatan beli+gratis produk yang sama menjadi gratis.
+// Dengan produk gratis khusus, produk tersebut harus ada di keranjang dan gratis sebanyak kelipatan pembelian
+func applyBuyXGetY(promotion entity.Promotion, productIds map[string]bool, cartItems []entity.Cart, remainingQty map[string]int, unitPrices map[string]float64) (freeUnits []promotionUnit, consumedUnits []promotionUnit) {
+	if promotion.BuyQty <= 0 || promotion.GetQty <= 0 {
+		return nil, nil
+	}
+
+	if promotion.IdProductGet == "" {
+		var productOrder []string
+		productCartItems := make(map[string][]entity.Cart)
+		for _, cartItem := range cartItems {
+			if !productIds[cartItem.IdProduct] {
+				continue
+			}
+			if productCartItems[cartItem.IdProduct] == nil {
+				productOrder = append(productOrder, cartItem.IdProduct)
+			}
+			productCartItems[cartItem.IdProduct] = append(productCartItems[cartItem.IdProduct], cartItem)
+		}
+
+		setQty := promotion.BuyQty + promotion.GetQty
+		for _, idProduct := range productOrder {
+			units := expandPromotionUnits(productCartItems[idProduct], remainingQty, unitPrices)
+			sets := len(units) / setQty
+			if sets == 0 {
+				continue
+			}
+			units = units[:sets*setQty]
+			consumedUnits = append(consumedUnits, units...)
+			freeUnits = append(freeUnits, units[len(units)-sets*promotion.GetQty:]...)
+		}
+		return freeUnits, consumedUnits
+	}
+
+	var buyCartItems, getCartItems []entity.Cart
+	for _, cartItem := range cartItems {
+		if cartItem.IdProduct == promotion.IdProductGet {
+			getCartItems = append(getCartItems, cartItem)
+		} else if productIds[cartItem.IdProduct] {
+			buyCartItems = append(buyCartItems, cartItem)
+		}
+	}
+	buyUnits := expandPromotionUnits(buyCartItems, remainingQty, unitPrices)
+	getUnits := expandPromotionUnits(getCartItems, remainingQty, unitPrices)
+	freeQty := minInt(len(buyUnits)/promotion.BuyQty*promotion.GetQty, len(getUnits))
+	if freeQty == 0 {
+		return nil, nil
+	}
+	sets := (freeQty + promotion.GetQty - 1) / promotion.GetQty
+	freeUnits = getUnits[:freeQty]
+	consumedUnits = append(consumedUnits, buyUnits[:sets*promotion.BuyQty]...)
+	consumedUnits = append(consumedUnits, freeUnits...)
+	return freeUnits, consumedUnits
+}
+
+// Paket harga tetap berlaku untuk setiap set lengkap semua produk paket
+func applyBundle(promotion entity.Promotion, cartItems []entity.Cart, remainingQty map[string]int, unitPrices map[string]float64) (consumedUnits []promotionUnit, discount float64) {
+	if len(promotion.PromotionItems) == 0 {
+		return nil, 0
+	}
+
+	sets := -1
+	itemUnits := make([][]promotionUnit, len(promotion.PromotionItems))
+	for i, promotionItem := range promotion.PromotionItems {
+		qty := promotionItem.Qty
+		if qty <= 0 {
+			qty = 1
+		}
+		var productCartItems []entity.Cart
+		for _, cartItem := range cartItems {
+			if cartItem.IdProduct == promotionItem.IdProduct {
+				productCartItems = append(productCartItems, cartItem)
+			}
+		}
+		itemUnits[i] = expandPromotionUnits(productCartItems, remainingQty, unitPrices)
+		if sets == -1 || len(itemUnits[i])/qty < sets {
+			sets = len(itemUnits[i]) / qty
+		}
+	}
+	if sets <= 0 {
+		return nil, 0
+	}
+
+	for i, promotionItem := range promotion.PromotionItems {
+		qty := promotionItem.Qty
+		if qty <= 0 {
+			qty = 1
+		}
+		consumedUnits = append(consumedUnits, itemUnits[i][:sets*qty]...)
+	}
+	discount = sumPromotionUnits(consumedUnits) - promotion.BundlePrice*float64(sets)
+	if discount <= 0 {
+		return nil, 0
+	}
+	return consumedUnits, discount
+}
+
+// Persentase potongan mengikuti tier dengan minimal qty tertinggi yang tercapai
+func applyTieredQuantity(promotion entity.Promotion, productIds map[string]bool, cartItems []entity.Cart, remainingQty map[string]int, unitPrices map[string]float64) (consumedUnits []promotionUnit, discount float64) {
+	var productCartItems []entity.Cart
+	for _, cartItem := range cartItems {
+		if productIds[cartItem.IdProduct] {
+			productCartItems = append(productCartItems, cartItem)
+		}
+	}
+	units := expandPromotionUnits(productCartItems, remainingQty, unitPrices)
+
+	var percentage float64
+	minQty := 0
+	for _, promotionTier := range promotion.PromotionTiers {
+		if len(units) >= promotionTier.MinQty && promotionTier.MinQty >= minQty {
+			minQty = promotionTier.MinQty
+			percentage = promotionTier.Percentage
+		}
+	}
+	if percentage <= 0 {
+		return nil, 0
+	}
+	return units, math.Floor(sumPromotionUnits(units) * percentage / 100)
+}
+
+// Unit yang masih tersedia, diurutkan dari harga tertinggi
+func expandPromotionUnits(cartItems []entity.Cart, remainingQty map[string]int, unitPrices map[string]float64) (units []promotionUnit) {
+	for _, cartItem := range cartItems {
+		for i := 0; i < remainingQty[cartItem.Id]; i++ {
+			units = append(units, promotionUnit{idCart: cartItem.Id, price: unitPrices[cartItem.Id]})
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].price > units[j].price
+	})
+	return units
+}
+
+func sumPromotionUnits(units []promotionUnit) (total float64) {
+	for _, unit := range units {
+		total = total + unit.price
+	}
+	return total
+}
+
+// Potongan dibagi sesuai nilai unit, sisa pembulatan masuk ke baris pertama
+func allocatePromotionDiscount(units []promotionUnit, discount float64) map[string]float64 {
+	lineDiscounts := make(map[string]float64)
+	total := sumPromotionUnits(units)
+	if total == 0 || len(units) == 0 {
+		return lineDiscounts
+	}
+	lineValues := make(map[string]float64)
+	var lineOrder []string
+	for _, unit := range units {
+		if _, ok := lineValues[unit.idCart]; !ok {
+			lineOrder = append(lineOrder, unit.idCart)
+		}
+		lineValues[unit.idCart] = lineValues[unit.idCart] + unit.price
+	}
+	allocated := 0.0
+	for _, idCart := range lineOrder {
+		lineDiscounts[idCart] = math.Floor(discount * lineValues[idCart] / total)
+		allocated = allocated + lineDiscounts[idCart]
+	}
+	lineDiscounts[lineOrder[0]] = lineDiscounts[lineOrder[0]] + discount - allocated
+	return lineDiscounts
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,121 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+func testCartItem(id string, idProduct string, price float64, qty int) entity.Cart {
+	return entity.Cart{Id: id, IdProduct: idProduct, Qty: qty, Product: entity.Product{Id: idProduct, Price: price}}
+}
+
+func promotionItems(idProducts ...string) (items []entity.PromotionItem) {
+	for _, idProduct := range idProducts {
+		items = append(items, entity.PromotionItem{IdProduct: idProduct, Qty: 1})
+	}
+	return items
+}
+
+func TestEvaluateCartPromotions(t *testing.T) {
+	buyTwoGetOne := entity.Promotion{Id: "b2g1", PromotionType: PromotionTypeBuyXGetY, BuyQty: 2, GetQty: 1, IsActive: 1, PromotionItems: promotionItems("a")}
+	buyTwoGetB := entity.Promotion{Id: "b2gb", PromotionType: PromotionTypeBuyXGetY, BuyQty: 2, GetQty: 1, IdProductGet: "b", IsActive: 1, PromotionItems: promotionItems("a")}
+	bundle := entity.Promotion{Id: "bundle", PromotionType: PromotionTypeBundle, BundlePrice: 25000, IsActive: 1, PromotionItems: promotionItems("a", "b")}
+	tiered := entity.Promotion{Id: "tiered", PromotionType: PromotionTypeTieredQuantity, IsActive: 1, PromotionItems: promotionItems("a"),
+		PromotionTiers: []entity.PromotionTier{{MinQty: 5, Percentage: 10}, {MinQty: 2, Percentage: 5}}}
+	inactive := buyTwoGetOne
+	inactive.IsActive = 0
+	ended := buyTwoGetOne
+	ended.EndDate = testNow.AddDate(0, 0, -1)
+	buyOneGetOne := entity.Promotion{Id: "b1g1", PromotionType: PromotionTypeBuyXGetY, BuyQty: 1, GetQty: 1, Priority: 2, IsActive: 1, PromotionItems: promotionItems("a")}
+	tieredLow := entity.Promotion{Id: "tiered-low", PromotionType: PromotionTypeTieredQuantity, Priority: 1, IsActive: 1, PromotionItems: promotionItems("a"),
+		PromotionTiers: []entity.PromotionTier{{MinQty: 1, Percentage: 10}}}
+	discountedItem := testCartItem("a", "a", 10000, 2)
+	discountedItem.Product.ProductDiscounts = []entity.ProductDiscount{percentageDiscount("d", 10, 1, 0)}
+
+	line := func(idCart string, qty int, discount float64) modelService.CartPromotionLine {
+		return modelService.CartPromotionLine{IdCart: idCart, Qty: qty, Discount: discount}
+	}
+	tests := []struct {
+		name           string
+		promotions     []entity.Promotion
+		cartItems      []entity.Cart
+		cartPromotions []modelService.CartPromotion
+	}{
+		{name: "buy x get y same product", promotions: []entity.Promotion{buyTwoGetOne}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 3)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "b2g1", PromotionType: PromotionTypeBuyXGetY, Discount: 10000, CartLines: []modelService.CartPromotionLine{line("a", 3, 10000)}}}},
+		{name: "buy x get y only complete sets", promotions: []entity.Promotion{buyTwoGetOne}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 5)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "b2g1", PromotionType: PromotionTypeBuyXGetY, Discount: 10000, CartLines: []modelService.CartPromotionLine{line("a", 3, 10000)}}}},
+		{name: "buy x get y cheapest unit free", promotions: []entity.Promotion{buyTwoGetOne},
+			cartItems: []entity.Cart{testCartItem("a1", "a", 12000, 2), testCartItem("a2", "a", 9000, 1)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "b2g1", PromotionType: PromotionTypeBuyXGetY, Discount: 9000,
+				CartLines: []modelService.CartPromotionLine{line("a1", 2, 0), line("a2", 1, 9000)}}}},
+		{name: "buy x get y incomplete set", promotions: []entity.Promotion{buyTwoGetOne}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 2)}},
+		{name: "buy x get specific product", promotions: []entity.Promotion{buyTwoGetB},
+			cartItems: []entity.Cart{testCartItem("a", "a", 10000, 2), testCartItem("b", "b", 5000, 3)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "b2gb", PromotionType: PromotionTypeBuyXGetY, Discount: 5000,
+				CartLines: []modelService.CartPromotionLine{line("a", 2, 0), line("b", 1, 5000)}}}},
+		{name: "buy x get specific product not in cart", promotions: []entity.Promotion{buyTwoGetB}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 4)}},
+		{name: "bundle discount allocated by value", promotions: []entity.Promotion{bundle},
+			cartItems: []entity.Cart{testCartItem("a", "a", 20000, 2), testCartItem("b", "b", 10000, 1)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "bundle", PromotionType: PromotionTypeBundle, Discount: 5000,
+				CartLines: []modelService.CartPromotionLine{line("a", 1, 3334), line("b", 1, 1666)}}}},
+		{name: "bundle incomplete", promotions: []entity.Promotion{bundle}, cartItems: []entity.Cart{testCartItem("a", "a", 20000, 2)}},
+		{name: "bundle price above normal price", promotions: []entity.Promotion{bundle},
+			cartItems: []entity.Cart{testCartItem("a", "a", 10000, 1), testCartItem("b", "b", 10000, 1)}},
+		{name: "tiered lower tier", promotions: []entity.Promotion{tiered}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 3)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "tiered", PromotionType: PromotionTypeTieredQuantity, Discount: 1500, CartLines: []modelService.CartPromotionLine{line("a", 3, 1500)}}}},
+		{name: "tiered highest reached tier", promotions: []entity.Promotion{tiered}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 6)},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "tiered", PromotionType: PromotionTypeTieredQuantity, Discount: 6000, CartLines: []modelService.CartPromotionLine{line("a", 6, 6000)}}}},
+		{name: "tiered below minimum", promotions: []entity.Promotion{tiered}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 1)}},
+		{name: "inactive and ended promotions ignored", promotions: []entity.Promotion{inactive, ended}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 3)}},
+		{name: "units used by higher priority promotion not reused", promotions: []entity.Promotion{tieredLow, buyOneGetOne}, cartItems: []entity.Cart{testCartItem("a", "a", 10000, 3)},
+			cartPromotions: []modelService.CartPromotion{
+				{IdPromotion: "b1g1", PromotionType: PromotionTypeBuyXGetY, Discount: 10000, CartLines: []modelService.CartPromotionLine{line("a", 2, 10000)}},
+				{IdPromotion: "tiered-low", PromotionType: PromotionTypeTieredQuantity, Discount: 1000, CartLines: []modelService.CartPromotionLine{line("a", 1, 1000)}},
+			}},
+		{name: "unit price after product discount", promotions: []entity.Promotion{buyOneGetOne}, cartItems: []entity.Cart{discountedItem},
+			cartPromotions: []modelService.CartPromotion{{IdPromotion: "b1g1", PromotionType: PromotionTypeBuyXGetY, Discount: 9000, CartLines: []modelService.CartPromotionLine{line("a", 2, 9000)}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cartPromotions := EvaluateCartPromotions(test.promotions, test.cartItems, testNow)
+			if !reflect.DeepEqual(cartPromotions, test.cartPromotions) {
+				t.Errorf("got %+v, want %+v", cartPromotions, test.cartPromotions)
+			}
+		})
+	}
+}
+
+func TestCartPromotionDiscounts(t *testing.T) {
+	lineDiscounts := CartPromotionDiscounts([]modelService.CartPromotion{
+		{CartLines: []modelService.CartPromotionLine{{IdCart: "a", Discount: 1000}, {IdCart: "b", Discount: 500}}},
+		{CartLines: []modelService.CartPromotionLine{{IdCart: "a", Discount: 250}}},
+	})
+	if !reflect.DeepEqual(lineDiscounts, map[string]float64{"a": 1250, "b": 500}) {
+		t.Errorf("got %v", lineDiscounts)
+	}
+}
+
+func TestIsPromotionActive(t *testing.T) {
+	today := time.Date(testNow.Year(), testNow.Month(), testNow.Day(), 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name      string
+		promotion entity.Promotion
+		active    bool
+	}{
+		{"no dates", entity.Promotion{IsActive: 1}, true},
+		{"disabled", entity.Promotion{IsActive: 0}, false},
+		{"not started", entity.Promotion{IsActive: 1, StartDate: testNow.Add(time.Minute)}, false},
+		{"ends today at midnight", entity.Promotion{IsActive: 1, EndDate: today}, true},
+		{"ended earlier today", entity.Promotion{IsActive: 1, EndDate: today.Add(time.Hour)}, false},
+	}
+	for _, test := range tests {
+		if active := IsPromotionActive(test.promotion, testNow); active != test.active {
+			t.Errorf("%s: got %v, want %v", test.name, active, test.active)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+// Harga item keranjang, harga flash sale menggantikan promo produk jika lebih murah
+func EvaluateCartItemPricing(cartItem entity.Cart, now time.Time) (productPricing modelService.ProductPricing) {
+	productPricing = EvaluateProductPricing(cartItem.Product, cartItem.ProductVariant, now)
+	if cartItem.FlashSaleItem == nil || !IsFlashSaleActive(cartItem.FlashSaleItem.FlashSale, now) {
+		return productPricing
+	}
+	if cartItem.FlashSaleItem.SalePrice >= productPricing.Price {
+		return productPricing
+	}
+
+	productPricing.Price = cartItem.FlashSaleItem.SalePrice
+	productPricing.OnPromo = true
+	productPricing.PromoEndAt = cartItem.FlashSaleItem.FlashSale.EndAt
+	productPricing.IdProductDiscounts = nil
+	productPricing.IdFlashSaleItem = cartItem.FlashSaleItem.Id
+	if productPricing.PriceBeforeDiscount > 0 {
+		productPricing.Percentage = math.Round((productPricing.PriceBeforeDiscount - productPricing.Price) / productPricing.PriceBeforeDiscount * 100)
+	}
+	return productPricing
+}
+
+// Flash sale berlaku mulai start_at sampai sebelum end_at
+func IsFlashSaleActive(flashSale entity.FlashSale, now time.Time) bool {
+	if flashSale.IsActive != 1 {
+		return false
+	}
+	return !now.Before(flashSale.StartAt) && now.Before(flashSale.EndAt)
+}
@@ -0,0 +1,66 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+func TestEvaluateCartItemPricing(t *testing.T) {
+	activeFlashSale := entity.FlashSale{IsActive: 1, StartAt: testNow.Add(-time.Hour), EndAt: testNow.Add(time.Hour)}
+	flashSaleItem := func(flashSale entity.FlashSale, salePrice float64) *entity.FlashSaleItem {
+		return &entity.FlashSaleItem{Id: "flash", FlashSale: flashSale, SalePrice: salePrice}
+	}
+	disabled := activeFlashSale
+	disabled.IsActive = 0
+	notStarted := activeFlashSale
+	notStarted.StartAt = testNow.Add(time.Minute)
+	startsNow := activeFlashSale
+	startsNow.StartAt = testNow
+	endsNow := activeFlashSale
+	endsNow.EndAt = testNow
+
+	tests := []struct {
+		name            string
+		discounts       []entity.ProductDiscount
+		variant         entity.ProductVariant
+		flashSaleItem   *entity.FlashSaleItem
+		price           float64
+		percentage      float64
+		idFlashSaleItem string
+	}{
+		{name: "without flash sale", discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 1, 0)}, price: 90000, percentage: 10},
+		{name: "flash sale cheaper than product promo", discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 1, 0)}, flashSaleItem: flashSaleItem(activeFlashSale, 70000),
+			price: 70000, percentage: 30, idFlashSaleItem: "flash"},
+		{name: "product promo cheaper than flash sale", discounts: []entity.ProductDiscount{percentageDiscount("a", 40, 1, 0)}, flashSaleItem: flashSaleItem(activeFlashSale, 70000),
+			price: 60000, percentage: 40},
+		{name: "same price keeps product promo", discounts: []entity.ProductDiscount{fixedPriceDiscount("a", 70000, 1, 0)}, flashSaleItem: flashSaleItem(activeFlashSale, 70000),
+			price: 70000, percentage: 30},
+		{name: "disabled flash sale", flashSaleItem: flashSaleItem(disabled, 70000), price: 100000},
+		{name: "flash sale not started", flashSaleItem: flashSaleItem(notStarted, 70000), price: 100000},
+		{name: "flash sale starts now", flashSaleItem: flashSaleItem(startsNow, 70000), price: 70000, percentage: 30, idFlashSaleItem: "flash"},
+		{name: "flash sale ends now", flashSaleItem: flashSaleItem(endsNow, 70000), price: 100000},
+		{name: "flash sale on variant", variant: entity.ProductVariant{Id: "v", Price: 120000}, flashSaleItem: flashSaleItem(activeFlashSale, 90000),
+			price: 90000, percentage: 25, idFlashSaleItem: "flash"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cartItem := entity.Cart{
+				Product:        entity.Product{Id: "p", Price: 100000, ProductDiscounts: test.discounts},
+				ProductVariant: test.variant,
+				FlashSaleItem:  test.flashSaleItem,
+			}
+			productPricing := EvaluateCartItemPricing(cartItem, testNow)
+			if productPricing.Price != test.price || productPricing.Percentage != test.percentage || productPricing.IdFlashSaleItem != test.idFlashSaleItem {
+				t.Errorf("got price %v percentage %v flash sale %q, want price %v percentage %v flash sale %q",
+					productPricing.Price, productPricing.Percentage, productPricing.IdFlashSaleItem, test.price, test.percentage, test.idFlashSaleItem)
+			}
+			if test.idFlashSaleItem != "" {
+				if !productPricing.OnPromo || productPricing.IdProductDiscounts != nil || !productPricing.PromoEndAt.Equal(test.flashSaleItem.FlashSale.EndAt) {
+					t.Errorf("flash sale pricing got on promo %v discounts %v promo end %v", productPricing.OnPromo, productPricing.IdProductDiscounts, productPricing.PromoEndAt)
+				}
+			}
+		})
+	}
+}
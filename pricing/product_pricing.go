@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+)
+
+// Jenis diskon, data lama tanpa jenis diperlakukan sebagai harga tetap dari nominal
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixedPrice = "fixed_price"
+)
+
+// Menghitung harga produk atau varian dari promo yang sedang berlaku.
+// Promo dengan prioritas tertinggi selalu dipakai, promo berikutnya hanya ditumpuk jika promo teratas dan promo itu sama-sama stackable
+func EvaluateProductPricing(product entity.Product, productVariant entity.ProductVariant, now time.Time) (productPricing modelService.ProductPricing) {
+	productPricing.PriceBeforeDiscount = product.Price
+	if productVariant.Id != "" {
+		productPricing.PriceBeforeDiscount = productVariant.Price
+	}
+	productPricing.Price = productPricing.PriceBeforeDiscount
+
+	var activeDiscounts []entity.ProductDiscount
+	for _, productDiscount := range product.ProductDiscounts {
+		if IsDiscountActive(productDiscount, now) {
+			activeDiscounts = append(activeDiscounts, productDiscount)
+		}
+	}
+	sort.SliceStable(activeDiscounts, func(i, j int) bool {
+		return activeDiscounts[i].Priority > activeDiscounts[j].Priority
+	})
+
+	for i, productDiscount := range activeDiscounts {
+		if i > 0 && (activeDiscounts[0].Stackable != 1 || productDiscount.Stackable != 1) {
+			continue
+		}
+		productPricing.Price = applyDiscount(productDiscount, product, productVariant, productPricing.Price)
+		productPricing.IdProductDiscounts = append(productPricing.IdProductDiscounts, productDiscount.Id)
+
+		endAt := ValidUntil(productDiscount.EndDate)
+		if !endAt.IsZero() && (productPricing.PromoEndAt.IsZero() || endAt.Before(productPricing.PromoEndAt)) {
+			productPricing.PromoEndAt = endAt
+		}
+	}
+
+	productPricing.OnPromo = len(productPricing.IdProductDiscounts) > 0
+	if productPricing.OnPromo && productPricing.PriceBeforeDiscount > 0 {
+		if len(productPricing.IdProductDiscounts) == 1 && activeDiscounts[0].DiscountType == DiscountTypePercentage {
+			productPricing.Percentage = activeDiscounts[0].Percentage
+		} else {
+			productPricing.Percentage = math.Round((productPricing.PriceBeforeDiscount - productPricing.Price) / productPricing.PriceBeforeDiscount * 100)
+		}
+	}
+	return productPricing
+}
+
+// Promo aktif jika flag menyala dan waktu sekarang berada di antara tanggal mulai dan tanggal berakhir
+func IsDiscountActive(productDiscount entity.ProductDiscount, now time.Time) bool {
+	if productDiscount.FlagPromo != "true" {
+		return false
+	}
+	if !productDiscount.StartDate.IsZero() && now.Before(productDiscount.StartDate) {
+		return false
+	}
+	endAt := ValidUntil(productDiscount.EndDate)
+	return endAt.IsZero() || now.Before(endAt)
+}
+
+// Tanggal berakhir tanpa jam berarti promo atau voucher berlaku sampai akhir hari tersebut
+func ValidUntil(endDate time.Time) time.Time {
+	if endDate.IsZero() {
+		return endDate
+	}
+	if endDate.Hour() == 0 && endDate.Minute() == 0 && endDate.Second() == 0 {
+		return endDate.AddDate(0, 0, 1)
+	}
+	return endDate
+}
+
+// Harga tetap pada produk bervarian diterapkan sebagai potongan yang sama terhadap harga varian
+func applyDiscount(productDiscount entity.ProductDiscount, product entity.Product, productVariant entity.ProductVariant, price float64) float64 {
+	if productDiscount.DiscountType == DiscountTypePercentage {
+		return math.Round(price * (100 - productDiscount.Percentage) / 100)
+	}
+
+	fixedPrice := productDiscount.Nominal
+	if productVariant.Id != "" {
+		fixedPrice = productVariant.Price - (product.Price - productDiscount.Nominal)
+	}
+	return math.Min(price, math.Max(fixedPrice, 0))
+}
@@ -0,0 +1,122 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+)
+
+var testNow = time.Date(2025, time.June, 15, 15, 0, 0, 0, time.Local)
+
+func percentageDiscount(id string, percentage float64, priority int, stackable int) entity.ProductDiscount {
+	return entity.ProductDiscount{Id: id, FlagPromo: "true", DiscountType: DiscountTypePercentage, Percentage: percentage, Priority: priority, Stackable: stackable}
+}
+
+func fixedPriceDiscount(id string, nominal float64, priority int, stackable int) entity.ProductDiscount {
+	return entity.ProductDiscount{Id: id, FlagPromo: "true", DiscountType: DiscountTypeFixedPrice, Nominal: nominal, Priority: priority, Stackable: stackable}
+}
+
+func TestEvaluateProductPricing(t *testing.T) {
+	today := time.Date(testNow.Year(), testNow.Month(), testNow.Day(), 0, 0, 0, 0, time.Local)
+	withDates := func(productDiscount entity.ProductDiscount, startDate time.Time, endDate time.Time) entity.ProductDiscount {
+		productDiscount.StartDate = startDate
+		productDiscount.EndDate = endDate
+		return productDiscount
+	}
+	inactive := percentageDiscount("inactive", 50, 9, 1)
+	inactive.FlagPromo = "false"
+
+	tests := []struct {
+		name               string
+		discounts          []entity.ProductDiscount
+		variant            entity.ProductVariant
+		price              float64
+		percentage         float64
+		idProductDiscounts []string
+		promoEndAt         time.Time
+	}{
+		{name: "no discount", price: 100000},
+		{name: "flag off", discounts: []entity.ProductDiscount{inactive}, price: 100000},
+		{name: "percentage", discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 1, 0)}, price: 90000, percentage: 10, idProductDiscounts: []string{"a"}},
+		{name: "fixed price", discounts: []entity.ProductDiscount{fixedPriceDiscount("a", 80000, 1, 0)}, price: 80000, percentage: 20, idProductDiscounts: []string{"a"}},
+		{name: "legacy discount without type is fixed price", discounts: []entity.ProductDiscount{{Id: "a", FlagPromo: "true", Nominal: 75000}}, price: 75000, percentage: 25, idProductDiscounts: []string{"a"}},
+		{name: "fixed price never raises price", discounts: []entity.ProductDiscount{fixedPriceDiscount("a", 120000, 1, 0)}, price: 100000, idProductDiscounts: []string{"a"}},
+		{name: "highest priority wins when not stackable",
+			discounts: []entity.ProductDiscount{percentageDiscount("low", 50, 1, 0), percentageDiscount("high", 5, 5, 0)},
+			price:     95000, percentage: 5, idProductDiscounts: []string{"high"}},
+		{name: "stackable discounts applied in priority order",
+			discounts: []entity.ProductDiscount{fixedPriceDiscount("low", 85000, 1, 1), percentageDiscount("high", 10, 2, 1)},
+			price:     85000, percentage: 15, idProductDiscounts: []string{"high", "low"}},
+		{name: "percentage stacked on percentage",
+			discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 2, 1), percentageDiscount("b", 10, 1, 1)},
+			price:     81000, percentage: 19, idProductDiscounts: []string{"a", "b"}},
+		{name: "top discount not stackable blocks others",
+			discounts: []entity.ProductDiscount{percentageDiscount("top", 10, 2, 0), percentageDiscount("other", 50, 1, 1)},
+			price:     90000, percentage: 10, idProductDiscounts: []string{"top"}},
+		{name: "non stackable lower discount skipped",
+			discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 3, 1), percentageDiscount("b", 50, 2, 0), percentageDiscount("c", 10, 1, 1)},
+			price:     81000, percentage: 19, idProductDiscounts: []string{"a", "c"}},
+		{name: "not started yet", discounts: []entity.ProductDiscount{withDates(percentageDiscount("a", 10, 1, 0), today.AddDate(0, 0, 1), time.Time{})}, price: 100000},
+		{name: "ended yesterday", discounts: []entity.ProductDiscount{withDates(percentageDiscount("a", 10, 1, 0), time.Time{}, today.AddDate(0, 0, -1))}, price: 100000},
+		{name: "end date at midnight lasts the whole day",
+			discounts: []entity.ProductDiscount{withDates(percentageDiscount("a", 10, 1, 0), today, today)},
+			price:     90000, percentage: 10, idProductDiscounts: []string{"a"}, promoEndAt: today.AddDate(0, 0, 1)},
+		{name: "end date with time ends at that time", discounts: []entity.ProductDiscount{withDates(percentageDiscount("a", 10, 1, 0), time.Time{}, today.Add(12*time.Hour))}, price: 100000},
+		{name: "promo end is earliest end of applied discounts",
+			discounts: []entity.ProductDiscount{
+				withDates(percentageDiscount("a", 10, 2, 1), time.Time{}, today.AddDate(0, 0, 3)),
+				withDates(percentageDiscount("b", 10, 1, 1), time.Time{}, today.Add(18*time.Hour)),
+			},
+			price: 81000, percentage: 19, idProductDiscounts: []string{"a", "b"}, promoEndAt: today.Add(18 * time.Hour)},
+		{name: "fixed price on variant keeps the product discount amount",
+			discounts: []entity.ProductDiscount{fixedPriceDiscount("a", 80000, 1, 0)}, variant: entity.ProductVariant{Id: "v", Price: 120000},
+			price: 100000, percentage: 17, idProductDiscounts: []string{"a"}},
+		{name: "percentage on variant", discounts: []entity.ProductDiscount{percentageDiscount("a", 10, 1, 0)}, variant: entity.ProductVariant{Id: "v", Price: 120000},
+			price: 108000, percentage: 10, idProductDiscounts: []string{"a"}},
+		{name: "fixed price offset on cheap variant clamped to zero",
+			discounts: []entity.ProductDiscount{fixedPriceDiscount("a", 80000, 1, 0)}, variant: entity.ProductVariant{Id: "v", Price: 10000},
+			price: 0, percentage: 100, idProductDiscounts: []string{"a"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			product := entity.Product{Id: "p", Price: 100000, ProductDiscounts: test.discounts}
+			productPricing := EvaluateProductPricing(product, test.variant, testNow)
+
+			priceBeforeDiscount := product.Price
+			if test.variant.Id != "" {
+				priceBeforeDiscount = test.variant.Price
+			}
+			if productPricing.PriceBeforeDiscount != priceBeforeDiscount || productPricing.Price != test.price || productPricing.Percentage != test.percentage {
+				t.Errorf("got before %v price %v percentage %v, want before %v price %v percentage %v",
+					productPricing.PriceBeforeDiscount, productPricing.Price, productPricing.Percentage, priceBeforeDiscount, test.price, test.percentage)
+			}
+			if !reflect.DeepEqual(productPricing.IdProductDiscounts, test.idProductDiscounts) || productPricing.OnPromo != (len(test.idProductDiscounts) > 0) {
+				t.Errorf("got discounts %v on promo %v, want %v", productPricing.IdProductDiscounts, productPricing.OnPromo, test.idProductDiscounts)
+			}
+			if !productPricing.PromoEndAt.Equal(test.promoEndAt) {
+				t.Errorf("got promo end %v, want %v", productPricing.PromoEndAt, test.promoEndAt)
+			}
+		})
+	}
+}
+
+func TestValidUntil(t *testing.T) {
+	midnight := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		endDate time.Time
+		want    time.Time
+	}{
+		{"zero", time.Time{}, time.Time{}},
+		{"midnight extends to end of day", midnight, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local)},
+		{"one second after midnight", midnight.Add(time.Second), midnight.Add(time.Second)},
+		{"with time", midnight.Add(17 * time.Hour), midnight.Add(17 * time.Hour)},
+	}
+	for _, test := range tests {
+		if got := ValidUntil(test.endDate); !got.Equal(test.want) {
+			t.Errorf("%s: ValidUntil(%v) = %v, want %v", test.name, test.endDate, got, test.want)
+		}
+	}
+}
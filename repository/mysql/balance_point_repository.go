@@ -4,11 +4,15 @@ import (
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BalancePointRepositoryInterface interface {
 	CreateBalancePoint(DB *gorm.DB, balancePoint entity.BalancePoint) (entity.BalancePoint, error)
 	FindBalancePointByIdUser(DB *gorm.DB, IdUser string) (entity.BalancePoint, error)
+	FindBalancePointWalletByIdUser(DB *gorm.DB, IdUser string) (entity.BalancePoint, error)
+	FindBalancePointWalletByIdUserForUpdate(DB *gorm.DB, IdUser string) (entity.BalancePoint, error)
+	FindBalancePointsByIdUsersForUpdate(DB *gorm.DB, idUsers []string) ([]entity.BalancePoint, error)
 	BalancePointUseCheck(DB *gorm.DB, IdUser string) (entity.BalancePoint, error)
 	UpdateBalancePoint(DB *gorm.DB, idUser string, balancePoint entity.BalancePoint) (entity.BalancePoint, error)
 }
@@ -34,6 +38,43 @@ func (repository *BalancePointRepositoryImplementation) FindBalancePointByIdUser
 	return balancePoint, results.Error
 }
 
+// Balance point yang dipakai user untuk transaksi, jika keluarga user memakai shared wallet
+// maka yang dipakai adalah balance point kepala keluarga
+func (repository *BalancePointRepositoryImplementation) FindBalancePointWalletByIdUser(DB *gorm.DB, IdUser string) (entity.BalancePoint, error) {
+	var balancePoint entity.BalancePoint
+	results := balancePointWalletScope(DB, IdUser).Find(&balancePoint)
+	return balancePoint, results.Error
+}
+
+// Dipanggil dalam transaksi, baris wallet terkunci sampai transaksi selesai agar saldo tidak dipakai dua kali
+func (repository *BalancePointRepositoryImplementation) FindBalancePointWalletByIdUserForUpdate(DB *gorm.DB, IdUser string) (entity.BalancePoint, error) {
+	var balancePoint entity.BalancePoint
+	results := balancePointWalletScope(DB, IdUser).Clauses(clause.Locking{Strength: "UPDATE"}).Find(&balancePoint)
+	return balancePoint, results.Error
+}
+
+// Baris dikunci berurutan sesuai id_user agar dua transaksi yang mengunci user yang sama tidak saling menunggu
+func (repository *BalancePointRepositoryImplementation) FindBalancePointsByIdUsersForUpdate(DB *gorm.DB, idUsers []string) ([]entity.BalancePoint, error) {
+	var balancePoints []entity.BalancePoint
+	results := DB.Where("balance_point.id_user IN ?", idUsers).
+		Order("balance_point.id_user asc").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&balancePoints)
+	return balancePoints, results.Error
+}
+
+func balancePointWalletScope(DB *gorm.DB, IdUser string) *gorm.DB {
+	return DB.Where("balance_point.id_user = COALESCE((?), ?)",
+		DB.Table("users").
+			Select("family.id_user_head").
+			Joins("JOIN family_members ON family_members.id = users.id_family_members").
+			Joins("JOIN family ON family.id = family_members.id_family").
+			Where("users.id = ?", IdUser).
+			Where("family.shared_wallet = ?", 1).
+			Where("family.id_user_head <> ?", ""),
+		IdUser)
+}
+
 func (repository *BalancePointRepositoryImplementation) BalancePointUseCheck(DB *gorm.DB, IdUser string) (entity.BalancePoint, error) {
 	var balancePoint entity.BalancePoint
 	results := DB.Where("balance_point.id_user = ?", IdUser).Find(&balancePoint)
@@ -44,8 +85,6 @@ func (repository *BalancePointRepositoryImplementation) UpdateBalancePoint(DB *g
 	result := DB.
 		Model(entity.BalancePoint{}).
 		Where("id_user = ?", idUser).
-		Updates(entity.BalancePoint{
-			BalancePoints: balancePoint.BalancePoints,
-		})
+		Update("balance_points", balancePoint.BalancePoints)
 	return balancePoint, result.Error
 }
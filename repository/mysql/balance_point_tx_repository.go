@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"strings"
+	"time"
 
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
@@ -11,6 +12,9 @@ import (
 type BalancePointTxRepositoryInterface interface {
 	CreateBalancePointTx(DB *gorm.DB, balancePoint entity.BalancePointTx) (entity.BalancePointTx, error)
 	FindBalancePointTxByIdBalancePoint(DB *gorm.DB, date string, idBalancePoint string) ([]entity.BalancePointTx, error)
+	FindBalancePointTxStatement(DB *gorm.DB, idBalancePoint string, dateFrom time.Time, dateTo time.Time, txType string, limit int, offset int) ([]entity.BalancePointTx, error)
+	CountBalancePointTxStatement(DB *gorm.DB, idBalancePoint string, dateFrom time.Time, dateTo time.Time, txType string) (int64, error)
+	FindLastBalancePointTxBeforeDate(DB *gorm.DB, idBalancePoint string, date time.Time) (entity.BalancePointTx, error)
 }
 
 type BalancePointTxRepositoryImplementation struct {
@@ -40,3 +44,42 @@ func (repository *BalancePointTxRepositoryImplementation) FindBalancePointTxById
 		return balancePointTx, results.Error
 	}
 }
+
+func (repository *BalancePointTxRepositoryImplementation) FindBalancePointTxStatement(DB *gorm.DB, idBalancePoint string, dateFrom time.Time, dateTo time.Time, txType string, limit int, offset int) ([]entity.BalancePointTx, error) {
+	var balancePointTx []entity.BalancePointTx
+	query := DB.Where("id_balance_point = ?", idBalancePoint).
+		Where("tx_date >= ?", dateFrom).
+		Where("tx_date <= ?", dateTo)
+	if txType != "" {
+		query = query.Where("tx_type = ?", txType)
+	}
+	// limit 0 berarti semua data, dipakai untuk export statement
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	results := query.Order("tx_date asc, created_at asc").Find(&balancePointTx)
+	return balancePointTx, results.Error
+}
+
+func (repository *BalancePointTxRepositoryImplementation) CountBalancePointTxStatement(DB *gorm.DB, idBalancePoint string, dateFrom time.Time, dateTo time.Time, txType string) (int64, error) {
+	var count int64
+	query := DB.Model(&entity.BalancePointTx{}).
+		Where("id_balance_point = ?", idBalancePoint).
+		Where("tx_date >= ?", dateFrom).
+		Where("tx_date <= ?", dateTo)
+	if txType != "" {
+		query = query.Where("tx_type = ?", txType)
+	}
+	results := query.Count(&count)
+	return count, results.Error
+}
+
+func (repository *BalancePointTxRepositoryImplementation) FindLastBalancePointTxBeforeDate(DB *gorm.DB, idBalancePoint string, date time.Time) (entity.BalancePointTx, error) {
+	var balancePointTx entity.BalancePointTx
+	results := DB.Where("id_balance_point = ?", idBalancePoint).
+		Where("tx_date < ?", date).
+		Order("tx_date desc, created_at desc").
+		Limit(1).
+		Find(&balancePointTx)
+	return balancePointTx, results.Error
+}
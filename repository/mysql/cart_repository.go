@@ -8,7 +8,7 @@ import (
 
 type CartRepositoryInterface interface {
 	FindCartByIdUser(DB *gorm.DB, IdUser string) ([]entity.Cart, error)
-	FindProductInCartByIdUser(DB *gorm.DB, IdUser string, IdProduct string) (entity.Cart, error)
+	FindProductInCartByIdUser(DB *gorm.DB, IdUser string, IdProduct string, IdProductVariant string) (entity.Cart, error)
 	AddProductToCart(DB *gorm.DB, cart entity.Cart) (entity.Cart, error)
 	UpdateProductInCart(DB *gorm.DB, IdCart string, cartEntity entity.Cart) (entity.Cart, error)
 	DeleteProductInCart(DB *gorm.DB, IdCart string) (err error)
@@ -36,7 +36,8 @@ func (repository *CartRepositoryImplementation) FindCartByIdUser(DB *gorm.DB, Id
 	// results := DB.Where("cart.id_user = ?", IdUser).Joins("Product").Preload("ProductDiscount").Find(&cart)
 	results := DB.Where("cart.id_user = ?", IdUser).
 		Joins("Product").
-		Preload("Product.ProductDiscount").
+		Preload("Product.ProductDiscounts").
+		Preload("ProductVariant").
 		Find(&cart)
 	// results := DB.Joins("JOIN products on products.id = cart.id_product").
 	// 	Joins("JOIN products_discount on products_discount.id_product = products.id").
@@ -51,9 +52,9 @@ func (repository *CartRepositoryImplementation) FindCartById(DB *gorm.DB, IdCart
 	return cart, results.Error
 }
 
-func (repository *CartRepositoryImplementation) FindProductInCartByIdUser(DB *gorm.DB, IdUser string, IdProduct string) (entity.Cart, error) {
+func (repository *CartRepositoryImplementation) FindProductInCartByIdUser(DB *gorm.DB, IdUser string, IdProduct string, IdProductVariant string) (entity.Cart, error) {
 	var cart entity.Cart
-	results := DB.Where("id_user = ?", IdUser).Where("id_product = ?", IdProduct).Find(&cart)
+	results := DB.Where("id_user = ?", IdUser).Where("id_product = ?", IdProduct).Where("COALESCE(id_product_variant, '') = ?", IdProductVariant).Find(&cart)
 	return cart, results.Error
 }
 
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type FamilyInvitationRepositoryInterface interface {
+	CreateFamilyInvitation(DB *gorm.DB, familyInvitation entity.FamilyInvitation) (entity.FamilyInvitation, error)
+	FindFamilyInvitationById(DB *gorm.DB, idFamilyInvitation string) (entity.FamilyInvitation, error)
+	FindPendingFamilyInvitation(DB *gorm.DB, idFamily string, idUserInvitee string) (entity.FamilyInvitation, error)
+	FindPendingFamilyInvitationByIdUserInvitee(DB *gorm.DB, idUserInvitee string) ([]entity.FamilyInvitation, error)
+	UpdateFamilyInvitationStatus(DB *gorm.DB, idFamilyInvitation string, familyInvitation entity.FamilyInvitation) error
+}
+
+type FamilyInvitationRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewFamilyInvitationRepository(configDatabase *config.Database) FamilyInvitationRepositoryInterface {
+	return &FamilyInvitationRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *FamilyInvitationRepositoryImplementation) CreateFamilyInvitation(DB *gorm.DB, familyInvitation entity.FamilyInvitation) (entity.FamilyInvitation, error) {
+	results := DB.Create(familyInvitation)
+	return familyInvitation, results.Error
+}
+
+func (repository *FamilyInvitationRepositoryImplementation) FindFamilyInvitationById(DB *gorm.DB, idFamilyInvitation string) (entity.FamilyInvitation, error) {
+	var familyInvitation entity.FamilyInvitation
+	results := DB.Where("family_invitation.id = ?", idFamilyInvitation).Find(&familyInvitation)
+	return familyInvitation, results.Error
+}
+
+func (repository *FamilyInvitationRepositoryImplementation) FindPendingFamilyInvitation(DB *gorm.DB, idFamily string, idUserInvitee string) (entity.FamilyInvitation, error) {
+	var familyInvitation entity.FamilyInvitation
+	results := DB.Where("family_invitation.id_family = ?", idFamily).
+		Where("family_invitation.id_user_invitee = ?", idUserInvitee).
+		Where("family_invitation.status = ?", "pending").
+		Find(&familyInvitation)
+	return familyInvitation, results.Error
+}
+
+func (repository *FamilyInvitationRepositoryImplementation) FindPendingFamilyInvitationByIdUserInvitee(DB *gorm.DB, idUserInvitee string) ([]entity.FamilyInvitation, error) {
+	var familyInvitations []entity.FamilyInvitation
+	results := DB.Where("family_invitation.id_user_invitee = ?", idUserInvitee).
+		Where("family_invitation.status = ?", "pending").
+		Preload("UserInviter.FamilyMembers").
+		Order("family_invitation.created_at desc").
+		Find(&familyInvitations)
+	return familyInvitations, results.Error
+}
+
+func (repository *FamilyInvitationRepositoryImplementation) UpdateFamilyInvitationStatus(DB *gorm.DB, idFamilyInvitation string, familyInvitation entity.FamilyInvitation) error {
+	result := DB.
+		Model(entity.FamilyInvitation{}).
+		Where("id = ?", idFamilyInvitation).
+		Updates(entity.FamilyInvitation{
+			Status:      familyInvitation.Status,
+			RespondedAt: familyInvitation.RespondedAt,
+		})
+	return result.Error
+}
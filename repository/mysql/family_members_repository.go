@@ -9,6 +9,7 @@ import (
 type FamilyMembersRepositoryInterface interface {
 	CreateFamilyMembers(DB *gorm.DB, user entity.FamilyMembers) (entity.FamilyMembers, error)
 	UpdateFamilyMembers(DB *gorm.DB, idFamilyMembers string, familyMembers entity.FamilyMembers) (entity.FamilyMembers, error)
+	UpdateFamilyMembersIdFamily(DB *gorm.DB, idFamilyMembers string, idFamily string) error
 }
 
 type FamilyMembersRepositoryImplementation struct {
@@ -42,3 +43,13 @@ func (repository *FamilyMembersRepositoryImplementation) UpdateFamilyMembers(DB
 		})
 	return familyMembers, result.Error
 }
+
+func (repository *FamilyMembersRepositoryImplementation) UpdateFamilyMembersIdFamily(DB *gorm.DB, idFamilyMembers string, idFamily string) error {
+	result := DB.
+		Model(entity.FamilyMembers{}).
+		Where("id = ?", idFamilyMembers).
+		Updates(entity.FamilyMembers{
+			IdFamily: idFamily,
+		})
+	return result.Error
+}
@@ -0,0 +1,39 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type FamilyPointTransferRepositoryInterface interface {
+	CreateFamilyPointTransfer(DB *gorm.DB, familyPointTransfer entity.FamilyPointTransfer) (entity.FamilyPointTransfer, error)
+	SumFamilyPointTransferByIdUserFrom(DB *gorm.DB, idUserFrom string, dateFrom time.Time) (float64, error)
+}
+
+type FamilyPointTransferRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewFamilyPointTransferRepository(configDatabase *config.Database) FamilyPointTransferRepositoryInterface {
+	return &FamilyPointTransferRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *FamilyPointTransferRepositoryImplementation) CreateFamilyPointTransfer(DB *gorm.DB, familyPointTransfer entity.FamilyPointTransfer) (entity.FamilyPointTransfer, error) {
+	results := DB.Create(familyPointTransfer)
+	return familyPointTransfer, results.Error
+}
+
+func (repository *FamilyPointTransferRepositoryImplementation) SumFamilyPointTransferByIdUserFrom(DB *gorm.DB, idUserFrom string, dateFrom time.Time) (float64, error) {
+	var total float64
+	results := DB.Model(&entity.FamilyPointTransfer{}).
+		Select("COALESCE(SUM(nominal), 0)").
+		Where("id_user_from = ?", idUserFrom).
+		Where("created_at >= ?", dateFrom).
+		Scan(&total)
+	return total, results.Error
+}
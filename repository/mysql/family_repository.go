@@ -8,6 +8,9 @@ import (
 
 type FamilyRepositoryInterface interface {
 	CreateFamily(DB *gorm.DB, user entity.Family) (entity.Family, error)
+	FindFamilyById(DB *gorm.DB, idFamily string) (entity.Family, error)
+	UpdateFamilyHead(DB *gorm.DB, idFamily string, idUserHead string) error
+	UpdateFamilySharedWallet(DB *gorm.DB, idFamily string, sharedWallet int) error
 }
 
 type FamilyRepositoryImplementation struct {
@@ -24,3 +27,29 @@ func (repository *FamilyRepositoryImplementation) CreateFamily(DB *gorm.DB, fami
 	results := DB.Create(family)
 	return family, results.Error
 }
+
+func (repository *FamilyRepositoryImplementation) FindFamilyById(DB *gorm.DB, idFamily string) (entity.Family, error) {
+	var family entity.Family
+	results := DB.Where("family.id = ?", idFamily).Find(&family)
+	return family, results.Error
+}
+
+func (repository *FamilyRepositoryImplementation) UpdateFamilyHead(DB *gorm.DB, idFamily string, idUserHead string) error {
+	updateFamily := make(map[string]interface{})
+	updateFamily["id_user_head"] = idUserHead
+	result := DB.
+		Model(entity.Family{}).
+		Where("id = ?", idFamily).
+		Updates(&updateFamily)
+	return result.Error
+}
+
+func (repository *FamilyRepositoryImplementation) UpdateFamilySharedWallet(DB *gorm.DB, idFamily string, sharedWallet int) error {
+	updateFamily := make(map[string]interface{})
+	updateFamily["shared_wallet"] = sharedWallet
+	result := DB.
+		Model(entity.Family{}).
+		Where("id = ?", idFamily).
+		Updates(&updateFamily)
+	return result.Error
+}
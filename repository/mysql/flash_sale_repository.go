@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type FlashSaleRepositoryInterface interface {
+	FindFlashSales(DB *gorm.DB, limit int, offset int) ([]entity.FlashSale, int64, error)
+	FindFlashSaleById(DB *gorm.DB, id string) (entity.FlashSale, error)
+	FindFlashSaleItemById(DB *gorm.DB, id string) (entity.FlashSaleItem, error)
+	FindUpcomingFlashSales(DB *gorm.DB, now time.Time) ([]entity.FlashSale, error)
+	FindActiveFlashSaleItems(DB *gorm.DB, idProducts []string, now time.Time) ([]entity.FlashSaleItem, error)
+	FindOverlappingFlashSaleItems(DB *gorm.DB, idProducts []string, startAt time.Time, endAt time.Time) ([]entity.FlashSaleItem, error)
+	CreateFlashSale(DB *gorm.DB, flashSale entity.FlashSale) (entity.FlashSale, error)
+	CreateFlashSaleItems(DB *gorm.DB, flashSaleItems []entity.FlashSaleItem) error
+	UpdateFlashSaleStatus(DB *gorm.DB, id string, flashSale entity.FlashSale) (entity.FlashSale, error)
+	IncreaseFlashSaleSoldQty(DB *gorm.DB, idFlashSaleItem string, qty int) (int64, error)
+	DecreaseFlashSaleSoldQty(DB *gorm.DB, idFlashSaleItem string, qty int) error
+}
+
+type FlashSaleRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewFlashSaleRepository(configDatabase *config.Database) FlashSaleRepositoryInterface {
+	return &FlashSaleRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *FlashSaleRepositoryImplementation) FindFlashSales(DB *gorm.DB, limit int, offset int) ([]entity.FlashSale, int64, error) {
+	var flashSales []entity.FlashSale
+	var total int64
+	results := DB.Model(&entity.FlashSale{}).Count(&total)
+	if results.Error != nil {
+		return flashSales, total, results.Error
+	}
+	results = DB.Preload("FlashSaleItems").
+		Preload("FlashSaleItems.Product").
+		Preload("FlashSaleItems.ProductVariant").
+		Order("flash_sale.start_at desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&flashSales)
+	return flashSales, total, results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) FindFlashSaleById(DB *gorm.DB, id string) (entity.FlashSale, error) {
+	var flashSale entity.FlashSale
+	results := DB.Preload("FlashSaleItems").
+		Preload("FlashSaleItems.Product").
+		Preload("FlashSaleItems.ProductVariant").
+		Where("flash_sale.id = ?", id).
+		Find(&flashSale)
+	return flashSale, results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) FindFlashSaleItemById(DB *gorm.DB, id string) (entity.FlashSaleItem, error) {
+	var flashSaleItem entity.FlashSaleItem
+	results := DB.Where("flash_sale_item.id = ?", id).Find(&flashSaleItem)
+	return flashSaleItem, results.Error
+}
+
+// Flash sale yang sedang berjalan dan yang akan datang, urut waktu mulai
+func (repository *FlashSaleRepositoryImplementation) FindUpcomingFlashSales(DB *gorm.DB, now time.Time) ([]entity.FlashSale, error) {
+	var flashSales []entity.FlashSale
+	results := DB.Preload("FlashSaleItems").
+		Preload("FlashSaleItems.Product").
+		Preload("FlashSaleItems.ProductVariant").
+		Where("flash_sale.is_active = ?", 1).
+		Where("flash_sale.end_at > ?", now).
+		Order("flash_sale.start_at asc").
+		Find(&flashSales)
+	return flashSales, results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) FindActiveFlashSaleItems(DB *gorm.DB, idProducts []string, now time.Time) ([]entity.FlashSaleItem, error) {
+	var flashSaleItems []entity.FlashSaleItem
+	results := DB.Joins("FlashSale").
+		Where("flash_sale_item.id_product IN ?", idProducts).
+		Where("FlashSale.is_active = ?", 1).
+		Where("FlashSale.start_at <= ?", now).
+		Where("FlashSale.end_at > ?", now).
+		Order("flash_sale_item.sale_price asc").
+		Find(&flashSaleItems)
+	return flashSaleItems, results.Error
+}
+
+// Item flash sale aktif lain untuk produk yang sama di rentang waktu yang beririsan
+func (repository *FlashSaleRepositoryImplementation) FindOverlappingFlashSaleItems(DB *gorm.DB, idProducts []string, startAt time.Time, endAt time.Time) ([]entity.FlashSaleItem, error) {
+	var flashSaleItems []entity.FlashSaleItem
+	results := DB.Joins("FlashSale").
+		Joins("Product").
+		Where("flash_sale_item.id_product IN ?", idProducts).
+		Where("FlashSale.is_active = ?", 1).
+		Where("FlashSale.start_at < ?", endAt).
+		Where("FlashSale.end_at > ?", startAt).
+		Find(&flashSaleItems)
+	return flashSaleItems, results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) CreateFlashSale(DB *gorm.DB, flashSale entity.FlashSale) (entity.FlashSale, error) {
+	results := DB.Omit("FlashSaleItems").Create(&flashSale)
+	return flashSale, results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) CreateFlashSaleItems(DB *gorm.DB, flashSaleItems []entity.FlashSaleItem) error {
+	results := DB.Omit("FlashSale", "Product", "ProductVariant").Create(&flashSaleItems)
+	return results.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) UpdateFlashSaleStatus(DB *gorm.DB, id string, flashSale entity.FlashSale) (entity.FlashSale, error) {
+	updateFlashSale := make(map[string]interface{})
+	updateFlashSale["is_active"] = flashSale.IsActive
+	result := DB.
+		Model(entity.FlashSale{}).
+		Where("id = ?", id).
+		Updates(&updateFlashSale)
+	return flashSale, result.Error
+}
+
+// Kuota dicek dan ditambah dalam satu query agar tidak terjual melebihi kuota saat order bersamaan
+func (repository *FlashSaleRepositoryImplementation) IncreaseFlashSaleSoldQty(DB *gorm.DB, idFlashSaleItem string, qty int) (int64, error) {
+	result := DB.
+		Model(entity.FlashSaleItem{}).
+		Where("id = ?", idFlashSaleItem).
+		Where("sold_qty + ? <= quota", qty).
+		Update("sold_qty", gorm.Expr("sold_qty + ?", qty))
+	return result.RowsAffected, result.Error
+}
+
+func (repository *FlashSaleRepositoryImplementation) DecreaseFlashSaleSoldQty(DB *gorm.DB, idFlashSaleItem string, qty int) error {
+	result := DB.
+		Model(entity.FlashSaleItem{}).
+		Where("id = ?", idFlashSaleItem).
+		Where("sold_qty >= ?", qty).
+		Update("sold_qty", gorm.Expr("sold_qty - ?", qty))
+	return result.Error
+}
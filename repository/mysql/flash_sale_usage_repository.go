@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type FlashSaleUsageRepositoryInterface interface {
+	SumFlashSaleUsageQtyByIdUser(DB *gorm.DB, idFlashSaleItem string, idUser string) (int, error)
+	FindFlashSaleUsagesByIdOrder(DB *gorm.DB, idOrder string) ([]entity.FlashSaleUsage, error)
+	CreateFlashSaleUsages(DB *gorm.DB, flashSaleUsages []entity.FlashSaleUsage) error
+	UpdateFlashSaleUsageStatus(DB *gorm.DB, idFlashSaleUsage string, flashSaleUsage entity.FlashSaleUsage) (entity.FlashSaleUsage, error)
+}
+
+type FlashSaleUsageRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewFlashSaleUsageRepository(configDatabase *config.Database) FlashSaleUsageRepositoryInterface {
+	return &FlashSaleUsageRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Pembelian dari order yang dibatalkan tidak dihitung
+func (repository *FlashSaleUsageRepositoryImplementation) SumFlashSaleUsageQtyByIdUser(DB *gorm.DB, idFlashSaleItem string, idUser string) (int, error) {
+	var total int
+	results := DB.Model(&entity.FlashSaleUsage{}).
+		Select("COALESCE(SUM(flash_sale_usage.qty), 0)").
+		Where("flash_sale_usage.id_flash_sale_item = ?", idFlashSaleItem).
+		Where("flash_sale_usage.id_user = ?", idUser).
+		Where("flash_sale_usage.status = ?", "used").
+		Scan(&total)
+	return total, results.Error
+}
+
+func (repository *FlashSaleUsageRepositoryImplementation) FindFlashSaleUsagesByIdOrder(DB *gorm.DB, idOrder string) ([]entity.FlashSaleUsage, error) {
+	var flashSaleUsages []entity.FlashSaleUsage
+	results := DB.Where("flash_sale_usage.id_order = ?", idOrder).
+		Where("flash_sale_usage.status = ?", "used").
+		Find(&flashSaleUsages)
+	return flashSaleUsages, results.Error
+}
+
+func (repository *FlashSaleUsageRepositoryImplementation) CreateFlashSaleUsages(DB *gorm.DB, flashSaleUsages []entity.FlashSaleUsage) error {
+	results := DB.Create(&flashSaleUsages)
+	return results.Error
+}
+
+func (repository *FlashSaleUsageRepositoryImplementation) UpdateFlashSaleUsageStatus(DB *gorm.DB, idFlashSaleUsage string, flashSaleUsage entity.FlashSaleUsage) (entity.FlashSaleUsage, error) {
+	result := DB.
+		Model(entity.FlashSaleUsage{}).
+		Where("id = ?", idFlashSaleUsage).
+		Updates(entity.FlashSaleUsage{
+			Status:     flashSaleUsage.Status,
+			ReleasedAt: flashSaleUsage.ReleasedAt,
+		})
+	return flashSaleUsage, result.Error
+}
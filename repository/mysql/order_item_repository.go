@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
 	"gorm.io/gorm"
@@ -9,6 +11,7 @@ import (
 type OrderItemRepositoryInterface interface {
 	CreateOrderItems(DB *gorm.DB, order []entity.OrderItem) error
 	FindOrderItemsByIdOrder(DB *gorm.DB, idOrder string) ([]entity.OrderItem, error)
+	SumOrderItemQtyByIdUser(DB *gorm.DB, idUser string, idProduct string, dateFrom time.Time) (int, error)
 }
 
 type OrderItemRepositoryImplementation struct {
@@ -31,3 +34,19 @@ func (repository *OrderItemRepositoryImplementation) FindOrderItemsByIdOrder(DB
 	results := DB.Where("id_order = ?", idOrder).Find(&orderItems)
 	return orderItems, results.Error
 }
+
+// Qty produk yang sudah dibeli user sejak dateFrom, dateFrom kosong berarti semua order. Order batal tidak dihitung
+func (repository *OrderItemRepositoryImplementation) SumOrderItemQtyByIdUser(DB *gorm.DB, idUser string, idProduct string, dateFrom time.Time) (int, error) {
+	var total int
+	query := DB.Model(&entity.OrderItem{}).
+		Select("COALESCE(SUM(orders_items.qty), 0)").
+		Joins("JOIN orders_transaction ON orders_transaction.id = orders_items.id_order").
+		Where("orders_transaction.id_user = ?", idUser).
+		Where("orders_items.id_product = ?", idProduct).
+		Where("orders_transaction.order_status <> ?", "Dibatalkan")
+	if !dateFrom.IsZero() {
+		query = query.Where("orders_transaction.ordered_at >= ?", dateFrom)
+	}
+	results := query.Scan(&total)
+	return total, results.Error
+}
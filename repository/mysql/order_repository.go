@@ -10,7 +10,8 @@ import (
 
 type OrderRepositoryInterface interface {
 	FindOrderByUser(DB *gorm.DB, idUser string, orderStatus string) ([]entity.Order, error)
-	FindOrderByDate(DB *gorm.DB, idUser string) ([]entity.Order, error)
+	FindCompletedOrderByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) ([]entity.Order, error)
+	SumPaymentByPointByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) (float64, error)
 	FindOrderByNumberOrder(DB *gorm.DB, numberOrder string) (entity.Order, error)
 	FindOrderById(DB *gorm.DB, idOrder string) (entity.Order, error)
 	CreateOrder(DB *gorm.DB, order entity.Order) (entity.Order, error)
@@ -39,14 +40,29 @@ func (repository *OrderRepositoryImplementation) FindOrderByUser(DB *gorm.DB, id
 	}
 }
 
-func (repository *OrderRepositoryImplementation) FindOrderByDate(DB *gorm.DB, idUser string) ([]entity.Order, error) {
+func (repository *OrderRepositoryImplementation) FindCompletedOrderByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) ([]entity.Order, error) {
 	var order []entity.Order
-	now := time.Now()
-	month := now.Month()
-	results := DB.Where("orders_transaction.id_user = ?", idUser).Where("month(ordered_at) = ?", int(month)).Where("order_status = ?", "Selesai").Find(&order)
+	results := DB.Where("orders_transaction.id_user = ?", idUser).
+		Where("orders_transaction.ordered_at >= ?", dateFrom).
+		Where("orders_transaction.ordered_at < ?", dateTo).
+		Where("order_status = ?", "Selesai").
+		Find(&order)
 	return order, results.Error
 }
 
+// Total point yang sudah dipakai untuk order yang tidak dibatalkan
+func (repository *OrderRepositoryImplementation) SumPaymentByPointByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) (float64, error) {
+	var total float64
+	results := DB.Model(&entity.Order{}).
+		Select("COALESCE(SUM(payment_by_point), 0)").
+		Where("id_user = ?", idUser).
+		Where("ordered_at >= ?", dateFrom).
+		Where("ordered_at < ?", dateTo).
+		Where("order_status <> ?", "Dibatalkan").
+		Scan(&total)
+	return total, results.Error
+}
+
 func (repository *OrderRepositoryImplementation) FindOrderByNumberOrder(DB *gorm.DB, numberOrder string) (entity.Order, error) {
 	var order entity.Order
 	results := DB.Where("orders_transaction.number_order = ?", numberOrder).First(&order)
@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type PointRedemptionRuleRepositoryInterface interface {
+	FindActivePointRedemptionRules(DB *gorm.DB, idLevelMember int) ([]entity.PointRedemptionRule, error)
+}
+
+type PointRedemptionRuleRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewPointRedemptionRuleRepository(configDatabase *config.Database) PointRedemptionRuleRepositoryInterface {
+	return &PointRedemptionRuleRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Rule dengan id_level_member 0 berlaku untuk semua level member
+func (repository *PointRedemptionRuleRepositoryImplementation) FindActivePointRedemptionRules(DB *gorm.DB, idLevelMember int) ([]entity.PointRedemptionRule, error) {
+	var pointRedemptionRules []entity.PointRedemptionRule
+	results := DB.Where("point_redemption_rule.is_active = ?", 1).
+		Where("point_redemption_rule.id_level_member IN ?", []int{0, idLevelMember}).
+		Order("point_redemption_rule.id_level_member asc").
+		Find(&pointRedemptionRules)
+	return pointRedemptionRules, results.Error
+}
@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ProductAttributeRepositoryInterface interface {
+	FindAllProductAttributes(DB *gorm.DB) ([]entity.ProductAttribute, error)
+	FindFilterableProductAttributes(DB *gorm.DB) ([]entity.ProductAttribute, error)
+	FindProductAttributeById(DB *gorm.DB, id string) (entity.ProductAttribute, error)
+	FindProductAttributeByCode(DB *gorm.DB, attributeCode string) (entity.ProductAttribute, error)
+	FindProductAttributesByIds(DB *gorm.DB, ids []string) ([]entity.ProductAttribute, error)
+	CreateProductAttribute(DB *gorm.DB, productAttribute entity.ProductAttribute) (entity.ProductAttribute, error)
+	UpdateProductAttribute(DB *gorm.DB, id string, productAttribute entity.ProductAttribute) (entity.ProductAttribute, error)
+	DeleteProductAttribute(DB *gorm.DB, id string) error
+	CreateProductAttributeOptions(DB *gorm.DB, productAttributeOptions []entity.ProductAttributeOption) ([]entity.ProductAttributeOption, error)
+	DeleteProductAttributeOptionsByIdProductAttribute(DB *gorm.DB, idProductAttribute string) error
+	CountProductAttributeValues(DB *gorm.DB, idProductAttribute string, values []string) (int64, error)
+	FindProductAttributeValuesByIdProduct(DB *gorm.DB, idProduct string) ([]entity.ProductAttributeValue, error)
+	CreateProductAttributeValues(DB *gorm.DB, productAttributeValues []entity.ProductAttributeValue) ([]entity.ProductAttributeValue, error)
+	DeleteProductAttributeValuesByIdProduct(DB *gorm.DB, idProduct string) error
+}
+
+type ProductAttributeRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductAttributeRepository(configDatabase *config.Database) ProductAttributeRepositoryInterface {
+	return &ProductAttributeRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindAllProductAttributes(DB *gorm.DB) ([]entity.ProductAttribute, error) {
+	var productAttributes []entity.ProductAttribute
+	results := preloadProductAttributeOptions(DB).
+		Order("products_attribute.position asc").
+		Order("products_attribute.attribute_name asc").
+		Find(&productAttributes)
+	return productAttributes, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindFilterableProductAttributes(DB *gorm.DB) ([]entity.ProductAttribute, error) {
+	var productAttributes []entity.ProductAttribute
+	results := preloadProductAttributeOptions(DB).
+		Where("products_attribute.is_filterable = ?", 1).
+		Order("products_attribute.position asc").
+		Order("products_attribute.attribute_name asc").
+		Find(&productAttributes)
+	return productAttributes, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindProductAttributeById(DB *gorm.DB, id string) (entity.ProductAttribute, error) {
+	var productAttribute entity.ProductAttribute
+	results := preloadProductAttributeOptions(DB).Where("products_attribute.id = ?", id).Find(&productAttribute)
+	return productAttribute, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindProductAttributeByCode(DB *gorm.DB, attributeCode string) (entity.ProductAttribute, error) {
+	var productAttribute entity.ProductAttribute
+	results := DB.Where("products_attribute.attribute_code = ?", attributeCode).Find(&productAttribute)
+	return productAttribute, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindProductAttributesByIds(DB *gorm.DB, ids []string) ([]entity.ProductAttribute, error) {
+	var productAttributes []entity.ProductAttribute
+	results := preloadProductAttributeOptions(DB).Where("products_attribute.id IN ?", ids).Find(&productAttributes)
+	return productAttributes, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) CreateProductAttribute(DB *gorm.DB, productAttribute entity.ProductAttribute) (entity.ProductAttribute, error) {
+	results := DB.Omit("ProductAttributeOptions").Create(&productAttribute)
+	return productAttribute, results.Error
+}
+
+// Kode dan tipe atribut tidak bisa diubah karena nilai produk tersimpan mengikuti tipenya
+func (repository *ProductAttributeRepositoryImplementation) UpdateProductAttribute(DB *gorm.DB, id string, productAttribute entity.ProductAttribute) (entity.ProductAttribute, error) {
+	updateProductAttribute := make(map[string]interface{})
+	updateProductAttribute["attribute_name"] = productAttribute.AttributeName
+	updateProductAttribute["unit"] = productAttribute.Unit
+	updateProductAttribute["is_filterable"] = productAttribute.IsFilterable
+	updateProductAttribute["position"] = productAttribute.Position
+	result := DB.
+		Model(entity.ProductAttribute{}).
+		Where("id = ?", id).
+		Updates(&updateProductAttribute)
+	return productAttribute, result.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) DeleteProductAttribute(DB *gorm.DB, id string) error {
+	results := DB.Where("id = ?", id).Delete(&entity.ProductAttribute{})
+	return results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) CreateProductAttributeOptions(DB *gorm.DB, productAttributeOptions []entity.ProductAttributeOption) ([]entity.ProductAttributeOption, error) {
+	results := DB.Create(&productAttributeOptions)
+	return productAttributeOptions, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) DeleteProductAttributeOptionsByIdProductAttribute(DB *gorm.DB, idProductAttribute string) error {
+	results := DB.Where("id_product_attribute = ?", idProductAttribute).Delete(&entity.ProductAttributeOption{})
+	return results.Error
+}
+
+// Jumlah nilai produk untuk atribut, values kosong berarti semua nilai
+func (repository *ProductAttributeRepositoryImplementation) CountProductAttributeValues(DB *gorm.DB, idProductAttribute string, values []string) (int64, error) {
+	var total int64
+	query := DB.Model(&entity.ProductAttributeValue{}).Where("products_attribute_value.id_product_attribute = ?", idProductAttribute)
+	if len(values) > 0 {
+		query = query.Where("products_attribute_value.value IN ?", values)
+	}
+	results := query.Count(&total)
+	return total, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) FindProductAttributeValuesByIdProduct(DB *gorm.DB, idProduct string) ([]entity.ProductAttributeValue, error) {
+	var productAttributeValues []entity.ProductAttributeValue
+	results := DB.Preload("ProductAttribute").Where("products_attribute_value.id_product = ?", idProduct).Find(&productAttributeValues)
+	return productAttributeValues, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) CreateProductAttributeValues(DB *gorm.DB, productAttributeValues []entity.ProductAttributeValue) ([]entity.ProductAttributeValue, error) {
+	results := DB.Omit("ProductAttribute").Create(&productAttributeValues)
+	return productAttributeValues, results.Error
+}
+
+func (repository *ProductAttributeRepositoryImplementation) DeleteProductAttributeValuesByIdProduct(DB *gorm.DB, idProduct string) error {
+	results := DB.Where("id_product = ?", idProduct).Delete(&entity.ProductAttributeValue{})
+	return results.Error
+}
+
+func preloadProductAttributeOptions(DB *gorm.DB) *gorm.DB {
+	return DB.Preload("ProductAttributeOptions", func(DB *gorm.DB) *gorm.DB {
+		return DB.Order("products_attribute_option.position asc")
+	})
+}
@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ProductBatchRepositoryInterface interface {
+	FindProductBatches(DB *gorm.DB, idProduct string, idProductVariant string) ([]entity.ProductBatch, error)
+	FindProductBatchById(DB *gorm.DB, id string) (entity.ProductBatch, error)
+	FindProductBatchByNumber(DB *gorm.DB, idProduct string, idProductVariant string, batchNumber string) (entity.ProductBatch, error)
+	FindProductBatchesByNumber(DB *gorm.DB, batchNumber string) ([]entity.ProductBatch, error)
+	FindSellableProductBatches(DB *gorm.DB, idProduct string, idProductVariant string, today time.Time) ([]entity.ProductBatch, error)
+	FindNearExpiryProductBatches(DB *gorm.DB, today time.Time, dateTo time.Time) ([]entity.ProductBatch, error)
+	FindExpiredProductBatches(DB *gorm.DB, today time.Time) ([]entity.ProductBatch, error)
+	CreateProductBatch(DB *gorm.DB, productBatch entity.ProductBatch) (entity.ProductBatch, error)
+	AddProductBatchReceivedQty(DB *gorm.DB, id string, qty int) error
+	UpdateProductBatchRemainingQty(DB *gorm.DB, id string, qty int) (int64, error)
+	UpdateProductBatchExpired(DB *gorm.DB, id string, productBatch entity.ProductBatch) (entity.ProductBatch, error)
+	CreateOrderItemBatches(DB *gorm.DB, orderItemBatches []entity.OrderItemBatch) error
+	FindOrderItemBatchesByIdProductBatches(DB *gorm.DB, idProductBatches []string) ([]entity.OrderItemBatch, error)
+}
+
+type ProductBatchRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductBatchRepository(configDatabase *config.Database) ProductBatchRepositoryInterface {
+	return &ProductBatchRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindProductBatches(DB *gorm.DB, idProduct string, idProductVariant string) ([]entity.ProductBatch, error) {
+	var productBatches []entity.ProductBatch
+	results := DB.Joins("Product").
+		Joins("ProductVariant").
+		Where("products_batch.id_product = ?", idProduct).
+		Where("products_batch.id_product_variant = ?", idProductVariant).
+		Order("products_batch.expiry_date asc").
+		Order("products_batch.created_at asc").
+		Find(&productBatches)
+	return productBatches, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindProductBatchById(DB *gorm.DB, id string) (entity.ProductBatch, error) {
+	var productBatch entity.ProductBatch
+	results := DB.Joins("Product").
+		Joins("ProductVariant").
+		Where("products_batch.id = ?", id).
+		Find(&productBatch)
+	return productBatch, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindProductBatchByNumber(DB *gorm.DB, idProduct string, idProductVariant string, batchNumber string) (entity.ProductBatch, error) {
+	var productBatch entity.ProductBatch
+	results := DB.Where("products_batch.id_product = ?", idProduct).
+		Where("products_batch.id_product_variant = ?", idProductVariant).
+		Where("products_batch.batch_number = ?", batchNumber).
+		Limit(1).
+		Find(&productBatch)
+	return productBatch, results.Error
+}
+
+// Nomor batch bisa sama di produk yang berbeda, untuk recall semua batch dengan nomor tersebut diambil
+func (repository *ProductBatchRepositoryImplementation) FindProductBatchesByNumber(DB *gorm.DB, batchNumber string) ([]entity.ProductBatch, error) {
+	var productBatches []entity.ProductBatch
+	results := DB.Joins("Product").
+		Joins("ProductVariant").
+		Where("products_batch.batch_number = ?", batchNumber).
+		Order("products_batch.created_at asc").
+		Find(&productBatches)
+	return productBatches, results.Error
+}
+
+// Batch yang masih bisa dijual urut kadaluarsa paling dekat (FEFO), batch yang kadaluarsa hari ini tidak dijual
+func (repository *ProductBatchRepositoryImplementation) FindSellableProductBatches(DB *gorm.DB, idProduct string, idProductVariant string, today time.Time) ([]entity.ProductBatch, error) {
+	var productBatches []entity.ProductBatch
+	results := DB.Where("products_batch.id_product = ?", idProduct).
+		Where("products_batch.id_product_variant = ?", idProductVariant).
+		Where("products_batch.remaining_qty > ?", 0).
+		Where("products_batch.expiry_date > ?", today).
+		Order("products_batch.expiry_date asc").
+		Order("products_batch.created_at asc").
+		Find(&productBatches)
+	return productBatches, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindNearExpiryProductBatches(DB *gorm.DB, today time.Time, dateTo time.Time) ([]entity.ProductBatch, error) {
+	var productBatches []entity.ProductBatch
+	results := DB.Joins("Product").
+		Joins("ProductVariant").
+		Where("products_batch.remaining_qty > ?", 0).
+		Where("products_batch.expiry_date > ?", today).
+		Where("products_batch.expiry_date <= ?", dateTo).
+		Order("products_batch.expiry_date asc").
+		Order("Product.product_name asc").
+		Find(&productBatches)
+	return productBatches, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindExpiredProductBatches(DB *gorm.DB, today time.Time) ([]entity.ProductBatch, error) {
+	var productBatches []entity.ProductBatch
+	results := DB.Joins("Product").
+		Joins("ProductVariant").
+		Where("products_batch.remaining_qty > ?", 0).
+		Where("products_batch.expiry_date <= ?", today).
+		Order("products_batch.expiry_date asc").
+		Find(&productBatches)
+	return productBatches, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) CreateProductBatch(DB *gorm.DB, productBatch entity.ProductBatch) (entity.ProductBatch, error) {
+	results := DB.Omit("Product", "ProductVariant").Create(&productBatch)
+	return productBatch, results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) AddProductBatchReceivedQty(DB *gorm.DB, id string, qty int) error {
+	updateProductBatch := make(map[string]interface{})
+	updateProductBatch["received_qty"] = gorm.Expr("received_qty + ?", qty)
+	updateProductBatch["remaining_qty"] = gorm.Expr("remaining_qty + ?", qty)
+	result := DB.
+		Model(entity.ProductBatch{}).
+		Where("id = ?", id).
+		Updates(&updateProductBatch)
+	return result.Error
+}
+
+// Qty negatif untuk mengurangi sisa batch, tidak ada baris yang berubah jika sisa batch tidak cukup
+func (repository *ProductBatchRepositoryImplementation) UpdateProductBatchRemainingQty(DB *gorm.DB, id string, qty int) (int64, error) {
+	result := DB.
+		Model(entity.ProductBatch{}).
+		Where("id = ?", id).
+		Where("remaining_qty + ? >= 0", qty).
+		Update("remaining_qty", gorm.Expr("remaining_qty + ?", qty))
+	return result.RowsAffected, result.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) UpdateProductBatchExpired(DB *gorm.DB, id string, productBatch entity.ProductBatch) (entity.ProductBatch, error) {
+	updateProductBatch := make(map[string]interface{})
+	updateProductBatch["remaining_qty"] = productBatch.RemainingQty
+	updateProductBatch["expired_at"] = productBatch.ExpiredAt
+	result := DB.
+		Model(entity.ProductBatch{}).
+		Where("id = ?", id).
+		Updates(&updateProductBatch)
+	return productBatch, result.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) CreateOrderItemBatches(DB *gorm.DB, orderItemBatches []entity.OrderItemBatch) error {
+	results := DB.Omit("Order", "ProductBatch").Create(&orderItemBatches)
+	return results.Error
+}
+
+func (repository *ProductBatchRepositoryImplementation) FindOrderItemBatchesByIdProductBatches(DB *gorm.DB, idProductBatches []string) ([]entity.OrderItemBatch, error) {
+	var orderItemBatches []entity.OrderItemBatch
+	results := DB.Joins("Order").
+		Joins("ProductBatch").
+		Where("orders_items_batch.id_product_batch IN ?", idProductBatches).
+		Order("orders_items_batch.created_at asc").
+		Find(&orderItemBatches)
+	return orderItemBatches, results.Error
+}
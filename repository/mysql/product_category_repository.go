@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"gorm.io/gorm"
+)
+
+type ProductCategoryRepositoryInterface interface {
+	FindAllCategories(DB *gorm.DB) ([]entity.ProductCategory, error)
+	FindCategoryById(DB *gorm.DB, id int) (entity.ProductCategory, error)
+	FindSubCategoryById(DB *gorm.DB, id int) (entity.ProductSubCategory, error)
+	FindProductCategoryCounts(DB *gorm.DB) ([]modelService.ProductCategoryCount, error)
+	CountProductsByCategory(DB *gorm.DB, idCategory int) (int64, error)
+	CountProductsBySubCategory(DB *gorm.DB, idSubCategory int) (int64, error)
+	CreateCategory(DB *gorm.DB, category entity.ProductCategory) (entity.ProductCategory, error)
+	UpdateCategory(DB *gorm.DB, id int, category entity.ProductCategory) (entity.ProductCategory, error)
+	DeleteCategory(DB *gorm.DB, id int) error
+	CreateSubCategory(DB *gorm.DB, subCategory entity.ProductSubCategory) (entity.ProductSubCategory, error)
+	UpdateSubCategory(DB *gorm.DB, id int, subCategory entity.ProductSubCategory) (entity.ProductSubCategory, error)
+	DeleteSubCategory(DB *gorm.DB, id int) error
+}
+
+type ProductCategoryRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductCategoryRepository(configDatabase *config.Database) ProductCategoryRepositoryInterface {
+	return &ProductCategoryRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductCategoryRepositoryImplementation) FindAllCategories(DB *gorm.DB) ([]entity.ProductCategory, error) {
+	var categories []entity.ProductCategory
+	results := DB.Preload("ProductSubCategories", func(DB *gorm.DB) *gorm.DB {
+		return DB.Order("products_sub_category.sort_order asc").Order("products_sub_category.sub_category_name asc")
+	}).
+		Order("products_category.sort_order asc").
+		Order("products_category.category_name asc").
+		Find(&categories)
+	return categories, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) FindCategoryById(DB *gorm.DB, id int) (entity.ProductCategory, error) {
+	var category entity.ProductCategory
+	results := DB.Preload("ProductSubCategories").Where("products_category.id = ?", id).Find(&category)
+	return category, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) FindSubCategoryById(DB *gorm.DB, id int) (entity.ProductSubCategory, error) {
+	var subCategory entity.ProductSubCategory
+	results := DB.Where("products_sub_category.id = ?", id).Find(&subCategory)
+	return subCategory, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) FindProductCategoryCounts(DB *gorm.DB) ([]modelService.ProductCategoryCount, error) {
+	var productCategoryCounts []modelService.ProductCategoryCount
+	results := DB.Model(&entity.Product{}).
+		Select("products.id_category, products.id_sub_category, COUNT(*) AS published, SUM(CASE WHEN products.stock > 0 THEN 1 ELSE 0 END) AS in_stock").
+		Where("products.published = ?", "1").
+		Group("products.id_category, products.id_sub_category").
+		Scan(&productCategoryCounts)
+	return productCategoryCounts, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) CountProductsByCategory(DB *gorm.DB, idCategory int) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.Product{}).Where("products.id_category = ?", idCategory).Count(&total)
+	return total, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) CountProductsBySubCategory(DB *gorm.DB, idSubCategory int) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.Product{}).Where("products.id_sub_category = ?", idSubCategory).Count(&total)
+	return total, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) CreateCategory(DB *gorm.DB, category entity.ProductCategory) (entity.ProductCategory, error) {
+	results := DB.Omit("ProductSubCategories").Create(&category)
+	return category, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) UpdateCategory(DB *gorm.DB, id int, category entity.ProductCategory) (entity.ProductCategory, error) {
+	updateCategory := make(map[string]interface{})
+	updateCategory["category_name"] = category.CategoryName
+	updateCategory["icon"] = category.Icon
+	updateCategory["sort_order"] = category.SortOrder
+	updateCategory["is_active"] = category.IsActive
+	result := DB.
+		Model(entity.ProductCategory{}).
+		Where("id = ?", id).
+		Updates(&updateCategory)
+	return category, result.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) DeleteCategory(DB *gorm.DB, id int) error {
+	results := DB.Where("id = ?", id).Delete(&entity.ProductCategory{})
+	return results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) CreateSubCategory(DB *gorm.DB, subCategory entity.ProductSubCategory) (entity.ProductSubCategory, error) {
+	results := DB.Create(&subCategory)
+	return subCategory, results.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) UpdateSubCategory(DB *gorm.DB, id int, subCategory entity.ProductSubCategory) (entity.ProductSubCategory, error) {
+	updateSubCategory := make(map[string]interface{})
+	updateSubCategory["id_category"] = subCategory.IdCategory
+	updateSubCategory["sub_category_name"] = subCategory.SubCategoryName
+	updateSubCategory["icon"] = subCategory.Icon
+	updateSubCategory["sort_order"] = subCategory.SortOrder
+	updateSubCategory["is_active"] = subCategory.IsActive
+	result := DB.
+		Model(entity.ProductSubCategory{}).
+		Where("id = ?", id).
+		Updates(&updateSubCategory)
+	return subCategory, result.Error
+}
+
+func (repository *ProductCategoryRepositoryImplementation) DeleteSubCategory(DB *gorm.DB, id int) error {
+	results := DB.Where("id = ?", id).Delete(&entity.ProductSubCategory{})
+	return results.Error
+}
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ProductDiscountRepositoryInterface interface {
+	CreateProductDiscount(DB *gorm.DB, productDiscount entity.ProductDiscount) (entity.ProductDiscount, error)
+	DeleteProductDiscountsByIdProduct(DB *gorm.DB, idProduct string) error
+}
+
+type ProductDiscountRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductDiscountRepository(configDatabase *config.Database) ProductDiscountRepositoryInterface {
+	return &ProductDiscountRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Tanggal kosong disimpan sebagai NULL, artinya diskon berlaku tanpa batas waktu
+func (repository *ProductDiscountRepositoryImplementation) CreateProductDiscount(DB *gorm.DB, productDiscount entity.ProductDiscount) (entity.ProductDiscount, error) {
+	createProductDiscount := make(map[string]interface{})
+	createProductDiscount["id"] = productDiscount.Id
+	createProductDiscount["id_product"] = productDiscount.IdProduct
+	createProductDiscount["percentage"] = productDiscount.Percentage
+	createProductDiscount["nominal"] = productDiscount.Nominal
+	createProductDiscount["flag_promo"] = productDiscount.FlagPromo
+	createProductDiscount["discount_type"] = productDiscount.DiscountType
+	createProductDiscount["priority"] = productDiscount.Priority
+	createProductDiscount["stackable"] = productDiscount.Stackable
+	createProductDiscount["start_date"] = nil
+	if !productDiscount.StartDate.IsZero() {
+		createProductDiscount["start_date"] = productDiscount.StartDate
+	}
+	createProductDiscount["end_date"] = nil
+	if !productDiscount.EndDate.IsZero() {
+		createProductDiscount["end_date"] = productDiscount.EndDate
+	}
+	results := DB.Model(&entity.ProductDiscount{}).Create(createProductDiscount)
+	return productDiscount, results.Error
+}
+
+func (repository *ProductDiscountRepositoryImplementation) DeleteProductDiscountsByIdProduct(DB *gorm.DB, idProduct string) error {
+	results := DB.Where("products_discount.id_product = ?", idProduct).Delete(&entity.ProductDiscount{})
+	return results.Error
+}
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ProductImageRepositoryInterface interface {
+	FindProductImagesByIdProduct(DB *gorm.DB, idProduct string) ([]entity.ProductImage, error)
+	FindProductImageById(DB *gorm.DB, id string) (entity.ProductImage, error)
+	CreateProductImage(DB *gorm.DB, productImage entity.ProductImage) (entity.ProductImage, error)
+	UpdateProductImagePosition(DB *gorm.DB, id string, position int) error
+	DeleteProductImage(DB *gorm.DB, id string) error
+}
+
+type ProductImageRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductImageRepository(configDatabase *config.Database) ProductImageRepositoryInterface {
+	return &ProductImageRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductImageRepositoryImplementation) FindProductImagesByIdProduct(DB *gorm.DB, idProduct string) ([]entity.ProductImage, error) {
+	var productImages []entity.ProductImage
+	results := DB.Where("products_image.id_product = ?", idProduct).
+		Order("products_image.position asc").
+		Find(&productImages)
+	return productImages, results.Error
+}
+
+func (repository *ProductImageRepositoryImplementation) FindProductImageById(DB *gorm.DB, id string) (entity.ProductImage, error) {
+	var productImage entity.ProductImage
+	results := DB.Where("products_image.id = ?", id).Find(&productImage)
+	return productImage, results.Error
+}
+
+func (repository *ProductImageRepositoryImplementation) CreateProductImage(DB *gorm.DB, productImage entity.ProductImage) (entity.ProductImage, error) {
+	results := DB.Create(productImage)
+	return productImage, results.Error
+}
+
+func (repository *ProductImageRepositoryImplementation) UpdateProductImagePosition(DB *gorm.DB, id string, position int) error {
+	result := DB.
+		Model(entity.ProductImage{}).
+		Where("id = ?", id).
+		Update("position", position)
+	return result.Error
+}
+
+func (repository *ProductImageRepositoryImplementation) DeleteProductImage(DB *gorm.DB, id string) error {
+	results := DB.Where("id = ?", id).Delete(&entity.ProductImage{})
+	return results.Error
+}
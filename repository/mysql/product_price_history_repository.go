@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"gorm.io/gorm"
+)
+
+type ProductPriceHistoryRepositoryInterface interface {
+	CreateProductPriceHistory(DB *gorm.DB, productPriceHistory entity.ProductPriceHistory) (entity.ProductPriceHistory, error)
+	FindProductPriceHistories(DB *gorm.DB, idProduct string, pagination modelService.Pagination) ([]entity.ProductPriceHistory, int64, error)
+	FindHighestOldSellingPrices(DB *gorm.DB, idProducts []string, dateFrom time.Time) (map[string]float64, error)
+}
+
+type ProductPriceHistoryRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductPriceHistoryRepository(configDatabase *config.Database) ProductPriceHistoryRepositoryInterface {
+	return &ProductPriceHistoryRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductPriceHistoryRepositoryImplementation) CreateProductPriceHistory(DB *gorm.DB, productPriceHistory entity.ProductPriceHistory) (entity.ProductPriceHistory, error) {
+	results := DB.Create(&productPriceHistory)
+	return productPriceHistory, results.Error
+}
+
+func (repository *ProductPriceHistoryRepositoryImplementation) FindProductPriceHistories(DB *gorm.DB, idProduct string, pagination modelService.Pagination) ([]entity.ProductPriceHistory, int64, error) {
+	var productPriceHistories []entity.ProductPriceHistory
+	var total int64
+	results := DB.Model(&entity.ProductPriceHistory{}).Where("products_price_history.id_product = ?", idProduct).Count(&total)
+	if results.Error != nil {
+		return productPriceHistories, total, results.Error
+	}
+
+	results = DB.Where("products_price_history.id_product = ?", idProduct).
+		Order("products_price_history.created_at desc").
+		Order("products_price_history.id asc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&productPriceHistories)
+	return productPriceHistories, total, results.Error
+}
+
+// Harga jual tertinggi sebelum perubahan sejak dateFrom, catatan produk baru dengan harga lama 0 tidak dihitung
+func (repository *ProductPriceHistoryRepositoryImplementation) FindHighestOldSellingPrices(DB *gorm.DB, idProducts []string, dateFrom time.Time) (map[string]float64, error) {
+	var productPrices []struct {
+		IdProduct string
+		Price     float64
+	}
+	results := DB.Model(&entity.ProductPriceHistory{}).
+		Select("products_price_history.id_product, MAX(products_price_history.old_selling_price) AS price").
+		Where("products_price_history.id_product IN ?", idProducts).
+		Where("products_price_history.created_at >= ?", dateFrom).
+		Where("products_price_history.old_selling_price > ?", 0).
+		Group("products_price_history.id_product").
+		Scan(&productPrices)
+
+	highestPrices := make(map[string]float64, len(productPrices))
+	for _, productPrice := range productPrices {
+		highestPrices[productPrice.IdProduct] = productPrice.Price
+	}
+	return highestPrices, results.Error
+}
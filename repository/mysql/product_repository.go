@@ -1,21 +1,42 @@
 package mysql
 
 import (
+	"sort"
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
 	"gorm.io/gorm"
 )
 
 type ProductRepositoryInterface interface {
-	FindAllProducts(DB *gorm.DB, limit int, page int) ([]entity.Product, error)
-	FindProductsBySearch(DB *gorm.DB, productName string) ([]entity.Product, error)
+	FindAllProducts(DB *gorm.DB, pagination modelService.Pagination) ([]entity.Product, int64, error)
+	FindAllPublishedProducts(DB *gorm.DB) ([]entity.Product, error)
+	FindProductSoldQty(DB *gorm.DB) (map[string]int, error)
 	FindProductById(DB *gorm.DB, id string) (entity.Product, error)
-	FindProductByIdCategory(DB *gorm.DB, idCategory string) ([]entity.Product, error)
-	FindProductByIdSubCategory(DB *gorm.DB, idSubCategory string) ([]entity.Product, error)
-	FindProductByIdBrand(DB *gorm.DB, idBrand string) ([]entity.Product, error)
+	FindProductsByIds(DB *gorm.DB, ids []string) ([]entity.Product, error)
+	FindProductByIdIncludeUnpublished(DB *gorm.DB, id string) (entity.Product, error)
+	FindProductByIdCategory(DB *gorm.DB, idCategory string, pagination modelService.Pagination) ([]entity.Product, int64, error)
+	FindProductByIdSubCategory(DB *gorm.DB, idSubCategory string, pagination modelService.Pagination) ([]entity.Product, int64, error)
+	FindProductByIdBrand(DB *gorm.DB, idBrand string, pagination modelService.Pagination) ([]entity.Product, int64, error)
 	UpdateProductStock(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error)
+	UpdateProductPicture(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error)
+	FindAdminProducts(DB *gorm.DB, status string, keyword string, pagination modelService.Pagination) ([]entity.Product, int64, error)
+	FindAdminProductsForExport(DB *gorm.DB, status string) ([]entity.Product, error)
+	FindProductByNoSku(DB *gorm.DB, noSku string) (entity.Product, error)
+	CreateProduct(DB *gorm.DB, product entity.Product) (entity.Product, error)
+	UpdateProduct(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error)
+	UpdateProductPublished(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error)
+	FindProductsForStockMonitor(DB *gorm.DB, ids []string) ([]entity.Product, error)
+	FindLowStockProducts(DB *gorm.DB) ([]entity.Product, error)
+	UpdateProductStockAlertLevel(DB *gorm.DB, idProduct string, fromLevel string, toLevel string) (int64, error)
 }
 
+// Jumlah terjual untuk pengurutan daftar produk
+const productSoldOrder = "(SELECT COALESCE(SUM(orders_items.qty), 0) FROM orders_items JOIN orders_transaction ON orders_transaction.id = orders_items.id_order WHERE orders_items.id_product = products.id AND orders_transaction.order_status <> 'Dibatalkan')"
+
 type ProductRepositoryImplementation struct {
 	configurationDatabase *config.Database
 }
@@ -36,45 +57,345 @@ func (repository *ProductRepositoryImplementation) UpdateProductStock(DB *gorm.D
 	return product, result.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindAllProducts(DB *gorm.DB, limit int, page int) ([]entity.Product, error) {
+// Hanya produk aktif yang dipantau, produk yang belum tayang atau diarsipkan tidak perlu dialert
+func (repository *ProductRepositoryImplementation) FindProductsForStockMonitor(DB *gorm.DB, ids []string) ([]entity.Product, error) {
 	var products []entity.Product
-	results := DB.Joins("ProductDiscount").
-		Limit(limit).
-		Offset(page-1).
+	results := DB.Select("id", "no_sku", "product_name", "stock", "reorder_point", "stock_alert_level").
+		Where("products.id IN ?", ids).
 		Where("products.published = ?", "1").
+		Where("products.archived_at IS NULL").
 		Find(&products)
 	return products, results.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindProductsBySearch(DB *gorm.DB, productName string) ([]entity.Product, error) {
+func (repository *ProductRepositoryImplementation) FindLowStockProducts(DB *gorm.DB) ([]entity.Product, error) {
 	var products []entity.Product
-	results := DB.Where("products.product_name LIKE ?", "%"+productName+"%").Where("products.published = ?", "1").Joins("ProductDiscount").Find(&products)
+	results := DB.Select("id", "no_sku", "product_name", "stock", "reorder_point", "stock_alert_level").
+		Where("products.published = ?", "1").
+		Where("products.archived_at IS NULL").
+		Where("products.stock <= 0 OR (products.reorder_point > 0 AND products.stock <= products.reorder_point)").
+		Order("products.stock asc").
+		Order("products.product_name asc").
+		Find(&products)
 	return products, results.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindProductById(DB *gorm.DB, id string) (entity.Product, error) {
+// Update bersyarat agar dua transaksi yang berjalan bersamaan tidak mengirim alert yang sama dua kali
+func (repository *ProductRepositoryImplementation) UpdateProductStockAlertLevel(DB *gorm.DB, idProduct string, fromLevel string, toLevel string) (int64, error) {
+	result := DB.
+		Model(entity.Product{}).
+		Where("id = ?", idProduct).
+		Where("stock_alert_level = ?", fromLevel).
+		Update("stock_alert_level", toLevel)
+	return result.RowsAffected, result.Error
+}
+
+func (repository *ProductRepositoryImplementation) UpdateProductPicture(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error) {
+	updateProduct := make(map[string]interface{})
+	updateProduct["picture_url"] = product.PictureUrl
+	updateProduct["thumbnail"] = product.Thumbnail
+	result := DB.
+		Model(entity.Product{}).
+		Where("id = ?", idProduct).
+		Updates(&updateProduct)
+	return product, result.Error
+}
+
+// Stok tidak ikut diubah, perubahan stok lewat jalur stok masuk beserta history
+func (repository *ProductRepositoryImplementation) UpdateProduct(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error) {
+	updateProduct := make(map[string]interface{})
+	updateProduct["no_sku"] = product.NoSku
+	updateProduct["product_name"] = product.ProductName
+	updateProduct["price"] = product.Price
+	updateProduct["description"] = product.Description
+	updateProduct["weight"] = product.Weight
+	updateProduct["volume"] = product.Volume
+	updateProduct["id_category"] = product.IdCategory
+	updateProduct["id_sub_category"] = product.IdSubCategory
+	updateProduct["id_brand"] = product.IdBrand
+	updateProduct["reorder_point"] = product.ReorderPoint
+	updateProduct["max_qty_per_order"] = product.MaxQtyPerOrder
+	updateProduct["max_qty_per_user"] = product.MaxQtyPerUser
+	updateProduct["max_qty_period_days"] = product.MaxQtyPeriodDays
+	result := DB.
+		Model(entity.Product{}).
+		Where("id = ?", idProduct).
+		Updates(&updateProduct)
+	return product, result.Error
+}
+
+func (repository *ProductRepositoryImplementation) UpdateProductPublished(DB *gorm.DB, idProduct string, product entity.Product) (entity.Product, error) {
+	updateProduct := make(map[string]interface{})
+	updateProduct["published"] = product.Published
+	updateProduct["archived_at"] = product.ArchivedAt
+	result := DB.
+		Model(entity.Product{}).
+		Where("id = ?", idProduct).
+		Updates(&updateProduct)
+	return product, result.Error
+}
+
+func (repository *ProductRepositoryImplementation) CreateProduct(DB *gorm.DB, product entity.Product) (entity.Product, error) {
+	results := DB.Omit("ProductCategory", "ProductDiscounts", "ProductBrand", "ProductOptions", "ProductVariants", "ProductImages", "ProductAttributeValues").Create(&product)
+	return product, results.Error
+}
+
+func (repository *ProductRepositoryImplementation) FindProductByNoSku(DB *gorm.DB, noSku string) (entity.Product, error) {
 	var product entity.Product
-	results := DB.Where("products.id = ?", id).Where("products.published = ?", "1").Joins("ProductDiscount").Find(&product)
+	results := DB.Where("products.no_sku = ?", noSku).Find(&product)
 	return product, results.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindProductByIdCategory(DB *gorm.DB, idCategory string) ([]entity.Product, error) {
+// Daftar produk untuk admin termasuk yang belum tayang, status kosong berarti semua produk
+func (repository *ProductRepositoryImplementation) FindAdminProducts(DB *gorm.DB, status string, keyword string, pagination modelService.Pagination) ([]entity.Product, int64, error) {
+	var products []entity.Product
+	var total int64
+	scope := func(DB *gorm.DB) *gorm.DB {
+		DB = adminProductStatusScope(DB, status)
+		if keyword != "" {
+			DB = DB.Where("(products.product_name LIKE ? OR products.no_sku LIKE ?)", "%"+keyword+"%", "%"+keyword+"%")
+		}
+		return DB
+	}
+	results := scope(DB.Model(&entity.Product{})).Count(&total)
+	if results.Error != nil {
+		return products, total, results.Error
+	}
+
+	results = scope(preloadProductVariants(DB)).
+		Preload("ProductDiscounts").
+		Joins("ProductCategory").
+		Joins("ProductBrand").
+		Order("products.created_at desc").
+		Order("products.id asc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&products)
+	return products, total, results.Error
+}
+
+func (repository *ProductRepositoryImplementation) FindAdminProductsForExport(DB *gorm.DB, status string) ([]entity.Product, error) {
 	var products []entity.Product
-	results := DB.Where("products.id_category = ?", idCategory).Where("products.published = ?", "1").Order("products.id_brand asc").Joins("ProductDiscount").Find(&products)
+	results := adminProductStatusScope(preloadProductVariants(DB), status).
+		Preload("ProductDiscounts").
+		Joins("ProductCategory").
+		Joins("ProductBrand").
+		Order("products.product_name asc").
+		Find(&products)
 	return products, results.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindProductByIdSubCategory(DB *gorm.DB, idSubCategory string) ([]entity.Product, error) {
+func adminProductStatusScope(DB *gorm.DB, status string) *gorm.DB {
+	switch status {
+	case "published":
+		return DB.Where("products.published = ?", "1")
+	case "unpublished":
+		return DB.Where("products.published <> ?", "1").Where("products.archived_at IS NULL")
+	case "archived":
+		return DB.Where("products.archived_at IS NOT NULL")
+	}
+	return DB
+}
+
+func (repository *ProductRepositoryImplementation) FindAllProducts(DB *gorm.DB, pagination modelService.Pagination) ([]entity.Product, int64, error) {
+	return findPaginatedProducts(DB, pagination, "products.product_name asc", func(DB *gorm.DB) *gorm.DB {
+		return DB.Where("products.published = ?", "1")
+	})
+}
+
+func (repository *ProductRepositoryImplementation) FindAllPublishedProducts(DB *gorm.DB) ([]entity.Product, error) {
 	var products []entity.Product
-	results := DB.Where("products.id_sub_category = ?", idSubCategory).
+	results := preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).
 		Where("products.published = ?", "1").
-		Order("products.stock = 0, products.product_name asc").
-		Joins("ProductDiscount").Find(&products)
+		Preload("ProductDiscounts").
+		Joins("ProductCategory").
+		Joins("ProductSubCategory").
+		Joins("ProductBrand").
+		Find(&products)
 	return products, results.Error
 }
 
-func (repository *ProductRepositoryImplementation) FindProductByIdBrand(DB *gorm.DB, idBrand string) ([]entity.Product, error) {
+func (repository *ProductRepositoryImplementation) FindProductById(DB *gorm.DB, id string) (entity.Product, error) {
+	var product entity.Product
+	results := preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).Where("products.id = ?", id).Where("products.published = ?", "1").Preload("ProductDiscounts").Find(&product)
+	return product, results.Error
+}
+
+func (repository *ProductRepositoryImplementation) FindProductsByIds(DB *gorm.DB, ids []string) ([]entity.Product, error) {
 	var products []entity.Product
-	results := DB.Where("products.id_brand = ?", idBrand).Where("products.published = ?", "1").Joins("ProductDiscount").Find(&products)
+	results := preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).Where("products.id IN ?", ids).Where("products.published = ?", "1").Preload("ProductDiscounts").Find(&products)
 	return products, results.Error
 }
+
+// Untuk admin, produk yang belum tayang tetap bisa dikelola
+func (repository *ProductRepositoryImplementation) FindProductByIdIncludeUnpublished(DB *gorm.DB, id string) (entity.Product, error) {
+	var product entity.Product
+	results := preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).
+		Where("products.id = ?", id).
+		Preload("ProductDiscounts").
+		Joins("ProductCategory").
+		Joins("ProductBrand").
+		Find(&product)
+	return product, results.Error
+}
+
+func (repository *ProductRepositoryImplementation) FindProductByIdCategory(DB *gorm.DB, idCategory string, pagination modelService.Pagination) ([]entity.Product, int64, error) {
+	return findPaginatedProducts(DB, pagination, "products.id_brand asc", func(DB *gorm.DB) *gorm.DB {
+		return DB.Where("products.id_category = ?", idCategory).Where("products.published = ?", "1")
+	})
+}
+
+func (repository *ProductRepositoryImplementation) FindProductByIdSubCategory(DB *gorm.DB, idSubCategory string, pagination modelService.Pagination) ([]entity.Product, int64, error) {
+	return findPaginatedProducts(DB, pagination, "products.stock = 0, products.product_name asc", func(DB *gorm.DB) *gorm.DB {
+		return DB.Where("products.id_sub_category = ?", idSubCategory).Where("products.published = ?", "1")
+	})
+}
+
+func (repository *ProductRepositoryImplementation) FindProductByIdBrand(DB *gorm.DB, idBrand string, pagination modelService.Pagination) ([]entity.Product, int64, error) {
+	return findPaginatedProducts(DB, pagination, "products.product_name asc", func(DB *gorm.DB) *gorm.DB {
+		return DB.Where("products.id_brand = ?", idBrand).Where("products.published = ?", "1")
+	})
+}
+
+func (repository *ProductRepositoryImplementation) FindProductSoldQty(DB *gorm.DB) (map[string]int, error) {
+	var productSolds []struct {
+		IdProduct string
+		Sold      int
+	}
+	results := DB.Table("orders_items").
+		Select("orders_items.id_product, SUM(orders_items.qty) AS sold").
+		Joins("JOIN orders_transaction ON orders_transaction.id = orders_items.id_order").
+		Where("orders_transaction.order_status <> ?", "Dibatalkan").
+		Group("orders_items.id_product").
+		Scan(&productSolds)
+
+	productSoldQty := make(map[string]int, len(productSolds))
+	for _, productSold := range productSolds {
+		productSoldQty[productSold.IdProduct] = productSold.Sold
+	}
+	return productSoldQty, results.Error
+}
+
+// Menghitung total lalu mengambil satu halaman produk, filter diberikan lewat scope agar sama untuk keduanya
+func findPaginatedProducts(DB *gorm.DB, pagination modelService.Pagination, defaultOrder string, scope func(DB *gorm.DB) *gorm.DB) ([]entity.Product, int64, error) {
+	var products []entity.Product
+	var total int64
+	results := productAttributeFilterScope(scope(DB.Model(&entity.Product{})), pagination.Attributes).Count(&total)
+	if results.Error != nil {
+		return products, total, results.Error
+	}
+
+	if pagination.Sort == "price_asc" || pagination.Sort == "price_desc" {
+		products, err := findProductsSortedByPrice(DB, pagination, scope)
+		return products, total, err
+	}
+
+	order := defaultOrder
+	switch pagination.Sort {
+	case "name_asc":
+		order = "products.product_name asc"
+	case "name_desc":
+		order = "products.product_name desc"
+	case "newest":
+		order = "products.created_at desc"
+	case "bestselling":
+		order = productSoldOrder + " desc"
+	}
+
+	results = productAttributeFilterScope(scope(preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).Preload("ProductDiscounts")), pagination.Attributes).
+		Order(order).
+		Order("products.id asc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&products)
+	return products, total, results.Error
+}
+
+// Harga promo mengikuti prioritas, stackable dan harga tetap yang sama dengan harga yang ditampilkan,
+// jadi semua produk dihitung harganya dulu lalu diurutkan sebelum halaman diambil
+func findProductsSortedByPrice(DB *gorm.DB, pagination modelService.Pagination, scope func(DB *gorm.DB) *gorm.DB) ([]entity.Product, error) {
+	var products []entity.Product
+	var productPrices []entity.Product
+	results := productAttributeFilterScope(scope(DB.Model(&entity.Product{}).Select("products.id", "products.price").Preload("ProductDiscounts")), pagination.Attributes).
+		Find(&productPrices)
+	if results.Error != nil {
+		return products, results.Error
+	}
+
+	now := time.Now()
+	prices := make(map[string]float64, len(productPrices))
+	for _, product := range productPrices {
+		prices[product.Id] = pricing.EvaluateProductPricing(product, entity.ProductVariant{}, now).Price
+	}
+	sort.SliceStable(productPrices, func(i, j int) bool {
+		priceI, priceJ := prices[productPrices[i].Id], prices[productPrices[j].Id]
+		if priceI != priceJ {
+			if pagination.Sort == "price_desc" {
+				return priceI > priceJ
+			}
+			return priceI < priceJ
+		}
+		return productPrices[i].Id < productPrices[j].Id
+	})
+
+	if pagination.Offset >= len(productPrices) {
+		return products, nil
+	}
+	end := len(productPrices)
+	if pagination.Limit > 0 && pagination.Offset+pagination.Limit < end {
+		end = pagination.Offset + pagination.Limit
+	}
+	var ids []string
+	positions := make(map[string]int)
+	for index, product := range productPrices[pagination.Offset:end] {
+		ids = append(ids, product.Id)
+		positions[product.Id] = index
+	}
+
+	results = preloadProductAttributes(preloadProductImages(preloadProductVariants(DB))).Preload("ProductDiscounts").
+		Where("products.id IN ?", ids).
+		Find(&products)
+	sort.Slice(products, func(i, j int) bool {
+		return positions[products[i].Id] < positions[products[j].Id]
+	})
+	return products, results.Error
+}
+
+// Opsi dan varian aktif produk, produk tanpa varian tetap memakai harga dan stok produk
+func preloadProductVariants(DB *gorm.DB) *gorm.DB {
+	return DB.
+		Preload("ProductOptions", func(DB *gorm.DB) *gorm.DB {
+			return DB.Order("products_option.position asc")
+		}).
+		Preload("ProductOptions.ProductOptionValues", func(DB *gorm.DB) *gorm.DB {
+			return DB.Order("products_option_value.position asc")
+		}).
+		Preload("ProductVariants", func(DB *gorm.DB) *gorm.DB {
+			return DB.Where("products_variant.is_active = ?", 1).Order("products_variant.created_at asc")
+		}).
+		Preload("ProductVariants.ProductOptionValues")
+}
+
+// Galeri gambar produk sesuai urutan yang diatur admin
+func preloadProductImages(DB *gorm.DB) *gorm.DB {
+	return DB.Preload("ProductImages", func(DB *gorm.DB) *gorm.DB {
+		return DB.Order("products_image.position asc")
+	})
+}
+
+// Nilai atribut produk beserta definisinya, urutan antar atribut diatur saat dikonversi ke response
+func preloadProductAttributes(DB *gorm.DB) *gorm.DB {
+	return DB.
+		Preload("ProductAttributeValues", func(DB *gorm.DB) *gorm.DB {
+			return DB.Order("products_attribute_value.position asc")
+		}).
+		Preload("ProductAttributeValues.ProductAttribute")
+}
+
+// Nilai dengan kode atribut yang sama digabung OR, antar kode atribut digabung AND
+func productAttributeFilterScope(DB *gorm.DB, productAttributeFilters []modelService.ProductAttributeFilter) *gorm.DB {
+	for _, productAttributeFilter := range productAttributeFilters {
+		DB = DB.Where("EXISTS (SELECT 1 FROM products_attribute_value JOIN products_attribute ON products_attribute.id = products_attribute_value.id_product_attribute WHERE products_attribute_value.id_product = products.id AND products_attribute.is_filterable = 1 AND products_attribute.attribute_code = ? AND products_attribute_value.value IN ?)", productAttributeFilter.AttributeCode, productAttributeFilter.Values)
+	}
+	return DB
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestFindAllProductsSortsByDisplayedPrice(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	DB, err := gorm.Open(gormMysql.New(gormMysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a: diskon 10% prioritas tinggi tidak stackable menutup diskon 50%, tampil 9000
+	// c: harga tetap di atas harga produk tidak menaikkan harga, tampil 12000
+	discountColumns := []string{"id", "id_product", "percentage", "nominal", "flag_promo", "discount_type", "priority", "stackable"}
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `products`").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT products.id,products.price FROM `products`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price"}).AddRow("a", 10000).AddRow("b", 8000).AddRow("c", 12000))
+	mock.ExpectQuery("SELECT \\* FROM `products_discount`").
+		WithArgs("a", "b", "c").
+		WillReturnRows(sqlmock.NewRows(discountColumns).
+			AddRow("d1", "a", 10, 0, "true", "percentage", 10, 0).
+			AddRow("d2", "a", 50, 0, "true", "percentage", 1, 0).
+			AddRow("d3", "c", 0, 20000, "true", "fixed_price", 0, 0))
+	mock.ExpectQuery("SELECT \\* FROM `products_discount`").
+		WithArgs("a", "b").
+		WillReturnRows(sqlmock.NewRows(discountColumns).
+			AddRow("d1", "a", 10, 0, "true", "percentage", 10, 0).
+			AddRow("d2", "a", 50, 0, "true", "percentage", 1, 0))
+	mock.ExpectQuery("SELECT \\* FROM `products` WHERE products.id IN").
+		WithArgs("b", "a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price"}).AddRow("a", 10000).AddRow("b", 8000))
+	for _, table := range []string{"products_attribute_value", "products_image", "products_option", "products_variant"} {
+		mock.ExpectQuery("SELECT \\* FROM `" + table + "`").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+
+	repository := NewProductRepository(nil)
+	products, total, err := repository.FindAllProducts(DB, modelService.Pagination{Limit: 2, Sort: "price_asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 {
+		t.Errorf("total = %d, want 3", total)
+	}
+	if len(products) != 2 || products[0].Id != "b" || products[1].Id != "a" {
+		t.Errorf("products = %+v, want b then a", products)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
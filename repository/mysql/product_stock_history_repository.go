@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
 	"gorm.io/gorm"
@@ -8,6 +10,9 @@ import (
 
 type ProductStockHistoryRepositoryInterface interface {
 	AddProductStockHistory(DB *gorm.DB, productStockHistory entity.ProductStockHistory) (entity.ProductStockHistory, error)
+	FindProductStockHistories(DB *gorm.DB, idProduct string, idProductVariant string, dateFrom time.Time, dateTo time.Time) ([]entity.ProductStockHistory, error)
+	FindLastProductStockHistoryBeforeDate(DB *gorm.DB, idProduct string, idProductVariant string, date time.Time) (entity.ProductStockHistory, error)
+	CountProductStockHistoryByReference(DB *gorm.DB, txType string, supplier string, reference string) (int64, error)
 }
 
 type ProductStockHistoryRepositoryImplementation struct {
@@ -24,3 +29,36 @@ func (repository *ProductStockHistoryRepositoryImplementation) AddProductStockHi
 	results := DB.Create(productStockHistory)
 	return productStockHistory, results.Error
 }
+
+// Kartu stok varian hanya berisi history varian tersebut, produk tanpa varian memakai id_product_variant kosong
+func (repository *ProductStockHistoryRepositoryImplementation) FindProductStockHistories(DB *gorm.DB, idProduct string, idProductVariant string, dateFrom time.Time, dateTo time.Time) ([]entity.ProductStockHistory, error) {
+	var productStockHistories []entity.ProductStockHistory
+	results := DB.Where("id_product = ?", idProduct).
+		Where("id_product_variant = ?", idProductVariant).
+		Where("tx_date >= ?", dateFrom).
+		Where("tx_date <= ?", dateTo).
+		Order("tx_date asc, created_at asc").
+		Find(&productStockHistories)
+	return productStockHistories, results.Error
+}
+
+func (repository *ProductStockHistoryRepositoryImplementation) FindLastProductStockHistoryBeforeDate(DB *gorm.DB, idProduct string, idProductVariant string, date time.Time) (entity.ProductStockHistory, error) {
+	var productStockHistory entity.ProductStockHistory
+	results := DB.Where("id_product = ?", idProduct).
+		Where("id_product_variant = ?", idProductVariant).
+		Where("tx_date < ?", date).
+		Order("tx_date desc, created_at desc").
+		Limit(1).
+		Find(&productStockHistory)
+	return productStockHistory, results.Error
+}
+
+func (repository *ProductStockHistoryRepositoryImplementation) CountProductStockHistoryByReference(DB *gorm.DB, txType string, supplier string, reference string) (int64, error) {
+	var count int64
+	results := DB.Model(&entity.ProductStockHistory{}).
+		Where("tx_type = ?", txType).
+		Where("supplier = ?", supplier).
+		Where("reference = ?", reference).
+		Count(&count)
+	return count, results.Error
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ProductVariantRepositoryInterface interface {
+	FindProductVariantById(DB *gorm.DB, id string) (entity.ProductVariant, error)
+	UpdateProductVariantStock(DB *gorm.DB, idProductVariant string, productVariant entity.ProductVariant) (entity.ProductVariant, error)
+}
+
+type ProductVariantRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewProductVariantRepository(configDatabase *config.Database) ProductVariantRepositoryInterface {
+	return &ProductVariantRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ProductVariantRepositoryImplementation) FindProductVariantById(DB *gorm.DB, id string) (entity.ProductVariant, error) {
+	var productVariant entity.ProductVariant
+	results := DB.Where("products_variant.id = ?", id).Where("products_variant.is_active = ?", 1).Find(&productVariant)
+	return productVariant, results.Error
+}
+
+func (repository *ProductVariantRepositoryImplementation) UpdateProductVariantStock(DB *gorm.DB, idProductVariant string, productVariant entity.ProductVariant) (entity.ProductVariant, error) {
+	updateProductVariant := make(map[string]interface{})
+	updateProductVariant["stock"] = productVariant.Stock
+	result := DB.
+		Model(entity.ProductVariant{}).
+		Where("id = ?", idProductVariant).
+		Updates(&updateProductVariant)
+	return productVariant, result.Error
+}
@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type PromotionRepositoryInterface interface {
+	FindActivePromotions(DB *gorm.DB) ([]entity.Promotion, error)
+}
+
+type PromotionRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewPromotionRepository(configDatabase *config.Database) PromotionRepositoryInterface {
+	return &PromotionRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Periode promo dicek saat evaluasi keranjang
+func (repository *PromotionRepositoryImplementation) FindActivePromotions(DB *gorm.DB) ([]entity.Promotion, error) {
+	var promotions []entity.Promotion
+	results := DB.Preload("PromotionItems").
+		Preload("PromotionTiers").
+		Where("promotion.is_active = ?", 1).
+		Order("promotion.priority desc").
+		Order("promotion.id asc").
+		Find(&promotions)
+	return promotions, results.Error
+}
@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"gorm.io/gorm"
+)
+
+type RecommendationRepositoryInterface interface {
+	FindPurchasedProducts(DB *gorm.DB) ([]modelService.PurchasedProduct, error)
+	FindProductRecommendations(DB *gorm.DB, idProduct string, recommendationType string, limit int) ([]entity.ProductRecommendation, error)
+	FindUserRecommendations(DB *gorm.DB, idUser string, recommendationType string, limit int) ([]entity.UserRecommendation, error)
+	DeleteAllProductRecommendations(DB *gorm.DB) error
+	DeleteAllUserRecommendations(DB *gorm.DB) error
+	CreateProductRecommendations(DB *gorm.DB, productRecommendations []entity.ProductRecommendation) error
+	CreateUserRecommendations(DB *gorm.DB, userRecommendations []entity.UserRecommendation) error
+}
+
+type RecommendationRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewRecommendationRepository(configDatabase *config.Database) RecommendationRepositoryInterface {
+	return &RecommendationRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Baris promo keranjang dan pesanan yang dibatalkan tidak dihitung
+func (repository *RecommendationRepositoryImplementation) FindPurchasedProducts(DB *gorm.DB) ([]modelService.PurchasedProduct, error) {
+	var purchasedProducts []modelService.PurchasedProduct
+	results := DB.Table("orders_items").
+		Select("orders_items.id_order, orders_transaction.id_user, orders_items.id_product, orders_transaction.ordered_at").
+		Joins("JOIN orders_transaction ON orders_transaction.id = orders_items.id_order").
+		Where("orders_transaction.order_status <> ?", "Dibatalkan").
+		Where("orders_items.id_product <> ?", "").
+		Order("orders_transaction.ordered_at asc").
+		Scan(&purchasedProducts)
+	return purchasedProducts, results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) FindProductRecommendations(DB *gorm.DB, idProduct string, recommendationType string, limit int) ([]entity.ProductRecommendation, error) {
+	var productRecommendations []entity.ProductRecommendation
+	results := DB.Where("product_recommendation.id_product = ?", idProduct).
+		Where("product_recommendation.recommendation_type = ?", recommendationType).
+		Order("product_recommendation.score desc").
+		Limit(limit).
+		Find(&productRecommendations)
+	return productRecommendations, results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) FindUserRecommendations(DB *gorm.DB, idUser string, recommendationType string, limit int) ([]entity.UserRecommendation, error) {
+	var userRecommendations []entity.UserRecommendation
+	results := DB.Where("user_recommendation.id_user = ?", idUser).
+		Where("user_recommendation.recommendation_type = ?", recommendationType).
+		Order("user_recommendation.score desc").
+		Order("user_recommendation.last_ordered_at desc").
+		Limit(limit).
+		Find(&userRecommendations)
+	return userRecommendations, results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) DeleteAllProductRecommendations(DB *gorm.DB) error {
+	results := DB.Where("1 = 1").Delete(&entity.ProductRecommendation{})
+	return results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) DeleteAllUserRecommendations(DB *gorm.DB) error {
+	results := DB.Where("1 = 1").Delete(&entity.UserRecommendation{})
+	return results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) CreateProductRecommendations(DB *gorm.DB, productRecommendations []entity.ProductRecommendation) error {
+	results := DB.CreateInBatches(productRecommendations, 500)
+	return results.Error
+}
+
+func (repository *RecommendationRepositoryImplementation) CreateUserRecommendations(DB *gorm.DB, userRecommendations []entity.UserRecommendation) error {
+	results := DB.CreateInBatches(userRecommendations, 500)
+	return results.Error
+}
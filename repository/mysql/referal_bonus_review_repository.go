@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type ReferalBonusReviewRepositoryInterface interface {
+	CreateReferalBonusReview(DB *gorm.DB, referalBonusReview entity.ReferalBonusReview) (entity.ReferalBonusReview, error)
+	FindReferalBonusReviewById(DB *gorm.DB, idReferalBonusReview string) (entity.ReferalBonusReview, error)
+	FindReferalBonusReviewByStatus(DB *gorm.DB, status string, limit int, offset int) ([]entity.ReferalBonusReview, error)
+	CountReferalBonusReviewByStatus(DB *gorm.DB, status string) (int64, error)
+	UpdateReferalBonusReviewStatus(DB *gorm.DB, idReferalBonusReview string, referalBonusReview entity.ReferalBonusReview) (int64, error)
+}
+
+type ReferalBonusReviewRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewReferalBonusReviewRepository(configDatabase *config.Database) ReferalBonusReviewRepositoryInterface {
+	return &ReferalBonusReviewRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *ReferalBonusReviewRepositoryImplementation) CreateReferalBonusReview(DB *gorm.DB, referalBonusReview entity.ReferalBonusReview) (entity.ReferalBonusReview, error) {
+	results := DB.Create(referalBonusReview)
+	return referalBonusReview, results.Error
+}
+
+func (repository *ReferalBonusReviewRepositoryImplementation) FindReferalBonusReviewById(DB *gorm.DB, idReferalBonusReview string) (entity.ReferalBonusReview, error) {
+	var referalBonusReview entity.ReferalBonusReview
+	results := DB.Where("referal_bonus_review.id = ?", idReferalBonusReview).Find(&referalBonusReview)
+	return referalBonusReview, results.Error
+}
+
+func (repository *ReferalBonusReviewRepositoryImplementation) FindReferalBonusReviewByStatus(DB *gorm.DB, status string, limit int, offset int) ([]entity.ReferalBonusReview, error) {
+	var referalBonusReviews []entity.ReferalBonusReview
+	results := DB.Where("referal_bonus_review.status = ?", status).
+		Preload("User.FamilyMembers").
+		Preload("UserReferal.FamilyMembers").
+		Order("referal_bonus_review.fraud_score desc").
+		Order("referal_bonus_review.created_at asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&referalBonusReviews)
+	return referalBonusReviews, results.Error
+}
+
+func (repository *ReferalBonusReviewRepositoryImplementation) CountReferalBonusReviewByStatus(DB *gorm.DB, status string) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.ReferalBonusReview{}).Where("status = ?", status).Count(&total)
+	return total, results.Error
+}
+
+// Hanya review yang masih pending yang bisa diubah, row tidak berubah jika sudah direview
+func (repository *ReferalBonusReviewRepositoryImplementation) UpdateReferalBonusReviewStatus(DB *gorm.DB, idReferalBonusReview string, referalBonusReview entity.ReferalBonusReview) (int64, error) {
+	result := DB.
+		Model(entity.ReferalBonusReview{}).
+		Where("id = ?", idReferalBonusReview).
+		Where("status = ?", "pending").
+		Updates(entity.ReferalBonusReview{
+			Status:     referalBonusReview.Status,
+			ReviewedBy: referalBonusReview.ReviewedBy,
+			ReviewNote: referalBonusReview.ReviewNote,
+			ReviewedAt: referalBonusReview.ReviewedAt,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type RegistrationCampaignRepositoryInterface interface {
+	FindActiveRegistrationCampaigns(DB *gorm.DB, date time.Time) ([]entity.RegistrationCampaign, error)
+	AddRegistrationCampaignRedemption(DB *gorm.DB, idCampaign string) (int64, error)
+}
+
+type RegistrationCampaignRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewRegistrationCampaignRepository(configDatabase *config.Database) RegistrationCampaignRepositoryInterface {
+	return &RegistrationCampaignRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *RegistrationCampaignRepositoryImplementation) FindActiveRegistrationCampaigns(DB *gorm.DB, date time.Time) ([]entity.RegistrationCampaign, error) {
+	var registrationCampaigns []entity.RegistrationCampaign
+	results := DB.Where("registration_campaign.is_active = ?", 1).
+		Where("registration_campaign.start_date <= ?", date).
+		Where("registration_campaign.end_date >= ?", date).
+		Order("registration_campaign.start_date desc").
+		Find(&registrationCampaigns)
+	return registrationCampaigns, results.Error
+}
+
+// Menambah jumlah redemption, row tidak berubah jika kuota campaign sudah habis
+func (repository *RegistrationCampaignRepositoryImplementation) AddRegistrationCampaignRedemption(DB *gorm.DB, idCampaign string) (int64, error) {
+	results := DB.Exec("UPDATE `registration_campaign` SET total_redemption = total_redemption + 1 WHERE id = ? AND (max_redemption = 0 OR total_redemption < max_redemption)", idCampaign)
+	return results.RowsAffected, results.Error
+}
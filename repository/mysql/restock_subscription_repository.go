@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type RestockSubscriptionRepositoryInterface interface {
+	FindRestockSubscriptionsByIdUser(DB *gorm.DB, idUser string) ([]entity.RestockSubscription, error)
+	FindRestockSubscription(DB *gorm.DB, idUser string, idProduct string, idProductVariant string) (entity.RestockSubscription, error)
+	FindRestockedSubscriptions(DB *gorm.DB, limit int) ([]entity.RestockSubscription, error)
+	CreateRestockSubscription(DB *gorm.DB, restockSubscription entity.RestockSubscription) (entity.RestockSubscription, error)
+	DeleteRestockSubscription(DB *gorm.DB, idUser string, idProduct string, idProductVariant string) error
+	DeleteRestockSubscriptionsByIds(DB *gorm.DB, ids []string) error
+}
+
+type RestockSubscriptionRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewRestockSubscriptionRepository(configDatabase *config.Database) RestockSubscriptionRepositoryInterface {
+	return &RestockSubscriptionRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *RestockSubscriptionRepositoryImplementation) FindRestockSubscriptionsByIdUser(DB *gorm.DB, idUser string) ([]entity.RestockSubscription, error) {
+	var restockSubscriptions []entity.RestockSubscription
+	results := DB.Preload("Product").
+		Preload("ProductVariant").
+		Where("restock_subscription.id_user = ?", idUser).
+		Order("restock_subscription.created_at desc").
+		Find(&restockSubscriptions)
+	return restockSubscriptions, results.Error
+}
+
+func (repository *RestockSubscriptionRepositoryImplementation) FindRestockSubscription(DB *gorm.DB, idUser string, idProduct string, idProductVariant string) (entity.RestockSubscription, error) {
+	var restockSubscription entity.RestockSubscription
+	results := DB.Where("restock_subscription.id_user = ?", idUser).
+		Where("restock_subscription.id_product = ?", idProduct).
+		Where("restock_subscription.id_product_variant = ?", idProductVariant).
+		Find(&restockSubscription)
+	return restockSubscription, results.Error
+}
+
+// Langganan yang produk atau variannya sudah punya stok lagi
+func (repository *RestockSubscriptionRepositoryImplementation) FindRestockedSubscriptions(DB *gorm.DB, limit int) ([]entity.RestockSubscription, error) {
+	var restockSubscriptions []entity.RestockSubscription
+	results := DB.Preload("User").
+		Preload("User.FamilyMembers").
+		Preload("Product").
+		Preload("ProductVariant").
+		Joins("JOIN products ON products.id = restock_subscription.id_product").
+		Joins("LEFT JOIN products_variant ON products_variant.id = restock_subscription.id_product_variant").
+		Where("products.published = ?", "1").
+		Where("(restock_subscription.id_product_variant = '' AND products.stock > 0) OR (restock_subscription.id_product_variant <> '' AND products_variant.is_active = 1 AND products_variant.stock > 0)").
+		Order("restock_subscription.id_product asc").
+		Order("restock_subscription.created_at asc").
+		Limit(limit).
+		Find(&restockSubscriptions)
+	return restockSubscriptions, results.Error
+}
+
+func (repository *RestockSubscriptionRepositoryImplementation) CreateRestockSubscription(DB *gorm.DB, restockSubscription entity.RestockSubscription) (entity.RestockSubscription, error) {
+	results := DB.Omit("User", "Product", "ProductVariant").Create(&restockSubscription)
+	return restockSubscription, results.Error
+}
+
+func (repository *RestockSubscriptionRepositoryImplementation) DeleteRestockSubscription(DB *gorm.DB, idUser string, idProduct string, idProductVariant string) error {
+	results := DB.Where("id_user = ?", idUser).
+		Where("id_product = ?", idProduct).
+		Where("id_product_variant = ?", idProductVariant).
+		Delete(&entity.RestockSubscription{})
+	return results.Error
+}
+
+func (repository *RestockSubscriptionRepositoryImplementation) DeleteRestockSubscriptionsByIds(DB *gorm.DB, ids []string) error {
+	results := DB.Where("id IN ?", ids).Delete(&entity.RestockSubscription{})
+	return results.Error
+}
@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"gorm.io/gorm"
+)
+
+const searchTermStatSelect = "products_search_log.term, " +
+	"COUNT(*) AS total_search, " +
+	"COUNT(DISTINCT NULLIF(products_search_log.id_user, '')) AS total_user, " +
+	"AVG(products_search_log.result_count) AS avg_result, " +
+	"SUM(CASE WHEN products_search_log.click_count > 0 THEN 1 ELSE 0 END) AS total_click, " +
+	"SUM(products_search_log.added_to_cart) AS total_cart, " +
+	"MAX(products_search_log.created_at) AS last_searched_at"
+
+type SearchLogRepositoryInterface interface {
+	CreateSearchLog(DB *gorm.DB, searchLog entity.SearchLog) (entity.SearchLog, error)
+	FindSearchLogById(DB *gorm.DB, idSearchLog string) (entity.SearchLog, error)
+	FindLatestSearchLogByIdUser(DB *gorm.DB, idUser string, dateFrom time.Time) (entity.SearchLog, error)
+	IncrementSearchLogClickCount(DB *gorm.DB, idSearchLog string) error
+	UpdateSearchLogAddedToCart(DB *gorm.DB, idSearchLog string) error
+	CreateSearchLogClick(DB *gorm.DB, searchLogClick entity.SearchLogClick) (entity.SearchLogClick, error)
+	FindLatestSearchLogClick(DB *gorm.DB, idUser string, idProduct string, dateFrom time.Time) (entity.SearchLogClick, error)
+	UpdateSearchLogClickAddedToCart(DB *gorm.DB, idSearchLogClick string, addedToCartAt time.Time) error
+	FindSearchTermStats(DB *gorm.DB, dateFrom time.Time, dateTo time.Time, zeroResult bool, limit int) ([]modelService.SearchTermStat, error)
+	FindSearchSummary(DB *gorm.DB, dateFrom time.Time, dateTo time.Time) (modelService.SearchSummary, error)
+}
+
+type SearchLogRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewSearchLogRepository(configDatabase *config.Database) SearchLogRepositoryInterface {
+	return &SearchLogRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *SearchLogRepositoryImplementation) CreateSearchLog(DB *gorm.DB, searchLog entity.SearchLog) (entity.SearchLog, error) {
+	results := DB.Create(&searchLog)
+	return searchLog, results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) FindSearchLogById(DB *gorm.DB, idSearchLog string) (entity.SearchLog, error) {
+	var searchLog entity.SearchLog
+	results := DB.Where("products_search_log.id = ?", idSearchLog).Find(&searchLog)
+	return searchLog, results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) FindLatestSearchLogByIdUser(DB *gorm.DB, idUser string, dateFrom time.Time) (entity.SearchLog, error) {
+	var searchLog entity.SearchLog
+	results := DB.Where("products_search_log.id_user = ?", idUser).
+		Where("products_search_log.created_at >= ?", dateFrom).
+		Order("products_search_log.created_at desc").
+		Limit(1).
+		Find(&searchLog)
+	return searchLog, results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) IncrementSearchLogClickCount(DB *gorm.DB, idSearchLog string) error {
+	results := DB.Model(&entity.SearchLog{}).
+		Where("products_search_log.id = ?", idSearchLog).
+		Update("click_count", gorm.Expr("click_count + ?", 1))
+	return results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) UpdateSearchLogAddedToCart(DB *gorm.DB, idSearchLog string) error {
+	results := DB.Model(&entity.SearchLog{}).
+		Where("products_search_log.id = ?", idSearchLog).
+		Update("added_to_cart", 1)
+	return results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) CreateSearchLogClick(DB *gorm.DB, searchLogClick entity.SearchLogClick) (entity.SearchLogClick, error) {
+	results := DB.Create(&searchLogClick)
+	return searchLogClick, results.Error
+}
+
+// Klik terakhir user untuk produk tersebut yang belum tercatat masuk keranjang
+func (repository *SearchLogRepositoryImplementation) FindLatestSearchLogClick(DB *gorm.DB, idUser string, idProduct string, dateFrom time.Time) (entity.SearchLogClick, error) {
+	var searchLogClick entity.SearchLogClick
+	results := DB.Where("products_search_click.id_user = ?", idUser).
+		Where("products_search_click.id_product = ?", idProduct).
+		Where("products_search_click.clicked_at >= ?", dateFrom).
+		Where("products_search_click.added_to_cart_at IS NULL").
+		Order("products_search_click.clicked_at desc").
+		Limit(1).
+		Find(&searchLogClick)
+	return searchLogClick, results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) UpdateSearchLogClickAddedToCart(DB *gorm.DB, idSearchLogClick string, addedToCartAt time.Time) error {
+	results := DB.Model(&entity.SearchLogClick{}).
+		Where("products_search_click.id = ?", idSearchLogClick).
+		Update("added_to_cart_at", addedToCartAt)
+	return results.Error
+}
+
+// Diurutkan dari term yang paling sering dicari, zeroResult hanya menghitung pencarian tanpa hasil
+func (repository *SearchLogRepositoryImplementation) FindSearchTermStats(DB *gorm.DB, dateFrom time.Time, dateTo time.Time, zeroResult bool, limit int) ([]modelService.SearchTermStat, error) {
+	var searchTermStats []modelService.SearchTermStat
+	query := DB.Model(&entity.SearchLog{}).
+		Select(searchTermStatSelect).
+		Where("products_search_log.created_at BETWEEN ? AND ?", dateFrom, dateTo)
+	if zeroResult {
+		query = query.Where("products_search_log.result_count = ?", 0)
+	}
+	results := query.Group("products_search_log.term").
+		Order("total_search desc").
+		Order("products_search_log.term asc").
+		Limit(limit).
+		Scan(&searchTermStats)
+	return searchTermStats, results.Error
+}
+
+func (repository *SearchLogRepositoryImplementation) FindSearchSummary(DB *gorm.DB, dateFrom time.Time, dateTo time.Time) (modelService.SearchSummary, error) {
+	var searchSummary modelService.SearchSummary
+	results := DB.Model(&entity.SearchLog{}).
+		Select("COUNT(*) AS total_search, "+
+			"COALESCE(SUM(CASE WHEN products_search_log.click_count > 0 THEN 1 ELSE 0 END), 0) AS total_click, "+
+			"COALESCE(SUM(products_search_log.added_to_cart), 0) AS total_cart").
+		Where("products_search_log.created_at BETWEEN ? AND ?", dateFrom, dateTo).
+		Scan(&searchSummary)
+	return searchSummary, results.Error
+}
@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type StockOpnameRepositoryInterface interface {
+	FindStockOpnames(DB *gorm.DB, limit int, offset int) ([]entity.StockOpname, int64, error)
+	FindStockOpnameById(DB *gorm.DB, id string) (entity.StockOpname, error)
+	FindOpenStockOpname(DB *gorm.DB) (entity.StockOpname, error)
+	CreateStockOpname(DB *gorm.DB, stockOpname entity.StockOpname) (entity.StockOpname, error)
+	CreateStockOpnameItems(DB *gorm.DB, stockOpnameItems []entity.StockOpnameItem) error
+	UpdateStockOpnameItemCount(DB *gorm.DB, id string, stockOpnameItem entity.StockOpnameItem) (entity.StockOpnameItem, error)
+	UpdateStockOpnameStatus(DB *gorm.DB, id string, stockOpname entity.StockOpname) (entity.StockOpname, error)
+}
+
+type StockOpnameRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewStockOpnameRepository(configDatabase *config.Database) StockOpnameRepositoryInterface {
+	return &StockOpnameRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *StockOpnameRepositoryImplementation) FindStockOpnames(DB *gorm.DB, limit int, offset int) ([]entity.StockOpname, int64, error) {
+	var stockOpnames []entity.StockOpname
+	var total int64
+	results := DB.Model(&entity.StockOpname{}).Count(&total)
+	if results.Error != nil {
+		return stockOpnames, total, results.Error
+	}
+	results = DB.Order("stock_opname.created_at desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&stockOpnames)
+	return stockOpnames, total, results.Error
+}
+
+func (repository *StockOpnameRepositoryImplementation) FindStockOpnameById(DB *gorm.DB, id string) (entity.StockOpname, error) {
+	var stockOpname entity.StockOpname
+	results := DB.Preload("StockOpnameItems", func(DB *gorm.DB) *gorm.DB {
+		return DB.Select("stock_opname_item.*").
+			Joins("JOIN products ON products.id = stock_opname_item.id_product").
+			Order("products.product_name asc").
+			Order("stock_opname_item.id_product_variant asc")
+	}).
+		Preload("StockOpnameItems.Product").
+		Preload("StockOpnameItems.ProductVariant").
+		Where("stock_opname.id = ?", id).
+		Find(&stockOpname)
+	return stockOpname, results.Error
+}
+
+// Hanya satu sesi opname terbuka dalam satu waktu agar selisih tidak diposting dua kali
+func (repository *StockOpnameRepositoryImplementation) FindOpenStockOpname(DB *gorm.DB) (entity.StockOpname, error) {
+	var stockOpname entity.StockOpname
+	results := DB.Where("stock_opname.status = ?", "open").Limit(1).Find(&stockOpname)
+	return stockOpname, results.Error
+}
+
+func (repository *StockOpnameRepositoryImplementation) CreateStockOpname(DB *gorm.DB, stockOpname entity.StockOpname) (entity.StockOpname, error) {
+	results := DB.Omit("StockOpnameItems").Create(&stockOpname)
+	return stockOpname, results.Error
+}
+
+func (repository *StockOpnameRepositoryImplementation) CreateStockOpnameItems(DB *gorm.DB, stockOpnameItems []entity.StockOpnameItem) error {
+	results := DB.Omit("Product", "ProductVariant").CreateInBatches(&stockOpnameItems, 500)
+	return results.Error
+}
+
+func (repository *StockOpnameRepositoryImplementation) UpdateStockOpnameItemCount(DB *gorm.DB, id string, stockOpnameItem entity.StockOpnameItem) (entity.StockOpnameItem, error) {
+	updateStockOpnameItem := make(map[string]interface{})
+	updateStockOpnameItem["counted_qty"] = stockOpnameItem.CountedQty
+	updateStockOpnameItem["difference_qty"] = stockOpnameItem.DifferenceQty
+	updateStockOpnameItem["counted_at"] = stockOpnameItem.CountedAt
+	result := DB.
+		Model(entity.StockOpnameItem{}).
+		Where("id = ?", id).
+		Updates(&updateStockOpnameItem)
+	return stockOpnameItem, result.Error
+}
+
+func (repository *StockOpnameRepositoryImplementation) UpdateStockOpnameStatus(DB *gorm.DB, id string, stockOpname entity.StockOpname) (entity.StockOpname, error) {
+	updateStockOpname := make(map[string]interface{})
+	updateStockOpname["status"] = stockOpname.Status
+	updateStockOpname["posted_at"] = stockOpname.PostedAt
+	result := DB.
+		Model(entity.StockOpname{}).
+		Where("id = ?", id).
+		Updates(&updateStockOpname)
+	return stockOpname, result.Error
+}
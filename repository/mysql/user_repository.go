@@ -1,9 +1,12 @@
 package mysql
 
 import (
+	"time"
+
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepositoryInterface interface {
@@ -20,10 +23,16 @@ type UserRepositoryInterface interface {
 	FindUserByPhone(DB *gorm.DB, phone string) (entity.User, error)
 	FindUserByReferal(DB *gorm.DB, referalCode string) (entity.User, error)
 	FindUserById(DB *gorm.DB, id string) (entity.User, error)
+	FindUserByIdForUpdate(DB *gorm.DB, id string) (entity.User, error)
 	CountUserByRegistrationReferal(DB *gorm.DB, referal string) (userCount int, err error)
 	SaveUserRefreshToken(DB *gorm.DB, id string, refreshToken string) (int64, error)
 	FindUserByUsernameAndRefreshToken(DB *gorm.DB, username string, refresh_token string) (entity.User, error)
 	FindUserByReferalCode(DB *gorm.DB, referalCode string) (entity.User, error)
+	FindUsersByIdFamily(DB *gorm.DB, idFamily string) ([]entity.User, error)
+	CountUserByTokenDevice(DB *gorm.DB, tokenDevice string, idUserExclude string) (int64, error)
+	CountUserByRegistrationIp(DB *gorm.DB, registrationIp string, dateFrom time.Time) (int64, error)
+	CountUserByRegistrationReferalSince(DB *gorm.DB, referalCode string, dateFrom time.Time) (int64, error)
+	CountUserByRegistrationReferalAndPhonePrefix(DB *gorm.DB, referalCode string, phonePrefix string) (int64, error)
 }
 
 type UserRepositoryImplementation struct {
@@ -154,6 +163,13 @@ func (repository *UserRepositoryImplementation) FindUserById(DB *gorm.DB, id str
 	return user, results.Error
 }
 
+// Mengunci baris user sampai transaksi selesai agar order user yang sama diproses bergantian
+func (repository *UserRepositoryImplementation) FindUserByIdForUpdate(DB *gorm.DB, id string) (entity.User, error) {
+	var user entity.User
+	results := DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("users.id = ?", id).First(&user)
+	return user, results.Error
+}
+
 func (repository *UserRepositoryImplementation) FindUserByReferalCode(DB *gorm.DB, referalCode string) (entity.User, error) {
 	var user entity.User
 	results := DB.Where("users.referal_code = ?", referalCode).
@@ -164,6 +180,54 @@ func (repository *UserRepositoryImplementation) FindUserByReferalCode(DB *gorm.D
 	return user, results.Error
 }
 
+func (repository *UserRepositoryImplementation) FindUsersByIdFamily(DB *gorm.DB, idFamily string) ([]entity.User, error) {
+	var users []entity.User
+	results := DB.Where("FamilyMembers.id_family = ?", idFamily).
+		Where("users.is_delete = ?", 0).
+		Joins("FamilyMembers").
+		Joins("BalancePoint").
+		Order("users.created_at asc").
+		Find(&users)
+	return users, results.Error
+}
+
+func (repository *UserRepositoryImplementation) CountUserByTokenDevice(DB *gorm.DB, tokenDevice string, idUserExclude string) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.User{}).
+		Where("token_device = ?", tokenDevice).
+		Where("id <> ?", idUserExclude).
+		Count(&total)
+	return total, results.Error
+}
+
+func (repository *UserRepositoryImplementation) CountUserByRegistrationIp(DB *gorm.DB, registrationIp string, dateFrom time.Time) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.User{}).
+		Where("registration_ip = ?", registrationIp).
+		Where("created_at >= ?", dateFrom).
+		Count(&total)
+	return total, results.Error
+}
+
+func (repository *UserRepositoryImplementation) CountUserByRegistrationReferalSince(DB *gorm.DB, referalCode string, dateFrom time.Time) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.User{}).
+		Where("registration_referal_code = ?", referalCode).
+		Where("created_at >= ?", dateFrom).
+		Count(&total)
+	return total, results.Error
+}
+
+func (repository *UserRepositoryImplementation) CountUserByRegistrationReferalAndPhonePrefix(DB *gorm.DB, referalCode string, phonePrefix string) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.User{}).
+		Joins("FamilyMembers").
+		Where("users.registration_referal_code = ?", referalCode).
+		Where("FamilyMembers.phone LIKE ?", phonePrefix+"%").
+		Count(&total)
+	return total, results.Error
+}
+
 func (repository *UserRepositoryImplementation) CountUserByRegistrationReferal(DB *gorm.DB, referalCode string) (countUser int, err error) {
 	var user []entity.User
 	results := DB.Model(&entity.User{}).Where("registration_referal_code = ?", referalCode).Find(&user)
@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type VoucherRepositoryInterface interface {
+	FindVoucherByCode(DB *gorm.DB, voucherCode string) (entity.Voucher, error)
+	IncreaseVoucherUsageCount(DB *gorm.DB, idVoucher string) (int64, error)
+	DecreaseVoucherUsageCount(DB *gorm.DB, idVoucher string) error
+}
+
+type VoucherRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewVoucherRepository(configDatabase *config.Database) VoucherRepositoryInterface {
+	return &VoucherRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *VoucherRepositoryImplementation) FindVoucherByCode(DB *gorm.DB, voucherCode string) (entity.Voucher, error) {
+	var voucher entity.Voucher
+	results := DB.Preload("VoucherRules").
+		Where("voucher.voucher_code = ?", voucherCode).
+		Where("voucher.is_active = ?", 1).
+		Find(&voucher)
+	return voucher, results.Error
+}
+
+// Kuota global dicek dan ditambah dalam satu query, baris voucher terkunci sampai transaksi selesai
+func (repository *VoucherRepositoryImplementation) IncreaseVoucherUsageCount(DB *gorm.DB, idVoucher string) (int64, error) {
+	result := DB.
+		Model(entity.Voucher{}).
+		Where("id = ?", idVoucher).
+		Where("usage_limit = 0 OR usage_count < usage_limit").
+		Update("usage_count", gorm.Expr("usage_count + ?", 1))
+	return result.RowsAffected, result.Error
+}
+
+func (repository *VoucherRepositoryImplementation) DecreaseVoucherUsageCount(DB *gorm.DB, idVoucher string) error {
+	result := DB.
+		Model(entity.Voucher{}).
+		Where("id = ?", idVoucher).
+		Where("usage_count > ?", 0).
+		Update("usage_count", gorm.Expr("usage_count - ?", 1))
+	return result.Error
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type VoucherUsageRepositoryInterface interface {
+	CountVoucherUsageByIdUser(DB *gorm.DB, idVoucher string, idUser string) (int64, error)
+	FindVoucherUsageByIdOrder(DB *gorm.DB, idOrder string) (entity.VoucherUsage, error)
+	CreateVoucherUsage(DB *gorm.DB, voucherUsage entity.VoucherUsage) (entity.VoucherUsage, error)
+	UpdateVoucherUsageStatus(DB *gorm.DB, idVoucherUsage string, voucherUsage entity.VoucherUsage) (entity.VoucherUsage, error)
+}
+
+type VoucherUsageRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewVoucherUsageRepository(configDatabase *config.Database) VoucherUsageRepositoryInterface {
+	return &VoucherUsageRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+// Pemakaian yang sudah dilepas karena order batal tidak dihitung
+func (repository *VoucherUsageRepositoryImplementation) CountVoucherUsageByIdUser(DB *gorm.DB, idVoucher string, idUser string) (int64, error) {
+	var total int64
+	results := DB.Model(&entity.VoucherUsage{}).
+		Where("voucher_usage.id_voucher = ?", idVoucher).
+		Where("voucher_usage.id_user = ?", idUser).
+		Where("voucher_usage.status = ?", "used").
+		Count(&total)
+	return total, results.Error
+}
+
+func (repository *VoucherUsageRepositoryImplementation) FindVoucherUsageByIdOrder(DB *gorm.DB, idOrder string) (entity.VoucherUsage, error) {
+	var voucherUsage entity.VoucherUsage
+	results := DB.Where("voucher_usage.id_order = ?", idOrder).
+		Where("voucher_usage.status = ?", "used").
+		Find(&voucherUsage)
+	return voucherUsage, results.Error
+}
+
+func (repository *VoucherUsageRepositoryImplementation) CreateVoucherUsage(DB *gorm.DB, voucherUsage entity.VoucherUsage) (entity.VoucherUsage, error) {
+	results := DB.Create(voucherUsage)
+	return voucherUsage, results.Error
+}
+
+func (repository *VoucherUsageRepositoryImplementation) UpdateVoucherUsageStatus(DB *gorm.DB, idVoucherUsage string, voucherUsage entity.VoucherUsage) (entity.VoucherUsage, error) {
+	result := DB.
+		Model(entity.VoucherUsage{}).
+		Where("id = ?", idVoucherUsage).
+		Updates(entity.VoucherUsage{
+			Status:     voucherUsage.Status,
+			ReleasedAt: voucherUsage.ReleasedAt,
+		})
+	return voucherUsage, result.Error
+}
@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"gorm.io/gorm"
+)
+
+type WishlistRepositoryInterface interface {
+	FindWishlistByIdUser(DB *gorm.DB, idUser string) ([]entity.Wishlist, error)
+	FindWishlistByIdUserAndIdProduct(DB *gorm.DB, idUser string, idProduct string) (entity.Wishlist, error)
+	FindWishlistedProductIds(DB *gorm.DB, idUser string, idProducts []string) ([]string, error)
+	FindAllWishlistWithUser(DB *gorm.DB) ([]entity.Wishlist, error)
+	CreateWishlist(DB *gorm.DB, wishlist entity.Wishlist) (entity.Wishlist, error)
+	UpdateWishlistNotification(DB *gorm.DB, idWishlist string, wishlist entity.Wishlist) (entity.Wishlist, error)
+	DeleteWishlist(DB *gorm.DB, idUser string, idProduct string) error
+}
+
+type WishlistRepositoryImplementation struct {
+	configurationDatabase *config.Database
+}
+
+func NewWishlistRepository(configDatabase *config.Database) WishlistRepositoryInterface {
+	return &WishlistRepositoryImplementation{
+		configurationDatabase: configDatabase,
+	}
+}
+
+func (repository *WishlistRepositoryImplementation) FindWishlistByIdUser(DB *gorm.DB, idUser string) ([]entity.Wishlist, error) {
+	var wishlists []entity.Wishlist
+	results := DB.Preload("Product").
+		Preload("Product.ProductDiscounts").
+		Where("wishlist.id_user = ?", idUser).
+		Order("wishlist.created_at desc").
+		Find(&wishlists)
+	return wishlists, results.Error
+}
+
+func (repository *WishlistRepositoryImplementation) FindWishlistByIdUserAndIdProduct(DB *gorm.DB, idUser string, idProduct string) (entity.Wishlist, error) {
+	var wishlist entity.Wishlist
+	results := DB.Where("wishlist.id_user = ?", idUser).
+		Where("wishlist.id_product = ?", idProduct).
+		Find(&wishlist)
+	return wishlist, results.Error
+}
+
+func (repository *WishlistRepositoryImplementation) FindWishlistedProductIds(DB *gorm.DB, idUser string, idProducts []string) ([]string, error) {
+	var wishlistedProductIds []string
+	results := DB.Model(&entity.Wishlist{}).
+		Where("wishlist.id_user = ?", idUser).
+		Where("wishlist.id_product IN ?", idProducts).
+		Pluck("wishlist.id_product", &wishlistedProductIds)
+	return wishlistedProductIds, results.Error
+}
+
+func (repository *WishlistRepositoryImplementation) FindAllWishlistWithUser(DB *gorm.DB) ([]entity.Wishlist, error) {
+	var wishlists []entity.Wishlist
+	results := DB.Preload("User").
+		Preload("Product").
+		Preload("Product.ProductDiscounts").
+		Find(&wishlists)
+	return wishlists, results.Error
+}
+
+func (repository *WishlistRepositoryImplementation) CreateWishlist(DB *gorm.DB, wishlist entity.Wishlist) (entity.Wishlist, error) {
+	results := DB.Create(wishlist)
+	return wishlist, results.Error
+}
+
+func (repository *WishlistRepositoryImplementation) UpdateWishlistNotification(DB *gorm.DB, idWishlist string, wishlist entity.Wishlist) (entity.Wishlist, error) {
+	updateWishlist := make(map[string]interface{})
+	updateWishlist["notified_price"] = wishlist.NotifiedPrice
+	updateWishlist["notified_promo"] = wishlist.NotifiedPromo
+	result := DB.
+		Model(entity.Wishlist{}).
+		Where("id = ?", idWishlist).
+		Updates(&updateWishlist)
+	return wishlist, result.Error
+}
+
+func (repository *WishlistRepositoryImplementation) DeleteWishlist(DB *gorm.DB, idUser string, idProduct string) error {
+	results := DB.Where("id_user = ?", idUser).Where("id_product = ?", idProduct).Delete(&entity.Wishlist{})
+	return results.Error
+}
@@ -69,12 +69,36 @@ func BalancePointRoute(e *echo.Echo, configWebserver config.Webserver, configura
 	group.GET("/balance_point", balancePointControllerInterface.FindBalancePointByIdUser, authMiddlerware.Authentication(configurationJWT))
 	group.GET("/balance_point/check/amount", balancePointControllerInterface.BalancePointCheckAmount, authMiddlerware.Authentication(configurationJWT))
 	group.GET("/balance_point/check/order_tx", balancePointControllerInterface.BalancePointCheckOrderTx, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/balance_point/check/redemption", balancePointControllerInterface.BalancePointCheckRedemption, authMiddlerware.Authentication(configurationJWT))
 }
 
 // Balance Point Tx
 func BalancePointTxRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, balancePointTxControllerInterface controllers.BalancePointTxControllerInterface) {
 	group := e.Group("api/v1")
 	group.GET("/balance_point_tx", balancePointTxControllerInterface.FindBalancePointTxByIdBalancePoint, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/balance_point_tx/statement", balancePointTxControllerInterface.FindBalancePointStatement, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/balance_point_tx/statement/csv", balancePointTxControllerInterface.ExportBalancePointStatementCsv, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/balance_point_tx/statement/pdf", balancePointTxControllerInterface.ExportBalancePointStatementPdf, authMiddlerware.Authentication(configurationJWT))
+}
+
+// Family Route
+func FamilyRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, familyControllerInterface controllers.FamilyControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/family", familyControllerInterface.FindFamily, authMiddlerware.Authentication(configurationJWT))
+	group.POST("/family/invite", familyControllerInterface.InviteFamilyMember, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/family/invitation", familyControllerInterface.FindFamilyInvitation, authMiddlerware.Authentication(configurationJWT))
+	group.PUT("/family/invitation/accept", familyControllerInterface.AcceptFamilyInvitation, authMiddlerware.Authentication(configurationJWT))
+	group.PUT("/family/invitation/reject", familyControllerInterface.RejectFamilyInvitation, authMiddlerware.Authentication(configurationJWT))
+	group.PUT("/family/shared_wallet", familyControllerInterface.UpdateFamilySharedWallet, authMiddlerware.Authentication(configurationJWT))
+	group.POST("/family/point/transfer", familyControllerInterface.TransferFamilyPoint, authMiddlerware.Authentication(configurationJWT))
+}
+
+// Admin Referal Bonus Review Route
+func ReferalBonusReviewRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, referalBonusReviewControllerInterface controllers.ReferalBonusReviewControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/referal_review", referalBonusReviewControllerInterface.FindReferalBonusReview, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/referal_review/approve", referalBonusReviewControllerInterface.ApproveReferalBonusReview, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/referal_review/reject", referalBonusReviewControllerInterface.RejectReferalBonusReview, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
 }
 
 // Product Route
@@ -94,6 +118,113 @@ func ProductRoute(e *echo.Echo, configWebserver config.Webserver, configurationJ
 	group.GET("/products/notoken/brand", productControllerInterface.FindProductByIdBrand)
 }
 
+// Product Category Route
+func ProductCategoryRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, productCategoryControllerInterface controllers.ProductCategoryControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/categories", productCategoryControllerInterface.FindCategoryTree)
+	group.GET("/admin/categories", productCategoryControllerInterface.FindAllCategories, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/category", productCategoryControllerInterface.CreateCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/category", productCategoryControllerInterface.UpdateCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.DELETE("/admin/category", productCategoryControllerInterface.DeleteCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/sub_category", productCategoryControllerInterface.CreateSubCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/sub_category", productCategoryControllerInterface.UpdateSubCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.DELETE("/admin/sub_category", productCategoryControllerInterface.DeleteSubCategory, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Product Attribute Route
+func ProductAttributeRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, productAttributeControllerInterface controllers.ProductAttributeControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/product/attributes", productAttributeControllerInterface.FindFilterableProductAttributes)
+	group.GET("/admin/product/attributes", productAttributeControllerInterface.FindAllProductAttributes, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/product/attribute", productAttributeControllerInterface.CreateProductAttribute, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/attribute", productAttributeControllerInterface.UpdateProductAttribute, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.DELETE("/admin/product/attribute", productAttributeControllerInterface.DeleteProductAttribute, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/product/attribute/values", productAttributeControllerInterface.FindProductAttributeValues, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/attribute/values", productAttributeControllerInterface.UpdateProductAttributeValues, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Product Image Route
+func ProductImageRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, configMedia config.Media, productImageControllerInterface controllers.ProductImageControllerInterface) {
+	e.Static("/media", configMedia.StoragePath)
+	group := e.Group("api/v1")
+	group.POST("/admin/product/image", productImageControllerInterface.UploadProductImage, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/image/order", productImageControllerInterface.ReorderProductImages, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.DELETE("/admin/product/image", productImageControllerInterface.DeleteProductImage, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Admin Product Price History Route
+func ProductPriceHistoryRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, productPriceHistoryControllerInterface controllers.ProductPriceHistoryControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/product/price_history", productPriceHistoryControllerInterface.FindProductPriceHistories, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Search Analytics Route
+func SearchAnalyticsRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, searchAnalyticsControllerInterface controllers.SearchAnalyticsControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/search/top", searchAnalyticsControllerInterface.FindTopSearches, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/search/zero_result", searchAnalyticsControllerInterface.FindZeroResultSearches, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/search/conversion", searchAnalyticsControllerInterface.FindSearchConversion, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Admin Product Route
+func AdminProductRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, adminProductControllerInterface controllers.AdminProductControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/products", adminProductControllerInterface.FindAdminProducts, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/products/export", adminProductControllerInterface.ExportProducts, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/products/import", adminProductControllerInterface.ImportProducts, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/product", adminProductControllerInterface.FindAdminProductById, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/product", adminProductControllerInterface.CreateProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product", adminProductControllerInterface.UpdateProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/publish", adminProductControllerInterface.PublishProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/unpublish", adminProductControllerInterface.UnpublishProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/archive", adminProductControllerInterface.ArchiveProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/product/restore", adminProductControllerInterface.RestoreProduct, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Restock Subscription Route
+func RestockSubscriptionRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, restockSubscriptionControllerInterface controllers.RestockSubscriptionControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/product/restock_subscription", restockSubscriptionControllerInterface.FindRestockSubscriptionsByIdUser, authMiddlerware.Authentication(configurationJWT))
+	group.POST("/product/restock_subscription", restockSubscriptionControllerInterface.SubscribeRestock, authMiddlerware.Authentication(configurationJWT))
+	group.DELETE("/product/restock_subscription", restockSubscriptionControllerInterface.UnsubscribeRestock, authMiddlerware.Authentication(configurationJWT))
+}
+
+// Admin Product Stock Route
+func ProductStockRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, productStockControllerInterface controllers.ProductStockControllerInterface) {
+	group := e.Group("api/v1")
+	group.POST("/admin/product/stock/in", productStockControllerInterface.StockIn, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/product/stock/receiving", productStockControllerInterface.ReceiveGoods, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/product/stock/adjustment", productStockControllerInterface.AdjustStock, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/product/stock/card", productStockControllerInterface.FindStockCard, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Admin Product Batch Route
+func ProductBatchRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, productBatchControllerInterface controllers.ProductBatchControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/product/batches", productBatchControllerInterface.FindProductBatches, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/product/batches/near_expiry", productBatchControllerInterface.FindNearExpiryProductBatches, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/product/batch/recall", productBatchControllerInterface.FindProductBatchRecall, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Admin Stock Opname Route
+func StockOpnameRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, stockOpnameControllerInterface controllers.StockOpnameControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/admin/stock_opname", stockOpnameControllerInterface.FindStockOpnames, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/stock_opname/detail", stockOpnameControllerInterface.FindStockOpnameById, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/stock_opname", stockOpnameControllerInterface.CreateStockOpname, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/stock_opname/count", stockOpnameControllerInterface.CountStockOpname, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/stock_opname/post", stockOpnameControllerInterface.PostStockOpname, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/stock_opname/cancel", stockOpnameControllerInterface.CancelStockOpname, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
+// Recommendation Route
+func RecommendationRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, recommendationControllerInterface controllers.RecommendationControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/products/recommendations", recommendationControllerInterface.FindProductRecommendations, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/products/notoken/recommendations", recommendationControllerInterface.FindProductRecommendations)
+	group.GET("/products/home_feed", recommendationControllerInterface.FindHomeFeed, authMiddlerware.Authentication(configurationJWT))
+}
+
 // Cart Route
 func CartRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, cartControllerInterface controllers.CartControllerInterface) {
 	group := e.Group("api/v1")
@@ -104,6 +235,31 @@ func CartRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT
 	group.PUT("/cart/update_qty", cartControllerInterface.UpdateQtyProductInCart, authMiddlerware.Authentication(configurationJWT))
 }
 
+// Wishlist Route
+func WishlistRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, wishlistControllerInterface controllers.WishlistControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/wishlist", wishlistControllerInterface.FindWishlistByIdUser, authMiddlerware.Authentication(configurationJWT))
+	group.POST("/wishlist", wishlistControllerInterface.AddProductToWishlist, authMiddlerware.Authentication(configurationJWT))
+	group.DELETE("/wishlist", wishlistControllerInterface.DeleteProductInWishlist, authMiddlerware.Authentication(configurationJWT))
+	group.POST("/wishlist/move_to_cart", wishlistControllerInterface.MoveWishlistToCart, authMiddlerware.Authentication(configurationJWT))
+}
+
+// Voucher Route
+func VoucherRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, voucherControllerInterface controllers.VoucherControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/voucher/validate", voucherControllerInterface.ValidateVoucher, authMiddlerware.Authentication(configurationJWT))
+}
+
+// Flash Sale Route
+func FlashSaleRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, flashSaleControllerInterface controllers.FlashSaleControllerInterface) {
+	group := e.Group("api/v1")
+	group.GET("/flash_sale", flashSaleControllerInterface.FindFlashSales, authMiddlerware.Authentication(configurationJWT))
+	group.GET("/admin/flash_sale", flashSaleControllerInterface.FindAdminFlashSales, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.GET("/admin/flash_sale/detail", flashSaleControllerInterface.FindFlashSaleById, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.POST("/admin/flash_sale", flashSaleControllerInterface.CreateFlashSale, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+	group.PUT("/admin/flash_sale/deactivate", flashSaleControllerInterface.DeactivateFlashSale, authMiddlerware.Authentication(configurationJWT), authMiddlerware.AdminAuthorization)
+}
+
 // Shipping Cost Route
 func ShippingRoute(e *echo.Echo, configWebserver config.Webserver, configurationJWT config.Jwt, shippingControllerInterface controllers.ShippingControllerInterface) {
 	group := e.Group("api/v1")
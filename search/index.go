@@ -0,0 +1,170 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Bobot kecocokan token query terhadap term di index
+const (
+	matchExact    = 1.0
+	matchPrefix   = 0.8
+	matchTypoOne  = 0.6
+	matchTypoTwo  = 0.4
+	minPrefixSize = 2
+	minTypoSize   = 4
+	maxTypoOneLen = 7
+)
+
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+type Document struct {
+	Id     string
+	Fields []Field
+}
+
+type Hit struct {
+	Id       string
+	Score    float64
+	Coverage int
+}
+
+// Inverted index in-memory, tidak boleh diubah setelah dibuat sehingga aman dibaca bersamaan
+type Index struct {
+	documentIds []string
+	postings    map[string]map[int]float64
+	terms       []string
+}
+
+func NewIndex(documents []Document) *Index {
+	index := &Index{
+		documentIds: make([]string, len(documents)),
+		postings:    make(map[string]map[int]float64),
+	}
+
+	for position, document := range documents {
+		index.documentIds[position] = document.Id
+		for _, field := range document.Fields {
+			// Setiap field hanya dihitung sekali per term agar deskripsi panjang tidak mendominasi
+			seen := make(map[string]bool)
+			for _, token := range Tokenize(field.Text) {
+				if seen[token] {
+					continue
+				}
+				seen[token] = true
+				if index.postings[token] == nil {
+					index.postings[token] = make(map[int]float64)
+				}
+				index.postings[token][position] = index.postings[token][position] + field.Weight
+			}
+		}
+	}
+
+	// Term yang jarang muncul lebih menentukan relevansi
+	for term, posting := range index.postings {
+		idf := math.Log(1 + float64(len(documents))/float64(len(posting)))
+		for position := range posting {
+			posting[position] = posting[position] * idf
+		}
+		index.terms = append(index.terms, term)
+	}
+	sort.Strings(index.terms)
+
+	return index
+}
+
+func (index *Index) Len() int {
+	return len(index.documentIds)
+}
+
+// Mencari dokumen yang cocok dengan semua token query, jika tidak ada maka dokumen yang cocok sebagian
+func (index *Index) Search(query string) []Hit {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	coverages := make(map[int]int)
+	for _, token := range tokens {
+		tokenScores := make(map[int]float64)
+		for term, factor := range index.expand(token) {
+			for position, weight := range index.postings[term] {
+				if score := weight * factor; score > tokenScores[position] {
+					tokenScores[position] = score
+				}
+			}
+		}
+		for position, score := range tokenScores {
+			scores[position] = scores[position] + score
+			coverages[position]++
+		}
+	}
+
+	var hits []Hit
+	fullMatch := false
+	for position, score := range scores {
+		if coverages[position] == len(tokens) {
+			fullMatch = true
+		}
+		hits = append(hits, Hit{Id: index.documentIds[position], Score: score, Coverage: coverages[position]})
+	}
+	if fullMatch {
+		fullHits := hits[:0]
+		for _, hit := range hits {
+			if hit.Coverage == len(tokens) {
+				fullHits = append(fullHits, hit)
+			}
+		}
+		hits = fullHits
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Coverage != hits[j].Coverage {
+			return hits[i].Coverage > hits[j].Coverage
+		}
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Id < hits[j].Id
+	})
+	return hits
+}
+
+// Term di index yang dianggap cocok dengan token: sama persis, awalan, atau salah ketik
+func (index *Index) expand(token string) map[string]float64 {
+	terms := make(map[string]float64)
+	if _, ok := index.postings[token]; ok {
+		terms[token] = matchExact
+	}
+
+	if len(token) >= minPrefixSize {
+		for position := sort.SearchStrings(index.terms, token); position < len(index.terms) && strings.HasPrefix(index.terms[position], token); position++ {
+			if _, ok := terms[index.terms[position]]; !ok {
+				terms[index.terms[position]] = matchPrefix
+			}
+		}
+	}
+
+	if len(terms) > 0 || len(token) < minTypoSize {
+		return terms
+	}
+
+	maxDistance := 1
+	if len(token) > maxTypoOneLen {
+		maxDistance = 2
+	}
+	for _, term := range index.terms {
+		distance := editDistance(token, term, maxDistance)
+		if distance == 1 {
+			terms[term] = matchTypoOne
+		} else if distance <= maxDistance {
+			terms[term] = matchTypoTwo
+		}
+	}
+	return terms
+}
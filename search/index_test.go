@@ -0,0 +1,80 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestIndex() *Index {
+	return NewIndex([]Document{
+		{Id: "1", Fields: []Field{{Text: "Susu Bayi Formula", Weight: 3}}},
+		{Id: "2", Fields: []Field{{Text: "Susu Kedelai", Weight: 3}}},
+		{Id: "3", Fields: []Field{{Text: "Popok Bayi", Weight: 3}}},
+		{Id: "4", Fields: []Field{{Text: "Minyak Goreng", Weight: 3}}},
+		{Id: "5", Fields: []Field{{Text: "Kopi Bubuk", Weight: 3}}},
+		{Id: "6", Fields: []Field{{Text: "Kopiah Putih", Weight: 3}}},
+	})
+}
+
+func hitIds(hits []Hit) []string {
+	ids := []string{}
+	for _, hit := range hits {
+		ids = append(ids, hit.Id)
+	}
+	return ids
+}
+
+func TestIndexSearch(t *testing.T) {
+	index := newTestIndex()
+	tests := []struct {
+		name  string
+		query string
+		ids   []string
+	}{
+		{"all tokens must match when possible", "susu bayi", []string{"1"}},
+		{"stop words in query are ignored", "susu untuk bayi", []string{"1"}},
+		{"equal score ordered by id", "bayi", []string{"1", "3"}},
+		{"prefix", "min", []string{"4"}},
+		{"prefix needs two characters", "m", []string{}},
+		{"exact ranks above prefix", "kopi", []string{"5", "6"}},
+		{"one typo", "minyk", []string{"4"}},
+		{"transposition typo", "mniyak", []string{"4"}},
+		{"two typos for long token", "formmulla", []string{"1"}},
+		{"no typo for short token", "bau", []string{}},
+		{"rare term ranks first on partial match", "susu goreng", []string{"4", "1", "2"}},
+		{"only stop words", "dan yang", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if ids := hitIds(index.Search(test.query)); !reflect.DeepEqual(ids, test.ids) {
+				t.Errorf("Search(%q) = %q, want %q", test.query, ids, test.ids)
+			}
+		})
+	}
+}
+
+func TestIndexSearchFieldWeight(t *testing.T) {
+	index := NewIndex([]Document{
+		{Id: "description", Fields: []Field{{Text: "Tisu Basah", Weight: 3}, {Text: "aman untuk bayi", Weight: 1}}},
+		{Id: "name", Fields: []Field{{Text: "Sabun Bayi", Weight: 3}}},
+	})
+	if ids := hitIds(index.Search("bayi")); !reflect.DeepEqual(ids, []string{"name", "description"}) {
+		t.Errorf("Search(bayi) = %q, want name before description", ids)
+	}
+}
+
+func TestIndexSearchTypoScoresBelowExact(t *testing.T) {
+	index := NewIndex([]Document{
+		{Id: "exact", Fields: []Field{{Text: "Sabun", Weight: 1}}},
+		{Id: "typo", Fields: []Field{{Text: "Sabut", Weight: 1}}},
+	})
+	hits := index.Search("sabun")
+	if ids := hitIds(hits); !reflect.DeepEqual(ids, []string{"exact"}) {
+		t.Fatalf("Search(sabun) = %q, want only exact match without typo expansion", ids)
+	}
+
+	hits = index.Search("sabur")
+	if len(hits) != 2 || hits[0].Score != hits[1].Score {
+		t.Errorf("Search(sabur) = %+v, want two typo hits with equal score", hits)
+	}
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Kata umum bahasa indonesia yang tidak membantu pencarian
+var stopWords = map[string]bool{
+	"ada": true, "adalah": true, "agar": true, "akan": true, "atau": true, "bagi": true,
+	"buat": true, "dan": true, "dari": true, "dengan": true, "di": true, "dll": true,
+	"untuk": true, "utk": true, "dgn": true, "yang": true, "yg": true, "ini": true,
+	"itu": true, "juga": true, "ke": true, "oleh": true, "pada": true, "para": true,
+	"per": true, "serta": true, "tanpa": true, "tidak": true, "sangat": true, "lebih": true,
+	"bisa": true, "dapat": true, "hingga": true, "sampai": true, "saat": true, "secara": true,
+	"sudah": true, "the": true, "and": true, "for": true, "with": true, "of": true,
+}
+
+// Memecah teks menjadi token huruf kecil tanpa tanda baca dan stop word
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) < 2 || stopWords[field] {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// Jarak damerau-levenshtein (optimal string alignment), berhenti lebih awal jika melebihi maxDistance
+func editDistance(a string, b string, maxDistance int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > maxDistance {
+		return maxDistance + 1
+	}
+
+	previous2 := make([]int, len(rb)+1)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = minInt(current[j], previous2[j-2]+1)
+			}
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(rb)]
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		tokens []string
+	}{
+		{"lowercase and punctuation", "Susu Bayi, 400gr!", []string{"susu", "bayi", "400gr"}},
+		{"stop words", "susu untuk bayi dan anak yg aktif", []string{"susu", "bayi", "anak", "aktif"}},
+		{"single character", "a b cd", []string{"cd"}},
+		{"hyphen splits words", "anti-nyamuk", []string{"anti", "nyamuk"}},
+		{"unicode letters", "Café Latte", []string{"café", "latte"}},
+		{"empty", "", []string{}},
+		{"only stop words", "dan yang untuk", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if tokens := Tokenize(test.text); !reflect.DeepEqual(tokens, test.tokens) {
+				t.Errorf("Tokenize(%q) = %q, want %q", test.text, tokens, test.tokens)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a           string
+		b           string
+		maxDistance int
+		distance    int
+	}{
+		{"susu", "susu", 1, 0},
+		{"susu", "sisu", 1, 1},
+		{"bayi", "bay", 1, 1},
+		{"bay", "bayi", 1, 1},
+		// Transposisi dua huruf bersebelahan dihitung satu
+		{"susu", "ssuu", 1, 1},
+		{"minyak", "minayk", 2, 1},
+		// Optimal string alignment tidak mengedit substring yang sudah ditransposisi
+		{"ca", "abc", 3, 3},
+		{"formmulla", "formula", 2, 2},
+		// Melebihi maxDistance dikembalikan maxDistance + 1
+		{"susu", "kopi", 1, 2},
+		{"su", "susu", 1, 2},
+	}
+	for _, test := range tests {
+		if distance := editDistance(test.a, test.b, test.maxDistance); distance != test.distance {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", test.a, test.b, test.maxDistance, distance, test.distance)
+		}
+	}
+}
@@ -0,0 +1,758 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"math"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+const (
+	productImportMaxUploadSize = 10 * 1024 * 1024
+	productImportMaxRows       = 5000
+	productImportDateLayout    = "2006-01-02 15:04:05"
+)
+
+// Kolom file import dan export. Kolom nama kategori, nama sub kategori dan status hanya informasi,
+// kolom yang tidak ada di file import tidak mengubah data produk yang sudah ada
+var productSpreadsheetColumns = []string{
+	"id", "no_sku", "product_name", "price", "stock", "reorder_point", "max_qty_per_order", "max_qty_per_user", "max_qty_period_days", "weight", "volume", "description",
+	"id_category", "category_name", "id_sub_category", "sub_category_name", "id_brand", "brand_name",
+	"published", "status", "discount_type", "discount_percentage", "discount_nominal", "discount_start_date", "discount_end_date",
+}
+
+type AdminProductServiceInterface interface {
+	FindAdminProducts(requestId string, adminProductListRequest *request.AdminProductListRequest) (adminProductListResponse response.FindAdminProductListResponse)
+	FindAdminProductById(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse)
+	CreateProduct(requestId string, idUser string, adminProductRequest *request.AdminProductRequest) (adminProductResponse response.AdminProductResponse)
+	UpdateProduct(requestId string, idUser string, adminProductRequest *request.AdminProductRequest) (adminProductResponse response.AdminProductResponse)
+	PublishProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse)
+	UnpublishProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse)
+	ArchiveProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse)
+	RestoreProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse)
+	ExportProducts(requestId string, adminProductExportRequest *request.AdminProductExportRequest) []byte
+	ImportProducts(requestId string, idUser string, adminProductImportRequest *request.AdminProductImportRequest, fileHeader *multipart.FileHeader) (productImportResponse response.ProductImportResponse)
+}
+
+type AdminProductServiceImplementation struct {
+	ConfigWebserver                     config.Webserver
+	DB                                  *gorm.DB
+	Validate                            *validator.Validate
+	Logger                              *logrus.Logger
+	ProductRepositoryInterface          mysql.ProductRepositoryInterface
+	ProductDiscountRepositoryInterface  mysql.ProductDiscountRepositoryInterface
+	ProductCategoryRepositoryInterface  mysql.ProductCategoryRepositoryInterface
+	ProductBrandRepositoryInterface     mysql.ProductBrandRepositoryInterface
+	ProductStockServiceInterface        ProductStockServiceInterface
+	ProductCategoryServiceInterface     ProductCategoryServiceInterface
+	RestockSubscriptionServiceInterface RestockSubscriptionServiceInterface
+	ProductPriceHistoryServiceInterface ProductPriceHistoryServiceInterface
+}
+
+// Kategori, sub kategori dan brand untuk validasi banyak baris sekaligus
+type productCatalogLookup struct {
+	categories    map[int]entity.ProductCategory
+	subCategories map[int]entity.ProductSubCategory
+	brands        map[string]entity.ProductBrand
+	brandsByName  map[string]entity.ProductBrand
+}
+
+type productImportRow struct {
+	row             int
+	productRequest  request.AdminProductRequest
+	existing        entity.Product
+	stockSet        bool
+	published       string
+	discountType    string
+	productDiscount entity.ProductDiscount
+	errors          []string
+}
+
+func NewAdminProductService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productDiscountRepositoryInterface mysql.ProductDiscountRepositoryInterface,
+	productCategoryRepositoryInterface mysql.ProductCategoryRepositoryInterface,
+	productBrandRepositoryInterface mysql.ProductBrandRepositoryInterface,
+	productStockServiceInterface ProductStockServiceInterface,
+	productCategoryServiceInterface ProductCategoryServiceInterface,
+	restockSubscriptionServiceInterface RestockSubscriptionServiceInterface,
+	productPriceHistoryServiceInterface ProductPriceHistoryServiceInterface) AdminProductServiceInterface {
+	return &AdminProductServiceImplementation{
+		ConfigWebserver:                     configWebserver,
+		DB:                                  DB,
+		Validate:                            validate,
+		Logger:                              logger,
+		ProductRepositoryInterface:          productRepositoryInterface,
+		ProductDiscountRepositoryInterface:  productDiscountRepositoryInterface,
+		ProductCategoryRepositoryInterface:  productCategoryRepositoryInterface,
+		ProductBrandRepositoryInterface:     productBrandRepositoryInterface,
+		ProductStockServiceInterface:        productStockServiceInterface,
+		ProductCategoryServiceInterface:     productCategoryServiceInterface,
+		RestockSubscriptionServiceInterface: restockSubscriptionServiceInterface,
+		ProductPriceHistoryServiceInterface: productPriceHistoryServiceInterface,
+	}
+}
+
+func (service *AdminProductServiceImplementation) FindAdminProducts(requestId string, adminProductListRequest *request.AdminProductListRequest) (adminProductListResponse response.FindAdminProductListResponse) {
+	request.ValidateAdminProductRequest(service.Validate, adminProductListRequest, requestId, service.Logger)
+
+	pagination := modelService.Pagination{Page: adminProductListRequest.Page, Limit: adminProductListRequest.Limit}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = 20
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+
+	products, totalData, err := service.ProductRepositoryInterface.FindAdminProducts(service.DB, adminProductListRequest.Status, strings.TrimSpace(adminProductListRequest.Keyword), pagination)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	adminProductListResponse = response.ToFindAdminProductListResponse(products, pagination, totalData)
+	return adminProductListResponse
+}
+
+func (service *AdminProductServiceImplementation) FindAdminProductById(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse) {
+	request.ValidateAdminProductRequest(service.Validate, adminProductIdRequest, requestId, service.Logger)
+	product := service.findProduct(requestId, adminProductIdRequest.Id)
+	adminProductResponse = response.ToAdminProductResponse(product)
+	return adminProductResponse
+}
+
+// Produk baru selalu belum tayang, stok awal dicatat sebagai stok masuk
+func (service *AdminProductServiceImplementation) CreateProduct(requestId string, idUser string, adminProductRequest *request.AdminProductRequest) (adminProductResponse response.AdminProductResponse) {
+	request.ValidateAdminProductRequest(service.Validate, adminProductRequest, requestId, service.Logger)
+	lookup := service.findProductCatalogLookup(requestId)
+	if errorStrings := validateProductCatalog(*adminProductRequest, lookup); len(errorStrings) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("invalid product catalog"), requestId, errorStrings, service.Logger)
+	}
+	productWithSku, err := service.ProductRepositoryInterface.FindProductByNoSku(service.DB, adminProductRequest.NoSku)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if productWithSku.Id != "" {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("no_sku already exist"), requestId, []string{"no_sku already used by another product"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	product := service.createProduct(tx, requestId, *adminProductRequest, "Stok awal produk")
+	service.ProductPriceHistoryServiceInterface.RecordProductPriceChange(tx, requestId, entity.Product{}, product, PriceSourceAdmin, idUser)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductCategoryServiceInterface.InvalidateCategoryTree()
+	adminProductResponse = response.ToAdminProductResponse(service.findProduct(requestId, product.Id))
+	return adminProductResponse
+}
+
+func (service *AdminProductServiceImplementation) UpdateProduct(requestId string, idUser string, adminProductRequest *request.AdminProductRequest) (adminProductResponse response.AdminProductResponse) {
+	request.ValidateAdminProductRequest(service.Validate, adminProductRequest, requestId, service.Logger)
+	product := service.findProduct(requestId, adminProductRequest.Id)
+	lookup := service.findProductCatalogLookup(requestId)
+	if errorStrings := validateProductCatalog(*adminProductRequest, lookup); len(errorStrings) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("invalid product catalog"), requestId, errorStrings, service.Logger)
+	}
+	productWithSku, err := service.ProductRepositoryInterface.FindProductByNoSku(service.DB, adminProductRequest.NoSku)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if productWithSku.Id != "" && productWithSku.Id != product.Id {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("no_sku already exist"), requestId, []string{"no_sku already used by another product"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	_, err = service.ProductRepositoryInterface.UpdateProduct(tx, product.Id, toProductEntity(*adminProductRequest))
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product"}, service.Logger, tx)
+	updatedProduct := product
+	updatedProduct.Price = adminProductRequest.Price
+	service.ProductPriceHistoryServiceInterface.RecordProductPriceChange(tx, requestId, product, updatedProduct, PriceSourceAdmin, idUser)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductCategoryServiceInterface.InvalidateCategoryTree()
+	adminProductResponse = response.ToAdminProductResponse(service.findProduct(requestId, product.Id))
+	return adminProductResponse
+}
+
+func (service *AdminProductServiceImplementation) PublishProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse) {
+	return service.changeProductStatus(requestId, adminProductIdRequest, "published")
+}
+
+func (service *AdminProductServiceImplementation) UnpublishProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse) {
+	return service.changeProductStatus(requestId, adminProductIdRequest, "unpublished")
+}
+
+func (service *AdminProductServiceImplementation) ArchiveProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse) {
+	return service.changeProductStatus(requestId, adminProductIdRequest, "archived")
+}
+
+// Produk arsip dipulihkan sebagai produk belum tayang
+func (service *AdminProductServiceImplementation) RestoreProduct(requestId string, adminProductIdRequest *request.AdminProductIdRequest) (adminProductResponse response.AdminProductResponse) {
+	return service.changeProductStatus(requestId, adminProductIdRequest, "restored")
+}
+
+func (service *AdminProductServiceImplementation) changeProductStatus(requestId string, adminProductIdRequest *request.AdminProductIdRequest, status string) (adminProductResponse response.AdminProductResponse) {
+	request.ValidateAdminProductRequest(service.Validate, adminProductIdRequest, requestId, service.Logger)
+	product := service.findProduct(requestId, adminProductIdRequest.Id)
+
+	productEntity := &entity.Product{}
+	productEntity.Published = "0"
+	productEntity.ArchivedAt = product.ArchivedAt
+	switch status {
+	case "published":
+		if product.ArchivedAt.Valid {
+			exceptions.PanicIfBadRequest(errors.New("product archived"), requestId, []string{"archived product must be restored before publishing"}, service.Logger)
+		}
+		productEntity.Published = "1"
+	case "archived":
+		if !product.ArchivedAt.Valid {
+			productEntity.ArchivedAt = null.TimeFrom(time.Now())
+		}
+	case "restored":
+		productEntity.ArchivedAt = null.Time{}
+	}
+
+	tx := service.DB.Begin()
+	_, err := service.ProductRepositoryInterface.UpdateProductPublished(tx, product.Id, *productEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product status"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductCategoryServiceInterface.InvalidateCategoryTree()
+	adminProductResponse = response.ToAdminProductResponse(service.findProduct(requestId, product.Id))
+	return adminProductResponse
+}
+
+// Diskon hanya ditulis jika produk punya tepat satu diskon, sehingga file hasil export aman diimport ulang
+func (service *AdminProductServiceImplementation) ExportProducts(requestId string, adminProductExportRequest *request.AdminProductExportRequest) []byte {
+	request.ValidateAdminProductRequest(service.Validate, adminProductExportRequest, requestId, service.Logger)
+
+	products, err := service.ProductRepositoryInterface.FindAdminProductsForExport(service.DB, adminProductExportRequest.Status)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	lookup := service.findProductCatalogLookup(requestId)
+
+	rows := [][]string{productSpreadsheetColumns}
+	for _, product := range products {
+		idSubCategory := ""
+		if product.IdSubCategory != 0 {
+			idSubCategory = strconv.Itoa(product.IdSubCategory)
+		}
+		discountType, discountPercentage, discountNominal, discountStartDate, discountEndDate := "none", "", "", "", ""
+		if len(product.ProductDiscounts) > 1 {
+			discountType = ""
+		}
+		if len(product.ProductDiscounts) == 1 {
+			productDiscount := product.ProductDiscounts[0]
+			discountType = productDiscount.DiscountType
+			if discountType == "" {
+				discountType = pricing.DiscountTypeFixedPrice
+			}
+			discountPercentage = formatImportNumber(productDiscount.Percentage)
+			discountNominal = formatImportNumber(productDiscount.Nominal)
+			if !productDiscount.StartDate.IsZero() {
+				discountStartDate = productDiscount.StartDate.Format(productImportDateLayout)
+			}
+			if !productDiscount.EndDate.IsZero() {
+				discountEndDate = productDiscount.EndDate.Format(productImportDateLayout)
+			}
+		}
+		published := "0"
+		if product.Published == "1" {
+			published = "1"
+		}
+
+		rows = append(rows, []string{
+			product.Id,
+			product.NoSku,
+			product.ProductName,
+			formatImportNumber(product.Price),
+			strconv.Itoa(product.Stock),
+			strconv.Itoa(product.ReorderPoint),
+			strconv.Itoa(product.MaxQtyPerOrder),
+			strconv.Itoa(product.MaxQtyPerUser),
+			strconv.Itoa(product.MaxQtyPeriodDays),
+			formatImportNumber(product.Weight),
+			formatImportNumber(product.Volume),
+			product.Description,
+			strconv.Itoa(product.IdCategory),
+			product.ProductCategory.CategoryName,
+			idSubCategory,
+			lookup.subCategories[product.IdSubCategory].SubCategoryName,
+			product.IdBrand,
+			product.ProductBrand.BrandName,
+			published,
+			response.AdminProductStatus(product),
+			discountType,
+			discountPercentage,
+			discountNominal,
+			discountStartDate,
+			discountEndDate,
+		})
+	}
+
+	if adminProductExportRequest.Format == "xlsx" {
+		data, err := utilities.GenerateXlsx("Products", rows)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		return data
+	}
+	data, err := utilities.GenerateCsv(rows)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	return data
+}
+
+// Semua baris divalidasi terlebih dahulu, data hanya disimpan jika tidak ada baris yang salah.
+// Produk dicocokkan dengan id, jika kosong dengan no_sku, selain itu dibuat sebagai produk baru
+func (service *AdminProductServiceImplementation) ImportProducts(requestId string, idUser string, adminProductImportRequest *request.AdminProductImportRequest, fileHeader *multipart.FileHeader) (productImportResponse response.ProductImportResponse) {
+	if fileHeader.Size > productImportMaxUploadSize {
+		exceptions.PanicIfBadRequest(errors.New("file too large"), requestId, []string{"file is too large"}, service.Logger)
+	}
+	file, err := fileHeader.Open()
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, productImportMaxUploadSize+1))
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if len(data) > productImportMaxUploadSize {
+		exceptions.PanicIfBadRequest(errors.New("file too large"), requestId, []string{"file is too large"}, service.Logger)
+	}
+
+	rows, err := utilities.ReadSpreadsheet(fileHeader.Filename, data)
+	if err != nil {
+		exceptions.PanicIfBadRequest(err, requestId, []string{err.Error()}, service.Logger)
+	}
+	if len(rows) < 2 {
+		exceptions.PanicIfBadRequest(errors.New("empty file"), requestId, []string{"file has no product rows"}, service.Logger)
+	}
+	if len(rows)-1 > productImportMaxRows {
+		exceptions.PanicIfBadRequest(errors.New("too many rows"), requestId, []string{"file can contain at most " + strconv.Itoa(productImportMaxRows) + " product rows"}, service.Logger)
+	}
+
+	columns := make(map[string]int)
+	for index, column := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = index
+	}
+	_, hasId := columns["id"]
+	_, hasNoSku := columns["no_sku"]
+	if !hasId && !hasNoSku {
+		exceptions.PanicIfBadRequest(errors.New("missing key column"), requestId, []string{"file must have an id or no_sku column"}, service.Logger)
+	}
+
+	products, err := service.ProductRepositoryInterface.FindAdminProductsForExport(service.DB, "")
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productsById := make(map[string]entity.Product, len(products))
+	productsBySku := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		productsById[product.Id] = product
+		if product.NoSku != "" {
+			productsBySku[strings.ToLower(product.NoSku)] = product
+		}
+	}
+	lookup := service.findProductCatalogLookup(requestId)
+
+	productImportResponse.DryRun = adminProductImportRequest.DryRun
+	productImportResponse.Errors = []response.ProductImportRowErrorResponse{}
+	var importRows []productImportRow
+	rowsBySku := make(map[string]int)
+	for index, row := range rows[1:] {
+		if isBlankImportRow(row) {
+			continue
+		}
+		importRow := service.parseProductImportRow(index+2, row, columns, productsById, productsBySku, lookup)
+
+		skuKey := strings.ToLower(importRow.productRequest.NoSku)
+		if firstRow, ok := rowsBySku[skuKey]; ok && skuKey != "" {
+			importRow.errors = append(importRow.errors, "no_sku is duplicated with row "+strconv.Itoa(firstRow))
+		} else {
+			rowsBySku[skuKey] = importRow.row
+		}
+
+		productImportResponse.TotalRows++
+		if len(importRow.errors) > 0 {
+			productImportResponse.Errors = append(productImportResponse.Errors, response.ProductImportRowErrorResponse{
+				Row:    importRow.row,
+				NoSku:  importRow.productRequest.NoSku,
+				Errors: importRow.errors,
+			})
+			continue
+		}
+		if importRow.existing.Id == "" {
+			productImportResponse.Created++
+		} else {
+			productImportResponse.Updated++
+		}
+		importRows = append(importRows, importRow)
+	}
+
+	if adminProductImportRequest.DryRun || len(productImportResponse.Errors) > 0 {
+		return productImportResponse
+	}
+
+	restocked := false
+	tx := service.DB.Begin()
+	for _, importRow := range importRows {
+		if service.applyProductImportRow(tx, requestId, idUser, importRow) {
+			restocked = true
+		}
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	if restocked {
+		go service.RestockSubscriptionServiceInterface.NotifyRestockSubscribers()
+	}
+	service.ProductCategoryServiceInterface.InvalidateCategoryTree()
+	productImportResponse.Applied = true
+	return productImportResponse
+}
+
+func (service *AdminProductServiceImplementation) parseProductImportRow(rowNumber int, row []string, columns map[string]int, productsById map[string]entity.Product, productsBySku map[string]entity.Product, lookup productCatalogLookup) (importRow productImportRow) {
+	importRow.row = rowNumber
+	hasColumn := func(name string) bool {
+		_, ok := columns[name]
+		return ok
+	}
+	cell := func(name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+	parseNumber := func(name string, target *float64) {
+		if !hasColumn(name) {
+			return
+		}
+		value, err := parseImportNumber(cell(name))
+		if err != nil {
+			importRow.errors = append(importRow.errors, name+" must be a number")
+			return
+		}
+		*target = value
+	}
+	parseInteger := func(name string, target *int) {
+		if !hasColumn(name) {
+			return
+		}
+		value, err := parseImportNumber(cell(name))
+		if err != nil || value != math.Trunc(value) {
+			importRow.errors = append(importRow.errors, name+" must be a whole number")
+			return
+		}
+		*target = int(value)
+	}
+
+	idProduct := cell("id")
+	noSku := cell("no_sku")
+	if idProduct != "" {
+		product, ok := productsById[idProduct]
+		if !ok {
+			importRow.errors = append(importRow.errors, "id is not found")
+		}
+		importRow.existing = product
+	} else if noSku != "" {
+		importRow.existing = productsBySku[strings.ToLower(noSku)]
+	}
+	if productWithSku, ok := productsBySku[strings.ToLower(noSku)]; ok && importRow.existing.Id != "" && productWithSku.Id != importRow.existing.Id {
+		importRow.errors = append(importRow.errors, "no_sku already used by another product")
+	}
+
+	// Produk yang sudah ada mulai dari data lama, hanya kolom yang ada di file yang menimpa
+	productRequest := &importRow.productRequest
+	productRequest.Id = importRow.existing.Id
+	productRequest.NoSku = importRow.existing.NoSku
+	productRequest.ProductName = importRow.existing.ProductName
+	productRequest.Price = importRow.existing.Price
+	productRequest.Description = importRow.existing.Description
+	productRequest.Weight = importRow.existing.Weight
+	productRequest.Volume = importRow.existing.Volume
+	productRequest.IdCategory = importRow.existing.IdCategory
+	productRequest.IdSubCategory = importRow.existing.IdSubCategory
+	productRequest.IdBrand = importRow.existing.IdBrand
+	productRequest.ReorderPoint = importRow.existing.ReorderPoint
+	productRequest.MaxQtyPerOrder = importRow.existing.MaxQtyPerOrder
+	productRequest.MaxQtyPerUser = importRow.existing.MaxQtyPerUser
+	productRequest.MaxQtyPeriodDays = importRow.existing.MaxQtyPeriodDays
+	if hasColumn("no_sku") {
+		productRequest.NoSku = noSku
+	}
+	if hasColumn("product_name") {
+		productRequest.ProductName = cell("product_name")
+	}
+	if hasColumn("description") {
+		productRequest.Description = cell("description")
+	}
+	parseNumber("price", &productRequest.Price)
+	parseNumber("weight", &productRequest.Weight)
+	parseNumber("volume", &productRequest.Volume)
+	parseInteger("id_category", &productRequest.IdCategory)
+	parseInteger("id_sub_category", &productRequest.IdSubCategory)
+	parseInteger("reorder_point", &productRequest.ReorderPoint)
+	parseInteger("max_qty_per_order", &productRequest.MaxQtyPerOrder)
+	parseInteger("max_qty_per_user", &productRequest.MaxQtyPerUser)
+	parseInteger("max_qty_period_days", &productRequest.MaxQtyPeriodDays)
+
+	if idBrand := cell("id_brand"); idBrand != "" {
+		productRequest.IdBrand = idBrand
+	} else if brandName := cell("brand_name"); brandName != "" {
+		productBrand, ok := lookup.brandsByName[strings.ToLower(brandName)]
+		if !ok {
+			importRow.errors = append(importRow.errors, "brand_name is not found")
+		}
+		productRequest.IdBrand = productBrand.Id
+	} else if hasColumn("id_brand") || hasColumn("brand_name") {
+		productRequest.IdBrand = ""
+	}
+
+	if cell("stock") != "" {
+		importRow.stockSet = true
+		parseInteger("stock", &productRequest.Stock)
+	}
+	if importRow.existing.Id != "" && importRow.stockSet && productRequest.Stock != importRow.existing.Stock {
+		if len(importRow.existing.ProductVariants) > 0 {
+			importRow.errors = append(importRow.errors, "stock of product with variants is managed per variant")
+		} else if productRequest.Stock < importRow.existing.Stock {
+			importRow.errors = append(importRow.errors, "stock can not be reduced by import")
+		}
+	}
+
+	importRow.errors = append(importRow.errors, service.productValidationErrors(*productRequest)...)
+	importRow.errors = append(importRow.errors, validateProductCatalog(*productRequest, lookup)...)
+
+	importRow.published = cell("published")
+	if importRow.published != "" && importRow.published != "0" && importRow.published != "1" {
+		importRow.errors = append(importRow.errors, "published must be 0 or 1")
+	}
+	if importRow.published == "1" && importRow.existing.ArchivedAt.Valid {
+		importRow.errors = append(importRow.errors, "archived product must be restored before publishing")
+	}
+
+	importRow.discountType = strings.ToLower(cell("discount_type"))
+	switch importRow.discountType {
+	case "", "none":
+	case pricing.DiscountTypePercentage, pricing.DiscountTypeFixedPrice:
+		productDiscount := &importRow.productDiscount
+		productDiscount.DiscountType = importRow.discountType
+		parseNumber("discount_percentage", &productDiscount.Percentage)
+		parseNumber("discount_nominal", &productDiscount.Nominal)
+		if importRow.discountType == pricing.DiscountTypePercentage && (productDiscount.Percentage <= 0 || productDiscount.Percentage > 100) {
+			importRow.errors = append(importRow.errors, "discount_percentage must be between 0 and 100")
+		}
+		if importRow.discountType == pricing.DiscountTypeFixedPrice && (productDiscount.Nominal <= 0 || productDiscount.Nominal >= productRequest.Price) {
+			importRow.errors = append(importRow.errors, "discount_nominal must be above 0 and below price")
+		}
+
+		var err error
+		productDiscount.StartDate, err = parseImportDate(cell("discount_start_date"))
+		if err != nil {
+			importRow.errors = append(importRow.errors, "discount_start_date must use format "+productImportDateLayout)
+		}
+		productDiscount.EndDate, err = parseImportDate(cell("discount_end_date"))
+		if err != nil {
+			importRow.errors = append(importRow.errors, "discount_end_date must use format "+productImportDateLayout)
+		}
+		if !productDiscount.StartDate.IsZero() && !productDiscount.EndDate.IsZero() && !productDiscount.EndDate.After(productDiscount.StartDate) {
+			importRow.errors = append(importRow.errors, "discount_end_date must be after discount_start_date")
+		}
+	default:
+		importRow.errors = append(importRow.errors, "discount_type must be none, percentage or fixed_price")
+	}
+	return importRow
+}
+
+// restocked bernilai true jika stok produk berubah dari kosong menjadi tersedia
+func (service *AdminProductServiceImplementation) applyProductImportRow(tx *gorm.DB, requestId string, idUser string, importRow productImportRow) (restocked bool) {
+	idProduct := importRow.existing.Id
+	updatedProduct := importRow.existing
+	if idProduct == "" {
+		product := service.createProduct(tx, requestId, importRow.productRequest, "Stok awal import produk")
+		idProduct = product.Id
+		updatedProduct = product
+	} else {
+		_, err := service.ProductRepositoryInterface.UpdateProduct(tx, idProduct, toProductEntity(importRow.productRequest))
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product"}, service.Logger, tx)
+		if importRow.stockSet && importRow.productRequest.Stock > importRow.existing.Stock {
+			_, restocked = service.ProductStockServiceInterface.PostStockMovement(tx, requestId, modelService.StockMovement{
+				IdProduct:   idProduct,
+				Qty:         importRow.productRequest.Stock - importRow.existing.Stock,
+				TxType:      StockTxImport,
+				Description: "Stok masuk import produk",
+			})
+		}
+	}
+
+	if importRow.published != "" && (importRow.existing.Id == "" || importRow.published != importRow.existing.Published) {
+		productEntity := &entity.Product{}
+		productEntity.Published = importRow.published
+		productEntity.ArchivedAt = importRow.existing.ArchivedAt
+		_, err := service.ProductRepositoryInterface.UpdateProductPublished(tx, idProduct, *productEntity)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product status"}, service.Logger, tx)
+	}
+
+	if importRow.discountType != "" {
+		err := service.ProductDiscountRepositoryInterface.DeleteProductDiscountsByIdProduct(tx, idProduct)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product discount"}, service.Logger, tx)
+		updatedProduct.ProductDiscounts = nil
+	}
+	if importRow.discountType == pricing.DiscountTypePercentage || importRow.discountType == pricing.DiscountTypeFixedPrice {
+		productDiscountEntity := importRow.productDiscount
+		productDiscountEntity.Id = utilities.RandomUUID()
+		productDiscountEntity.IdProduct = idProduct
+		productDiscountEntity.FlagPromo = "true"
+		_, err := service.ProductDiscountRepositoryInterface.CreateProductDiscount(tx, productDiscountEntity)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product discount"}, service.Logger, tx)
+		updatedProduct.ProductDiscounts = append(updatedProduct.ProductDiscounts, productDiscountEntity)
+	}
+
+	updatedProduct.Price = importRow.productRequest.Price
+	service.ProductPriceHistoryServiceInterface.RecordProductPriceChange(tx, requestId, importRow.existing, updatedProduct, PriceSourceImport, idUser)
+	return restocked
+}
+
+func (service *AdminProductServiceImplementation) createProduct(tx *gorm.DB, requestId string, adminProductRequest request.AdminProductRequest, stockDescription string) (product entity.Product) {
+	productEntity := toProductEntity(adminProductRequest)
+	productEntity.Id = utilities.RandomUUID()
+	productEntity.Published = "0"
+	productEntity.CreatedAt = time.Now()
+	product, err := service.ProductRepositoryInterface.CreateProduct(tx, productEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error create product"}, service.Logger, tx)
+
+	if adminProductRequest.Stock > 0 {
+		service.ProductStockServiceInterface.PostStockMovement(tx, requestId, modelService.StockMovement{
+			IdProduct:   product.Id,
+			Qty:         adminProductRequest.Stock,
+			TxType:      StockTxInitial,
+			Description: stockDescription,
+		})
+	}
+	return product
+}
+
+func (service *AdminProductServiceImplementation) findProduct(requestId string, idProduct string) (product entity.Product) {
+	product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, idProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger)
+	}
+	return product
+}
+
+func (service *AdminProductServiceImplementation) findProductCatalogLookup(requestId string) (lookup productCatalogLookup) {
+	categories, err := service.ProductCategoryRepositoryInterface.FindAllCategories(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productBrands, err := service.ProductBrandRepositoryInterface.FindAllProductBrand(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	lookup.categories = make(map[int]entity.ProductCategory, len(categories))
+	lookup.subCategories = make(map[int]entity.ProductSubCategory)
+	for _, category := range categories {
+		lookup.categories[category.Id] = category
+		for _, subCategory := range category.ProductSubCategories {
+			lookup.subCategories[subCategory.Id] = subCategory
+		}
+	}
+	lookup.brands = make(map[string]entity.ProductBrand, len(productBrands))
+	lookup.brandsByName = make(map[string]entity.ProductBrand, len(productBrands))
+	for _, productBrand := range productBrands {
+		lookup.brands[productBrand.Id] = productBrand
+		lookup.brandsByName[strings.ToLower(strings.TrimSpace(productBrand.BrandName))] = productBrand
+	}
+	return lookup
+}
+
+// Pesan validasi sama dengan request API, dikumpulkan per baris tanpa panic
+func (service *AdminProductServiceImplementation) productValidationErrors(adminProductRequest request.AdminProductRequest) (errorStrings []string) {
+	err := service.Validate.Struct(adminProductRequest)
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		for _, errorValidation := range validationErrors {
+			errorStrings = append(errorStrings, errorValidation.Field()+" is "+errorValidation.Tag())
+		}
+	}
+	return errorStrings
+}
+
+func validateProductCatalog(adminProductRequest request.AdminProductRequest, lookup productCatalogLookup) (errorStrings []string) {
+	if _, ok := lookup.categories[adminProductRequest.IdCategory]; !ok && adminProductRequest.IdCategory != 0 {
+		errorStrings = append(errorStrings, "id_category is not found")
+	}
+	if adminProductRequest.IdSubCategory != 0 {
+		subCategory, ok := lookup.subCategories[adminProductRequest.IdSubCategory]
+		if !ok {
+			errorStrings = append(errorStrings, "id_sub_category is not found")
+		} else if subCategory.IdCategory != adminProductRequest.IdCategory {
+			errorStrings = append(errorStrings, "id_sub_category does not belong to id_category")
+		}
+	}
+	if _, ok := lookup.brands[adminProductRequest.IdBrand]; !ok && adminProductRequest.IdBrand != "" {
+		errorStrings = append(errorStrings, "id_brand is not found")
+	}
+	return errorStrings
+}
+
+func toProductEntity(adminProductRequest request.AdminProductRequest) (product entity.Product) {
+	product.NoSku = strings.TrimSpace(adminProductRequest.NoSku)
+	product.ProductName = strings.TrimSpace(adminProductRequest.ProductName)
+	product.Price = adminProductRequest.Price
+	product.Description = adminProductRequest.Description
+	product.Weight = adminProductRequest.Weight
+	product.Volume = adminProductRequest.Volume
+	product.IdCategory = adminProductRequest.IdCategory
+	product.IdSubCategory = adminProductRequest.IdSubCategory
+	product.IdBrand = adminProductRequest.IdBrand
+	product.ReorderPoint = adminProductRequest.ReorderPoint
+	product.MaxQtyPerOrder = adminProductRequest.MaxQtyPerOrder
+	product.MaxQtyPerUser = adminProductRequest.MaxQtyPerUser
+	product.MaxQtyPeriodDays = adminProductRequest.MaxQtyPeriodDays
+	return product
+}
+
+func isBlankImportRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseImportNumber(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func formatImportNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Tanggal dari xlsx bisa berupa nomor seri tanggal Excel jika selnya berformat tanggal
+func parseImportDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{productImportDateLayout, "2006-01-02 15:04", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial <= 0 {
+		return time.Time{}, errors.New("invalid date")
+	}
+	excelEpoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+	return excelEpoch.Add(time.Duration(math.Round(serial*86400)) * time.Second), nil
+}
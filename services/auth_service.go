@@ -218,6 +218,7 @@ func (service *AuthServiceImplementation) Login(requestId string, authRequest *r
 		userModelService.Id = user.Id
 		userModelService.Username = user.Username
 		userModelService.IdKelurahan = user.FamilyMembers.IdKelurahan
+		userModelService.IdRole = user.IdRole
 
 		token, err := service.GenerateToken(userModelService)
 		exceptions.PanicIfError(err, requestId, service.Logger)
@@ -265,6 +266,7 @@ func (service *AuthServiceImplementation) NewToken(requestId string, refreshToke
 		var userModelService modelService.User
 		userModelService.Id = user.Id
 		userModelService.Username = user.Username
+		userModelService.IdRole = user.IdRole
 		// userModelService.CreatedDate = user.CreatedDate
 		token, err := service.GenerateRefreshToken(userModelService)
 		exceptions.PanicIfError(err, requestId, service.Logger)
@@ -282,6 +284,7 @@ func (service *AuthServiceImplementation) GenerateToken(user modelService.User)
 		Id:          user.Id,
 		Username:    user.Username,
 		IdKelurahan: user.IdKelurahan,
+		IdRole:      user.IdRole,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(time.Minute * time.Duration(service.ConfigJwt.Tokenexpiredtime)).Unix(),
 			Issuer:    "aether",
@@ -301,6 +304,7 @@ func (service *AuthServiceImplementation) GenerateRefreshToken(user modelService
 	claims := modelService.TokenClaims{
 		Id:       user.Id,
 		Username: user.Username,
+		IdRole:   user.IdRole,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().AddDate(0, 0, int(service.ConfigJwt.Refreshtokenexpiredtime)).Unix(),
 			Issuer:    "aether",
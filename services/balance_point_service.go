@@ -15,6 +15,7 @@ type BalancePointServiceInterface interface {
 	FindBalancePointByIdUser(requestId string, IdUser string) (balancePointResponses response.FindBalancePointByIdUser)
 	BalancePointCheckAmount(requestId string, IdUser string, amount float64) string
 	BalancePointCheckOrderTx(requestId string, IdUser string, totalBill float64) string
+	BalancePointCheckRedemption(requestId string, idUser string, amount float64, totalBill float64) (pointRedemptionResponse response.PointRedemptionCheckResponse)
 }
 
 type BalancePointServiceImplementation struct {
@@ -23,7 +24,7 @@ type BalancePointServiceImplementation struct {
 	Logger                          *logrus.Logger
 	BalancePointRepositoryInterface mysql.BalancePointRepositoryInterface
 	SettingsRepositoryInterface     mysql.SettingRepositoryInterface
-	OrderRepositoryInterface        mysql.OrderRepositoryInterface
+	PointRedemptionServiceInterface PointRedemptionServiceInterface
 }
 
 func NewBalancePointService(configWebserver config.Webserver,
@@ -31,19 +32,19 @@ func NewBalancePointService(configWebserver config.Webserver,
 	logger *logrus.Logger,
 	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface,
 	settingsRepositoryInterface mysql.SettingRepositoryInterface,
-	orderRepositoryInterface mysql.OrderRepositoryInterface) BalancePointServiceInterface {
+	pointRedemptionServiceInterface PointRedemptionServiceInterface) BalancePointServiceInterface {
 	return &BalancePointServiceImplementation{
 		ConfigWebserver:                 configWebserver,
 		DB:                              DB,
 		Logger:                          logger,
 		BalancePointRepositoryInterface: balancePointRepositoryInterface,
 		SettingsRepositoryInterface:     settingsRepositoryInterface,
-		OrderRepositoryInterface:        orderRepositoryInterface,
+		PointRedemptionServiceInterface: pointRedemptionServiceInterface,
 	}
 }
 
 func (service *BalancePointServiceImplementation) FindBalancePointByIdUser(requestId string, IdUser string) (balancePointResponse response.FindBalancePointByIdUser) {
-	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, IdUser)
+	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUser(service.DB, IdUser)
 	if balancePoint.IdUser == "" {
 		err := errors.New("user not found")
 		exceptions.PanicIfRecordNotFound(err, requestId, []string{"Not Found"}, service.Logger)
@@ -53,32 +54,23 @@ func (service *BalancePointServiceImplementation) FindBalancePointByIdUser(reque
 }
 
 func (service *BalancePointServiceImplementation) BalancePointCheckOrderTx(requestId string, idUser string, totalBill float64) string {
-
-	// get limit order to use point value
-	settings, _ := service.SettingsRepositoryInterface.FindSettingsByName(service.DB, "limit_order")
-	if settings.SettingsName == "" {
-		exceptions.PanicIfRecordNotFound(errors.New("settings not found"), requestId, []string{"Not Found"}, service.Logger)
-	}
-
-	// cek apakah jumlah order bulan ini sudah sesuai dengan limit order untuk menggunakan point
-	// Get data order bulan ini
-	orders, _ := service.OrderRepositoryInterface.FindOrderByDate(service.DB, idUser)
-	var totalOrderAcumulate float64
-	for _, order := range orders {
-		totalOrderAcumulate = totalOrderAcumulate + order.PaymentByCash
-	}
-	totalOrderAcumulate = totalOrderAcumulate + totalBill
-
-	if totalOrderAcumulate < settings.Value {
-		exceptions.PanicIfBadRequest(errors.New("cant use point"), requestId, []string{"akumulasi total belanja kurang"}, service.Logger)
+	// cek rule pemakaian point terhadap isi keranjang
+	pointRedemption := service.PointRedemptionServiceInterface.EvaluateCartPointRedemption(requestId, idUser, 0, totalBill)
+	if len(pointRedemption.Violations) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("cant use point"), requestId, pointRedemption.Violations, service.Logger)
 	}
 
 	return "ok"
 }
 
+func (service *BalancePointServiceImplementation) BalancePointCheckRedemption(requestId string, idUser string, amount float64, totalBill float64) (pointRedemptionResponse response.PointRedemptionCheckResponse) {
+	pointRedemptionResponse = service.PointRedemptionServiceInterface.CheckPointRedemption(requestId, idUser, amount, totalBill)
+	return pointRedemptionResponse
+}
+
 func (service *BalancePointServiceImplementation) BalancePointCheckAmount(requestId string, idUser string, amount float64) string {
 	//check balance point
-	balancePoint, _ := service.BalancePointRepositoryInterface.BalancePointUseCheck(service.DB, idUser)
+	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUser(service.DB, idUser)
 	if balancePoint.IdUser == "" {
 		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"Not Found"}, service.Logger)
 	}
@@ -87,5 +79,10 @@ func (service *BalancePointServiceImplementation) BalancePointCheckAmount(reques
 		exceptions.PanicIfBadRequest(errors.New("point not enough"), requestId, []string{"point not enough"}, service.Logger)
 	}
 
+	pointRedemption := service.PointRedemptionServiceInterface.EvaluateCartPointRedemption(requestId, idUser, amount, 0)
+	if len(pointRedemption.Violations) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("cant use point"), requestId, pointRedemption.Violations, service.Logger)
+	}
+
 	return "ok"
 }
@@ -1,23 +1,41 @@
 package services
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/go-playground/validator"
 	"github.com/sirupsen/logrus"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
 	"gorm.io/gorm"
 )
 
+const (
+	balancePointStatementDefaultLimit = 20
+	balancePointStatementMaxLimit     = 100
+)
+
 type BalancePointTxServiceInterface interface {
 	FindBalancePointWithTxByIdBalancePoint(requestId string, date string, idUser string) (balancePointTxWithTxResponses []response.FindBalancePointTxByIdBalancePoint)
+	FindBalancePointStatement(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) (balancePointStatementResponse response.BalancePointStatementResponse)
+	ExportBalancePointStatementCsv(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) []byte
+	ExportBalancePointStatementPdf(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) []byte
 }
 
 type BalancePointTxServiceImplementation struct {
 	ConfigWebserver                   config.Webserver
 	DB                                *gorm.DB
+	Validate                          *validator.Validate
 	Logger                            *logrus.Logger
 	BalancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface
 	BalancePointRepositoryInterface   mysql.BalancePointRepositoryInterface
@@ -25,12 +43,14 @@ type BalancePointTxServiceImplementation struct {
 
 func NewBalancePointTxService(configWebserver config.Webserver,
 	DB *gorm.DB,
+	validate *validator.Validate,
 	logger *logrus.Logger,
 	balancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface,
 	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface) BalancePointTxServiceInterface {
 	return &BalancePointTxServiceImplementation{
 		ConfigWebserver:                   configWebserver,
 		DB:                                DB,
+		Validate:                          validate,
 		Logger:                            logger,
 		BalancePointTxRepositoryInterface: balancePointTxRepositoryInterface,
 		BalancePointRepositoryInterface:   balancePointRepositoryInterface,
@@ -39,7 +59,7 @@ func NewBalancePointTxService(configWebserver config.Webserver,
 
 func (service *BalancePointTxServiceImplementation) FindBalancePointWithTxByIdBalancePoint(requestId string, date string, idUser string) (balancePointTxResponses []response.FindBalancePointTxByIdBalancePoint) {
 	// Get User balance point
-	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, idUser)
+	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUser(service.DB, idUser)
 	if balancePoint.Id == "" {
 		exceptions.PanicIfRecordNotFound(errors.New("data not found"), requestId, []string{"Data Not Found"}, service.Logger)
 	}
@@ -52,3 +72,150 @@ func (service *BalancePointTxServiceImplementation) FindBalancePointWithTxByIdBa
 	balancePointTxResponses = response.ToFindBalancePointTxByIdBalancePoint(balancePointTx)
 	return balancePointTxResponses
 }
+
+func (service *BalancePointTxServiceImplementation) FindBalancePointStatement(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) (balancePointStatementResponse response.BalancePointStatementResponse) {
+	request.ValidateBalancePointStatementRequest(service.Validate, balancePointStatementRequest, requestId, service.Logger)
+
+	balancePoint := service.FindStatementBalancePoint(requestId, idUser)
+	dateFrom, dateTo := service.ParseStatementPeriod(requestId, balancePointStatementRequest)
+
+	page := balancePointStatementRequest.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := balancePointStatementRequest.Limit
+	if limit < 1 {
+		limit = balancePointStatementDefaultLimit
+	} else if limit > balancePointStatementMaxLimit {
+		limit = balancePointStatementMaxLimit
+	}
+
+	totalData, err := service.BalancePointTxRepositoryInterface.CountBalancePointTxStatement(service.DB, balancePoint.Id, dateFrom, dateTo, balancePointStatementRequest.TxType)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	balancePointTxs, err := service.BalancePointTxRepositoryInterface.FindBalancePointTxStatement(service.DB, balancePoint.Id, dateFrom, dateTo, balancePointStatementRequest.TxType, limit, (page-1)*limit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	openingBalance, closingBalance := service.FindStatementBalances(requestId, balancePoint.Id, dateFrom, dateTo)
+
+	balancePointStatementResponse = response.ToBalancePointStatementResponse(dateFrom, dateTo, balancePointStatementRequest.TxType, openingBalance, closingBalance, page, limit, totalData, balancePointTxs)
+	return balancePointStatementResponse
+}
+
+func (service *BalancePointTxServiceImplementation) ExportBalancePointStatementCsv(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) []byte {
+	dateFrom, dateTo, openingBalance, closingBalance, balancePointTxs := service.FindStatementForExport(requestId, idUser, balancePointStatementRequest)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"Periode", dateFrom.Format("2006-01-02"), dateTo.Format("2006-01-02")})
+	writer.Write([]string{"Saldo Awal", formatPoint(openingBalance)})
+	writer.Write([]string{"Tanggal", "No Order", "Tipe", "Keterangan", "Nominal", "Saldo Sebelum", "Saldo Sesudah"})
+	for _, balancePointTx := range balancePointTxs {
+		writer.Write([]string{
+			balancePointTx.TxDate.Format("2006-01-02 15:04:05"),
+			balancePointTx.NoOrder,
+			balancePointTx.TxType,
+			balancePointTx.Description,
+			formatPoint(balancePointTx.TxNominal),
+			formatPoint(balancePointTx.LastPointBalance),
+			formatPoint(balancePointTx.NewPointBalance),
+		})
+	}
+	writer.Write([]string{"Saldo Akhir", formatPoint(closingBalance)})
+	writer.Flush()
+	exceptions.PanicIfError(writer.Error(), requestId, service.Logger)
+
+	return buffer.Bytes()
+}
+
+func (service *BalancePointTxServiceImplementation) ExportBalancePointStatementPdf(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) []byte {
+	dateFrom, dateTo, openingBalance, closingBalance, balancePointTxs := service.FindStatementForExport(requestId, idUser, balancePointStatementRequest)
+
+	var lines []string
+	lines = append(lines, "Periode     : "+dateFrom.Format("02-01-2006")+" s/d "+dateTo.Format("02-01-2006"))
+	lines = append(lines, "Saldo Awal  : "+formatPoint(openingBalance))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%-19s %-26s %-8s %12s %12s", "Tanggal", "No Order / Keterangan", "Tipe", "Nominal", "Saldo"))
+	for _, balancePointTx := range balancePointTxs {
+		note := balancePointTx.NoOrder
+		if note == "" {
+			note = balancePointTx.Description
+		}
+		if len(note) > 26 {
+			note = note[:26]
+		}
+		lines = append(lines, fmt.Sprintf("%-19s %-26s %-8s %12s %12s",
+			balancePointTx.TxDate.Format("2006-01-02 15:04:05"),
+			note,
+			balancePointTx.TxType,
+			formatPoint(balancePointTx.TxNominal),
+			formatPoint(balancePointTx.NewPointBalance)))
+	}
+	lines = append(lines, "")
+	lines = append(lines, "Saldo Akhir : "+formatPoint(closingBalance))
+
+	return utilities.GenerateTextPdf("Statement Point Teman Bunda", lines)
+}
+
+func (service *BalancePointTxServiceImplementation) FindStatementForExport(requestId string, idUser string, balancePointStatementRequest *request.BalancePointStatementRequest) (dateFrom time.Time, dateTo time.Time, openingBalance float64, closingBalance float64, balancePointTxs []entity.BalancePointTx) {
+	request.ValidateBalancePointStatementRequest(service.Validate, balancePointStatementRequest, requestId, service.Logger)
+
+	balancePoint := service.FindStatementBalancePoint(requestId, idUser)
+	dateFrom, dateTo = service.ParseStatementPeriod(requestId, balancePointStatementRequest)
+
+	// export berisi semua transaksi dalam periode tanpa pagination
+	balancePointTxs, err := service.BalancePointTxRepositoryInterface.FindBalancePointTxStatement(service.DB, balancePoint.Id, dateFrom, dateTo, balancePointStatementRequest.TxType, 0, 0)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	openingBalance, closingBalance = service.FindStatementBalances(requestId, balancePoint.Id, dateFrom, dateTo)
+	return dateFrom, dateTo, openingBalance, closingBalance, balancePointTxs
+}
+
+func (service *BalancePointTxServiceImplementation) FindStatementBalancePoint(requestId string, idUser string) entity.BalancePoint {
+	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUser(service.DB, idUser)
+	if balancePoint.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("data not found"), requestId, []string{"Data Not Found"}, service.Logger)
+	}
+	return balancePoint
+}
+
+// Periode default adalah awal bulan ini sampai hari ini, date_to dihitung sampai akhir hari
+func (service *BalancePointTxServiceImplementation) ParseStatementPeriod(requestId string, balancePointStatementRequest *request.BalancePointStatementRequest) (dateFrom time.Time, dateTo time.Time) {
+	now := time.Now()
+	dateFrom = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	dateTo = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	var err error
+	if balancePointStatementRequest.DateFrom != "" {
+		dateFrom, err = time.ParseInLocation("2006-01-02", balancePointStatementRequest.DateFrom, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_from format must be YYYY-MM-DD"}, service.Logger)
+	}
+	if balancePointStatementRequest.DateTo != "" {
+		dateTo, err = time.ParseInLocation("2006-01-02", balancePointStatementRequest.DateTo, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_to format must be YYYY-MM-DD"}, service.Logger)
+	}
+
+	if dateTo.Before(dateFrom) {
+		exceptions.PanicIfBadRequest(errors.New("invalid date range"), requestId, []string{"date_to must be after date_from"}, service.Logger)
+	}
+
+	dateTo = dateTo.Add(24*time.Hour - time.Second)
+	return dateFrom, dateTo
+}
+
+// Saldo awal dan akhir diambil dari saldo transaksi terakhir sebelum batas periode
+func (service *BalancePointTxServiceImplementation) FindStatementBalances(requestId string, idBalancePoint string, dateFrom time.Time, dateTo time.Time) (openingBalance float64, closingBalance float64) {
+	openingTx, err := service.BalancePointTxRepositoryInterface.FindLastBalancePointTxBeforeDate(service.DB, idBalancePoint, dateFrom)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	openingBalance = openingTx.NewPointBalance
+
+	closingTx, err := service.BalancePointTxRepositoryInterface.FindLastBalancePointTxBeforeDate(service.DB, idBalancePoint, dateTo.Add(time.Second))
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	closingBalance = closingTx.NewPointBalance
+
+	return openingBalance, closingBalance
+}
+
+func formatPoint(point float64) string {
+	return strconv.FormatFloat(point, 'f', -1, 64)
+}
@@ -27,14 +27,18 @@ type CartServiceInterface interface {
 }
 
 type CartServiceImplementation struct {
-	ConfigWebserver             config.Webserver
-	DB                          *gorm.DB
-	Validate                    *validator.Validate
-	Logger                      *logrus.Logger
-	CartRepositoryInterface     mysql.CartRepositoryInterface
-	ShippingRepositoryInterface mysql.ShippingRepositoryInterface
-	ProductRepositoryInterface  mysql.ProductRepositoryInterface
-	SettingRepositoryInterface  mysql.SettingRepositoryInterface
+	ConfigWebserver                 config.Webserver
+	DB                              *gorm.DB
+	Validate                        *validator.Validate
+	Logger                          *logrus.Logger
+	CartRepositoryInterface         mysql.CartRepositoryInterface
+	ShippingRepositoryInterface     mysql.ShippingRepositoryInterface
+	ProductRepositoryInterface      mysql.ProductRepositoryInterface
+	SettingRepositoryInterface      mysql.SettingRepositoryInterface
+	PromotionServiceInterface       PromotionServiceInterface
+	FlashSaleServiceInterface       FlashSaleServiceInterface
+	PurchaseLimitServiceInterface   PurchaseLimitServiceInterface
+	SearchAnalyticsServiceInterface SearchAnalyticsServiceInterface
 }
 
 func NewCartService(
@@ -45,31 +49,46 @@ func NewCartService(
 	cartRepositoryInterface mysql.CartRepositoryInterface,
 	shippingRepositoryInterface mysql.ShippingRepositoryInterface,
 	productRepositoryInterface mysql.ProductRepositoryInterface,
-	settingRepositoryInterface mysql.SettingRepositoryInterface) CartServiceInterface {
+	settingRepositoryInterface mysql.SettingRepositoryInterface,
+	promotionServiceInterface PromotionServiceInterface,
+	flashSaleServiceInterface FlashSaleServiceInterface,
+	purchaseLimitServiceInterface PurchaseLimitServiceInterface,
+	searchAnalyticsServiceInterface SearchAnalyticsServiceInterface) CartServiceInterface {
 	return &CartServiceImplementation{
-		ConfigWebserver:             configWebserver,
-		DB:                          DB,
-		Validate:                    validate,
-		Logger:                      logger,
-		CartRepositoryInterface:     cartRepositoryInterface,
-		ShippingRepositoryInterface: shippingRepositoryInterface,
-		ProductRepositoryInterface:  productRepositoryInterface,
-		SettingRepositoryInterface:  settingRepositoryInterface,
+		ConfigWebserver:                 configWebserver,
+		DB:                              DB,
+		Validate:                        validate,
+		Logger:                          logger,
+		CartRepositoryInterface:         cartRepositoryInterface,
+		ShippingRepositoryInterface:     shippingRepositoryInterface,
+		ProductRepositoryInterface:      productRepositoryInterface,
+		SettingRepositoryInterface:      settingRepositoryInterface,
+		PromotionServiceInterface:       promotionServiceInterface,
+		FlashSaleServiceInterface:       flashSaleServiceInterface,
+		PurchaseLimitServiceInterface:   purchaseLimitServiceInterface,
+		SearchAnalyticsServiceInterface: searchAnalyticsServiceInterface,
 	}
 }
 
 func (service *CartServiceImplementation) FindCartByIdUser(requestId string, IdUser string, IdKelurahan int) (addProductToCartResponse response.FindCartByIdUserResponse) {
 	carts, _ := service.CartRepositoryInterface.FindCartByIdUser(service.DB, IdUser)
+	carts = service.FlashSaleServiceInterface.ApplyFlashSales(requestId, IdUser, carts, time.Now())
 	shippingCost, err := service.SettingRepositoryInterface.FindSettingShippingCost(service.DB)
 
 	exceptions.PanicIfError(err, requestId, service.Logger)
-	addProductToCartResponse = response.ToFindCartByIdUserResponse(carts, shippingCost.Value)
+	cartPromotions := service.PromotionServiceInterface.EvaluateCartPromotions(requestId, carts, time.Now())
+	addProductToCartResponse = response.ToFindCartByIdUserResponse(carts, cartPromotions, shippingCost.Value)
+
+	// Pelanggaran batas pembelian ditampilkan agar user bisa mengubah keranjang sebelum checkout
+	addProductToCartResponse.MinOrderValue = service.PurchaseLimitServiceInterface.FindMinOrderValue(requestId)
+	addProductToCartResponse.Violations = append(addProductToCartResponse.Violations, service.PurchaseLimitServiceInterface.EvaluatePurchaseLimits(requestId, IdUser, carts, time.Now())...)
 	return addProductToCartResponse
 }
 
 func (service *CartServiceImplementation) CartPlusQtyProduct(requestId string, updateQtyProductInCartRequest *request.UpdateQtyProductInCartRequest) (updateProductQtyInCartResponse response.UpdateProductQtyInCartResponse) {
 	request.ValidateUpdateQtyProductInCartRequest(service.Validate, updateQtyProductInCartRequest, requestId, service.Logger)
 	cartProductExist, _ := service.CartRepositoryInterface.FindCartById(service.DB, updateQtyProductInCartRequest.IdCart)
+	service.checkCartQty(requestId, cartProductExist, cartProductExist.Qty+1)
 	cartEntity := &entity.Cart{}
 	cartEntity.Id = updateQtyProductInCartRequest.IdCart
 	cartEntity.Qty = cartProductExist.Qty + 1
@@ -118,6 +137,7 @@ func (service *CartServiceImplementation) UpdateQtyProductInCart(requestId strin
 		updateProductQtyInCartResponse = response.ToUpdateProductQtyInCartResponse(entity.Cart{Id: cartProductExist.Id})
 		return updateProductQtyInCartResponse
 	} else {
+		service.checkCartQty(requestId, cartProductExist, updateQtyProductInCartRequest.Qty)
 		cartEntity := &entity.Cart{}
 		cartEntity.Id = updateQtyProductInCartRequest.IdCart
 		cartEntity.Qty = updateQtyProductInCartRequest.Qty
@@ -133,24 +153,51 @@ func (service *CartServiceImplementation) AddProductToCart(requestId string, IdU
 	request.ValidateAddProductToCartRequest(service.Validate, addProductToCartRequest, requestId, service.Logger)
 
 	// Cek apakah produk yang dimasukkan sudah ada di keranjang
-	cartProductExist, _ := service.CartRepositoryInterface.FindProductInCartByIdUser(service.DB, IdUser, addProductToCartRequest.IdProduct)
+	cartProductExist, _ := service.CartRepositoryInterface.FindProductInCartByIdUser(service.DB, IdUser, addProductToCartRequest.IdProduct, addProductToCartRequest.IdProductVariant)
 
-	// Cek Prduct Stock
+	// Produk dengan varian wajib memilih salah satu varian aktif
 	product, _ := service.ProductRepositoryInterface.FindProductById(service.DB, addProductToCartRequest.IdProduct)
-	if product.Stock < 1 {
+	productVariant := entity.ProductVariant{}
+	for _, variant := range product.ProductVariants {
+		if variant.Id == addProductToCartRequest.IdProductVariant {
+			productVariant = variant
+		}
+	}
+	if len(product.ProductVariants) > 0 && productVariant.Id == "" {
+		exceptions.PanicIfBadRequest(errors.New("variant required"), requestId, []string{"Mohon pilih varian produk"}, service.Logger)
+	}
+	if len(product.ProductVariants) == 0 && addProductToCartRequest.IdProductVariant != "" {
+		exceptions.PanicIfBadRequest(errors.New("variant not found"), requestId, []string{"Varian produk tidak ditemukan"}, service.Logger)
+	}
+
+	// Cek Prduct Stock
+	stock := product.Stock
+	if productVariant.Id != "" {
+		stock = productVariant.Stock
+	}
+	if stock < 1 {
 		exceptions.PanicIfBadRequest(errors.New("stock kosong"), requestId, []string{"Mohon maaf stock sedang kosong"}, service.Logger)
 	}
 
+	// Produk baru masuk keranjang dengan qty 1
+	cartQty := 1
+	if cartProductExist.Id != "" {
+		cartQty = cartProductExist.Qty + addProductToCartRequest.Qty
+	}
+	service.PurchaseLimitServiceInterface.CheckCartQty(requestId, IdUser, product, cartProductExist.Id, cartQty)
+
 	// Produk belum pernah dimasukkan
 	if cartProductExist.Id == "" {
 		cartEntity := &entity.Cart{}
 		cartEntity.Id = utilities.RandomUUID()
 		cartEntity.IdUser = IdUser
 		cartEntity.IdProduct = addProductToCartRequest.IdProduct
+		cartEntity.IdProductVariant = productVariant.Id
 		cartEntity.Qty = cartEntity.Qty + 1
 		cartEntity.CreatedAt = time.Now()
 		cart, err := service.CartRepositoryInterface.AddProductToCart(service.DB, *cartEntity)
 		exceptions.PanicIfError(err, requestId, service.Logger)
+		service.SearchAnalyticsServiceInterface.RecordSearchAddToCart(IdUser, cart.IdProduct)
 		addProductToCartResponse = response.ToAddProductToCartResponse(cart)
 		return addProductToCartResponse
 	} else {
@@ -161,8 +208,19 @@ func (service *CartServiceImplementation) AddProductToCart(requestId string, IdU
 
 		cart, err := service.CartRepositoryInterface.UpdateProductInCart(service.DB, cartProductExist.Id, *cartEntity)
 		exceptions.PanicIfError(err, requestId, service.Logger)
+		service.SearchAnalyticsServiceInterface.RecordSearchAddToCart(IdUser, cartProductExist.IdProduct)
 
 		addProductToCartResponse = response.ToAddProductToCartResponse(cart)
 		return addProductToCartResponse
 	}
 }
+
+// Batas pembelian hanya dicek saat qty bertambah agar user tetap bisa mengurangi qty
+func (service *CartServiceImplementation) checkCartQty(requestId string, cart entity.Cart, qty int) {
+	if cart.Id == "" || qty <= cart.Qty {
+		return
+	}
+	product, err := service.ProductRepositoryInterface.FindProductById(service.DB, cart.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	service.PurchaseLimitServiceInterface.CheckCartQty(requestId, cart.IdUser, product, cart.Id, qty)
+}
@@ -0,0 +1,441 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+type FamilyServiceInterface interface {
+	FindFamily(requestId string, idUser string) (familyResponse response.FindFamilyResponse)
+	InviteFamilyMember(requestId string, idUser string, createFamilyInvitationRequest *request.CreateFamilyInvitationRequest) error
+	FindFamilyInvitation(requestId string, idUser string) (familyInvitationResponses []response.FindFamilyInvitationResponse)
+	AcceptFamilyInvitation(requestId string, idUser string, respondFamilyInvitationRequest *request.RespondFamilyInvitationRequest) error
+	RejectFamilyInvitation(requestId string, idUser string, respondFamilyInvitationRequest *request.RespondFamilyInvitationRequest) error
+	UpdateFamilySharedWallet(requestId string, idUser string, updateFamilySharedWalletRequest *request.UpdateFamilySharedWalletRequest) error
+	TransferFamilyPoint(requestId string, idUser string, familyPointTransferRequest *request.FamilyPointTransferRequest) (familyPointTransferResponse response.FamilyPointTransferResponse)
+}
+
+type FamilyServiceImplementation struct {
+	ConfigurationWebserver                 config.Webserver
+	DB                                     *gorm.DB
+	Validate                               *validator.Validate
+	Logger                                 *logrus.Logger
+	UserRepositoryInterface                mysql.UserRepositoryInterface
+	FamilyRepositoryInterface              mysql.FamilyRepositoryInterface
+	FamilyMembersRepositoryInterface       mysql.FamilyMembersRepositoryInterface
+	FamilyInvitationRepositoryInterface    mysql.FamilyInvitationRepositoryInterface
+	FamilyPointTransferRepositoryInterface mysql.FamilyPointTransferRepositoryInterface
+	BalancePointRepositoryInterface        mysql.BalancePointRepositoryInterface
+	BalancePointTxRepositoryInterface      mysql.BalancePointTxRepositoryInterface
+	SettingRepositoryInterface             mysql.SettingRepositoryInterface
+}
+
+func NewFamilyService(
+	configurationWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	userRepositoryInterface mysql.UserRepositoryInterface,
+	familyRepositoryInterface mysql.FamilyRepositoryInterface,
+	familyMembersRepositoryInterface mysql.FamilyMembersRepositoryInterface,
+	familyInvitationRepositoryInterface mysql.FamilyInvitationRepositoryInterface,
+	familyPointTransferRepositoryInterface mysql.FamilyPointTransferRepositoryInterface,
+	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface,
+	balancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface,
+	settingRepositoryInterface mysql.SettingRepositoryInterface) FamilyServiceInterface {
+	return &FamilyServiceImplementation{
+		ConfigurationWebserver:                 configurationWebserver,
+		DB:                                     DB,
+		Validate:                               validate,
+		Logger:                                 logger,
+		UserRepositoryInterface:                userRepositoryInterface,
+		FamilyRepositoryInterface:              familyRepositoryInterface,
+		FamilyMembersRepositoryInterface:       familyMembersRepositoryInterface,
+		FamilyInvitationRepositoryInterface:    familyInvitationRepositoryInterface,
+		FamilyPointTransferRepositoryInterface: familyPointTransferRepositoryInterface,
+		BalancePointRepositoryInterface:        balancePointRepositoryInterface,
+		BalancePointTxRepositoryInterface:      balancePointTxRepositoryInterface,
+		SettingRepositoryInterface:             settingRepositoryInterface,
+	}
+}
+
+func (service *FamilyServiceImplementation) FindFamily(requestId string, idUser string) (familyResponse response.FindFamilyResponse) {
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User tidak ditemukan"}, service.Logger)
+	}
+
+	family, _ := service.FamilyRepositoryInterface.FindFamilyById(service.DB, user.FamilyMembers.IdFamily)
+	if family.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("family not found"), requestId, []string{"Keluarga tidak ditemukan"}, service.Logger)
+	}
+
+	users, err := service.UserRepositoryInterface.FindUsersByIdFamily(service.DB, family.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUser(service.DB, idUser)
+
+	familyResponse = response.ToFindFamilyResponse(family, users, balancePoint)
+	return familyResponse
+}
+
+func (service *FamilyServiceImplementation) InviteFamilyMember(requestId string, idUser string, createFamilyInvitationRequest *request.CreateFamilyInvitationRequest) error {
+	request.ValidateCreateFamilyInvitationRequest(service.Validate, createFamilyInvitationRequest, requestId, service.Logger)
+
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User tidak ditemukan"}, service.Logger)
+	}
+
+	// Undangan bisa memakai email atau nomor hp
+	var invitee entity.User
+	credential := strings.TrimSpace(createFamilyInvitationRequest.Credential)
+	if strings.Contains(credential, "@") {
+		invitee, _ = service.UserRepositoryInterface.FindUserByEmail(service.DB, strings.ToLower(credential))
+	} else {
+		phone := strings.Replace(credential, "-", "", -1)
+		phoneFinal := strings.Replace(phone, "+62", "0", -1)
+		invitee, _ = service.UserRepositoryInterface.FindUserByPhone(service.DB, phoneFinal)
+	}
+
+	if invitee.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User yang diundang tidak ditemukan"}, service.Logger)
+	}
+
+	if invitee.Id == user.Id || invitee.FamilyMembers.IdFamily == user.FamilyMembers.IdFamily {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("already family member"), requestId, []string{"User sudah menjadi anggota keluarga"}, service.Logger)
+	}
+
+	family, _ := service.FamilyRepositoryInterface.FindFamilyById(service.DB, user.FamilyMembers.IdFamily)
+	if family.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("family not found"), requestId, []string{"Keluarga tidak ditemukan"}, service.Logger)
+	}
+
+	if family.IdUserHead != "" && family.IdUserHead != user.Id {
+		exceptions.PanicIfUnauthorized(errors.New("not family head"), requestId, []string{"Hanya kepala keluarga yang dapat mengundang anggota"}, service.Logger)
+	}
+
+	service.CheckFamilyMaxMembers(requestId, family.Id)
+
+	checkInvitation, _ := service.FamilyInvitationRepositoryInterface.FindPendingFamilyInvitation(service.DB, family.Id, invitee.Id)
+	if checkInvitation.Id != "" {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("invitation already exist"), requestId, []string{"Undangan sudah dikirim"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+
+	// User yang pertama mengundang menjadi kepala keluarga
+	if family.IdUserHead == "" {
+		err := service.FamilyRepositoryInterface.UpdateFamilyHead(tx, family.Id, user.Id)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update family error"}, service.Logger, tx)
+	}
+
+	familyInvitationEntity := &entity.FamilyInvitation{}
+	familyInvitationEntity.Id = utilities.RandomUUID()
+	familyInvitationEntity.IdFamily = family.Id
+	familyInvitationEntity.IdUserInviter = user.Id
+	familyInvitationEntity.IdUserInvitee = invitee.Id
+	familyInvitationEntity.Status = "pending"
+	familyInvitationEntity.CreatedDate = time.Now()
+
+	_, err := service.FamilyInvitationRepositoryInterface.CreateFamilyInvitation(tx, *familyInvitationEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create family invitation error"}, service.Logger, tx)
+
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	go utilities.SendPushNotification(invitee.TokenDevice, &modelService.NotificationData{Title: "Undangan Keluarga", Body: user.FamilyMembers.FullName + " mengundang Anda untuk bergabung ke keluarga"})
+
+	return nil
+}
+
+func (service *FamilyServiceImplementation) FindFamilyInvitation(requestId string, idUser string) (familyInvitationResponses []response.FindFamilyInvitationResponse) {
+	familyInvitations, err := service.FamilyInvitationRepositoryInterface.FindPendingFamilyInvitationByIdUserInvitee(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	familyInvitationResponses = response.ToFindFamilyInvitationResponse(familyInvitations)
+	return familyInvitationResponses
+}
+
+func (service *FamilyServiceImplementation) AcceptFamilyInvitation(requestId string, idUser string, respondFamilyInvitationRequest *request.RespondFamilyInvitationRequest) error {
+	request.ValidateRespondFamilyInvitationRequest(service.Validate, respondFamilyInvitationRequest, requestId, service.Logger)
+
+	familyInvitation := service.FindPendingFamilyInvitation(requestId, idUser, respondFamilyInvitationRequest.IdInvitation)
+
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User tidak ditemukan"}, service.Logger)
+	}
+
+	// User yang masih punya anggota keluarga lain tidak bisa pindah keluarga
+	currentMembers, _ := service.UserRepositoryInterface.FindUsersByIdFamily(service.DB, user.FamilyMembers.IdFamily)
+	if len(currentMembers) > 1 {
+		exceptions.PanicIfBadRequest(errors.New("already in family"), requestId, []string{"Anda sudah tergabung dalam keluarga lain"}, service.Logger)
+	}
+
+	family, _ := service.FamilyRepositoryInterface.FindFamilyById(service.DB, familyInvitation.IdFamily)
+	if family.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("family not found"), requestId, []string{"Keluarga tidak ditemukan"}, service.Logger)
+	}
+
+	service.CheckFamilyMaxMembers(requestId, family.Id)
+
+	tx := service.DB.Begin()
+
+	err := service.FamilyMembersRepositoryInterface.UpdateFamilyMembersIdFamily(tx, user.IdFamilyMembers, family.Id)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update family members error"}, service.Logger, tx)
+
+	familyInvitationEntity := &entity.FamilyInvitation{}
+	familyInvitationEntity.Status = "accepted"
+	familyInvitationEntity.RespondedAt = null.NewTime(time.Now(), true)
+
+	err = service.FamilyInvitationRepositoryInterface.UpdateFamilyInvitationStatus(tx, familyInvitation.Id, *familyInvitationEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update family invitation error"}, service.Logger, tx)
+
+	// Jika dompet keluarga aktif, point anggota baru dipindah ke kepala keluarga
+	if family.SharedWallet == 1 && family.IdUserHead != "" {
+		service.MergeBalancePoint(tx, requestId, user, family.IdUserHead)
+	}
+
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	inviter, _ := service.UserRepositoryInterface.FindUserById(service.DB, familyInvitation.IdUserInviter)
+	go utilities.SendPushNotification(inviter.TokenDevice, &modelService.NotificationData{Title: "Undangan Keluarga Diterima", Body: user.FamilyMembers.FullName + " sudah bergabung ke keluarga Anda"})
+
+	return nil
+}
+
+func (service *FamilyServiceImplementation) RejectFamilyInvitation(requestId string, idUser string, respondFamilyInvitationRequest *request.RespondFamilyInvitationRequest) error {
+	request.ValidateRespondFamilyInvitationRequest(service.Validate, respondFamilyInvitationRequest, requestId, service.Logger)
+
+	familyInvitation := service.FindPendingFamilyInvitation(requestId, idUser, respondFamilyInvitationRequest.IdInvitation)
+
+	familyInvitationEntity := &entity.FamilyInvitation{}
+	familyInvitationEntity.Status = "rejected"
+	familyInvitationEntity.RespondedAt = null.NewTime(time.Now(), true)
+
+	err := service.FamilyInvitationRepositoryInterface.UpdateFamilyInvitationStatus(service.DB, familyInvitation.Id, *familyInvitationEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	return nil
+}
+
+func (service *FamilyServiceImplementation) UpdateFamilySharedWallet(requestId string, idUser string, updateFamilySharedWalletRequest *request.UpdateFamilySharedWalletRequest) error {
+	request.ValidateUpdateFamilySharedWalletRequest(service.Validate, updateFamilySharedWalletRequest, requestId, service.Logger)
+
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User tidak ditemukan"}, service.Logger)
+	}
+
+	family, _ := service.FamilyRepositoryInterface.FindFamilyById(service.DB, user.FamilyMembers.IdFamily)
+	if family.IdUserHead != user.Id {
+		exceptions.PanicIfUnauthorized(errors.New("not family head"), requestId, []string{"Hanya kepala keluarga yang dapat mengubah dompet keluarga"}, service.Logger)
+	}
+
+	if family.SharedWallet == updateFamilySharedWalletRequest.SharedWallet {
+		return nil
+	}
+
+	users, err := service.UserRepositoryInterface.FindUsersByIdFamily(service.DB, family.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	tx := service.DB.Begin()
+
+	err = service.FamilyRepositoryInterface.UpdateFamilySharedWallet(tx, family.Id, updateFamilySharedWalletRequest.SharedWallet)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update family error"}, service.Logger, tx)
+
+	// Point seluruh anggota dipindah ke kepala keluarga, saat dinonaktifkan point tetap di kepala keluarga
+	if updateFamilySharedWalletRequest.SharedWallet == 1 {
+		for _, member := range users {
+			if member.Id != family.IdUserHead {
+				service.MergeBalancePoint(tx, requestId, member, family.IdUserHead)
+			}
+		}
+	}
+
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	body := "Dompet keluarga dinonaktifkan"
+	if updateFamilySharedWalletRequest.SharedWallet == 1 {
+		body = "Dompet keluarga diaktifkan, point anggota digabung ke kepala keluarga"
+	}
+	for _, member := range users {
+		go utilities.SendPushNotification(member.TokenDevice, &modelService.NotificationData{Title: "Dompet Keluarga", Body: body})
+	}
+
+	return nil
+}
+
+func (service *FamilyServiceImplementation) TransferFamilyPoint(requestId string, idUser string, familyPointTransferRequest *request.FamilyPointTransferRequest) (familyPointTransferResponse response.FamilyPointTransferResponse) {
+	request.ValidateFamilyPointTransferRequest(service.Validate, familyPointTransferRequest, requestId, service.Logger)
+
+	if familyPointTransferRequest.IdUserTo == idUser {
+		exceptions.PanicIfBadRequest(errors.New("transfer to self"), requestId, []string{"Tidak bisa transfer ke diri sendiri"}, service.Logger)
+	}
+
+	userFrom, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	userTo, _ := service.UserRepositoryInterface.FindUserById(service.DB, familyPointTransferRequest.IdUserTo)
+	if userFrom.Id == "" || userTo.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"User tidak ditemukan"}, service.Logger)
+	}
+
+	if userFrom.FamilyMembers.IdFamily != userTo.FamilyMembers.IdFamily {
+		exceptions.PanicIfBadRequest(errors.New("not family member"), requestId, []string{"Transfer hanya bisa ke anggota keluarga"}, service.Logger)
+	}
+
+	family, _ := service.FamilyRepositoryInterface.FindFamilyById(service.DB, userFrom.FamilyMembers.IdFamily)
+	if family.SharedWallet == 1 {
+		exceptions.PanicIfBadRequest(errors.New("shared wallet active"), requestId, []string{"Transfer tidak tersedia saat dompet keluarga aktif"}, service.Logger)
+	}
+
+	// Batas nominal per transaksi dan per hari, 0 berarti tanpa batas
+	maxNominal := service.FindFamilySettingValue("family_transfer_max_nominal")
+	if maxNominal > 0 && familyPointTransferRequest.Nominal > maxNominal {
+		exceptions.PanicIfBadRequest(errors.New("max nominal exceeded"), requestId, []string{"Nominal melebihi batas transfer"}, service.Logger)
+	}
+
+	dailyLimit := service.FindFamilySettingValue("family_transfer_daily_limit")
+
+	tx := service.DB.Begin()
+
+	// Saldo dan batas harian dicek setelah baris balance point terkunci agar transfer bersamaan tidak melebihi saldo
+	balancePoints := service.LockBalancePoints(tx, requestId, userFrom.Id, userTo.Id)
+	balancePointFrom, balancePointTo := balancePoints[userFrom.Id], balancePoints[userTo.Id]
+	if balancePointFrom.BalancePoints < familyPointTransferRequest.Nominal {
+		tx.Rollback()
+		exceptions.PanicIfBadRequest(errors.New("point not enough"), requestId, []string{"point not enough"}, service.Logger)
+	}
+	if dailyLimit > 0 {
+		now := time.Now()
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		totalToday, err := service.FamilyPointTransferRepositoryInterface.SumFamilyPointTransferByIdUserFrom(tx, idUser, startOfDay)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"sum family point transfer error"}, service.Logger, tx)
+		if totalToday+familyPointTransferRequest.Nominal > dailyLimit {
+			tx.Rollback()
+			exceptions.PanicIfBadRequest(errors.New("daily limit exceeded"), requestId, []string{"Transfer melebihi batas harian"}, service.Logger)
+		}
+	}
+
+	familyPointTransferEntity := &entity.FamilyPointTransfer{}
+	familyPointTransferEntity.Id = utilities.RandomUUID()
+	familyPointTransferEntity.IdFamily = family.Id
+	familyPointTransferEntity.IdUserFrom = userFrom.Id
+	familyPointTransferEntity.IdUserTo = userTo.Id
+	familyPointTransferEntity.Nominal = familyPointTransferRequest.Nominal
+	familyPointTransferEntity.Note = familyPointTransferRequest.Note
+	familyPointTransferEntity.CreatedDate = time.Now()
+
+	familyPointTransfer, err := service.FamilyPointTransferRepositoryInterface.CreateFamilyPointTransfer(tx, *familyPointTransferEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create family point transfer error"}, service.Logger, tx)
+
+	newBalanceFrom := service.MoveBalancePoint(tx, requestId, balancePointFrom, "credit", familyPointTransferRequest.Nominal, "Transfer Point ke "+userTo.FamilyMembers.FullName)
+	service.MoveBalancePoint(tx, requestId, balancePointTo, "debit", familyPointTransferRequest.Nominal, "Transfer Point dari "+userFrom.FamilyMembers.FullName)
+
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	nominal := formatPoint(familyPointTransferRequest.Nominal)
+	go utilities.SendPushNotification(userFrom.TokenDevice, &modelService.NotificationData{Title: "Transfer Point Berhasil", Body: nominal + " point terkirim ke " + userTo.FamilyMembers.FullName})
+	go utilities.SendPushNotification(userTo.TokenDevice, &modelService.NotificationData{Title: "Point Diterima", Body: "Anda menerima " + nominal + " point dari " + userFrom.FamilyMembers.FullName})
+
+	familyPointTransferResponse = response.ToFamilyPointTransferResponse(familyPointTransfer, newBalanceFrom)
+	return familyPointTransferResponse
+}
+
+func (service *FamilyServiceImplementation) FindPendingFamilyInvitation(requestId string, idUser string, idInvitation string) entity.FamilyInvitation {
+	familyInvitation, _ := service.FamilyInvitationRepositoryInterface.FindFamilyInvitationById(service.DB, idInvitation)
+	if familyInvitation.Id == "" || familyInvitation.IdUserInvitee != idUser || familyInvitation.Status != "pending" {
+		exceptions.PanicIfRecordNotFound(errors.New("invitation not found"), requestId, []string{"Undangan tidak ditemukan"}, service.Logger)
+	}
+	return familyInvitation
+}
+
+func (service *FamilyServiceImplementation) CheckFamilyMaxMembers(requestId string, idFamily string) {
+	maxMembers := service.FindFamilySettingValue("family_max_members")
+	if maxMembers <= 0 {
+		return
+	}
+
+	users, err := service.UserRepositoryInterface.FindUsersByIdFamily(service.DB, idFamily)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if float64(len(users)) >= maxMembers {
+		exceptions.PanicIfBadRequest(errors.New("max members exceeded"), requestId, []string{"Jumlah anggota keluarga sudah maksimal"}, service.Logger)
+	}
+}
+
+func (service *FamilyServiceImplementation) FindFamilySettingValue(settingName string) float64 {
+	settings, _ := service.SettingRepositoryInterface.FindSettingsByName(service.DB, settingName)
+	return settings.Value
+}
+
+// Pindahkan seluruh point user ke balance point kepala keluarga
+func (service *FamilyServiceImplementation) MergeBalancePoint(tx *gorm.DB, requestId string, user entity.User, idUserHead string) {
+	balancePoints := service.LockBalancePoints(tx, requestId, user.Id, idUserHead)
+	balancePoint, balancePointHead := balancePoints[user.Id], balancePoints[idUserHead]
+	if balancePoint.BalancePoints <= 0 {
+		return
+	}
+
+	nominal := balancePoint.BalancePoints
+	service.MoveBalancePoint(tx, requestId, balancePoint, "credit", nominal, "Gabung Dompet Keluarga")
+	service.MoveBalancePoint(tx, requestId, balancePointHead, "debit", nominal, "Gabung Dompet Keluarga dari "+user.FamilyMembers.FullName)
+}
+
+// Balance point dibaca ulang dalam transaksi dengan baris terkunci, dikembalikan per id user
+func (service *FamilyServiceImplementation) LockBalancePoints(tx *gorm.DB, requestId string, idUsers ...string) map[string]entity.BalancePoint {
+	balancePoints, err := service.BalancePointRepositoryInterface.FindBalancePointsByIdUsersForUpdate(tx, idUsers)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"find balance point error"}, service.Logger, tx)
+
+	balancePointByIdUser := make(map[string]entity.BalancePoint, len(balancePoints))
+	for _, balancePoint := range balancePoints {
+		balancePointByIdUser[balancePoint.IdUser] = balancePoint
+	}
+	return balancePointByIdUser
+}
+
+// Update balance point dan catat ke point history, mengembalikan saldo baru
+func (service *FamilyServiceImplementation) MoveBalancePoint(tx *gorm.DB, requestId string, balancePoint entity.BalancePoint, txType string, nominal float64, description string) float64 {
+	newBalance := balancePoint.BalancePoints + nominal
+	if txType == "credit" {
+		newBalance = balancePoint.BalancePoints - nominal
+	}
+
+	balancePointEntity := &entity.BalancePoint{}
+	balancePointEntity.BalancePoints = newBalance
+
+	_, errUpdateBalancePoint := service.BalancePointRepositoryInterface.UpdateBalancePoint(tx, balancePoint.IdUser, *balancePointEntity)
+	exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
+
+	// Add to point history
+	balancePointTxEntity := &entity.BalancePointTx{}
+	balancePointTxEntity.Id = utilities.RandomUUID()
+	balancePointTxEntity.IdBalancePoint = balancePoint.Id
+	balancePointTxEntity.TxType = txType
+	balancePointTxEntity.TxDate = time.Now()
+	balancePointTxEntity.TxNominal = nominal
+	balancePointTxEntity.LastPointBalance = balancePoint.BalancePoints
+	balancePointTxEntity.NewPointBalance = newBalance
+	balancePointTxEntity.CreatedDate = time.Now()
+	balancePointTxEntity.Description = description
+
+	_, errCreateBalancePointTx := service.BalancePointTxRepositoryInterface.CreateBalancePointTx(tx, *balancePointTxEntity)
+	exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
+
+	return newBalance
+}
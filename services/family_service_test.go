@@ -0,0 +1,68 @@
+package services
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	DB, err := gorm.Open(gormMysql.New(gormMysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return DB, mock
+}
+
+func TestMergeBalancePointEmptiesMemberBalance(t *testing.T) {
+	DB, mock := newMockDB(t)
+	service := &FamilyServiceImplementation{
+		DB:                                DB,
+		Logger:                            logrus.New(),
+		BalancePointRepositoryInterface:   mysql.NewBalancePointRepository(nil),
+		BalancePointTxRepositoryInterface: mysql.NewBalancePointTxRepository(nil),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `balance_point` WHERE balance_point.id_user IN (?,?) ORDER BY balance_point.id_user asc FOR UPDATE")).
+		WithArgs("member", "head").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "id_user", "balance_points"}).
+			AddRow("bp-head", "head", 1000).
+			AddRow("bp-member", "member", 2500))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `balance_point` SET `balance_points`=? WHERE id_user = ?")).
+		WithArgs(float64(0), "member").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `balance_point_transcation`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `balance_point` SET `balance_points`=? WHERE id_user = ?")).
+		WithArgs(float64(3500), "head").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `balance_point_transcation`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx := DB.Begin()
+	service.MergeBalancePoint(tx, "request", entity.User{Id: "member"}, "head")
+	if err := tx.Commit().Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
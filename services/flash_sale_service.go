@@ -0,0 +1,315 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// Status pembelian flash sale
+const (
+	flashSaleUsageUsed     = "used"
+	flashSaleUsageReleased = "released"
+)
+
+type FlashSaleServiceInterface interface {
+	FindFlashSales(requestId string) (flashSaleListResponse response.FlashSaleListResponse)
+	FindAdminFlashSales(requestId string, flashSaleListRequest *request.FlashSaleListRequest) (flashSaleListResponse response.FindFlashSaleListResponse)
+	FindFlashSaleById(requestId string, flashSaleIdRequest *request.FlashSaleIdRequest) (flashSaleResponse response.FlashSaleResponse)
+	CreateFlashSale(requestId string, flashSaleCreateRequest *request.FlashSaleCreateRequest) (flashSaleResponse response.FlashSaleResponse)
+	DeactivateFlashSale(requestId string, flashSaleIdRequest *request.FlashSaleIdRequest) (flashSaleResponse response.FlashSaleResponse)
+	ApplyFlashSales(requestId string, idUser string, cartItems []entity.Cart, now time.Time) []entity.Cart
+	RedeemFlashSale(tx *gorm.DB, requestId string, order entity.Order, orderItems []entity.OrderItem)
+	ReleaseFlashSale(tx *gorm.DB, requestId string, order entity.Order)
+}
+
+type FlashSaleServiceImplementation struct {
+	ConfigWebserver                   config.Webserver
+	DB                                *gorm.DB
+	Validate                          *validator.Validate
+	Logger                            *logrus.Logger
+	FlashSaleRepositoryInterface      mysql.FlashSaleRepositoryInterface
+	FlashSaleUsageRepositoryInterface mysql.FlashSaleUsageRepositoryInterface
+	ProductRepositoryInterface        mysql.ProductRepositoryInterface
+}
+
+func NewFlashSaleService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	flashSaleRepositoryInterface mysql.FlashSaleRepositoryInterface,
+	flashSaleUsageRepositoryInterface mysql.FlashSaleUsageRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface) FlashSaleServiceInterface {
+	return &FlashSaleServiceImplementation{
+		ConfigWebserver:                   configWebserver,
+		DB:                                DB,
+		Validate:                          validate,
+		Logger:                            logger,
+		FlashSaleRepositoryInterface:      flashSaleRepositoryInterface,
+		FlashSaleUsageRepositoryInterface: flashSaleUsageRepositoryInterface,
+		ProductRepositoryInterface:        productRepositoryInterface,
+	}
+}
+
+// Flash sale yang sedang berjalan dan yang akan datang beserta waktu server untuk hitung mundur
+func (service *FlashSaleServiceImplementation) FindFlashSales(requestId string) (flashSaleListResponse response.FlashSaleListResponse) {
+	now := time.Now()
+	flashSales, err := service.FlashSaleRepositoryInterface.FindUpcomingFlashSales(service.DB, now)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	flashSaleListResponse = response.ToFlashSaleListResponse(flashSales, now)
+	return flashSaleListResponse
+}
+
+func (service *FlashSaleServiceImplementation) FindAdminFlashSales(requestId string, flashSaleListRequest *request.FlashSaleListRequest) (flashSaleListResponse response.FindFlashSaleListResponse) {
+	request.ValidateFlashSaleRequest(service.Validate, flashSaleListRequest, requestId, service.Logger)
+
+	pagination := modelService.Pagination{Page: flashSaleListRequest.Page, Limit: flashSaleListRequest.Limit}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = 20
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+
+	flashSales, totalData, err := service.FlashSaleRepositoryInterface.FindFlashSales(service.DB, pagination.Limit, pagination.Offset)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	flashSaleListResponse = response.ToFindFlashSaleListResponse(flashSales, totalData, time.Now())
+	return flashSaleListResponse
+}
+
+func (service *FlashSaleServiceImplementation) FindFlashSaleById(requestId string, flashSaleIdRequest *request.FlashSaleIdRequest) (flashSaleResponse response.FlashSaleResponse) {
+	request.ValidateFlashSaleRequest(service.Validate, flashSaleIdRequest, requestId, service.Logger)
+	flashSale := service.findFlashSale(requestId, flashSaleIdRequest.Id)
+	flashSaleResponse = response.ToFlashSaleResponse(flashSale, time.Now())
+	return flashSaleResponse
+}
+
+// Satu produk atau varian hanya boleh ada di satu flash sale aktif pada waktu yang sama
+func (service *FlashSaleServiceImplementation) CreateFlashSale(requestId string, flashSaleCreateRequest *request.FlashSaleCreateRequest) (flashSaleResponse response.FlashSaleResponse) {
+	request.ValidateFlashSaleRequest(service.Validate, flashSaleCreateRequest, requestId, service.Logger)
+
+	startAt, err := time.ParseInLocation("2006-01-02 15:04:05", flashSaleCreateRequest.StartAt, time.Local)
+	exceptions.PanicIfBadRequest(err, requestId, []string{"start_at format must be YYYY-MM-DD HH:MM:SS"}, service.Logger)
+	endAt, err := time.ParseInLocation("2006-01-02 15:04:05", flashSaleCreateRequest.EndAt, time.Local)
+	exceptions.PanicIfBadRequest(err, requestId, []string{"end_at format must be YYYY-MM-DD HH:MM:SS"}, service.Logger)
+	if !endAt.After(startAt) {
+		exceptions.PanicIfBadRequest(errors.New("invalid period"), requestId, []string{"end_at must be after start_at"}, service.Logger)
+	}
+	if !endAt.After(time.Now()) {
+		exceptions.PanicIfBadRequest(errors.New("invalid period"), requestId, []string{"end_at must be in the future"}, service.Logger)
+	}
+
+	flashSaleEntity := &entity.FlashSale{}
+	flashSaleEntity.Id = utilities.RandomUUID()
+	flashSaleEntity.FlashSaleName = flashSaleCreateRequest.FlashSaleName
+	flashSaleEntity.StartAt = startAt
+	flashSaleEntity.EndAt = endAt
+	flashSaleEntity.IsActive = 1
+	flashSaleEntity.CreatedAt = time.Now()
+
+	var idProducts []string
+	var flashSaleItems []entity.FlashSaleItem
+	itemKeys := make(map[string]bool)
+	for _, flashSaleItemRequest := range flashSaleCreateRequest.Items {
+		itemKey := flashSaleItemRequest.IdProduct + "|" + flashSaleItemRequest.IdProductVariant
+		if itemKeys[itemKey] {
+			exceptions.PanicIfBadRequest(errors.New("duplicate item"), requestId, []string{"product " + flashSaleItemRequest.IdProduct + " is listed more than once"}, service.Logger)
+		}
+		itemKeys[itemKey] = true
+
+		product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, flashSaleItemRequest.IdProduct)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if product.Id == "" {
+			exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product " + flashSaleItemRequest.IdProduct + " not found"}, service.Logger)
+		}
+
+		// Produk bervarian didaftarkan per varian karena harga tiap varian berbeda
+		price := product.Price
+		if len(product.ProductVariants) > 0 {
+			var productVariant entity.ProductVariant
+			for _, variant := range product.ProductVariants {
+				if variant.Id == flashSaleItemRequest.IdProductVariant {
+					productVariant = variant
+				}
+			}
+			if productVariant.Id == "" {
+				exceptions.PanicIfBadRequest(errors.New("variant required"), requestId, []string{"id_product_variant of product " + product.ProductName + " is required"}, service.Logger)
+			}
+			price = productVariant.Price
+		} else if flashSaleItemRequest.IdProductVariant != "" {
+			exceptions.PanicIfBadRequest(errors.New("product has no variant"), requestId, []string{"product " + product.ProductName + " has no variant"}, service.Logger)
+		}
+		if flashSaleItemRequest.SalePrice >= price {
+			exceptions.PanicIfBadRequest(errors.New("invalid sale price"), requestId, []string{"sale_price of " + product.ProductName + " must be lower than " + strconv.FormatFloat(price, 'f', 0, 64)}, service.Logger)
+		}
+
+		idProducts = append(idProducts, product.Id)
+		flashSaleItems = append(flashSaleItems, entity.FlashSaleItem{
+			Id:               utilities.RandomUUID(),
+			IdFlashSale:      flashSaleEntity.Id,
+			IdProduct:        product.Id,
+			IdProductVariant: flashSaleItemRequest.IdProductVariant,
+			SalePrice:        flashSaleItemRequest.SalePrice,
+			Quota:            flashSaleItemRequest.Quota,
+			LimitPerUser:     flashSaleItemRequest.LimitPerUser,
+			CreatedAt:        flashSaleEntity.CreatedAt,
+		})
+	}
+
+	overlappingItems, err := service.FlashSaleRepositoryInterface.FindOverlappingFlashSaleItems(service.DB, idProducts, startAt, endAt)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	for _, overlappingItem := range overlappingItems {
+		if itemKeys[overlappingItem.IdProduct+"|"+overlappingItem.IdProductVariant] {
+			exceptions.PanicIfRecordAlreadyExists(errors.New("flash sale overlap"), requestId, []string{"product " + overlappingItem.Product.ProductName + " is already in flash sale " + overlappingItem.FlashSale.FlashSaleName}, service.Logger)
+		}
+	}
+
+	tx := service.DB.Begin()
+	_, errCreateFlashSale := service.FlashSaleRepositoryInterface.CreateFlashSale(tx, *flashSaleEntity)
+	exceptions.PanicIfErrorWithRollback(errCreateFlashSale, requestId, []string{"create flash sale error"}, service.Logger, tx)
+	errCreateItems := service.FlashSaleRepositoryInterface.CreateFlashSaleItems(tx, flashSaleItems)
+	exceptions.PanicIfErrorWithRollback(errCreateItems, requestId, []string{"create flash sale item error"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	flashSale := service.findFlashSale(requestId, flashSaleEntity.Id)
+	flashSaleResponse = response.ToFlashSaleResponse(flashSale, time.Now())
+	return flashSaleResponse
+}
+
+// Flash sale yang dinonaktifkan langsung berhenti, pembelian yang sudah terjadi tetap tercatat
+func (service *FlashSaleServiceImplementation) DeactivateFlashSale(requestId string, flashSaleIdRequest *request.FlashSaleIdRequest) (flashSaleResponse response.FlashSaleResponse) {
+	request.ValidateFlashSaleRequest(service.Validate, flashSaleIdRequest, requestId, service.Logger)
+	flashSale := service.findFlashSale(requestId, flashSaleIdRequest.Id)
+
+	flashSaleEntity := &entity.FlashSale{}
+	flashSaleEntity.IsActive = 0
+	_, err := service.FlashSaleRepositoryInterface.UpdateFlashSaleStatus(service.DB, flashSale.Id, *flashSaleEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	flashSale.IsActive = 0
+	flashSaleResponse = response.ToFlashSaleResponse(flashSale, time.Now())
+	return flashSaleResponse
+}
+
+// Menandai item keranjang yang mendapat harga flash sale. Harga flash sale hanya berlaku jika
+// seluruh qty masih masuk sisa kuota dan batas pembelian user, selebihnya memakai harga normal
+func (service *FlashSaleServiceImplementation) ApplyFlashSales(requestId string, idUser string, cartItems []entity.Cart, now time.Time) []entity.Cart {
+	if len(cartItems) == 0 {
+		return cartItems
+	}
+
+	var idProducts []string
+	for _, cartItem := range cartItems {
+		idProducts = append(idProducts, cartItem.IdProduct)
+	}
+	flashSaleItems, err := service.FlashSaleRepositoryInterface.FindActiveFlashSaleItems(service.DB, idProducts, now)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	for i, cartItem := range cartItems {
+		for _, flashSaleItem := range flashSaleItems {
+			if flashSaleItem.IdProduct != cartItem.IdProduct || flashSaleItem.IdProductVariant != cartItem.IdProductVariant {
+				continue
+			}
+			if flashSaleItem.SoldQty+cartItem.Qty > flashSaleItem.Quota {
+				continue
+			}
+			if flashSaleItem.LimitPerUser > 0 {
+				usedQty, err := service.FlashSaleUsageRepositoryInterface.SumFlashSaleUsageQtyByIdUser(service.DB, flashSaleItem.Id, idUser)
+				exceptions.PanicIfError(err, requestId, service.Logger)
+				if usedQty+cartItem.Qty > flashSaleItem.LimitPerUser {
+					continue
+				}
+			}
+			flashSaleItem := flashSaleItem
+			cartItems[i].FlashSaleItem = &flashSaleItem
+			break
+		}
+	}
+	return cartItems
+}
+
+// Kuota flash sale dipakai di dalam transaksi order, ikut dibatalkan jika transaksi order gagal
+func (service *FlashSaleServiceImplementation) RedeemFlashSale(tx *gorm.DB, requestId string, order entity.Order, orderItems []entity.OrderItem) {
+	var flashSaleUsages []entity.FlashSaleUsage
+	for _, orderItem := range orderItems {
+		if orderItem.IdFlashSaleItem == "" {
+			continue
+		}
+
+		rowsAffected, errIncreaseSold := service.FlashSaleRepositoryInterface.IncreaseFlashSaleSoldQty(tx, orderItem.IdFlashSaleItem, orderItem.Qty)
+		exceptions.PanicIfErrorWithRollback(errIncreaseSold, requestId, []string{"update flash sale error"}, service.Logger, tx)
+		if rowsAffected == 0 {
+			exceptions.PanicIfErrorWithRollback(errors.New("flash sale quota exceeded"), requestId, []string{"Kuota flash sale " + orderItem.ProductName + " sudah habis"}, service.Logger, tx)
+		}
+
+		// Dihitung setelah baris item flash sale terkunci agar order bersamaan dari user yang sama tetap terhitung
+		flashSaleItem, errFindItem := service.FlashSaleRepositoryInterface.FindFlashSaleItemById(tx, orderItem.IdFlashSaleItem)
+		exceptions.PanicIfErrorWithRollback(errFindItem, requestId, []string{"flash sale item not found"}, service.Logger, tx)
+		if flashSaleItem.LimitPerUser > 0 {
+			usedQty, errSumUsage := service.FlashSaleUsageRepositoryInterface.SumFlashSaleUsageQtyByIdUser(tx, orderItem.IdFlashSaleItem, order.IdUser)
+			exceptions.PanicIfErrorWithRollback(errSumUsage, requestId, []string{"count flash sale usage error"}, service.Logger, tx)
+			if usedQty+orderItem.Qty > flashSaleItem.LimitPerUser {
+				exceptions.PanicIfErrorWithRollback(errors.New("flash sale user limit exceeded"), requestId, []string{"Maksimal pembelian flash sale " + orderItem.ProductName + " adalah " + strconv.Itoa(flashSaleItem.LimitPerUser)}, service.Logger, tx)
+			}
+		}
+
+		flashSaleUsages = append(flashSaleUsages, entity.FlashSaleUsage{
+			Id:              utilities.RandomUUID(),
+			IdFlashSaleItem: orderItem.IdFlashSaleItem,
+			IdUser:          order.IdUser,
+			IdOrder:         order.Id,
+			NumberOrder:     order.NumberOrder,
+			Qty:             orderItem.Qty,
+			Status:          flashSaleUsageUsed,
+			UsedAt:          time.Now(),
+		})
+	}
+	if len(flashSaleUsages) == 0 {
+		return
+	}
+
+	errCreateUsage := service.FlashSaleUsageRepositoryInterface.CreateFlashSaleUsages(tx, flashSaleUsages)
+	exceptions.PanicIfErrorWithRollback(errCreateUsage, requestId, []string{"create flash sale usage error"}, service.Logger, tx)
+}
+
+// Mengembalikan kuota flash sale dari order yang dibatalkan atau kedaluwarsa
+func (service *FlashSaleServiceImplementation) ReleaseFlashSale(tx *gorm.DB, requestId string, order entity.Order) {
+	flashSaleUsages, errFindUsage := service.FlashSaleUsageRepositoryInterface.FindFlashSaleUsagesByIdOrder(tx, order.Id)
+	exceptions.PanicIfErrorWithRollback(errFindUsage, requestId, []string{"flash sale usage not found"}, service.Logger, tx)
+
+	for _, flashSaleUsage := range flashSaleUsages {
+		flashSaleUsageEntity := &entity.FlashSaleUsage{}
+		flashSaleUsageEntity.Status = flashSaleUsageReleased
+		flashSaleUsageEntity.ReleasedAt = null.NewTime(time.Now(), true)
+
+		_, errUpdateUsage := service.FlashSaleUsageRepositoryInterface.UpdateFlashSaleUsageStatus(tx, flashSaleUsage.Id, *flashSaleUsageEntity)
+		exceptions.PanicIfErrorWithRollback(errUpdateUsage, requestId, []string{"update flash sale usage error"}, service.Logger, tx)
+
+		errDecreaseSold := service.FlashSaleRepositoryInterface.DecreaseFlashSaleSoldQty(tx, flashSaleUsage.IdFlashSaleItem, flashSaleUsage.Qty)
+		exceptions.PanicIfErrorWithRollback(errDecreaseSold, requestId, []string{"update flash sale error"}, service.Logger, tx)
+	}
+}
+
+func (service *FlashSaleServiceImplementation) findFlashSale(requestId string, id string) (flashSale entity.FlashSale) {
+	flashSale, err := service.FlashSaleRepositoryInterface.FindFlashSaleById(service.DB, id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if flashSale.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("flash sale not found"), requestId, []string{"flash sale not found"}, service.Logger)
+	}
+	return flashSale
+}
@@ -16,6 +16,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
 	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
 	"gopkg.in/guregu/null.v4"
@@ -44,26 +46,33 @@ type OrderServiceInterface interface {
 }
 
 type OrderServiceImplementation struct {
-	ConfigurationWebserver                 config.Webserver
-	DB                                     *gorm.DB
-	ConfigJwt                              config.Jwt
-	Validate                               *validator.Validate
-	Logger                                 *logrus.Logger
-	ConfigPayment                          config.Payment
-	ConfigTelegram                         config.Telegram
-	OrderRepositoryInterface               mysql.OrderRepositoryInterface
-	CartRepositoryInterface                mysql.CartRepositoryInterface
-	UserRepositoryInterface                mysql.UserRepositoryInterface
-	OrderItemRepositoryInterface           mysql.OrderItemRepositoryInterface
-	PaymentLogRepositoryInterface          mysql.PaymentLogRepositoryInterface
-	BankTransferRepositoryInterface        mysql.BankTransferRepositoryInterface
-	BankVaRepositoryInterface              mysql.BankVaRepositoryInterface
-	ProductRepositoryInterface             mysql.ProductRepositoryInterface
-	ProductStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface
-	BalancePointRepositoryInterface        mysql.BalancePointRepositoryInterface
-	BalancePointTxRepositoryInterface      mysql.BalancePointTxRepositoryInterface
-	UserLevelRepositoryInterface           mysql.UserLevelMemberRepositoryInterface
-	SettingRepositoryInterface             mysql.SettingRepositoryInterface
+	ConfigurationWebserver            config.Webserver
+	DB                                *gorm.DB
+	ConfigJwt                         config.Jwt
+	Validate                          *validator.Validate
+	Logger                            *logrus.Logger
+	ConfigPayment                     config.Payment
+	ConfigTelegram                    config.Telegram
+	OrderRepositoryInterface          mysql.OrderRepositoryInterface
+	CartRepositoryInterface           mysql.CartRepositoryInterface
+	UserRepositoryInterface           mysql.UserRepositoryInterface
+	OrderItemRepositoryInterface      mysql.OrderItemRepositoryInterface
+	PaymentLogRepositoryInterface     mysql.PaymentLogRepositoryInterface
+	BankTransferRepositoryInterface   mysql.BankTransferRepositoryInterface
+	BankVaRepositoryInterface         mysql.BankVaRepositoryInterface
+	BalancePointRepositoryInterface   mysql.BalancePointRepositoryInterface
+	BalancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface
+	UserLevelRepositoryInterface      mysql.UserLevelMemberRepositoryInterface
+	SettingRepositoryInterface        mysql.SettingRepositoryInterface
+	ReferalFraudServiceInterface      ReferalFraudServiceInterface
+	PointRedemptionServiceInterface   PointRedemptionServiceInterface
+	ProductSearchServiceInterface     ProductSearchServiceInterface
+	ProductStockServiceInterface      ProductStockServiceInterface
+	VoucherServiceInterface           VoucherServiceInterface
+	PromotionServiceInterface         PromotionServiceInterface
+	StockMonitorServiceInterface      StockMonitorServiceInterface
+	FlashSaleServiceInterface         FlashSaleServiceInterface
+	PurchaseLimitServiceInterface     PurchaseLimitServiceInterface
 }
 
 func NewOrderService(
@@ -81,33 +90,47 @@ func NewOrderService(
 	paymentLogRepositoryInterface mysql.PaymentLogRepositoryInterface,
 	bankTransferRepositoryInterface mysql.BankTransferRepositoryInterface,
 	bankVaRepositoryInterface mysql.BankVaRepositoryInterface,
-	productRepositoryInterface mysql.ProductRepositoryInterface,
-	productStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface,
 	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface,
 	balancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface,
 	userLevelMemberRepositoryInterface mysql.UserLevelMemberRepositoryInterface,
-	settingRepositoryInterface mysql.SettingRepositoryInterface) OrderServiceInterface {
+	settingRepositoryInterface mysql.SettingRepositoryInterface,
+	referalFraudServiceInterface ReferalFraudServiceInterface,
+	pointRedemptionServiceInterface PointRedemptionServiceInterface,
+	productSearchServiceInterface ProductSearchServiceInterface,
+	productStockServiceInterface ProductStockServiceInterface,
+	voucherServiceInterface VoucherServiceInterface,
+	promotionServiceInterface PromotionServiceInterface,
+	stockMonitorServiceInterface StockMonitorServiceInterface,
+	flashSaleServiceInterface FlashSaleServiceInterface,
+	purchaseLimitServiceInterface PurchaseLimitServiceInterface) OrderServiceInterface {
 	return &OrderServiceImplementation{
-		ConfigurationWebserver:                 configurationWebserver,
-		DB:                                     DB,
-		ConfigJwt:                              configJwt,
-		Validate:                               validate,
-		Logger:                                 logger,
-		ConfigPayment:                          configPayment,
-		ConfigTelegram:                         configTelegram,
-		OrderRepositoryInterface:               orderRepositoryInterface,
-		CartRepositoryInterface:                cartRepositoryInterface,
-		UserRepositoryInterface:                userRepositoryInterface,
-		OrderItemRepositoryInterface:           orderItemRepositoryInterface,
-		PaymentLogRepositoryInterface:          paymentLogRepositoryInterface,
-		BankTransferRepositoryInterface:        bankTransferRepositoryInterface,
-		BankVaRepositoryInterface:              bankVaRepositoryInterface,
-		ProductRepositoryInterface:             productRepositoryInterface,
-		ProductStockHistoryRepositoryInterface: productStockHistoryRepositoryInterface,
-		BalancePointRepositoryInterface:        balancePointRepositoryInterface,
-		BalancePointTxRepositoryInterface:      balancePointTxRepositoryInterface,
-		UserLevelRepositoryInterface:           userLevelMemberRepositoryInterface,
-		SettingRepositoryInterface:             settingRepositoryInterface,
+		ConfigurationWebserver:            configurationWebserver,
+		DB:                                DB,
+		ConfigJwt:                         configJwt,
+		Validate:                          validate,
+		Logger:                            logger,
+		ConfigPayment:                     configPayment,
+		ConfigTelegram:                    configTelegram,
+		OrderRepositoryInterface:          orderRepositoryInterface,
+		CartRepositoryInterface:           cartRepositoryInterface,
+		UserRepositoryInterface:           userRepositoryInterface,
+		OrderItemRepositoryInterface:      orderItemRepositoryInterface,
+		PaymentLogRepositoryInterface:     paymentLogRepositoryInterface,
+		BankTransferRepositoryInterface:   bankTransferRepositoryInterface,
+		BankVaRepositoryInterface:         bankVaRepositoryInterface,
+		BalancePointRepositoryInterface:   balancePointRepositoryInterface,
+		BalancePointTxRepositoryInterface: balancePointTxRepositoryInterface,
+		UserLevelRepositoryInterface:      userLevelMemberRepositoryInterface,
+		SettingRepositoryInterface:        settingRepositoryInterface,
+		ReferalFraudServiceInterface:      referalFraudServiceInterface,
+		PointRedemptionServiceInterface:   pointRedemptionServiceInterface,
+		ProductSearchServiceInterface:     productSearchServiceInterface,
+		ProductStockServiceInterface:      productStockServiceInterface,
+		VoucherServiceInterface:           voucherServiceInterface,
+		PromotionServiceInterface:         promotionServiceInterface,
+		StockMonitorServiceInterface:      stockMonitorServiceInterface,
+		FlashSaleServiceInterface:         flashSaleServiceInterface,
+		PurchaseLimitServiceInterface:     purchaseLimitServiceInterface,
 	}
 }
 
@@ -191,7 +214,8 @@ func (service *OrderServiceImplementation) CompleteOrderById(requestId string, i
 
 			// Bonus pribadi dari perbelanjaan
 			// Get bonus point from order order
-			balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, order.IdUser)
+			balancePoint, errFindBalancePoint := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, order.IdUser)
+			exceptions.PanicIfErrorWithRollback(errFindBalancePoint, requestId, []string{"find balance point error"}, service.Logger, tx)
 
 			// Add to point history
 			balancePointTxEntity := &entity.BalancePointTx{}
@@ -218,9 +242,26 @@ func (service *OrderServiceImplementation) CompleteOrderById(requestId string, i
 			exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
 
 			// bonus point untuk referal
-			if user.RegistrationReferalCode != "" {
-				userReferal, _ := service.UserRepositoryInterface.FindUserByReferalCode(service.DB, user.RegistrationReferalCode)
-				balancePointReferal, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, userReferal.Id)
+			// kode promo campaign registrasi tidak dimiliki user, jadi tidak ada bonus referal
+			userReferal, _ := service.UserRepositoryInterface.FindUserByReferalCode(service.DB, user.RegistrationReferalCode)
+			holdBonus := false
+			if user.RegistrationReferalCode != "" && userReferal.Id != "" {
+				// Bonus referal ditahan sampai direview admin jika terindikasi fraud
+				fraudScore, fraudReasons := service.ReferalFraudServiceInterface.ScoreOrder(order, user, userReferal)
+				if service.ReferalFraudServiceInterface.IsReferalSuspicious(fraudScore) {
+					holdBonus = true
+					referalBonusReviewEntity := &entity.ReferalBonusReview{}
+					referalBonusReviewEntity.IdUser = user.Id
+					referalBonusReviewEntity.IdUserReferal = userReferal.Id
+					referalBonusReviewEntity.Event = "order"
+					referalBonusReviewEntity.NoOrder = order.NumberOrder
+					referalBonusReviewEntity.BonusReferal = bonusPoint
+					service.ReferalFraudServiceInterface.HoldReferalBonus(tx, requestId, *referalBonusReviewEntity, fraudScore, fraudReasons)
+				}
+			}
+			if user.RegistrationReferalCode != "" && userReferal.Id != "" && !holdBonus {
+				balancePointReferal, errFindBalancePointReferal := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, userReferal.Id)
+				exceptions.PanicIfErrorWithRollback(errFindBalancePointReferal, requestId, []string{"find balance point error"}, service.Logger, tx)
 
 				// Add to point history
 				balancePointTxEntityReferal := &entity.BalancePointTx{}
@@ -264,8 +305,9 @@ func (service *OrderServiceImplementation) CancelOrderById(requestId string, idO
 		tx := service.DB.Begin()
 
 		if order.PaymentByPoint != 0 {
-			// get data balance point
-			balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, order.IdUser)
+			// get data balance point, dikunci agar pengembalian tidak menimpa saldo yang berubah bersamaan
+			balancePoint, errFindBalancePoint := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, order.IdUser)
+			exceptions.PanicIfErrorWithRollback(errFindBalancePoint, requestId, []string{"find balance point error"}, service.Logger, tx)
 
 			balancePointEntity := &entity.BalancePoint{}
 			balancePointEntity.BalancePoints = balancePoint.BalancePoints + order.PaymentByPoint
@@ -290,6 +332,10 @@ func (service *OrderServiceImplementation) CancelOrderById(requestId string, idO
 			exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
 		}
 
+		// Kuota voucher dan flash sale dikembalikan
+		service.VoucherServiceInterface.ReleaseVoucher(tx, requestId, order)
+		service.FlashSaleServiceInterface.ReleaseFlashSale(tx, requestId, order)
+
 		orderEntity := &entity.Order{}
 		orderEntity.OrderSatus = "Dibatalkan"
 		orderEntity.CanceledAt = null.NewTime(time.Now(), true)
@@ -398,32 +444,17 @@ func (service *OrderServiceImplementation) UpdateStatusOrder(requestId string, p
 				//update product stock
 				orderItems, _ := service.OrderItemRepositoryInterface.FindOrderItemsByIdOrder(service.DB, order.Id)
 				for _, orderItem := range orderItems {
-					productEntity := &entity.Product{}
-					productEntityStockHistory := &entity.ProductStockHistory{}
-					product, errFindProduct := service.ProductRepositoryInterface.FindProductById(tx, orderItem.IdProduct)
-					exceptions.PanicIfErrorWithRollback(errFindProduct, requestId, []string{"product not found"}, service.Logger, tx)
-
-					productEntityStockHistory.IdProduct = orderItem.IdProduct
-					productEntityStockHistory.TxDate = time.Now()
-					productEntityStockHistory.StockOpname = product.Stock
-					productEntityStockHistory.StockOutQty = orderItem.Qty
-					productEntityStockHistory.StockFinal = product.Stock - orderItem.Qty
-					productEntityStockHistory.Description = "Pembelian " + order.NumberOrder
-					productEntityStockHistory.CreatedAt = time.Now()
-					_, errAddProductStockHistory := service.ProductStockHistoryRepositoryInterface.AddProductStockHistory(tx, *productEntityStockHistory)
-					exceptions.PanicIfErrorWithRollback(errAddProductStockHistory, requestId, []string{"add stock history error"}, service.Logger, tx)
-
-					productEntity.Stock = product.Stock - orderItem.Qty
-					_, errUpdateProductStock := service.ProductRepositoryInterface.UpdateProductStock(tx, orderItem.IdProduct, *productEntity)
-					exceptions.PanicIfErrorWithRollback(errUpdateProductStock, requestId, []string{"update stock error"}, service.Logger, tx)
+					service.ProductStockServiceInterface.DecreaseOrderItemStock(tx, requestId, orderItem, "Pembelian "+order.NumberOrder)
 				}
 
 				commit := tx.Commit()
 				exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+				service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
 
 				runtime.GOMAXPROCS(1)
 				// Send notif telegram
 				go service.SendTelegram(order.NumberOrder, "Pembayaran Sukses (VA/QRIS)")
+				go service.StockMonitorServiceInterface.CheckProductStocks(orderItemIdProducts(orderItems))
 
 				// Send push notification
 				user, _ := service.UserRepositoryInterface.FindUserById(service.DB, order.IdUser)
@@ -457,6 +488,10 @@ func (service *OrderServiceImplementation) UpdateStatusOrder(requestId string, p
 				_, errCreateLog := service.PaymentLogRepositoryInterface.CreatePaymentLog(tx, *paymentLogEntity)
 				exceptions.PanicIfErrorWithRollback(errCreateLog, requestId, []string{"Error create log"}, service.Logger, tx)
 
+				// Kuota voucher dan flash sale dikembalikan
+				service.VoucherServiceInterface.ReleaseVoucher(tx, requestId, order)
+				service.FlashSaleServiceInterface.ReleaseFlashSale(tx, requestId, order)
+
 				commit := tx.Commit()
 				exceptions.PanicIfError(commit.Error, requestId, service.Logger)
 				orderResponse = response.ToUpdateOrderStatusResponse(orderResult)
@@ -507,9 +542,46 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 	// Get data user
 	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
 
+	// Get data cart
+	cartItems, _ := service.CartRepositoryInterface.FindCartByIdUser(service.DB, idUser)
+	if len(cartItems) == 0 {
+		// error jika tidak ada item di cart
+		exceptions.PanicIfRecordNotFound(errors.New("data not found"), requestId, []string{"Keranjang Kosong"}, service.Logger)
+	}
+
+	// Harga flash sale, voucher dan point dihitung dengan harga pada waktu order dibuat
+	orderedAt := time.Now()
+	cartItems = service.FlashSaleServiceInterface.ApplyFlashSales(requestId, idUser, cartItems, orderedAt)
+
+	// Cek batas pembelian per produk dan minimal belanja
+	purchaseLimitViolations := service.PurchaseLimitServiceInterface.EvaluatePurchaseLimits(requestId, idUser, cartItems, orderedAt)
+	if len(purchaseLimitViolations) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("purchase limit exceeded"), requestId, purchaseLimitViolations, service.Logger)
+	}
+
+	// Cek voucher
+	var voucherRedemption modelService.VoucherRedemption
+	if orderRequest.VoucherCode != "" {
+		voucherRedemption = service.VoucherServiceInterface.EvaluateVoucher(requestId, user, cartItems, orderRequest.VoucherCode, orderRequest.ShippingCost, orderedAt)
+		if len(voucherRedemption.Violations) > 0 {
+			exceptions.PanicIfBadRequest(errors.New("cant use voucher"), requestId, voucherRedemption.Violations, service.Logger)
+		}
+	}
+
+	// Cek rule pemakaian point
+	if orderRequest.PaymentByPoint > 0 {
+		pointRedemption := service.PointRedemptionServiceInterface.EvaluatePointRedemption(requestId, user, cartItems, orderRequest.PaymentByPoint, orderRequest.TotalBill)
+		if len(pointRedemption.Violations) > 0 {
+			exceptions.PanicIfBadRequest(errors.New("cant use point"), requestId, pointRedemption.Violations, service.Logger)
+		}
+	}
+
 	tx := service.DB.Begin()
 	exceptions.PanicIfError(tx.Error, requestId, service.Logger)
 
+	// Batas per user dicek ulang dengan lock agar dua order bersamaan tidak sama-sama lolos
+	service.PurchaseLimitServiceInterface.CheckUserPurchaseLimits(tx, requestId, idUser, cartItems, orderedAt)
+
 	// Create Order
 	orderEntity := &entity.Order{}
 	orderEntity.Id = utilities.RandomUUID()
@@ -521,7 +593,7 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 	orderEntity.Phone = user.FamilyMembers.Phone
 	orderEntity.CourierNote = orderRequest.CourierNote
 	orderEntity.OrderSatus = "Menunggu Pembayaran"
-	orderEntity.OrderedAt = time.Now()
+	orderEntity.OrderedAt = orderedAt
 	orderEntity.PaymentMethod = orderRequest.PaymentMethod
 	orderEntity.PaymentChannel = orderRequest.PaymentChannel
 	orderEntity.PaymentStatus = "Belum Dibayar"
@@ -532,11 +604,19 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 	}
 	orderEntity.ShippingCost = orderRequest.ShippingCost
 	orderEntity.ShippingStatus = "Menunggu"
+	orderEntity.IdVoucher = voucherRedemption.IdVoucher
+	orderEntity.VoucherCode = voucherRedemption.VoucherCode
+	orderEntity.VoucherDiscount = voucherRedemption.Discount
 
 	// Jika berbelanja menggunakan point
 	if orderRequest.PaymentByPoint > 0 {
-		// get data balance point
-		balancePoint, _ := service.BalancePointRepositoryInterface.FindBalancePointByIdUser(service.DB, orderEntity.IdUser)
+		// Wallet dibaca dengan baris terkunci agar saldo tidak terpakai dua kali oleh order bersamaan
+		balancePoint, errFindBalancePoint := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, orderEntity.IdUser)
+		exceptions.PanicIfErrorWithRollback(errFindBalancePoint, requestId, []string{"find balance point error"}, service.Logger, tx)
+		if balancePoint.BalancePoints < orderEntity.PaymentByPoint {
+			tx.Rollback()
+			exceptions.PanicIfBadRequest(errors.New("point not enough"), requestId, []string{"point not enough"}, service.Logger)
+		}
 
 		// update balance point
 		balancePointEntity := &entity.BalancePoint{}
@@ -561,13 +641,6 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 		exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
 	}
 
-	// Get data cart
-	cartItems, _ := service.CartRepositoryInterface.FindCartByIdUser(service.DB, idUser)
-	if len(cartItems) == 0 {
-		// error jika tidak ada item di cart
-		exceptions.PanicIfRecordNotFound(errors.New("data not found"), requestId, []string{"Keranjang Kosong"}, service.Logger)
-	}
-
 	// Create order items
 	var totalPriceProduct float64
 	var orderItems []entity.OrderItem
@@ -588,16 +661,28 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 		orderItemEntity.Weight = cartItem.Product.Weight
 		orderItemEntity.Volume = cartItem.Product.Volume
 		orderItemEntity.Qty = cartItem.Qty
-		orderItemEntity.FlagPromo = cartItem.Product.ProductDiscount.FlagPromo
 		orderItemEntity.Thumbnail = cartItem.Product.Thumbnail
-		orderItemEntity.PriceBeforeDiscount = cartItem.Product.Price
-		orderItemEntity.PriceAfterDiscount = cartItem.Product.ProductDiscount.Nominal
-		if cartItem.Product.ProductDiscount.FlagPromo == "true" {
-			orderItemEntity.Price = cartItem.Product.ProductDiscount.Nominal
-			totalPriceProduct = cartItem.Product.ProductDiscount.Nominal
-		} else {
-			orderItemEntity.Price = cartItem.Product.Price
-			totalPriceProduct = cartItem.Product.Price
+
+		// Harga mengikuti promo atau flash sale yang berlaku saat order dibuat
+		productPricing := pricing.EvaluateCartItemPricing(cartItem, orderEntity.OrderedAt)
+		orderItemEntity.IdFlashSaleItem = productPricing.IdFlashSaleItem
+		orderItemEntity.FlagPromo = strconv.FormatBool(productPricing.OnPromo)
+		orderItemEntity.PriceBeforeDiscount = productPricing.PriceBeforeDiscount
+		orderItemEntity.PriceAfterDiscount = productPricing.Price
+		orderItemEntity.Price = productPricing.Price
+		totalPriceProduct = orderItemEntity.Price
+
+		// Data varian menggantikan data produk pada item order
+		if cartItem.ProductVariant.Id != "" {
+			orderItemEntity.IdProductVariant = cartItem.ProductVariant.Id
+			orderItemEntity.VariantName = cartItem.ProductVariant.VariantName
+			orderItemEntity.NoSku = cartItem.ProductVariant.NoSku
+			orderItemEntity.Weight = cartItem.ProductVariant.Weight
+			orderItemEntity.Volume = cartItem.ProductVariant.Volume
+			if cartItem.ProductVariant.PictureUrl != "" {
+				orderItemEntity.PictureUrl = cartItem.ProductVariant.PictureUrl
+				orderItemEntity.Thumbnail = cartItem.ProductVariant.Thumbnail
+			}
 		}
 
 		orderItemEntity.TotalPrice = totalPriceProduct * (float64(cartItem.Qty))
@@ -612,6 +697,31 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 		}
 	}
 
+	// Potongan promo keranjang dicatat sebagai item order tersendiri
+	cartPromotions := service.PromotionServiceInterface.EvaluateCartPromotions(requestId, cartItems, orderEntity.OrderedAt)
+	for _, cartPromotion := range cartPromotions {
+		orderItemEntity := &entity.OrderItem{}
+		orderItemEntity.Id = utilities.RandomUUID()
+		orderItemEntity.IdOrder = orderEntity.Id
+		orderItemEntity.IdPromotion = cartPromotion.IdPromotion
+		orderItemEntity.ProductName = cartPromotion.PromotionName
+		orderItemEntity.Description = cartPromotion.PromotionType
+		orderItemEntity.FlagPromo = "true"
+		orderItemEntity.Qty = 1
+		orderItemEntity.Price = cartPromotion.Discount * (-1)
+		orderItemEntity.TotalPrice = orderItemEntity.Price
+		orderItemEntity.CreatedAt = time.Now()
+		totalPrice = totalPrice + orderItemEntity.TotalPrice
+		orderItems = append(orderItems, *orderItemEntity)
+		if orderRequest.PaymentMethod == "cc" {
+			product = append(product, orderItemEntity.ProductName)
+			qty = append(qty, orderItemEntity.Qty)
+			price = append(price, orderItemEntity.Price)
+		}
+	}
+
+	// Total tagihan sudah dipotong voucher
+	totalPrice = totalPrice - orderEntity.VoucherDiscount
 	orderEntity.TotalBill = totalPrice + orderRequest.ShippingCost
 
 	fmt.Println("Total Bill = ", totalPrice+orderRequest.ShippingCost-orderRequest.PaymentByPoint)
@@ -633,6 +743,14 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 	errCreateOrderItem := service.OrderItemRepositoryInterface.CreateOrderItems(tx, orderItems)
 	exceptions.PanicIfErrorWithRollback(errCreateOrderItem, requestId, []string{"Error create order"}, service.Logger, tx)
 
+	// Catat pemakaian voucher, ikut dibatalkan jika transaksi order gagal
+	if orderEntity.IdVoucher != "" {
+		service.VoucherServiceInterface.RedeemVoucher(tx, requestId, *orderEntity)
+	}
+
+	// Kuota flash sale dipakai dalam transaksi yang sama sehingga tidak terjual melebihi kuota
+	service.FlashSaleServiceInterface.RedeemFlashSale(tx, requestId, *orderEntity, orderItems)
+
 	// Pilih metode pembayaran
 	switch orderRequest.PaymentMethod {
 	// Credit Card
@@ -644,6 +762,11 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 		product = append(product, "Shipping Cost", "Payment Fee", "Payment Point")
 		qty = append(qty, 1, 1, 1)
 		price = append(price, orderRequest.ShippingCost, orderRequest.PaymentFee, paymentPointForCC)
+		if orderEntity.VoucherDiscount > 0 {
+			product = append(product, "Voucher "+orderEntity.VoucherCode)
+			qty = append(qty, 1)
+			price = append(price, orderEntity.VoucherDiscount*(-1))
+		}
 
 		url, _ := url.Parse(string(service.ConfigPayment.IpaymuSnapUrl))
 
@@ -927,27 +1050,9 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 		order, errUpdateOrderPayment := service.OrderRepositoryInterface.CreateOrder(tx, *orderEntity)
 		exceptions.PanicIfErrorWithRollback(errUpdateOrderPayment, requestId, []string{"Error update order"}, service.Logger, tx)
 
-		//update product stock
-		orderItems, _ := service.OrderItemRepositoryInterface.FindOrderItemsByIdOrder(service.DB, order.Id)
+		// Update product stock, item order belum di-commit sehingga dipakai dari slice yang baru dibuat
 		for _, orderItem := range orderItems {
-			product, errFindProduct := service.ProductRepositoryInterface.FindProductById(tx, orderItem.IdProduct)
-			exceptions.PanicIfErrorWithRollback(errFindProduct, requestId, []string{"product not found"}, service.Logger, tx)
-
-			productEntity := &entity.Product{}
-			productEntity.Stock = product.Stock - orderItem.Qty
-			_, errUpdateProductStock := service.ProductRepositoryInterface.UpdateProductStock(tx, orderItem.IdProduct, *productEntity)
-			exceptions.PanicIfErrorWithRollback(errUpdateProductStock, requestId, []string{"update stock error"}, service.Logger, tx)
-
-			productEntityStockHistory := &entity.ProductStockHistory{}
-			productEntityStockHistory.IdProduct = orderItem.IdProduct
-			productEntityStockHistory.TxDate = time.Now()
-			productEntityStockHistory.StockOpname = product.Stock
-			productEntityStockHistory.StockOutQty = orderItem.Qty
-			productEntityStockHistory.StockFinal = product.Stock - orderItem.Qty
-			productEntityStockHistory.Description = "Pembelian " + order.NumberOrder
-			productEntityStockHistory.CreatedAt = time.Now()
-			_, errAddProductStockHistory := service.ProductStockHistoryRepositoryInterface.AddProductStockHistory(tx, *productEntityStockHistory)
-			exceptions.PanicIfErrorWithRollback(errAddProductStockHistory, requestId, []string{"add stock history error"}, service.Logger, tx)
+			service.ProductStockServiceInterface.DecreaseOrderItemStock(tx, requestId, orderItem, "Pembelian "+order.NumberOrder)
 		}
 
 		// delete data item in cart
@@ -956,9 +1061,11 @@ func (service *OrderServiceImplementation) CreateOrder(requestId string, idUser
 
 		commit := tx.Commit()
 		exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+		service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
 
 		runtime.GOMAXPROCS(1)
 		go service.SendTelegram(order.NumberOrder, "Ada Orderan Masuk (Point)")
+		go service.StockMonitorServiceInterface.CheckProductStocks(orderItemIdProducts(orderItems))
 
 		orderResponse = response.ToCreateOrderFullPointResponse(order)
 		return orderResponse
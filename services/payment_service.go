@@ -33,16 +33,17 @@ type PaymentServiceInterface interface {
 }
 
 type PaymentServiceImplementation struct {
-	ConfigWebserver                        config.Webserver
-	DB                                     *gorm.DB
-	Validate                               *validator.Validate
-	Logger                                 *logrus.Logger
-	ConfigPayment                          config.Payment
-	OrderRepositoryInterface               mysql.OrderRepositoryInterface
-	OrderItemRepositoryInterface           mysql.OrderItemRepositoryInterface
-	ProductRepositoryInterface             mysql.ProductRepositoryInterface
-	ProductStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface
-	PaymentLogRepositoryInterface          mysql.PaymentLogRepositoryInterface
+	ConfigWebserver               config.Webserver
+	DB                            *gorm.DB
+	Validate                      *validator.Validate
+	Logger                        *logrus.Logger
+	ConfigPayment                 config.Payment
+	OrderRepositoryInterface      mysql.OrderRepositoryInterface
+	OrderItemRepositoryInterface  mysql.OrderItemRepositoryInterface
+	PaymentLogRepositoryInterface mysql.PaymentLogRepositoryInterface
+	ProductSearchServiceInterface ProductSearchServiceInterface
+	ProductStockServiceInterface  ProductStockServiceInterface
+	StockMonitorServiceInterface  StockMonitorServiceInterface
 }
 
 func NewPaymentService(
@@ -53,20 +54,22 @@ func NewPaymentService(
 	configPayment config.Payment,
 	orderRepositoryInterface mysql.OrderRepositoryInterface,
 	orderItemRepositoryInterface mysql.OrderItemRepositoryInterface,
-	productRepositoryInterface mysql.ProductRepositoryInterface,
-	productStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface,
-	PaymentLogRepositoryInterface mysql.PaymentLogRepositoryInterface) PaymentServiceInterface {
+	PaymentLogRepositoryInterface mysql.PaymentLogRepositoryInterface,
+	productSearchServiceInterface ProductSearchServiceInterface,
+	productStockServiceInterface ProductStockServiceInterface,
+	stockMonitorServiceInterface StockMonitorServiceInterface) PaymentServiceInterface {
 	return &PaymentServiceImplementation{
-		ConfigWebserver:                        configWebserver,
-		DB:                                     DB,
-		Validate:                               validate,
-		Logger:                                 logger,
-		ConfigPayment:                          configPayment,
-		OrderRepositoryInterface:               orderRepositoryInterface,
-		OrderItemRepositoryInterface:           orderItemRepositoryInterface,
-		ProductRepositoryInterface:             productRepositoryInterface,
-		ProductStockHistoryRepositoryInterface: productStockHistoryRepositoryInterface,
-		PaymentLogRepositoryInterface:          PaymentLogRepositoryInterface,
+		ConfigWebserver:               configWebserver,
+		DB:                            DB,
+		Validate:                      validate,
+		Logger:                        logger,
+		ConfigPayment:                 configPayment,
+		OrderRepositoryInterface:      orderRepositoryInterface,
+		OrderItemRepositoryInterface:  orderItemRepositoryInterface,
+		PaymentLogRepositoryInterface: PaymentLogRepositoryInterface,
+		ProductSearchServiceInterface: productSearchServiceInterface,
+		ProductStockServiceInterface:  productStockServiceInterface,
+		StockMonitorServiceInterface:  stockMonitorServiceInterface,
 	}
 }
 
@@ -145,25 +148,7 @@ func (service *PaymentServiceImplementation) PaymentStatus(requestId string, pay
 			// Update product stock
 			orderItems, _ := service.OrderItemRepositoryInterface.FindOrderItemsByIdOrder(service.DB, order.Id)
 			for _, orderItem := range orderItems {
-				productEntity := &entity.Product{}
-				productEntityStockHistory := &entity.ProductStockHistory{}
-				product, errFindProduct := service.ProductRepositoryInterface.FindProductById(tx, orderItem.IdProduct)
-				exceptions.PanicIfErrorWithRollback(errFindProduct, requestId, []string{"product not found"}, service.Logger, tx)
-
-				productEntityStockHistory.IdProduct = orderItem.IdProduct
-				productEntityStockHistory.TxDate = time.Now()
-				productEntityStockHistory.StockOpname = product.Stock
-				productEntityStockHistory.StockOutQty = orderItem.Qty
-				productEntityStockHistory.StockFinal = product.Stock - orderItem.Qty
-				productEntityStockHistory.Description = "Pembelian " + order.NumberOrder
-				productEntityStockHistory.CreatedAt = time.Now()
-
-				_, errAddProductStockHistory := service.ProductStockHistoryRepositoryInterface.AddProductStockHistory(tx, *productEntityStockHistory)
-				exceptions.PanicIfErrorWithRollback(errAddProductStockHistory, requestId, []string{"add stock history error"}, service.Logger, tx)
-
-				productEntity.Stock = product.Stock - orderItem.Qty
-				_, errUpdateProductStock := service.ProductRepositoryInterface.UpdateProductStock(tx, orderItem.IdProduct, *productEntity)
-				exceptions.PanicIfErrorWithRollback(errUpdateProductStock, requestId, []string{"update stock error"}, service.Logger, tx)
+				service.ProductStockServiceInterface.DecreaseOrderItemStock(tx, requestId, orderItem, "Pembelian "+order.NumberOrder)
 			}
 
 			// Create response log
@@ -182,6 +167,8 @@ func (service *PaymentServiceImplementation) PaymentStatus(requestId string, pay
 
 			commit := tx.Commit()
 			exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+			service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+			go service.StockMonitorServiceInterface.CheckProductStocks(orderItemIdProducts(orderItems))
 		}
 	}
 
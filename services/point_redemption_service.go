@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+// Jenis rule pemakaian point
+const (
+	pointRuleMinBasket         = "min_basket"
+	pointRuleMaxPercentage     = "max_percentage"
+	pointRuleMaxPoint          = "max_point"
+	pointRuleExcludeCategory   = "exclude_category"
+	pointRuleExcludeBrand      = "exclude_brand"
+	pointRuleMinPeriodSpending = "min_period_spending"
+	pointRuleMaxPeriodPoint    = "max_period_point"
+)
+
+type PointRedemptionServiceInterface interface {
+	CheckPointRedemption(requestId string, idUser string, paymentByPoint float64, totalBill float64) (pointRedemptionResponse response.PointRedemptionCheckResponse)
+	EvaluateCartPointRedemption(requestId string, idUser string, paymentByPoint float64, totalBill float64) (pointRedemption modelService.PointRedemption)
+	EvaluatePointRedemption(requestId string, user entity.User, cartItems []entity.Cart, paymentByPoint float64, totalBill float64) (pointRedemption modelService.PointRedemption)
+}
+
+type PointRedemptionServiceImplementation struct {
+	ConfigWebserver                        config.Webserver
+	DB                                     *gorm.DB
+	Logger                                 *logrus.Logger
+	PointRedemptionRuleRepositoryInterface mysql.PointRedemptionRuleRepositoryInterface
+	OrderRepositoryInterface               mysql.OrderRepositoryInterface
+	CartRepositoryInterface                mysql.CartRepositoryInterface
+	UserRepositoryInterface                mysql.UserRepositoryInterface
+	SettingRepositoryInterface             mysql.SettingRepositoryInterface
+	PromotionServiceInterface              PromotionServiceInterface
+	FlashSaleServiceInterface              FlashSaleServiceInterface
+}
+
+func NewPointRedemptionService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	pointRedemptionRuleRepositoryInterface mysql.PointRedemptionRuleRepositoryInterface,
+	orderRepositoryInterface mysql.OrderRepositoryInterface,
+	cartRepositoryInterface mysql.CartRepositoryInterface,
+	userRepositoryInterface mysql.UserRepositoryInterface,
+	settingRepositoryInterface mysql.SettingRepositoryInterface,
+	promotionServiceInterface PromotionServiceInterface,
+	flashSaleServiceInterface FlashSaleServiceInterface) PointRedemptionServiceInterface {
+	return &PointRedemptionServiceImplementation{
+		ConfigWebserver:                        configWebserver,
+		DB:                                     DB,
+		Logger:                                 logger,
+		PointRedemptionRuleRepositoryInterface: pointRedemptionRuleRepositoryInterface,
+		OrderRepositoryInterface:               orderRepositoryInterface,
+		CartRepositoryInterface:                cartRepositoryInterface,
+		UserRepositoryInterface:                userRepositoryInterface,
+		SettingRepositoryInterface:             settingRepositoryInterface,
+		PromotionServiceInterface:              promotionServiceInterface,
+		FlashSaleServiceInterface:              flashSaleServiceInterface,
+	}
+}
+
+func (service *PointRedemptionServiceImplementation) CheckPointRedemption(requestId string, idUser string, paymentByPoint float64, totalBill float64) (pointRedemptionResponse response.PointRedemptionCheckResponse) {
+	pointRedemption := service.EvaluateCartPointRedemption(requestId, idUser, paymentByPoint, totalBill)
+	pointRedemptionResponse = response.ToPointRedemptionCheckResponse(pointRedemption)
+	return pointRedemptionResponse
+}
+
+func (service *PointRedemptionServiceImplementation) EvaluateCartPointRedemption(requestId string, idUser string, paymentByPoint float64, totalBill float64) (pointRedemption modelService.PointRedemption) {
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	cartItems, err := service.CartRepositoryInterface.FindCartByIdUser(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	cartItems = service.FlashSaleServiceInterface.ApplyFlashSales(requestId, idUser, cartItems, time.Now())
+
+	pointRedemption = service.EvaluatePointRedemption(requestId, user, cartItems, paymentByPoint, totalBill)
+	return pointRedemption
+}
+
+func (service *PointRedemptionServiceImplementation) EvaluatePointRedemption(requestId string, user entity.User, cartItems []entity.Cart, paymentByPoint float64, totalBill float64) (pointRedemption modelService.PointRedemption) {
+	rules := service.FindPointRedemptionRules(requestId, user.IdLevelMember)
+
+	// Produk dari kategori atau brand yang dikecualikan tidak bisa dibayar dengan point
+	excludedCategories := make(map[string]bool)
+	excludedBrands := make(map[string]bool)
+	now := time.Now()
+	for _, rule := range rules {
+		switch rule.RuleType {
+		case pointRuleExcludeCategory:
+			excludedCategories[rule.RefId] = true
+		case pointRuleExcludeBrand:
+			excludedBrands[rule.RefId] = true
+		}
+	}
+
+	// Potongan promo keranjang ikut mengurangi total per item
+	lineDiscounts := pricing.CartPromotionDiscounts(service.PromotionServiceInterface.EvaluateCartPromotions(requestId, cartItems, now))
+	for _, cartItem := range cartItems {
+		price := pricing.EvaluateCartItemPricing(cartItem, now).Price
+		totalPrice := price*float64(cartItem.Qty) - lineDiscounts[cartItem.Id]
+		pointRedemption.Subtotal = pointRedemption.Subtotal + totalPrice
+		if !excludedCategories[strconv.Itoa(cartItem.Product.IdCategory)] && !excludedBrands[cartItem.Product.IdBrand] {
+			pointRedemption.EligibleTotal = pointRedemption.EligibleTotal + totalPrice
+		}
+	}
+	if totalBill == 0 {
+		totalBill = pointRedemption.Subtotal
+	}
+
+	pointRedemption.MaxPoint = pointRedemption.EligibleTotal
+	hasPeriodSpendingRule := false
+	for _, rule := range rules {
+		switch rule.RuleType {
+		case pointRuleMinBasket:
+			if pointRedemption.Subtotal < rule.Value {
+				pointRedemption.Violations = append(pointRedemption.Violations, "Minimal belanja "+formatPoint(rule.Value)+" untuk memakai point")
+			}
+		case pointRuleMaxPercentage:
+			pointRedemption.MaxPoint = math.Min(pointRedemption.MaxPoint, math.Floor(pointRedemption.EligibleTotal*rule.Value/100))
+		case pointRuleMaxPoint:
+			pointRedemption.MaxPoint = math.Min(pointRedemption.MaxPoint, rule.Value)
+		case pointRuleMinPeriodSpending:
+			hasPeriodSpendingRule = true
+			dateFrom, dateTo := pointRedemptionPeriod(rule.Period, now)
+			if service.SumPeriodSpending(requestId, user.Id, dateFrom, dateTo)+totalBill < rule.Value {
+				pointRedemption.Violations = append(pointRedemption.Violations, "akumulasi total belanja kurang")
+			}
+		case pointRuleMaxPeriodPoint:
+			dateFrom, dateTo := pointRedemptionPeriod(rule.Period, now)
+			usedPoint, err := service.OrderRepositoryInterface.SumPaymentByPointByDateRange(service.DB, user.Id, dateFrom, dateTo)
+			exceptions.PanicIfError(err, requestId, service.Logger)
+			pointRedemption.MaxPoint = math.Min(pointRedemption.MaxPoint, math.Max(rule.Value-usedPoint, 0))
+		}
+	}
+
+	// Tanpa rule akumulasi belanja, tetap memakai setting limit_order per bulan
+	if !hasPeriodSpendingRule {
+		settings, _ := service.SettingRepositoryInterface.FindSettingsByName(service.DB, "limit_order")
+		dateFrom, dateTo := pointRedemptionPeriod("month", now)
+		if settings.SettingsName != "" && service.SumPeriodSpending(requestId, user.Id, dateFrom, dateTo)+totalBill < settings.Value {
+			pointRedemption.Violations = append(pointRedemption.Violations, "akumulasi total belanja kurang")
+		}
+	}
+
+	if paymentByPoint > pointRedemption.MaxPoint {
+		pointRedemption.Violations = append(pointRedemption.Violations, "Maksimal point yang bisa dipakai "+formatPoint(pointRedemption.MaxPoint))
+	}
+
+	return pointRedemption
+}
+
+// Rule khusus level member menggantikan rule umum dengan jenis yang sama
+func (service *PointRedemptionServiceImplementation) FindPointRedemptionRules(requestId string, idLevelMember int) []entity.PointRedemptionRule {
+	pointRedemptionRules, err := service.PointRedemptionRuleRepositoryInterface.FindActivePointRedemptionRules(service.DB, idLevelMember)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	var rules []entity.PointRedemptionRule
+	ruleIndex := make(map[string]int)
+	for _, pointRedemptionRule := range pointRedemptionRules {
+		key := pointRedemptionRule.RuleType + ":" + pointRedemptionRule.RefId + ":" + pointRedemptionRule.Period
+		if index, ok := ruleIndex[key]; ok {
+			if pointRedemptionRule.IdLevelMember != 0 {
+				rules[index] = pointRedemptionRule
+			}
+			continue
+		}
+		ruleIndex[key] = len(rules)
+		rules = append(rules, pointRedemptionRule)
+	}
+	return rules
+}
+
+func (service *PointRedemptionServiceImplementation) SumPeriodSpending(requestId string, idUser string, dateFrom time.Time, dateTo time.Time) float64 {
+	orders, err := service.OrderRepositoryInterface.FindCompletedOrderByDateRange(service.DB, idUser, dateFrom, dateTo)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	var totalOrderAcumulate float64
+	for _, order := range orders {
+		totalOrderAcumulate = totalOrderAcumulate + order.PaymentByCash
+	}
+	return totalOrderAcumulate
+}
+
+// Rentang periode kalender [dateFrom, dateTo) yang berisi waktu now, minggu dimulai hari senin
+func pointRedemptionPeriod(period string, now time.Time) (dateFrom time.Time, dateTo time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case "day":
+		return today, today.AddDate(0, 0, 1)
+	case "week":
+		weekday := (int(today.Weekday()) + 6) % 7
+		dateFrom = today.AddDate(0, 0, -weekday)
+		return dateFrom, dateFrom.AddDate(0, 0, 7)
+	case "year":
+		dateFrom = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		return dateFrom, dateFrom.AddDate(1, 0, 0)
+	default:
+		dateFrom = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return dateFrom, dateFrom.AddDate(0, 1, 0)
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+type fakePointRedemptionRuleRepository struct {
+	mysql.PointRedemptionRuleRepositoryInterface
+	rules []entity.PointRedemptionRule
+}
+
+func (repository *fakePointRedemptionRuleRepository) FindActivePointRedemptionRules(DB *gorm.DB, idLevelMember int) ([]entity.PointRedemptionRule, error) {
+	return repository.rules, nil
+}
+
+type fakePointOrderRepository struct {
+	mysql.OrderRepositoryInterface
+	spending  float64
+	usedPoint float64
+}
+
+func (repository *fakePointOrderRepository) FindCompletedOrderByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) ([]entity.Order, error) {
+	return []entity.Order{{PaymentByCash: repository.spending}}, nil
+}
+
+func (repository *fakePointOrderRepository) SumPaymentByPointByDateRange(DB *gorm.DB, idUser string, dateFrom time.Time, dateTo time.Time) (float64, error) {
+	return repository.usedPoint, nil
+}
+
+type fakeSettingRepository struct {
+	mysql.SettingRepositoryInterface
+	settings map[string]float64
+}
+
+func (repository *fakeSettingRepository) FindSettingsByName(DB *gorm.DB, settingName string) (entity.Settings, error) {
+	value, ok := repository.settings[settingName]
+	if !ok {
+		return entity.Settings{}, nil
+	}
+	return entity.Settings{SettingsName: settingName, Value: value}, nil
+}
+
+type fakePromotionService struct {
+	PromotionServiceInterface
+}
+
+func (service *fakePromotionService) EvaluateCartPromotions(requestId string, cartItems []entity.Cart, now time.Time) (cartPromotions []modelService.CartPromotion) {
+	return nil
+}
+
+func TestPointRedemptionPeriod(t *testing.T) {
+	date := func(year int, month time.Month, day int, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		period   string
+		now      time.Time
+		dateFrom time.Time
+		dateTo   time.Time
+	}{
+		{"day end of year", "day", date(2025, time.December, 31, 23), date(2025, time.December, 31, 0), date(2026, time.January, 1, 0)},
+		{"week on monday", "week", date(2025, time.March, 3, 8), date(2025, time.March, 3, 0), date(2025, time.March, 10, 0)},
+		{"week on sunday across month", "week", date(2025, time.March, 2, 8), date(2025, time.February, 24, 0), date(2025, time.March, 3, 0)},
+		{"week across year", "week", date(2026, time.January, 1, 8), date(2025, time.December, 29, 0), date(2026, time.January, 5, 0)},
+		{"month rollover to next year", "month", date(2025, time.December, 15, 8), date(2025, time.December, 1, 0), date(2026, time.January, 1, 0)},
+		{"month leap february", "month", date(2024, time.February, 29, 8), date(2024, time.February, 1, 0), date(2024, time.March, 1, 0)},
+		{"unknown period is month", "", date(2025, time.January, 31, 8), date(2025, time.January, 1, 0), date(2025, time.February, 1, 0)},
+		{"year", "year", date(2025, time.December, 31, 23), date(2025, time.January, 1, 0), date(2026, time.January, 1, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dateFrom, dateTo := pointRedemptionPeriod(test.period, test.now)
+			if !dateFrom.Equal(test.dateFrom) || !dateTo.Equal(test.dateTo) {
+				t.Errorf("got [%v, %v), want [%v, %v)", dateFrom, dateTo, test.dateFrom, test.dateTo)
+			}
+		})
+	}
+}
+
+func TestEvaluatePointRedemption(t *testing.T) {
+	// Subtotal 200000: kategori 1 brand b1 100000, kategori 2 brand b2 2 x 50000
+	cartItems := []entity.Cart{
+		{Id: "cart-1", Qty: 1, Product: entity.Product{Id: "product-1", Price: 100000, IdCategory: 1, IdBrand: "b1"}},
+		{Id: "cart-2", Qty: 2, Product: entity.Product{Id: "product-2", Price: 50000, IdCategory: 2, IdBrand: "b2"}},
+	}
+	rule := func(ruleType string, refId string, value float64, period string) entity.PointRedemptionRule {
+		return entity.PointRedemptionRule{RuleType: ruleType, RefId: refId, Value: value, Period: period}
+	}
+	tests := []struct {
+		name           string
+		rules          []entity.PointRedemptionRule
+		settings       map[string]float64
+		spending       float64
+		usedPoint      float64
+		paymentByPoint float64
+		eligibleTotal  float64
+		maxPoint       float64
+		violations     []string
+	}{
+		{name: "no rule", eligibleTotal: 200000, maxPoint: 200000},
+		{name: "min basket reached", rules: []entity.PointRedemptionRule{rule(pointRuleMinBasket, "", 150000, "")}, eligibleTotal: 200000, maxPoint: 200000},
+		{name: "min basket not reached", rules: []entity.PointRedemptionRule{rule(pointRuleMinBasket, "", 250000, "")}, eligibleTotal: 200000, maxPoint: 200000,
+			violations: []string{"Minimal belanja 250000 untuk memakai point"}},
+		{name: "max percentage", rules: []entity.PointRedemptionRule{rule(pointRuleMaxPercentage, "", 10, "")}, paymentByPoint: 25000, eligibleTotal: 200000, maxPoint: 20000,
+			violations: []string{"Maksimal point yang bisa dipakai 20000"}},
+		{name: "max point", rules: []entity.PointRedemptionRule{rule(pointRuleMaxPoint, "", 5000, "")}, paymentByPoint: 5000, eligibleTotal: 200000, maxPoint: 5000},
+		{name: "exclude category", rules: []entity.PointRedemptionRule{rule(pointRuleExcludeCategory, "2", 0, "")}, eligibleTotal: 100000, maxPoint: 100000},
+		{name: "exclude brand", rules: []entity.PointRedemptionRule{rule(pointRuleExcludeBrand, "b1", 0, "")}, eligibleTotal: 100000, maxPoint: 100000},
+		{name: "period spending reached ignores limit order", rules: []entity.PointRedemptionRule{rule(pointRuleMinPeriodSpending, "", 500000, "month")},
+			settings: map[string]float64{"limit_order": 1000000}, spending: 300000, eligibleTotal: 200000, maxPoint: 200000},
+		{name: "period spending not reached", rules: []entity.PointRedemptionRule{rule(pointRuleMinPeriodSpending, "", 500000, "week")}, spending: 250000, eligibleTotal: 200000, maxPoint: 200000,
+			violations: []string{"akumulasi total belanja kurang"}},
+		{name: "max period point remaining", rules: []entity.PointRedemptionRule{rule(pointRuleMaxPeriodPoint, "", 30000, "month")}, usedPoint: 10000, eligibleTotal: 200000, maxPoint: 20000},
+		{name: "max period point used up", rules: []entity.PointRedemptionRule{rule(pointRuleMaxPeriodPoint, "", 30000, "month")}, usedPoint: 40000, paymentByPoint: 1, eligibleTotal: 200000, maxPoint: 0,
+			violations: []string{"Maksimal point yang bisa dipakai 0"}},
+		{name: "fallback limit order not reached", settings: map[string]float64{"limit_order": 300000}, spending: 50000, eligibleTotal: 200000, maxPoint: 200000,
+			violations: []string{"akumulasi total belanja kurang"}},
+		{name: "fallback limit order reached", settings: map[string]float64{"limit_order": 300000}, spending: 150000, eligibleTotal: 200000, maxPoint: 200000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &PointRedemptionServiceImplementation{
+				Logger:                                 logrus.New(),
+				PointRedemptionRuleRepositoryInterface: &fakePointRedemptionRuleRepository{rules: test.rules},
+				OrderRepositoryInterface:               &fakePointOrderRepository{spending: test.spending, usedPoint: test.usedPoint},
+				SettingRepositoryInterface:             &fakeSettingRepository{settings: test.settings},
+				PromotionServiceInterface:              &fakePromotionService{},
+			}
+			pointRedemption := service.EvaluatePointRedemption("request", entity.User{Id: "user"}, cartItems, test.paymentByPoint, 0)
+			if pointRedemption.Subtotal != 200000 || pointRedemption.EligibleTotal != test.eligibleTotal || pointRedemption.MaxPoint != test.maxPoint {
+				t.Errorf("got subtotal %v eligible %v max point %v, want eligible %v max point %v",
+					pointRedemption.Subtotal, pointRedemption.EligibleTotal, pointRedemption.MaxPoint, test.eligibleTotal, test.maxPoint)
+			}
+			if !reflect.DeepEqual(pointRedemption.Violations, test.violations) {
+				t.Errorf("got violations %v, want %v", pointRedemption.Violations, test.violations)
+			}
+		})
+	}
+}
+
+func TestFindPointRedemptionRulesPrefersLevelMemberRule(t *testing.T) {
+	service := &PointRedemptionServiceImplementation{
+		Logger: logrus.New(),
+		PointRedemptionRuleRepositoryInterface: &fakePointRedemptionRuleRepository{rules: []entity.PointRedemptionRule{
+			{Id: "general", RuleType: pointRuleMaxPoint, Value: 5000},
+			{Id: "level", RuleType: pointRuleMaxPoint, IdLevelMember: 2, Value: 8000},
+			{Id: "basket", RuleType: pointRuleMinBasket, Value: 100000},
+		}},
+	}
+	rules := service.FindPointRedemptionRules("request", 2)
+	if len(rules) != 2 || rules[0].Id != "level" || rules[1].Id != "basket" {
+		t.Errorf("got rules %+v", rules)
+	}
+}
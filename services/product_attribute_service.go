@@ -0,0 +1,342 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Kode atribut dipakai di query filter kode:nilai sehingga dibatasi huruf kecil, angka dan underscore
+var productAttributeCodePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type ProductAttributeServiceInterface interface {
+	FindFilterableProductAttributes(requestId string) (productAttributeResponses []response.ProductAttributeResponse)
+	FindAllProductAttributes(requestId string) (productAttributeResponses []response.ProductAttributeResponse)
+	CreateProductAttribute(requestId string, productAttributeRequest *request.ProductAttributeRequest) (productAttributeResponse response.ProductAttributeResponse)
+	UpdateProductAttribute(requestId string, productAttributeRequest *request.ProductAttributeRequest) (productAttributeResponse response.ProductAttributeResponse)
+	DeleteProductAttribute(requestId string, productAttributeIdRequest *request.ProductAttributeIdRequest)
+	FindProductAttributeValues(requestId string, productAttributeValueFindRequest *request.ProductAttributeValueFindRequest) (productAttributeValueResponses []response.ProductAttributeValueResponse)
+	UpdateProductAttributeValues(requestId string, productAttributeValueRequest *request.ProductAttributeValueRequest) (productAttributeValueResponses []response.ProductAttributeValueResponse)
+}
+
+type ProductAttributeServiceImplementation struct {
+	ConfigWebserver                     config.Webserver
+	DB                                  *gorm.DB
+	Validate                            *validator.Validate
+	Logger                              *logrus.Logger
+	ProductAttributeRepositoryInterface mysql.ProductAttributeRepositoryInterface
+	ProductRepositoryInterface          mysql.ProductRepositoryInterface
+	ProductSearchServiceInterface       ProductSearchServiceInterface
+}
+
+func NewProductAttributeService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productAttributeRepositoryInterface mysql.ProductAttributeRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) ProductAttributeServiceInterface {
+	return &ProductAttributeServiceImplementation{
+		ConfigWebserver:                     configWebserver,
+		DB:                                  DB,
+		Validate:                            validate,
+		Logger:                              logger,
+		ProductAttributeRepositoryInterface: productAttributeRepositoryInterface,
+		ProductRepositoryInterface:          productRepositoryInterface,
+		ProductSearchServiceInterface:       productSearchServiceInterface,
+	}
+}
+
+// Definisi atribut untuk menyusun filter di aplikasi
+func (service *ProductAttributeServiceImplementation) FindFilterableProductAttributes(requestId string) (productAttributeResponses []response.ProductAttributeResponse) {
+	productAttributes, err := service.ProductAttributeRepositoryInterface.FindFilterableProductAttributes(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productAttributeResponses = response.ToProductAttributeResponses(productAttributes)
+	return productAttributeResponses
+}
+
+func (service *ProductAttributeServiceImplementation) FindAllProductAttributes(requestId string) (productAttributeResponses []response.ProductAttributeResponse) {
+	productAttributes, err := service.ProductAttributeRepositoryInterface.FindAllProductAttributes(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productAttributeResponses = response.ToProductAttributeResponses(productAttributes)
+	return productAttributeResponses
+}
+
+func (service *ProductAttributeServiceImplementation) CreateProductAttribute(requestId string, productAttributeRequest *request.ProductAttributeRequest) (productAttributeResponse response.ProductAttributeResponse) {
+	request.ValidateProductAttributeRequest(service.Validate, productAttributeRequest, requestId, service.Logger)
+
+	attributeCode := strings.ToLower(strings.TrimSpace(productAttributeRequest.AttributeCode))
+	if !productAttributeCodePattern.MatchString(attributeCode) {
+		exceptions.PanicIfBadRequest(errors.New("invalid attribute code"), requestId, []string{"attribute_code may only contain lowercase letters, numbers and underscore"}, service.Logger)
+	}
+	if productAttributeRequest.AttributeType == "" {
+		exceptions.PanicIfBadRequest(errors.New("attribute type required"), requestId, []string{"AttributeType is required"}, service.Logger)
+	}
+
+	productAttributeWithCode, err := service.ProductAttributeRepositoryInterface.FindProductAttributeByCode(service.DB, attributeCode)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if productAttributeWithCode.Id != "" {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("attribute code already exist"), requestId, []string{"attribute_code already used by another attribute"}, service.Logger)
+	}
+
+	productAttributeEntity := &entity.ProductAttribute{}
+	productAttributeEntity.Id = utilities.RandomUUID()
+	productAttributeEntity.AttributeCode = attributeCode
+	productAttributeEntity.AttributeName = productAttributeRequest.AttributeName
+	productAttributeEntity.AttributeType = productAttributeRequest.AttributeType
+	productAttributeEntity.Unit = productAttributeRequest.Unit
+	productAttributeEntity.IsFilterable = productAttributeRequest.IsFilterable
+	productAttributeEntity.Position = productAttributeRequest.Position
+	productAttributeEntity.CreatedAt = time.Now()
+	productAttributeOptions := service.toProductAttributeOptions(requestId, *productAttributeEntity, productAttributeRequest.Options)
+
+	tx := service.DB.Begin()
+	_, errCreateAttribute := service.ProductAttributeRepositoryInterface.CreateProductAttribute(tx, *productAttributeEntity)
+	exceptions.PanicIfErrorWithRollback(errCreateAttribute, requestId, []string{"create product attribute error"}, service.Logger, tx)
+	if len(productAttributeOptions) > 0 {
+		_, errCreateOptions := service.ProductAttributeRepositoryInterface.CreateProductAttributeOptions(tx, productAttributeOptions)
+		exceptions.PanicIfErrorWithRollback(errCreateOptions, requestId, []string{"create product attribute option error"}, service.Logger, tx)
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	productAttribute := service.findProductAttribute(requestId, productAttributeEntity.Id)
+	productAttributeResponse = response.ToProductAttributeResponse(productAttribute)
+	return productAttributeResponse
+}
+
+// Pilihan yang dihapus tidak boleh masih dipakai produk, ganti dulu nilai di produknya
+func (service *ProductAttributeServiceImplementation) UpdateProductAttribute(requestId string, productAttributeRequest *request.ProductAttributeRequest) (productAttributeResponse response.ProductAttributeResponse) {
+	request.ValidateProductAttributeRequest(service.Validate, productAttributeRequest, requestId, service.Logger)
+	productAttribute := service.findProductAttribute(requestId, productAttributeRequest.Id)
+
+	productAttribute.AttributeName = productAttributeRequest.AttributeName
+	productAttribute.Unit = productAttributeRequest.Unit
+	productAttribute.IsFilterable = productAttributeRequest.IsFilterable
+	productAttribute.Position = productAttributeRequest.Position
+	productAttributeOptions := service.toProductAttributeOptions(requestId, productAttribute, productAttributeRequest.Options)
+
+	keptValues := make(map[string]bool)
+	for _, productAttributeOption := range productAttributeOptions {
+		keptValues[productAttributeOption.Value] = true
+	}
+	var removedValues []string
+	for _, productAttributeOption := range productAttribute.ProductAttributeOptions {
+		if !keptValues[productAttributeOption.Value] {
+			removedValues = append(removedValues, productAttributeOption.Value)
+		}
+	}
+	if len(removedValues) > 0 {
+		totalValues, err := service.ProductAttributeRepositoryInterface.CountProductAttributeValues(service.DB, productAttribute.Id, removedValues)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if totalValues > 0 {
+			exceptions.PanicIfBadRequest(errors.New("option still used"), requestId, []string{"removed options are still used by products"}, service.Logger)
+		}
+	}
+
+	tx := service.DB.Begin()
+	_, errUpdateAttribute := service.ProductAttributeRepositoryInterface.UpdateProductAttribute(tx, productAttribute.Id, productAttribute)
+	exceptions.PanicIfErrorWithRollback(errUpdateAttribute, requestId, []string{"update product attribute error"}, service.Logger, tx)
+	errDeleteOptions := service.ProductAttributeRepositoryInterface.DeleteProductAttributeOptionsByIdProductAttribute(tx, productAttribute.Id)
+	exceptions.PanicIfErrorWithRollback(errDeleteOptions, requestId, []string{"delete product attribute option error"}, service.Logger, tx)
+	if len(productAttributeOptions) > 0 {
+		_, errCreateOptions := service.ProductAttributeRepositoryInterface.CreateProductAttributeOptions(tx, productAttributeOptions)
+		exceptions.PanicIfErrorWithRollback(errCreateOptions, requestId, []string{"create product attribute option error"}, service.Logger, tx)
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	productAttribute = service.findProductAttribute(requestId, productAttribute.Id)
+	productAttributeResponse = response.ToProductAttributeResponse(productAttribute)
+	return productAttributeResponse
+}
+
+// Atribut yang masih dipakai produk tidak bisa dihapus
+func (service *ProductAttributeServiceImplementation) DeleteProductAttribute(requestId string, productAttributeIdRequest *request.ProductAttributeIdRequest) {
+	request.ValidateProductAttributeRequest(service.Validate, productAttributeIdRequest, requestId, service.Logger)
+	productAttribute := service.findProductAttribute(requestId, productAttributeIdRequest.Id)
+
+	totalValues, err := service.ProductAttributeRepositoryInterface.CountProductAttributeValues(service.DB, productAttribute.Id, nil)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if totalValues > 0 {
+		exceptions.PanicIfBadRequest(errors.New("attribute still used"), requestId, []string{"attribute still used by products"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	errDeleteOptions := service.ProductAttributeRepositoryInterface.DeleteProductAttributeOptionsByIdProductAttribute(tx, productAttribute.Id)
+	exceptions.PanicIfErrorWithRollback(errDeleteOptions, requestId, []string{"delete product attribute option error"}, service.Logger, tx)
+	errDeleteAttribute := service.ProductAttributeRepositoryInterface.DeleteProductAttribute(tx, productAttribute.Id)
+	exceptions.PanicIfErrorWithRollback(errDeleteAttribute, requestId, []string{"delete product attribute error"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+}
+
+func (service *ProductAttributeServiceImplementation) FindProductAttributeValues(requestId string, productAttributeValueFindRequest *request.ProductAttributeValueFindRequest) (productAttributeValueResponses []response.ProductAttributeValueResponse) {
+	request.ValidateProductAttributeRequest(service.Validate, productAttributeValueFindRequest, requestId, service.Logger)
+	product := service.findProduct(requestId, productAttributeValueFindRequest.IdProduct)
+
+	productAttributeValues, err := service.ProductAttributeRepositoryInterface.FindProductAttributeValuesByIdProduct(service.DB, product.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productAttributeValueResponses = response.ToProductAttributeValueResponses(productAttributeValues)
+	return productAttributeValueResponses
+}
+
+// Nilai disimpan dalam bentuk baku sesuai tipe atribut agar filter cukup mencocokkan teks
+func (service *ProductAttributeServiceImplementation) UpdateProductAttributeValues(requestId string, productAttributeValueRequest *request.ProductAttributeValueRequest) (productAttributeValueResponses []response.ProductAttributeValueResponse) {
+	request.ValidateProductAttributeRequest(service.Validate, productAttributeValueRequest, requestId, service.Logger)
+	product := service.findProduct(requestId, productAttributeValueRequest.IdProduct)
+
+	var idProductAttributes []string
+	requestedAttributes := make(map[string]bool)
+	for _, productAttributeValueItemRequest := range productAttributeValueRequest.Attributes {
+		if requestedAttributes[productAttributeValueItemRequest.IdProductAttribute] {
+			exceptions.PanicIfBadRequest(errors.New("duplicate attribute"), requestId, []string{"attribute " + productAttributeValueItemRequest.IdProductAttribute + " is listed more than once"}, service.Logger)
+		}
+		requestedAttributes[productAttributeValueItemRequest.IdProductAttribute] = true
+		idProductAttributes = append(idProductAttributes, productAttributeValueItemRequest.IdProductAttribute)
+	}
+
+	productAttributes := make(map[string]entity.ProductAttribute)
+	if len(idProductAttributes) > 0 {
+		productAttributeEntities, err := service.ProductAttributeRepositoryInterface.FindProductAttributesByIds(service.DB, idProductAttributes)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		for _, productAttribute := range productAttributeEntities {
+			productAttributes[productAttribute.Id] = productAttribute
+		}
+	}
+
+	var productAttributeValues []entity.ProductAttributeValue
+	for _, productAttributeValueItemRequest := range productAttributeValueRequest.Attributes {
+		productAttribute, ok := productAttributes[productAttributeValueItemRequest.IdProductAttribute]
+		if !ok {
+			exceptions.PanicIfRecordNotFound(errors.New("attribute not found"), requestId, []string{"attribute " + productAttributeValueItemRequest.IdProductAttribute + " not found"}, service.Logger)
+		}
+		productAttributeValues = append(productAttributeValues, service.toProductAttributeValues(requestId, product.Id, productAttribute, productAttributeValueItemRequest.Values)...)
+	}
+
+	tx := service.DB.Begin()
+	errDeleteValues := service.ProductAttributeRepositoryInterface.DeleteProductAttributeValuesByIdProduct(tx, product.Id)
+	exceptions.PanicIfErrorWithRollback(errDeleteValues, requestId, []string{"delete product attribute value error"}, service.Logger, tx)
+	if len(productAttributeValues) > 0 {
+		_, errCreateValues := service.ProductAttributeRepositoryInterface.CreateProductAttributeValues(tx, productAttributeValues)
+		exceptions.PanicIfErrorWithRollback(errCreateValues, requestId, []string{"create product attribute value error"}, service.Logger, tx)
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	productAttributeValueResponses = service.FindProductAttributeValues(requestId, &request.ProductAttributeValueFindRequest{IdProduct: product.Id})
+	return productAttributeValueResponses
+}
+
+// Pilihan hanya untuk tipe select dan multi_select, koma tidak boleh dipakai karena menjadi pemisah filter
+func (service *ProductAttributeServiceImplementation) toProductAttributeOptions(requestId string, productAttribute entity.ProductAttribute, options []string) (productAttributeOptions []entity.ProductAttributeOption) {
+	hasOptions := productAttribute.AttributeType == "select" || productAttribute.AttributeType == "multi_select"
+	if hasOptions && len(options) == 0 {
+		exceptions.PanicIfBadRequest(errors.New("options required"), requestId, []string{"options is required for " + productAttribute.AttributeType + " attribute"}, service.Logger)
+	}
+	if !hasOptions && len(options) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("options not allowed"), requestId, []string{"options is not allowed for " + productAttribute.AttributeType + " attribute"}, service.Logger)
+	}
+
+	optionValues := make(map[string]bool)
+	for position, option := range options {
+		value := strings.TrimSpace(option)
+		if value == "" || strings.Contains(value, ",") {
+			exceptions.PanicIfBadRequest(errors.New("invalid option"), requestId, []string{"option " + option + " is invalid"}, service.Logger)
+		}
+		if optionValues[strings.ToLower(value)] {
+			exceptions.PanicIfBadRequest(errors.New("duplicate option"), requestId, []string{"option " + value + " is listed more than once"}, service.Logger)
+		}
+		optionValues[strings.ToLower(value)] = true
+
+		productAttributeOption := entity.ProductAttributeOption{}
+		productAttributeOption.Id = utilities.RandomUUID()
+		productAttributeOption.IdProductAttribute = productAttribute.Id
+		productAttributeOption.Value = value
+		productAttributeOption.Position = position
+		productAttributeOptions = append(productAttributeOptions, productAttributeOption)
+	}
+	return productAttributeOptions
+}
+
+// Select dan multi_select mengikuti teks pilihan, boolean menjadi true/false dan number tanpa nol di belakang koma
+func (service *ProductAttributeServiceImplementation) toProductAttributeValues(requestId string, idProduct string, productAttribute entity.ProductAttribute, values []string) (productAttributeValues []entity.ProductAttributeValue) {
+	if productAttribute.AttributeType != "multi_select" && len(values) > 1 {
+		exceptions.PanicIfBadRequest(errors.New("too many values"), requestId, []string{"attribute " + productAttribute.AttributeCode + " only accepts one value"}, service.Logger)
+	}
+
+	usedValues := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		position := 0
+		switch productAttribute.AttributeType {
+		case "select", "multi_select":
+			found := false
+			for _, productAttributeOption := range productAttribute.ProductAttributeOptions {
+				if strings.EqualFold(productAttributeOption.Value, value) {
+					value = productAttributeOption.Value
+					position = productAttributeOption.Position
+					found = true
+					break
+				}
+			}
+			if !found {
+				exceptions.PanicIfBadRequest(errors.New("invalid attribute value"), requestId, []string{"value " + value + " is not an option of attribute " + productAttribute.AttributeCode}, service.Logger)
+			}
+		case "boolean":
+			boolValue, err := strconv.ParseBool(value)
+			exceptions.PanicIfBadRequest(err, requestId, []string{"attribute " + productAttribute.AttributeCode + " must be true or false"}, service.Logger)
+			value = strconv.FormatBool(boolValue)
+		case "number":
+			numberValue, err := strconv.ParseFloat(value, 64)
+			exceptions.PanicIfBadRequest(err, requestId, []string{"attribute " + productAttribute.AttributeCode + " must be a number"}, service.Logger)
+			value = strconv.FormatFloat(numberValue, 'f', -1, 64)
+		}
+		if usedValues[value] {
+			continue
+		}
+		usedValues[value] = true
+
+		productAttributeValue := entity.ProductAttributeValue{}
+		productAttributeValue.Id = utilities.RandomUUID()
+		productAttributeValue.IdProduct = idProduct
+		productAttributeValue.IdProductAttribute = productAttribute.Id
+		productAttributeValue.Value = value
+		productAttributeValue.Position = position
+		productAttributeValues = append(productAttributeValues, productAttributeValue)
+	}
+	return productAttributeValues
+}
+
+func (service *ProductAttributeServiceImplementation) findProductAttribute(requestId string, id string) (productAttribute entity.ProductAttribute) {
+	productAttribute, err := service.ProductAttributeRepositoryInterface.FindProductAttributeById(service.DB, id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if productAttribute.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("attribute not found"), requestId, []string{"attribute not found"}, service.Logger)
+	}
+	return productAttribute
+}
+
+func (service *ProductAttributeServiceImplementation) findProduct(requestId string, idProduct string) (product entity.Product) {
+	product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, idProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger)
+	}
+	return product
+}
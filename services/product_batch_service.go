@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+type ProductBatchServiceInterface interface {
+	FindProductBatches(requestId string, productBatchListRequest *request.ProductBatchListRequest) (productBatchResponses []response.ProductBatchResponse)
+	FindNearExpiryProductBatches(requestId string, productBatchNearExpiryRequest *request.ProductBatchNearExpiryRequest) (productBatchResponses []response.ProductBatchResponse)
+	FindProductBatchRecall(requestId string, productBatchRecallRequest *request.ProductBatchRecallRequest) (productBatchRecallResponse response.ProductBatchRecallResponse)
+	ExpireProductBatches()
+}
+
+type ProductBatchServiceImplementation struct {
+	ConfigWebserver                 config.Webserver
+	DB                              *gorm.DB
+	Validate                        *validator.Validate
+	Logger                          *logrus.Logger
+	ProductBatchRepositoryInterface mysql.ProductBatchRepositoryInterface
+	ProductStockServiceInterface    ProductStockServiceInterface
+	StockMonitorServiceInterface    StockMonitorServiceInterface
+	ProductSearchServiceInterface   ProductSearchServiceInterface
+}
+
+func NewProductBatchService(
+	configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productBatchRepositoryInterface mysql.ProductBatchRepositoryInterface,
+	productStockServiceInterface ProductStockServiceInterface,
+	stockMonitorServiceInterface StockMonitorServiceInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) ProductBatchServiceInterface {
+	return &ProductBatchServiceImplementation{
+		ConfigWebserver:                 configWebserver,
+		DB:                              DB,
+		Validate:                        validate,
+		Logger:                          logger,
+		ProductBatchRepositoryInterface: productBatchRepositoryInterface,
+		ProductStockServiceInterface:    productStockServiceInterface,
+		StockMonitorServiceInterface:    stockMonitorServiceInterface,
+		ProductSearchServiceInterface:   productSearchServiceInterface,
+	}
+}
+
+func (service *ProductBatchServiceImplementation) FindProductBatches(requestId string, productBatchListRequest *request.ProductBatchListRequest) (productBatchResponses []response.ProductBatchResponse) {
+	request.ValidateProductBatchRequest(service.Validate, productBatchListRequest, requestId, service.Logger)
+
+	productBatches, err := service.ProductBatchRepositoryInterface.FindProductBatches(service.DB, productBatchListRequest.IdProduct, productBatchListRequest.IdProductVariant)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	currentDate := today()
+	var filteredProductBatches []entity.ProductBatch
+	for _, productBatch := range productBatches {
+		if productBatchListRequest.Status == "" || response.ProductBatchStatus(productBatch, currentDate) == productBatchListRequest.Status {
+			filteredProductBatches = append(filteredProductBatches, productBatch)
+		}
+	}
+	productBatchResponses = response.ToProductBatchResponses(filteredProductBatches, currentDate)
+	return productBatchResponses
+}
+
+// Batch yang masih ada sisa dan akan kadaluarsa dalam beberapa hari ke depan
+func (service *ProductBatchServiceImplementation) FindNearExpiryProductBatches(requestId string, productBatchNearExpiryRequest *request.ProductBatchNearExpiryRequest) (productBatchResponses []response.ProductBatchResponse) {
+	request.ValidateProductBatchRequest(service.Validate, productBatchNearExpiryRequest, requestId, service.Logger)
+
+	days := productBatchNearExpiryRequest.Days
+	if days == 0 {
+		days = 30
+	}
+	currentDate := today()
+	productBatches, err := service.ProductBatchRepositoryInterface.FindNearExpiryProductBatches(service.DB, currentDate, currentDate.AddDate(0, 0, days))
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productBatchResponses = response.ToProductBatchResponses(productBatches, currentDate)
+	return productBatchResponses
+}
+
+// Daftar order yang menerima batch tertentu untuk keperluan penarikan produk
+func (service *ProductBatchServiceImplementation) FindProductBatchRecall(requestId string, productBatchRecallRequest *request.ProductBatchRecallRequest) (productBatchRecallResponse response.ProductBatchRecallResponse) {
+	request.ValidateProductBatchRequest(service.Validate, productBatchRecallRequest, requestId, service.Logger)
+
+	var productBatches []entity.ProductBatch
+	switch {
+	case productBatchRecallRequest.IdProductBatch != "":
+		productBatch, err := service.ProductBatchRepositoryInterface.FindProductBatchById(service.DB, productBatchRecallRequest.IdProductBatch)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if productBatch.Id != "" {
+			productBatches = append(productBatches, productBatch)
+		}
+	case productBatchRecallRequest.BatchNumber != "":
+		var err error
+		productBatches, err = service.ProductBatchRepositoryInterface.FindProductBatchesByNumber(service.DB, productBatchRecallRequest.BatchNumber)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+	default:
+		exceptions.PanicIfBadRequest(errors.New("batch required"), requestId, []string{"id_product_batch or batch_number is required"}, service.Logger)
+	}
+	if len(productBatches) == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("product batch not found"), requestId, []string{"product batch not found"}, service.Logger)
+	}
+
+	var idProductBatches []string
+	for _, productBatch := range productBatches {
+		idProductBatches = append(idProductBatches, productBatch.Id)
+	}
+	orderItemBatches, err := service.ProductBatchRepositoryInterface.FindOrderItemBatchesByIdProductBatches(service.DB, idProductBatches)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	productBatchRecallResponse = response.ToProductBatchRecallResponse(productBatches, orderItemBatches, today())
+	return productBatchRecallResponse
+}
+
+// Sisa batch yang sudah kadaluarsa dikeluarkan dari stok agar tidak bisa terjual
+func (service *ProductBatchServiceImplementation) ExpireProductBatches() {
+	productBatches, err := service.ProductBatchRepositoryInterface.FindExpiredProductBatches(service.DB, today())
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+
+	var idProducts []string
+	for _, productBatch := range productBatches {
+		if service.expireProductBatch(productBatch) {
+			idProducts = append(idProducts, productBatch.IdProduct)
+		}
+	}
+	if len(idProducts) > 0 {
+		service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	}
+	service.StockMonitorServiceInterface.CheckProductStocks(idProducts)
+}
+
+// Panic dari transaksi satu batch hanya dicatat agar job tetap berjalan untuk batch lainnya
+func (service *ProductBatchServiceImplementation) expireProductBatch(productBatch entity.ProductBatch) (expired bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			service.Logger.Error(fmt.Sprint(err))
+			expired = false
+		}
+	}()
+
+	// Stok produk bisa lebih kecil dari sisa batch jika ada koreksi stok tanpa batch
+	stock := productBatch.Product.Stock
+	if productBatch.IdProductVariant != "" {
+		stock = productBatch.ProductVariant.Stock
+	}
+	qty := productBatch.RemainingQty
+	if qty > stock {
+		qty = stock
+	}
+
+	tx := service.DB.Begin()
+	if qty > 0 {
+		service.ProductStockServiceInterface.PostStockMovement(tx, "", modelService.StockMovement{
+			IdProduct:        productBatch.IdProduct,
+			IdProductVariant: productBatch.IdProductVariant,
+			Qty:              -qty,
+			TxType:           StockTxAdjustment,
+			ReasonCode:       "expired",
+			BatchNumber:      productBatch.BatchNumber,
+			Description:      "Batch " + productBatch.BatchNumber + " kadaluarsa " + productBatch.ExpiryDate.Format("2006-01-02"),
+		})
+	}
+	productBatchEntity := &entity.ProductBatch{}
+	productBatchEntity.RemainingQty = 0
+	productBatchEntity.ExpiredAt = null.TimeFrom(time.Now())
+	_, err := service.ProductBatchRepositoryInterface.UpdateProductBatchExpired(tx, productBatch.Id, *productBatchEntity)
+	exceptions.PanicIfErrorWithRollback(err, "", []string{"expire batch error"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, "", service.Logger)
+	return qty > 0
+}
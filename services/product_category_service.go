@@ -0,0 +1,243 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+// Pohon kategori di-cache, jumlah produk ikut berubah saat stok berubah sehingga tetap dibangun ulang berkala
+const productCategoryTreeTtl = 5 * time.Minute
+
+type ProductCategoryServiceInterface interface {
+	FindCategoryTree(requestId string) (categoryResponses []response.FindProductCategoryResponse)
+	FindAllCategories(requestId string) (categoryResponses []response.FindProductCategoryResponse)
+	CreateCategory(requestId string, productCategoryRequest *request.ProductCategoryRequest) (categoryResponse response.FindProductCategoryResponse)
+	UpdateCategory(requestId string, productCategoryRequest *request.ProductCategoryRequest) (categoryResponse response.FindProductCategoryResponse)
+	DeleteCategory(requestId string, productCategoryDeleteRequest *request.ProductCategoryDeleteRequest)
+	CreateSubCategory(requestId string, productSubCategoryRequest *request.ProductSubCategoryRequest) (subCategoryResponse response.FindProductSubCategoryResponse)
+	UpdateSubCategory(requestId string, productSubCategoryRequest *request.ProductSubCategoryRequest) (subCategoryResponse response.FindProductSubCategoryResponse)
+	DeleteSubCategory(requestId string, productCategoryDeleteRequest *request.ProductCategoryDeleteRequest)
+	InvalidateCategoryTree()
+}
+
+type ProductCategoryServiceImplementation struct {
+	ConfigWebserver                    config.Webserver
+	DB                                 *gorm.DB
+	Validate                           *validator.Validate
+	Logger                             *logrus.Logger
+	ProductCategoryRepositoryInterface mysql.ProductCategoryRepositoryInterface
+	ProductSearchServiceInterface      ProductSearchServiceInterface
+	mutex                              sync.RWMutex
+	categoryTree                       []response.FindProductCategoryResponse
+	builtAt                            time.Time
+	dirty                              bool
+}
+
+func NewProductCategoryService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productCategoryRepositoryInterface mysql.ProductCategoryRepositoryInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) ProductCategoryServiceInterface {
+	return &ProductCategoryServiceImplementation{
+		ConfigWebserver:                    configWebserver,
+		DB:                                 DB,
+		Validate:                           validate,
+		Logger:                             logger,
+		ProductCategoryRepositoryInterface: productCategoryRepositoryInterface,
+		ProductSearchServiceInterface:      productSearchServiceInterface,
+		dirty:                              true,
+	}
+}
+
+// Perubahan kategori juga mengubah nama kategori di index pencarian
+func (service *ProductCategoryServiceImplementation) InvalidateCategoryTree() {
+	service.mutex.Lock()
+	service.dirty = true
+	service.mutex.Unlock()
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+}
+
+// Pohon kategori aktif untuk aplikasi
+func (service *ProductCategoryServiceImplementation) FindCategoryTree(requestId string) (categoryResponses []response.FindProductCategoryResponse) {
+	service.mutex.RLock()
+	if !service.dirty && time.Since(service.builtAt) < productCategoryTreeTtl {
+		defer service.mutex.RUnlock()
+		return service.categoryTree
+	}
+	service.mutex.RUnlock()
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if !service.dirty && time.Since(service.builtAt) < productCategoryTreeTtl {
+		return service.categoryTree
+	}
+
+	service.categoryTree = service.findCategoryTree(requestId, false)
+	service.builtAt = time.Now()
+	service.dirty = false
+	return service.categoryTree
+}
+
+// Semua kategori termasuk yang nonaktif untuk admin, tidak memakai cache
+func (service *ProductCategoryServiceImplementation) FindAllCategories(requestId string) (categoryResponses []response.FindProductCategoryResponse) {
+	categoryResponses = service.findCategoryTree(requestId, true)
+	return categoryResponses
+}
+
+func (service *ProductCategoryServiceImplementation) CreateCategory(requestId string, productCategoryRequest *request.ProductCategoryRequest) (categoryResponse response.FindProductCategoryResponse) {
+	request.ValidateProductCategoryRequest(service.Validate, productCategoryRequest, requestId, service.Logger)
+
+	categoryEntity := &entity.ProductCategory{}
+	categoryEntity.CategoryName = productCategoryRequest.CategoryName
+	categoryEntity.Icon = productCategoryRequest.Icon
+	categoryEntity.SortOrder = productCategoryRequest.SortOrder
+	categoryEntity.IsActive = productCategoryRequest.IsActive
+	categoryEntity.CreatedAt = time.Now()
+
+	category, err := service.ProductCategoryRepositoryInterface.CreateCategory(service.DB, *categoryEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	service.InvalidateCategoryTree()
+	categoryResponse = response.ToFindProductCategoryResponse(category)
+	return categoryResponse
+}
+
+func (service *ProductCategoryServiceImplementation) UpdateCategory(requestId string, productCategoryRequest *request.ProductCategoryRequest) (categoryResponse response.FindProductCategoryResponse) {
+	request.ValidateProductCategoryRequest(service.Validate, productCategoryRequest, requestId, service.Logger)
+
+	category, err := service.ProductCategoryRepositoryInterface.FindCategoryById(service.DB, productCategoryRequest.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if category.Id == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("category not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	category.CategoryName = productCategoryRequest.CategoryName
+	category.Icon = productCategoryRequest.Icon
+	category.SortOrder = productCategoryRequest.SortOrder
+	category.IsActive = productCategoryRequest.IsActive
+
+	category, err = service.ProductCategoryRepositoryInterface.UpdateCategory(service.DB, category.Id, category)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	service.InvalidateCategoryTree()
+	categoryResponse = response.ToFindProductCategoryResponse(category)
+	return categoryResponse
+}
+
+// Kategori yang masih punya sub kategori atau produk tidak bisa dihapus, nonaktifkan saja
+func (service *ProductCategoryServiceImplementation) DeleteCategory(requestId string, productCategoryDeleteRequest *request.ProductCategoryDeleteRequest) {
+	request.ValidateProductCategoryRequest(service.Validate, productCategoryDeleteRequest, requestId, service.Logger)
+
+	category, err := service.ProductCategoryRepositoryInterface.FindCategoryById(service.DB, productCategoryDeleteRequest.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if category.Id == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("category not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+	if len(category.ProductSubCategories) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("category has sub categories"), requestId, []string{"category still has sub categories"}, service.Logger)
+	}
+
+	totalProducts, err := service.ProductCategoryRepositoryInterface.CountProductsByCategory(service.DB, category.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if totalProducts > 0 {
+		exceptions.PanicIfBadRequest(errors.New("category has products"), requestId, []string{"category still has products"}, service.Logger)
+	}
+
+	err = service.ProductCategoryRepositoryInterface.DeleteCategory(service.DB, category.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	service.InvalidateCategoryTree()
+}
+
+func (service *ProductCategoryServiceImplementation) CreateSubCategory(requestId string, productSubCategoryRequest *request.ProductSubCategoryRequest) (subCategoryResponse response.FindProductSubCategoryResponse) {
+	request.ValidateProductCategoryRequest(service.Validate, productSubCategoryRequest, requestId, service.Logger)
+	service.findCategoryById(requestId, productSubCategoryRequest.IdCategory)
+
+	subCategoryEntity := &entity.ProductSubCategory{}
+	subCategoryEntity.IdCategory = productSubCategoryRequest.IdCategory
+	subCategoryEntity.SubCategoryName = productSubCategoryRequest.SubCategoryName
+	subCategoryEntity.Icon = productSubCategoryRequest.Icon
+	subCategoryEntity.SortOrder = productSubCategoryRequest.SortOrder
+	subCategoryEntity.IsActive = productSubCategoryRequest.IsActive
+	subCategoryEntity.CreatedAt = time.Now()
+
+	subCategory, err := service.ProductCategoryRepositoryInterface.CreateSubCategory(service.DB, *subCategoryEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	service.InvalidateCategoryTree()
+	subCategoryResponse = response.ToFindProductSubCategoryResponse(subCategory)
+	return subCategoryResponse
+}
+
+func (service *ProductCategoryServiceImplementation) UpdateSubCategory(requestId string, productSubCategoryRequest *request.ProductSubCategoryRequest) (subCategoryResponse response.FindProductSubCategoryResponse) {
+	request.ValidateProductCategoryRequest(service.Validate, productSubCategoryRequest, requestId, service.Logger)
+
+	subCategory, err := service.ProductCategoryRepositoryInterface.FindSubCategoryById(service.DB, productSubCategoryRequest.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if subCategory.Id == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("sub category not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+	service.findCategoryById(requestId, productSubCategoryRequest.IdCategory)
+
+	subCategory.IdCategory = productSubCategoryRequest.IdCategory
+	subCategory.SubCategoryName = productSubCategoryRequest.SubCategoryName
+	subCategory.Icon = productSubCategoryRequest.Icon
+	subCategory.SortOrder = productSubCategoryRequest.SortOrder
+	subCategory.IsActive = productSubCategoryRequest.IsActive
+
+	subCategory, err = service.ProductCategoryRepositoryInterface.UpdateSubCategory(service.DB, subCategory.Id, subCategory)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	service.InvalidateCategoryTree()
+	subCategoryResponse = response.ToFindProductSubCategoryResponse(subCategory)
+	return subCategoryResponse
+}
+
+func (service *ProductCategoryServiceImplementation) DeleteSubCategory(requestId string, productCategoryDeleteRequest *request.ProductCategoryDeleteRequest) {
+	request.ValidateProductCategoryRequest(service.Validate, productCategoryDeleteRequest, requestId, service.Logger)
+
+	subCategory, err := service.ProductCategoryRepositoryInterface.FindSubCategoryById(service.DB, productCategoryDeleteRequest.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if subCategory.Id == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("sub category not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	totalProducts, err := service.ProductCategoryRepositoryInterface.CountProductsBySubCategory(service.DB, subCategory.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if totalProducts > 0 {
+		exceptions.PanicIfBadRequest(errors.New("sub category has products"), requestId, []string{"sub category still has products"}, service.Logger)
+	}
+
+	err = service.ProductCategoryRepositoryInterface.DeleteSubCategory(service.DB, subCategory.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	service.InvalidateCategoryTree()
+}
+
+func (service *ProductCategoryServiceImplementation) findCategoryById(requestId string, idCategory int) (category entity.ProductCategory) {
+	category, err := service.ProductCategoryRepositoryInterface.FindCategoryById(service.DB, idCategory)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if category.Id == 0 {
+		exceptions.PanicIfRecordNotFound(errors.New("category not found"), requestId, []string{"category not found"}, service.Logger)
+	}
+	return category
+}
+
+func (service *ProductCategoryServiceImplementation) findCategoryTree(requestId string, includeInactive bool) (categoryResponses []response.FindProductCategoryResponse) {
+	categories, err := service.ProductCategoryRepositoryInterface.FindAllCategories(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productCategoryCounts, err := service.ProductCategoryRepositoryInterface.FindProductCategoryCounts(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	categoryResponses = response.ToFindProductCategoryTreeResponses(categories, productCategoryCounts, includeInactive)
+	return categoryResponses
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/media"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Batas ukuran file upload jika tidak diatur di config
+const productImageDefaultMaxUploadSize = 5 * 1024 * 1024
+
+type ProductImageServiceInterface interface {
+	UploadProductImage(requestId string, productImageUploadRequest *request.ProductImageUploadRequest, fileHeader *multipart.FileHeader) (productImageResponse response.ProductImageResponse)
+	ReorderProductImages(requestId string, productImageReorderRequest *request.ProductImageReorderRequest) (productImageResponses []response.ProductImageResponse)
+	DeleteProductImage(requestId string, productImageDeleteRequest *request.ProductImageDeleteRequest)
+}
+
+type ProductImageServiceImplementation struct {
+	ConfigWebserver                 config.Webserver
+	ConfigMedia                     config.Media
+	DB                              *gorm.DB
+	Validate                        *validator.Validate
+	Logger                          *logrus.Logger
+	Storage                         media.Storage
+	ProductImageRepositoryInterface mysql.ProductImageRepositoryInterface
+	ProductRepositoryInterface      mysql.ProductRepositoryInterface
+	ProductSearchServiceInterface   ProductSearchServiceInterface
+}
+
+func NewProductImageService(configWebserver config.Webserver,
+	configMedia config.Media,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	storage media.Storage,
+	productImageRepositoryInterface mysql.ProductImageRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) ProductImageServiceInterface {
+	return &ProductImageServiceImplementation{
+		ConfigWebserver:                 configWebserver,
+		ConfigMedia:                     configMedia,
+		DB:                              DB,
+		Validate:                        validate,
+		Logger:                          logger,
+		Storage:                         storage,
+		ProductImageRepositoryInterface: productImageRepositoryInterface,
+		ProductRepositoryInterface:      productRepositoryInterface,
+		ProductSearchServiceInterface:   productSearchServiceInterface,
+	}
+}
+
+// Gambar baru masuk di urutan terakhir galeri
+func (service *ProductImageServiceImplementation) UploadProductImage(requestId string, productImageUploadRequest *request.ProductImageUploadRequest, fileHeader *multipart.FileHeader) (productImageResponse response.ProductImageResponse) {
+	request.ValidateProductImageRequest(service.Validate, productImageUploadRequest, requestId, service.Logger)
+
+	product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, productImageUploadRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger)
+	}
+
+	maxUploadSize := service.ConfigMedia.MaxUploadSize
+	if maxUploadSize <= 0 {
+		maxUploadSize = productImageDefaultMaxUploadSize
+	}
+	if fileHeader.Size > maxUploadSize {
+		exceptions.PanicIfBadRequest(errors.New("image too large"), requestId, []string{"image file is too large"}, service.Logger)
+	}
+	file, err := fileHeader.Open()
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxUploadSize+1))
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if int64(len(data)) > maxUploadSize {
+		exceptions.PanicIfBadRequest(errors.New("image too large"), requestId, []string{"image file is too large"}, service.Logger)
+	}
+
+	width, height, imageVariants, err := media.ProcessImage(data)
+	if err != nil {
+		exceptions.PanicIfBadRequest(err, requestId, []string{"image must be a jpeg, png or gif within the allowed dimension"}, service.Logger)
+	}
+
+	productImageEntity := &entity.ProductImage{}
+	productImageEntity.Id = utilities.RandomUUID()
+	productImageEntity.IdProduct = product.Id
+	productImageEntity.FileKey = "products/" + product.Id + "/" + productImageEntity.Id
+	productImageEntity.Width = width
+	productImageEntity.Height = height
+	productImageEntity.Position = len(product.ProductImages)
+	productImageEntity.CreatedAt = time.Now()
+
+	for _, imageVariant := range imageVariants {
+		url, err := service.Storage.Save(productImageVariantKey(productImageEntity.FileKey, imageVariant.Name), imageVariant.Data)
+		if err != nil {
+			service.deleteProductImageFiles(productImageEntity.FileKey)
+			exceptions.PanicIfError(err, requestId, service.Logger)
+		}
+		switch imageVariant.Name {
+		case media.VariantThumbnail:
+			productImageEntity.ThumbnailUrl = url
+		case media.VariantMedium:
+			productImageEntity.MediumUrl = url
+		case media.VariantLarge:
+			productImageEntity.LargeUrl = url
+		}
+	}
+
+	tx := service.DB.Begin()
+	productImage, err := service.ProductImageRepositoryInterface.CreateProductImage(tx, *productImageEntity)
+	if err != nil {
+		service.deleteProductImageFiles(productImageEntity.FileKey)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error create product image"}, service.Logger, tx)
+	}
+	service.updateProductPicture(tx, requestId, product.Id)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	productImageResponse = response.ToProductImageResponse(productImage)
+	return productImageResponse
+}
+
+func (service *ProductImageServiceImplementation) ReorderProductImages(requestId string, productImageReorderRequest *request.ProductImageReorderRequest) (productImageResponses []response.ProductImageResponse) {
+	request.ValidateProductImageRequest(service.Validate, productImageReorderRequest, requestId, service.Logger)
+
+	productImages, err := service.ProductImageRepositoryInterface.FindProductImagesByIdProduct(service.DB, productImageReorderRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	productImagesById := make(map[string]entity.ProductImage, len(productImages))
+	for _, productImage := range productImages {
+		productImagesById[productImage.Id] = productImage
+	}
+	ordered := make(map[string]bool, len(productImageReorderRequest.IdImages))
+	for _, idImage := range productImageReorderRequest.IdImages {
+		if _, ok := productImagesById[idImage]; !ok || ordered[idImage] {
+			exceptions.PanicIfBadRequest(errors.New("invalid image order"), requestId, []string{"id_images must list every product image once"}, service.Logger)
+		}
+		ordered[idImage] = true
+	}
+	if len(ordered) != len(productImages) {
+		exceptions.PanicIfBadRequest(errors.New("invalid image order"), requestId, []string{"id_images must list every product image once"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	for position, idImage := range productImageReorderRequest.IdImages {
+		err := service.ProductImageRepositoryInterface.UpdateProductImagePosition(tx, idImage, position)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product image"}, service.Logger, tx)
+	}
+	service.updateProductPicture(tx, requestId, productImageReorderRequest.IdProduct)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	productImages, err = service.ProductImageRepositoryInterface.FindProductImagesByIdProduct(service.DB, productImageReorderRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productImageResponses = response.ToProductImageResponses(productImages)
+	return productImageResponses
+}
+
+// File dihapus setelah data terhapus, kegagalan hapus file hanya dicatat
+func (service *ProductImageServiceImplementation) DeleteProductImage(requestId string, productImageDeleteRequest *request.ProductImageDeleteRequest) {
+	request.ValidateProductImageRequest(service.Validate, productImageDeleteRequest, requestId, service.Logger)
+
+	productImage, err := service.ProductImageRepositoryInterface.FindProductImageById(service.DB, productImageDeleteRequest.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if productImage.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product image not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	err = service.ProductImageRepositoryInterface.DeleteProductImage(tx, productImage.Id)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error delete product image"}, service.Logger, tx)
+
+	productImages, err := service.ProductImageRepositoryInterface.FindProductImagesByIdProduct(tx, productImage.IdProduct)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error delete product image"}, service.Logger, tx)
+	for position, remainingImage := range productImages {
+		if remainingImage.Position == position {
+			continue
+		}
+		err := service.ProductImageRepositoryInterface.UpdateProductImagePosition(tx, remainingImage.Id, position)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error delete product image"}, service.Logger, tx)
+	}
+	service.updateProductPicture(tx, requestId, productImage.IdProduct)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+	service.deleteProductImageFiles(productImage.FileKey)
+}
+
+// Gambar pertama galeri menjadi picture_url dan thumbnail produk untuk aplikasi lama.
+// Produk tanpa galeri tetap memakai gambar yang diisi manual
+func (service *ProductImageServiceImplementation) updateProductPicture(tx *gorm.DB, requestId string, idProduct string) {
+	productImages, err := service.ProductImageRepositoryInterface.FindProductImagesByIdProduct(tx, idProduct)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product picture"}, service.Logger, tx)
+	if len(productImages) == 0 {
+		return
+	}
+
+	productEntity := &entity.Product{}
+	productEntity.PictureUrl = productImages[0].LargeUrl
+	productEntity.Thumbnail = productImages[0].ThumbnailUrl
+	_, err = service.ProductRepositoryInterface.UpdateProductPicture(tx, idProduct, *productEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update product picture"}, service.Logger, tx)
+}
+
+func (service *ProductImageServiceImplementation) deleteProductImageFiles(fileKey string) {
+	for _, variantName := range []string{media.VariantThumbnail, media.VariantMedium, media.VariantLarge} {
+		if err := service.Storage.Delete(productImageVariantKey(fileKey, variantName)); err != nil {
+			service.Logger.Error(err)
+		}
+	}
+}
+
+func productImageVariantKey(fileKey string, variantName string) string {
+	return fileKey + "_" + variantName + ".jpg"
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Sumber perubahan harga
+const (
+	PriceSourceAdmin  = "admin"
+	PriceSourceImport = "import"
+)
+
+// Badge turun harga dibandingkan dengan harga jual tertinggi dalam periode ini
+const productPriceDropPeriod = 7 * 24 * time.Hour
+
+type ProductPriceHistoryServiceInterface interface {
+	RecordProductPriceChange(tx *gorm.DB, requestId string, before entity.Product, after entity.Product, source string, createdBy string)
+	FindProductPriceHistories(requestId string, productPriceHistoryRequest *request.ProductPriceHistoryRequest) (productPriceHistoryListResponse response.ProductPriceHistoryListResponse)
+	MarkPriceDroppedProducts(requestId string, productResponses []response.FindProductResponse)
+}
+
+type ProductPriceHistoryServiceImplementation struct {
+	ConfigWebserver                        config.Webserver
+	DB                                     *gorm.DB
+	Validate                               *validator.Validate
+	Logger                                 *logrus.Logger
+	ProductPriceHistoryRepositoryInterface mysql.ProductPriceHistoryRepositoryInterface
+	ProductRepositoryInterface             mysql.ProductRepositoryInterface
+}
+
+func NewProductPriceHistoryService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productPriceHistoryRepositoryInterface mysql.ProductPriceHistoryRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface) ProductPriceHistoryServiceInterface {
+	return &ProductPriceHistoryServiceImplementation{
+		ConfigWebserver:                        configWebserver,
+		DB:                                     DB,
+		Validate:                               validate,
+		Logger:                                 logger,
+		ProductPriceHistoryRepositoryInterface: productPriceHistoryRepositoryInterface,
+		ProductRepositoryInterface:             productRepositoryInterface,
+	}
+}
+
+// Dicatat dalam transaksi yang sama dengan perubahan produk, before kosong untuk produk baru.
+// after harus sudah berisi harga dan diskon yang baru
+func (service *ProductPriceHistoryServiceImplementation) RecordProductPriceChange(tx *gorm.DB, requestId string, before entity.Product, after entity.Product, source string, createdBy string) {
+	oldDiscount := describeProductDiscounts(before.ProductDiscounts)
+	newDiscount := describeProductDiscounts(after.ProductDiscounts)
+	if before.Id != "" && before.Price == after.Price && oldDiscount == newDiscount {
+		return
+	}
+
+	now := time.Now()
+	productPriceHistoryEntity := &entity.ProductPriceHistory{}
+	productPriceHistoryEntity.Id = utilities.RandomUUID()
+	productPriceHistoryEntity.IdProduct = after.Id
+	productPriceHistoryEntity.OldPrice = before.Price
+	productPriceHistoryEntity.NewPrice = after.Price
+	if before.Id != "" {
+		productPriceHistoryEntity.OldSellingPrice = pricing.EvaluateProductPricing(before, entity.ProductVariant{}, now).Price
+	}
+	productPriceHistoryEntity.NewSellingPrice = pricing.EvaluateProductPricing(after, entity.ProductVariant{}, now).Price
+	productPriceHistoryEntity.OldDiscount = oldDiscount
+	productPriceHistoryEntity.NewDiscount = newDiscount
+	productPriceHistoryEntity.Source = source
+	productPriceHistoryEntity.CreatedBy = createdBy
+	productPriceHistoryEntity.CreatedAt = now
+
+	_, err := service.ProductPriceHistoryRepositoryInterface.CreateProductPriceHistory(tx, *productPriceHistoryEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error create product price history"}, service.Logger, tx)
+}
+
+func (service *ProductPriceHistoryServiceImplementation) FindProductPriceHistories(requestId string, productPriceHistoryRequest *request.ProductPriceHistoryRequest) (productPriceHistoryListResponse response.ProductPriceHistoryListResponse) {
+	request.ValidateProductPriceHistoryRequest(service.Validate, productPriceHistoryRequest, requestId, service.Logger)
+
+	product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, productPriceHistoryRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger)
+	}
+
+	pagination := modelService.Pagination{Page: productPriceHistoryRequest.Page, Limit: productPriceHistoryRequest.Limit}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = 20
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+
+	productPriceHistories, totalData, err := service.ProductPriceHistoryRepositoryInterface.FindProductPriceHistories(service.DB, product.Id, pagination)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	sellingPrice := pricing.EvaluateProductPricing(product, entity.ProductVariant{}, time.Now()).Price
+	productPriceHistoryListResponse = response.ToProductPriceHistoryListResponse(product, sellingPrice, productPriceHistories, pagination, totalData)
+	return productPriceHistoryListResponse
+}
+
+// Produk ditandai turun harga jika harga jualnya sekarang lebih murah dari harga jual sebelum perubahan dalam periode badge
+func (service *ProductPriceHistoryServiceImplementation) MarkPriceDroppedProducts(requestId string, productResponses []response.FindProductResponse) {
+	if len(productResponses) == 0 {
+		return
+	}
+
+	var idProducts []string
+	for _, productResponse := range productResponses {
+		idProducts = append(idProducts, productResponse.Id)
+	}
+	highestPrices, err := service.ProductPriceHistoryRepositoryInterface.FindHighestOldSellingPrices(service.DB, idProducts, time.Now().Add(-productPriceDropPeriod))
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	for i := range productResponses {
+		sellingPrice := productResponses[i].Price
+		if productResponses[i].FlagPromo == "true" {
+			sellingPrice = productResponses[i].Nominal
+		}
+		if highestPrice := highestPrices[productResponses[i].Id]; highestPrice > sellingPrice {
+			productResponses[i].PriceDropped = true
+			productResponses[i].PriceDropFrom = highestPrice
+		}
+	}
+}
+
+// Ringkasan diskon yang menyala untuk dibandingkan dan ditampilkan di riwayat harga
+func describeProductDiscounts(productDiscounts []entity.ProductDiscount) string {
+	var descriptions []string
+	for _, productDiscount := range productDiscounts {
+		if productDiscount.FlagPromo != "true" {
+			continue
+		}
+		description := productDiscount.DiscountType + " " + strconv.FormatFloat(productDiscount.Nominal, 'f', -1, 64)
+		if productDiscount.DiscountType == pricing.DiscountTypePercentage {
+			description = productDiscount.DiscountType + " " + strconv.FormatFloat(productDiscount.Percentage, 'f', -1, 64) + "%"
+		}
+		if !productDiscount.StartDate.IsZero() || !productDiscount.EndDate.IsZero() {
+			description = description + " (" + formatDiscountDate(productDiscount.StartDate) + " - " + formatDiscountDate(productDiscount.EndDate) + ")"
+		}
+		descriptions = append(descriptions, description)
+	}
+	sort.Strings(descriptions)
+	return strings.Join(descriptions, "; ")
+}
+
+func formatDiscountDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/search"
+	"gorm.io/gorm"
+)
+
+// Index dibangun ulang jika sudah lebih lama dari ttl, untuk perubahan produk dari luar service ini
+const productSearchIndexTtl = 5 * time.Minute
+
+// Bobot field produk pada index pencarian
+const (
+	productSearchWeightName        = 3.0
+	productSearchWeightVariant     = 2.0
+	productSearchWeightBrand       = 2.0
+	productSearchWeightCategory    = 1.5
+	productSearchWeightDescription = 1.0
+)
+
+// Dimensi filter, dipakai untuk menghitung facet tanpa filter dimensinya sendiri
+const (
+	productSearchFilterCategory    = "category"
+	productSearchFilterSubCategory = "sub_category"
+	productSearchFilterBrand       = "brand"
+	productSearchFilterPrice       = "price"
+	productSearchFilterStock       = "stock"
+	productSearchFilterPromo       = "promo"
+	productSearchFilterAttribute   = "attribute:"
+)
+
+type ProductSearchServiceInterface interface {
+	SearchProducts(requestId string, productSearchRequest *request.ProductSearchRequest, pagination modelService.Pagination) (productSearch modelService.ProductSearch)
+	InvalidateProductSearchIndex()
+}
+
+type ProductSearchServiceImplementation struct {
+	ConfigWebserver            config.Webserver
+	DB                         *gorm.DB
+	Logger                     *logrus.Logger
+	ProductRepositoryInterface mysql.ProductRepositoryInterface
+	mutex                      sync.RWMutex
+	index                      *search.Index
+	products                   map[string]entity.Product
+	productIds                 []string
+	productSoldQty             map[string]int
+	builtAt                    time.Time
+	dirty                      bool
+}
+
+func NewProductSearchService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	productRepositoryInterface mysql.ProductRepositoryInterface) ProductSearchServiceInterface {
+	return &ProductSearchServiceImplementation{
+		ConfigWebserver:            configWebserver,
+		DB:                         DB,
+		Logger:                     logger,
+		ProductRepositoryInterface: productRepositoryInterface,
+		dirty:                      true,
+	}
+}
+
+func (service *ProductSearchServiceImplementation) InvalidateProductSearchIndex() {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.dirty = true
+}
+
+func (service *ProductSearchServiceImplementation) SearchProducts(requestId string, productSearchRequest *request.ProductSearchRequest, pagination modelService.Pagination) (productSearch modelService.ProductSearch) {
+	index, products, productIds, productSoldQty := service.FindProductSearchIndex(requestId)
+
+	// Tanpa kata kunci semua produk menjadi kandidat, diurutkan berdasarkan nama
+	candidateIds := productIds
+	hasQuery := len(search.Tokenize(productSearchRequest.Product)) > 0
+	if hasQuery {
+		candidateIds = nil
+		for _, hit := range index.Search(productSearchRequest.Product) {
+			candidateIds = append(candidateIds, hit.Id)
+		}
+	}
+
+	categoryFacets := make(map[string]*modelService.ProductSearchFacet)
+	subCategoryFacets := make(map[string]*modelService.ProductSearchFacet)
+	brandFacets := make(map[string]*modelService.ProductSearchFacet)
+	attributeFacets := make(map[string]*modelService.ProductSearchAttributeFacet)
+	priceFacetFound := false
+	now := time.Now()
+	productPrices := make(map[string]float64)
+	var results []entity.Product
+	for _, id := range candidateIds {
+		product := products[id]
+		productPricing := pricing.EvaluateProductPricing(product, entity.ProductVariant{}, now)
+		productPrices[id] = productPricing.Price
+		failed := productSearchFailedFilters(productSearchRequest, pagination.Attributes, product, productPricing)
+
+		if productSearchOnlyFailed(failed, productSearchFilterCategory) {
+			countProductSearchFacet(categoryFacets, strconv.Itoa(product.IdCategory), product.ProductCategory.CategoryName)
+		}
+		if productSearchOnlyFailed(failed, productSearchFilterSubCategory) {
+			countProductSearchFacet(subCategoryFacets, strconv.Itoa(product.IdSubCategory), product.ProductSubCategory.SubCategoryName)
+		}
+		if productSearchOnlyFailed(failed, productSearchFilterBrand) && product.IdBrand != "" {
+			countProductSearchFacet(brandFacets, product.IdBrand, product.ProductBrand.BrandName)
+		}
+		if productSearchOnlyFailed(failed, productSearchFilterPrice) {
+			price := productPricing.Price
+			if !priceFacetFound || price < productSearch.PriceMin {
+				productSearch.PriceMin = price
+			}
+			if !priceFacetFound || price > productSearch.PriceMax {
+				productSearch.PriceMax = price
+			}
+			priceFacetFound = true
+		}
+		if productSearchOnlyFailed(failed, productSearchFilterStock) && product.Stock > 0 {
+			productSearch.InStockCount++
+		}
+		if productSearchOnlyFailed(failed, productSearchFilterPromo) && productPricing.OnPromo {
+			productSearch.OnPromoCount++
+		}
+		for _, productAttributeValue := range product.ProductAttributeValues {
+			productAttribute := productAttributeValue.ProductAttribute
+			if productAttribute.IsFilterable == 1 && productSearchOnlyFailed(failed, productSearchFilterAttribute+productAttribute.AttributeCode) {
+				countProductSearchAttributeFacet(attributeFacets, productAttribute, productAttributeValue.Value)
+			}
+		}
+
+		if len(failed) == 0 {
+			results = append(results, product)
+		}
+	}
+
+	sortProductSearchResults(results, productPrices, productSoldQty, pagination.Sort, hasQuery)
+
+	productSearch.TotalData = int64(len(results))
+	if offset := pagination.Offset; offset < len(results) {
+		end := offset + pagination.Limit
+		if end > len(results) {
+			end = len(results)
+		}
+		productSearch.Products = results[offset:end]
+	}
+	productSearch.CategoryFacets = sortProductSearchFacets(categoryFacets)
+	productSearch.SubCategoryFacets = sortProductSearchFacets(subCategoryFacets)
+	productSearch.BrandFacets = sortProductSearchFacets(brandFacets)
+	productSearch.AttributeFacets = sortProductSearchAttributeFacets(attributeFacets)
+	return productSearch
+}
+
+// Mengembalikan index yang berlaku, dibangun ulang jika ditandai berubah atau sudah kedaluwarsa
+func (service *ProductSearchServiceImplementation) FindProductSearchIndex(requestId string) (*search.Index, map[string]entity.Product, []string, map[string]int) {
+	service.mutex.RLock()
+	if !service.dirty && time.Since(service.builtAt) < productSearchIndexTtl {
+		defer service.mutex.RUnlock()
+		return service.index, service.products, service.productIds, service.productSoldQty
+	}
+	service.mutex.RUnlock()
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if !service.dirty && time.Since(service.builtAt) < productSearchIndexTtl {
+		return service.index, service.products, service.productIds, service.productSoldQty
+	}
+
+	productEntities, err := service.ProductRepositoryInterface.FindAllPublishedProducts(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	productSoldQty, err := service.ProductRepositoryInterface.FindProductSoldQty(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	sort.SliceStable(productEntities, func(i, j int) bool {
+		return strings.ToLower(productEntities[i].ProductName) < strings.ToLower(productEntities[j].ProductName)
+	})
+
+	documents := make([]search.Document, 0, len(productEntities))
+	products := make(map[string]entity.Product, len(productEntities))
+	productIds := make([]string, 0, len(productEntities))
+	for _, product := range productEntities {
+		var variantNames []string
+		for _, productVariant := range product.ProductVariants {
+			variantNames = append(variantNames, productVariant.VariantName)
+		}
+		documents = append(documents, search.Document{
+			Id: product.Id,
+			Fields: []search.Field{
+				{Text: product.ProductName, Weight: productSearchWeightName},
+				{Text: strings.Join(variantNames, " "), Weight: productSearchWeightVariant},
+				{Text: product.ProductBrand.BrandName, Weight: productSearchWeightBrand},
+				{Text: product.ProductCategory.CategoryName, Weight: productSearchWeightCategory},
+				{Text: product.Description, Weight: productSearchWeightDescription},
+			},
+		})
+		products[product.Id] = product
+		productIds = append(productIds, product.Id)
+	}
+
+	service.index = search.NewIndex(documents)
+	service.products = products
+	service.productIds = productIds
+	service.productSoldQty = productSoldQty
+	service.builtAt = time.Now()
+	service.dirty = false
+	return service.index, service.products, service.productIds, service.productSoldQty
+}
+
+// Daftar dimensi filter yang tidak dipenuhi produk
+func productSearchFailedFilters(productSearchRequest *request.ProductSearchRequest, productAttributeFilters []modelService.ProductAttributeFilter, product entity.Product, productPricing modelService.ProductPricing) (failed []string) {
+	if productSearchRequest.IdCategory != 0 && product.IdCategory != productSearchRequest.IdCategory {
+		failed = append(failed, productSearchFilterCategory)
+	}
+	if productSearchRequest.IdSubCategory != 0 && product.IdSubCategory != productSearchRequest.IdSubCategory {
+		failed = append(failed, productSearchFilterSubCategory)
+	}
+	if productSearchRequest.IdBrand != "" && product.IdBrand != productSearchRequest.IdBrand {
+		failed = append(failed, productSearchFilterBrand)
+	}
+	if (productSearchRequest.PriceMin > 0 && productPricing.Price < productSearchRequest.PriceMin) || (productSearchRequest.PriceMax > 0 && productPricing.Price > productSearchRequest.PriceMax) {
+		failed = append(failed, productSearchFilterPrice)
+	}
+	if productSearchRequest.InStock && product.Stock <= 0 {
+		failed = append(failed, productSearchFilterStock)
+	}
+	if productSearchRequest.OnPromo && !productPricing.OnPromo {
+		failed = append(failed, productSearchFilterPromo)
+	}
+	for _, productAttributeFilter := range productAttributeFilters {
+		if !productSearchMatchAttribute(product, productAttributeFilter) {
+			failed = append(failed, productSearchFilterAttribute+productAttributeFilter.AttributeCode)
+		}
+	}
+	return failed
+}
+
+// Produk lolos jika salah satu nilainya sama dengan salah satu nilai filter, sama seperti filter di daftar produk
+func productSearchMatchAttribute(product entity.Product, productAttributeFilter modelService.ProductAttributeFilter) bool {
+	for _, productAttributeValue := range product.ProductAttributeValues {
+		productAttribute := productAttributeValue.ProductAttribute
+		if productAttribute.IsFilterable != 1 || productAttribute.AttributeCode != productAttributeFilter.AttributeCode {
+			continue
+		}
+		for _, value := range productAttributeFilter.Values {
+			if strings.EqualFold(productAttributeValue.Value, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Produk dihitung pada facet jika lolos semua filter selain filter facet itu sendiri
+func productSearchOnlyFailed(failed []string, filter string) bool {
+	return len(failed) == 0 || (len(failed) == 1 && failed[0] == filter)
+}
+
+func countProductSearchFacet(facets map[string]*modelService.ProductSearchFacet, id string, name string) {
+	if facets[id] == nil {
+		facets[id] = &modelService.ProductSearchFacet{Id: id, Name: name}
+	}
+	facets[id].Count++
+}
+
+func countProductSearchAttributeFacet(attributeFacets map[string]*modelService.ProductSearchAttributeFacet, productAttribute entity.ProductAttribute, value string) {
+	if attributeFacets[productAttribute.AttributeCode] == nil {
+		attributeFacets[productAttribute.AttributeCode] = &modelService.ProductSearchAttributeFacet{
+			AttributeCode: productAttribute.AttributeCode,
+			AttributeName: productAttribute.AttributeName,
+			Unit:          productAttribute.Unit,
+			Position:      productAttribute.Position,
+			Values:        make(map[string]*modelService.ProductSearchFacet),
+		}
+	}
+	countProductSearchFacet(attributeFacets[productAttribute.AttributeCode].Values, value, value)
+}
+
+func sortProductSearchAttributeFacets(attributeFacets map[string]*modelService.ProductSearchAttributeFacet) (productSearchAttributeFacets []modelService.ProductSearchAttributeFacet) {
+	for _, attributeFacet := range attributeFacets {
+		attributeFacet.Facets = sortProductSearchFacets(attributeFacet.Values)
+		productSearchAttributeFacets = append(productSearchAttributeFacets, *attributeFacet)
+	}
+	sort.Slice(productSearchAttributeFacets, func(i, j int) bool {
+		if productSearchAttributeFacets[i].Position != productSearchAttributeFacets[j].Position {
+			return productSearchAttributeFacets[i].Position < productSearchAttributeFacets[j].Position
+		}
+		return productSearchAttributeFacets[i].AttributeName < productSearchAttributeFacets[j].AttributeName
+	})
+	return productSearchAttributeFacets
+}
+
+func sortProductSearchFacets(facets map[string]*modelService.ProductSearchFacet) (productSearchFacets []modelService.ProductSearchFacet) {
+	for _, facet := range facets {
+		productSearchFacets = append(productSearchFacets, *facet)
+	}
+	sort.Slice(productSearchFacets, func(i, j int) bool {
+		if productSearchFacets[i].Count != productSearchFacets[j].Count {
+			return productSearchFacets[i].Count > productSearchFacets[j].Count
+		}
+		if productSearchFacets[i].Name != productSearchFacets[j].Name {
+			return productSearchFacets[i].Name < productSearchFacets[j].Name
+		}
+		return productSearchFacets[i].Id < productSearchFacets[j].Id
+	})
+	return productSearchFacets
+}
+
+// Urutan relevance mengikuti skor index dengan produk yang stoknya habis di akhir
+func sortProductSearchResults(products []entity.Product, productPrices map[string]float64, productSoldQty map[string]int, sortBy string, hasQuery bool) {
+	if sortBy == "" {
+		sortBy = "name_asc"
+		if hasQuery {
+			sortBy = "relevance"
+		}
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		switch sortBy {
+		case "price_asc":
+			return productPrices[products[i].Id] < productPrices[products[j].Id]
+		case "price_desc":
+			return productPrices[products[i].Id] > productPrices[products[j].Id]
+		case "name_desc":
+			return strings.ToLower(products[i].ProductName) > strings.ToLower(products[j].ProductName)
+		case "name_asc":
+			return strings.ToLower(products[i].ProductName) < strings.ToLower(products[j].ProductName)
+		case "newest":
+			return products[i].CreatedAt.After(products[j].CreatedAt)
+		case "bestselling":
+			return productSoldQty[products[i].Id] > productSoldQty[products[j].Id]
+		default:
+			return products[i].Stock > 0 && products[j].Stock <= 0
+		}
+	})
+}
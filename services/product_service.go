@@ -2,87 +2,212 @@ package services
 
 import (
 	"errors"
+	"strings"
 
+	"github.com/go-playground/validator"
 	"github.com/sirupsen/logrus"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
 	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
 	"gorm.io/gorm"
 )
 
+const productPaginationDefaultLimit = 20
+
 type ProductServiceInterface interface {
-	FindAllProducts(requestId string, limit int, page int) (productResponses []response.FindProductResponse)
-	FindProductsBySearch(requestId string, productName string) (productsResponses []response.FindProductResponse)
-	FindProductById(requestId string, id string) (productsResponse response.FindProductResponse)
-	FindProductByIdCategory(requestId string, idCategory string) (productsResponses []response.FindProductResponse)
-	FindProductByIdSubCategory(requestId string, idSubCategory string) (productsResponses []response.FindProductResponse)
-	FindProductByIdBrand(requestId string, idBrand string) (productsResponses []response.FindProductResponse)
+	FindAllProducts(requestId string, idUser string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse)
+	FindProductsBySearch(requestId string, idUser string, productSearchRequest *request.ProductSearchRequest) (productSearchResponse response.ProductSearchResponse)
+	FindProductById(requestId string, idUser string, id string, idSearchLog string) (productsResponse response.FindProductResponse)
+	FindProductByIdCategory(requestId string, idUser string, idCategory string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse)
+	FindProductByIdSubCategory(requestId string, idUser string, idSubCategory string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse)
+	FindProductByIdBrand(requestId string, idUser string, idBrand string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse)
+	MarkWishlistedProducts(requestId string, idUser string, productResponses []response.FindProductResponse)
 }
 
 type ProductServiceImplementation struct {
-	ConfigWebserver            config.Webserver
-	DB                         *gorm.DB
-	Logger                     *logrus.Logger
-	ProductRepositoryInterface mysql.ProductRepositoryInterface
-	ConfigPayment              config.Payment
+	ConfigWebserver                     config.Webserver
+	DB                                  *gorm.DB
+	Validate                            *validator.Validate
+	Logger                              *logrus.Logger
+	ProductRepositoryInterface          mysql.ProductRepositoryInterface
+	WishlistRepositoryInterface         mysql.WishlistRepositoryInterface
+	ConfigPayment                       config.Payment
+	ProductSearchServiceInterface       ProductSearchServiceInterface
+	ProductPriceHistoryServiceInterface ProductPriceHistoryServiceInterface
+	SearchAnalyticsServiceInterface     SearchAnalyticsServiceInterface
 }
 
 func NewProductService(
 	configWebserver config.Webserver,
-	DB *gorm.DB, logger *logrus.Logger,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
 	productRepositoryInterface mysql.ProductRepositoryInterface,
-	configPayment config.Payment) ProductServiceInterface {
+	wishlistRepositoryInterface mysql.WishlistRepositoryInterface,
+	configPayment config.Payment,
+	productSearchServiceInterface ProductSearchServiceInterface,
+	productPriceHistoryServiceInterface ProductPriceHistoryServiceInterface,
+	searchAnalyticsServiceInterface SearchAnalyticsServiceInterface) ProductServiceInterface {
 	return &ProductServiceImplementation{
-		ConfigWebserver:            configWebserver,
-		DB:                         DB,
-		Logger:                     logger,
-		ProductRepositoryInterface: productRepositoryInterface,
-		ConfigPayment:              configPayment,
+		ConfigWebserver:                     configWebserver,
+		DB:                                  DB,
+		Validate:                            validate,
+		Logger:                              logger,
+		ProductRepositoryInterface:          productRepositoryInterface,
+		WishlistRepositoryInterface:         wishlistRepositoryInterface,
+		ConfigPayment:                       configPayment,
+		ProductSearchServiceInterface:       productSearchServiceInterface,
+		ProductPriceHistoryServiceInterface: productPriceHistoryServiceInterface,
+		SearchAnalyticsServiceInterface:     searchAnalyticsServiceInterface,
 	}
 }
 
-func (service *ProductServiceImplementation) FindAllProducts(requestId string, limit int, page int) (productResponses []response.FindProductResponse) {
-	products, err := service.ProductRepositoryInterface.FindAllProducts(service.DB, limit, page)
+func (service *ProductServiceImplementation) FindAllProducts(requestId string, idUser string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse) {
+	pagination := service.FindPagination(requestId, paginationRequest)
+	products, total, err := service.ProductRepositoryInterface.FindAllProducts(service.DB, pagination)
 	exceptions.PanicIfError(err, requestId, service.Logger)
-	productResponses = response.ToFindProductResponses(products)
-	return productResponses
+	productListResponse = response.ToFindProductListResponse(products, pagination, total)
+	service.MarkWishlistedProducts(requestId, idUser, productListResponse.Products)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productListResponse.Products)
+	return productListResponse
 }
 
-func (service *ProductServiceImplementation) FindProductsBySearch(requestId string, productName string) (productResponses []response.FindProductResponse) {
-	products, err := service.ProductRepositoryInterface.FindProductsBySearch(service.DB, productName)
-	exceptions.PanicIfError(err, requestId, service.Logger)
-	productResponses = response.ToFindProductResponses(products)
-	return productResponses
+func (service *ProductServiceImplementation) FindProductsBySearch(requestId string, idUser string, productSearchRequest *request.ProductSearchRequest) (productSearchResponse response.ProductSearchResponse) {
+	request.ValidateProductSearchRequest(service.Validate, productSearchRequest, requestId, service.Logger)
+	if productSearchRequest.PriceMax > 0 && productSearchRequest.PriceMin > productSearchRequest.PriceMax {
+		exceptions.PanicIfBadRequest(errors.New("price min greater than price max"), requestId, []string{"price_min is greater than price_max"}, service.Logger)
+	}
+
+	pagination := service.FindPagination(requestId, &productSearchRequest.PaginationRequest)
+	productSearch := service.ProductSearchServiceInterface.SearchProducts(requestId, productSearchRequest, pagination)
+	productSearchResponse = response.ToProductSearchResponse(productSearchRequest.Product, pagination, productSearch)
+	// Hanya halaman pertama yang dicatat, halaman berikutnya memakai search_id dari halaman pertama
+	if pagination.Offset == 0 {
+		productSearchResponse.SearchId = service.SearchAnalyticsServiceInterface.RecordSearch(idUser, productSearchRequest.Product, productSearch.TotalData)
+	}
+	service.MarkWishlistedProducts(requestId, idUser, productSearchResponse.Products)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productSearchResponse.Products)
+	return productSearchResponse
 }
 
-func (service *ProductServiceImplementation) FindProductById(requestId string, id string) (productResponse response.FindProductResponse) {
+func (service *ProductServiceImplementation) FindProductById(requestId string, idUser string, id string, idSearchLog string) (productResponse response.FindProductResponse) {
 	product, _ := service.ProductRepositoryInterface.FindProductById(service.DB, id)
 	if product.Id == "" {
 		err := errors.New("product not found")
 		exceptions.PanicIfRecordNotFound(err, requestId, []string{"Not Found"}, service.Logger)
 	}
+	service.SearchAnalyticsServiceInterface.RecordSearchClick(idUser, idSearchLog, product.Id)
 	productResponse = response.ToFindProductResponse(product)
+	productResponses := []response.FindProductResponse{productResponse}
+	service.MarkWishlistedProducts(requestId, idUser, productResponses)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productResponses)
+	productResponse = productResponses[0]
 	return productResponse
 }
 
-func (service *ProductServiceImplementation) FindProductByIdCategory(requestId string, idCategory string) (productResponses []response.FindProductResponse) {
-	products, err := service.ProductRepositoryInterface.FindProductByIdCategory(service.DB, idCategory)
+func (service *ProductServiceImplementation) FindProductByIdCategory(requestId string, idUser string, idCategory string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse) {
+	pagination := service.FindPagination(requestId, paginationRequest)
+	products, total, err := service.ProductRepositoryInterface.FindProductByIdCategory(service.DB, idCategory, pagination)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productListResponse = response.ToFindProductListResponse(products, pagination, total)
+	service.MarkWishlistedProducts(requestId, idUser, productListResponse.Products)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productListResponse.Products)
+	return productListResponse
+}
+
+func (service *ProductServiceImplementation) FindProductByIdSubCategory(requestId string, idUser string, idSubCategory string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse) {
+	pagination := service.FindPagination(requestId, paginationRequest)
+	products, total, err := service.ProductRepositoryInterface.FindProductByIdSubCategory(service.DB, idSubCategory, pagination)
 	exceptions.PanicIfError(err, requestId, service.Logger)
-	productResponses = response.ToFindProductResponses(products)
-	return productResponses
+	productListResponse = response.ToFindProductListResponse(products, pagination, total)
+	service.MarkWishlistedProducts(requestId, idUser, productListResponse.Products)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productListResponse.Products)
+	return productListResponse
 }
 
-func (service *ProductServiceImplementation) FindProductByIdSubCategory(requestId string, idSubCategory string) (productResponses []response.FindProductResponse) {
-	products, err := service.ProductRepositoryInterface.FindProductByIdSubCategory(service.DB, idSubCategory)
+func (service *ProductServiceImplementation) FindProductByIdBrand(requestId string, idUser string, idBrand string, paginationRequest *request.PaginationRequest) (productListResponse response.FindProductListResponse) {
+	pagination := service.FindPagination(requestId, paginationRequest)
+	products, total, err := service.ProductRepositoryInterface.FindProductByIdBrand(service.DB, idBrand, pagination)
 	exceptions.PanicIfError(err, requestId, service.Logger)
-	productResponses = response.ToFindProductResponses(products)
-	return productResponses
+	productListResponse = response.ToFindProductListResponse(products, pagination, total)
+	service.MarkWishlistedProducts(requestId, idUser, productListResponse.Products)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productListResponse.Products)
+	return productListResponse
 }
 
-func (service *ProductServiceImplementation) FindProductByIdBrand(requestId string, idBrand string) (productResponses []response.FindProductResponse) {
-	products, err := service.ProductRepositoryInterface.FindProductByIdBrand(service.DB, idBrand)
+// Menandai produk yang ada di wishlist user, route tanpa token tidak punya user
+func (service *ProductServiceImplementation) MarkWishlistedProducts(requestId string, idUser string, productResponses []response.FindProductResponse) {
+	if idUser == "" || len(productResponses) == 0 {
+		return
+	}
+
+	var idProducts []string
+	for _, productResponse := range productResponses {
+		idProducts = append(idProducts, productResponse.Id)
+	}
+	wishlistedProductIds, err := service.WishlistRepositoryInterface.FindWishlistedProductIds(service.DB, idUser, idProducts)
 	exceptions.PanicIfError(err, requestId, service.Logger)
-	productResponses = response.ToFindProductResponses(products)
-	return productResponses
+
+	wishlisted := make(map[string]bool)
+	for _, idProduct := range wishlistedProductIds {
+		wishlisted[idProduct] = true
+	}
+	for i := range productResponses {
+		productResponses[i].IsWishlisted = wishlisted[productResponses[i].Id]
+	}
+}
+
+// Cursor dari halaman sebelumnya menggantikan page dan harus memakai sort yang sama
+func (service *ProductServiceImplementation) FindPagination(requestId string, paginationRequest *request.PaginationRequest) (pagination modelService.Pagination) {
+	request.ValidatePaginationRequest(service.Validate, paginationRequest, requestId, service.Logger)
+
+	pagination.Sort = paginationRequest.Sort
+	pagination.Limit = paginationRequest.Limit
+	if pagination.Limit == 0 {
+		pagination.Limit = productPaginationDefaultLimit
+	}
+	pagination.Page = paginationRequest.Page
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+	pagination.Attributes = service.FindProductAttributeFilters(requestId, paginationRequest.Attributes)
+
+	if paginationRequest.Cursor != "" {
+		sort, offset, err := utilities.DecodeCursor(paginationRequest.Cursor)
+		if err != nil || sort != pagination.Sort {
+			exceptions.PanicIfBadRequest(errors.New("invalid cursor"), requestId, []string{"cursor is invalid"}, service.Logger)
+		}
+		pagination.Offset = offset
+		pagination.Page = offset/pagination.Limit + 1
+	}
+	return pagination
+}
+
+// Filter atribut dikelompokkan per kode atribut dengan urutan sesuai kemunculan pertama
+func (service *ProductServiceImplementation) FindProductAttributeFilters(requestId string, attributes string) (productAttributeFilters []modelService.ProductAttributeFilter) {
+	indexes := make(map[string]int)
+	for _, attribute := range strings.Split(attributes, ",") {
+		if strings.TrimSpace(attribute) == "" {
+			continue
+		}
+		parts := strings.SplitN(attribute, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			exceptions.PanicIfBadRequest(errors.New("invalid attributes"), requestId, []string{"attributes is invalid"}, service.Logger)
+		}
+		attributeCode := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+		index, ok := indexes[attributeCode]
+		if !ok {
+			index = len(productAttributeFilters)
+			indexes[attributeCode] = index
+			productAttributeFilters = append(productAttributeFilters, modelService.ProductAttributeFilter{AttributeCode: attributeCode})
+		}
+		productAttributeFilters[index].Values = append(productAttributeFilters[index].Values, value)
+	}
+	return productAttributeFilters
 }
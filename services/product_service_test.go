@@ -0,0 +1,53 @@
+package services
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestFindPaginationCursor(t *testing.T) {
+	service := &ProductServiceImplementation{Validate: validator.New(), Logger: newTestLogger()}
+
+	pagination := service.FindPagination("request", &request.PaginationRequest{Limit: 20, Page: 1, Sort: "price_asc", Cursor: utilities.EncodeCursor("price_asc", 40)})
+	if pagination.Offset != 40 || pagination.Page != 3 || pagination.Sort != "price_asc" {
+		t.Errorf("got offset %d page %d sort %q, want offset 40 page 3 sort price_asc", pagination.Offset, pagination.Page, pagination.Sort)
+	}
+
+	pagination = service.FindPagination("request", &request.PaginationRequest{Page: 2})
+	if pagination.Offset != productPaginationDefaultLimit || pagination.Limit != productPaginationDefaultLimit {
+		t.Errorf("got offset %d limit %d without cursor", pagination.Offset, pagination.Limit)
+	}
+}
+
+func TestFindPaginationRejectsInvalidCursor(t *testing.T) {
+	service := &ProductServiceImplementation{Validate: validator.New(), Logger: newTestLogger()}
+	tests := []struct {
+		name              string
+		paginationRequest request.PaginationRequest
+	}{
+		{"malformed", request.PaginationRequest{Sort: "price_asc", Cursor: "!!!"}},
+		{"different sort", request.PaginationRequest{Sort: "price_desc", Cursor: utilities.EncodeCursor("price_asc", 20)}},
+		{"sort removed", request.PaginationRequest{Cursor: utilities.EncodeCursor("newest", 20)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("FindPagination accepted invalid cursor")
+				}
+			}()
+			service.FindPagination("request", &test.paginationRequest)
+		})
+	}
+}
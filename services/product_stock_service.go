@@ -0,0 +1,509 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Jenis transaksi di history stok
+const (
+	StockTxSale       = "sale"
+	StockTxReceiving  = "receiving"
+	StockTxAdjustment = "adjustment"
+	StockTxOpname     = "opname"
+	StockTxInitial    = "initial"
+	StockTxImport     = "import"
+)
+
+// Rentang kartu stok paling panjang
+const stockCardMaxDays = 366
+
+type ProductStockServiceInterface interface {
+	DecreaseOrderItemStock(tx *gorm.DB, requestId string, orderItem entity.OrderItem, description string)
+	PostStockMovement(tx *gorm.DB, requestId string, stockMovement modelService.StockMovement) (productStockHistory entity.ProductStockHistory, restocked bool)
+	StockIn(requestId string, idUser string, productStockInRequest *request.ProductStockInRequest) (productStockMovementResponse response.ProductStockMovementResponse)
+	ReceiveGoods(requestId string, idUser string, goodsReceivingRequest *request.GoodsReceivingRequest) (goodsReceivingResponse response.GoodsReceivingResponse)
+	AdjustStock(requestId string, idUser string, stockAdjustmentRequest *request.StockAdjustmentRequest) (productStockMovementResponse response.ProductStockMovementResponse)
+	FindStockCard(requestId string, stockCardRequest *request.StockCardRequest) (stockCardResponse response.StockCardResponse)
+}
+
+type ProductStockServiceImplementation struct {
+	ConfigWebserver                        config.Webserver
+	DB                                     *gorm.DB
+	Validate                               *validator.Validate
+	Logger                                 *logrus.Logger
+	ProductRepositoryInterface             mysql.ProductRepositoryInterface
+	ProductVariantRepositoryInterface      mysql.ProductVariantRepositoryInterface
+	ProductStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface
+	ProductBatchRepositoryInterface        mysql.ProductBatchRepositoryInterface
+	RestockSubscriptionServiceInterface    RestockSubscriptionServiceInterface
+	StockMonitorServiceInterface           StockMonitorServiceInterface
+	ProductSearchServiceInterface          ProductSearchServiceInterface
+}
+
+func NewProductStockService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productVariantRepositoryInterface mysql.ProductVariantRepositoryInterface,
+	productStockHistoryRepositoryInterface mysql.ProductStockHistoryRepositoryInterface,
+	productBatchRepositoryInterface mysql.ProductBatchRepositoryInterface,
+	restockSubscriptionServiceInterface RestockSubscriptionServiceInterface,
+	stockMonitorServiceInterface StockMonitorServiceInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) ProductStockServiceInterface {
+	return &ProductStockServiceImplementation{
+		ConfigWebserver:                        configWebserver,
+		DB:                                     DB,
+		Validate:                               validate,
+		Logger:                                 logger,
+		ProductRepositoryInterface:             productRepositoryInterface,
+		ProductVariantRepositoryInterface:      productVariantRepositoryInterface,
+		ProductStockHistoryRepositoryInterface: productStockHistoryRepositoryInterface,
+		ProductBatchRepositoryInterface:        productBatchRepositoryInterface,
+		RestockSubscriptionServiceInterface:    restockSubscriptionServiceInterface,
+		StockMonitorServiceInterface:           stockMonitorServiceInterface,
+		ProductSearchServiceInterface:          productSearchServiceInterface,
+	}
+}
+
+// Mengurangi stok item order beserta history, stok produk tetap menjadi total stok semua varian
+func (service *ProductStockServiceImplementation) DecreaseOrderItemStock(tx *gorm.DB, requestId string, orderItem entity.OrderItem, description string) {
+	// Baris potongan promo keranjang tidak punya stok
+	if orderItem.IdPromotion != "" {
+		return
+	}
+
+	product, errFindProduct := service.ProductRepositoryInterface.FindProductById(tx, orderItem.IdProduct)
+	exceptions.PanicIfErrorWithRollback(errFindProduct, requestId, []string{"product not found"}, service.Logger, tx)
+
+	stockOpname := product.Stock
+	if orderItem.IdProductVariant != "" {
+		productVariant, errFindProductVariant := service.ProductVariantRepositoryInterface.FindProductVariantById(tx, orderItem.IdProductVariant)
+		exceptions.PanicIfErrorWithRollback(errFindProductVariant, requestId, []string{"product variant not found"}, service.Logger, tx)
+		stockOpname = productVariant.Stock
+
+		productVariantEntity := &entity.ProductVariant{}
+		productVariantEntity.Stock = productVariant.Stock - orderItem.Qty
+		_, errUpdateProductVariantStock := service.ProductVariantRepositoryInterface.UpdateProductVariantStock(tx, orderItem.IdProductVariant, *productVariantEntity)
+		exceptions.PanicIfErrorWithRollback(errUpdateProductVariantStock, requestId, []string{"update stock error"}, service.Logger, tx)
+	}
+
+	productEntityStockHistory := &entity.ProductStockHistory{}
+	productEntityStockHistory.IdProduct = orderItem.IdProduct
+	productEntityStockHistory.IdProductVariant = orderItem.IdProductVariant
+	productEntityStockHistory.TxDate = time.Now()
+	productEntityStockHistory.StockOpname = stockOpname
+	productEntityStockHistory.StockOutQty = orderItem.Qty
+	productEntityStockHistory.StockFinal = stockOpname - orderItem.Qty
+	productEntityStockHistory.Description = description
+	productEntityStockHistory.TxType = StockTxSale
+	productEntityStockHistory.CreatedAt = time.Now()
+	_, errAddProductStockHistory := service.ProductStockHistoryRepositoryInterface.AddProductStockHistory(tx, *productEntityStockHistory)
+	exceptions.PanicIfErrorWithRollback(errAddProductStockHistory, requestId, []string{"add stock history error"}, service.Logger, tx)
+
+	productEntity := &entity.Product{}
+	productEntity.Stock = product.Stock - orderItem.Qty
+	_, errUpdateProductStock := service.ProductRepositoryInterface.UpdateProductStock(tx, orderItem.IdProduct, *productEntity)
+	exceptions.PanicIfErrorWithRollback(errUpdateProductStock, requestId, []string{"update stock error"}, service.Logger, tx)
+
+	service.allocateOrderItemBatches(tx, requestId, orderItem)
+}
+
+// Alokasi item order ke batch yang kadaluarsa paling dekat (FEFO). Batch yang sudah kadaluarsa tidak ikut dialokasikan,
+// qty yang tidak tertutup batch berasal dari stok lama yang belum dilacak per batch
+func (service *ProductStockServiceImplementation) allocateOrderItemBatches(tx *gorm.DB, requestId string, orderItem entity.OrderItem) {
+	productBatches, err := service.ProductBatchRepositoryInterface.FindSellableProductBatches(tx, orderItem.IdProduct, orderItem.IdProductVariant, today())
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"allocate batch error"}, service.Logger, tx)
+
+	var orderItemBatches []entity.OrderItemBatch
+	qty := orderItem.Qty
+	for _, productBatch := range productBatches {
+		if qty <= 0 {
+			break
+		}
+		allocatedQty := productBatch.RemainingQty
+		if allocatedQty > qty {
+			allocatedQty = qty
+		}
+		updated, err := service.ProductBatchRepositoryInterface.UpdateProductBatchRemainingQty(tx, productBatch.Id, -allocatedQty)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"allocate batch error"}, service.Logger, tx)
+		if updated == 0 {
+			continue
+		}
+		orderItemBatches = append(orderItemBatches, entity.OrderItemBatch{
+			Id:             utilities.RandomUUID(),
+			IdOrder:        orderItem.IdOrder,
+			IdOrderItem:    orderItem.Id,
+			IdProductBatch: productBatch.Id,
+			Qty:            allocatedQty,
+			CreatedAt:      time.Now(),
+		})
+		qty -= allocatedQty
+	}
+	if len(orderItemBatches) > 0 {
+		err = service.ProductBatchRepositoryInterface.CreateOrderItemBatches(tx, orderItemBatches)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"allocate batch error"}, service.Logger, tx)
+	}
+}
+
+// Satu-satunya jalur perubahan stok selain penjualan, setiap perubahan dicatat di history stok.
+// restocked bernilai true jika stok produk atau varian berubah dari kosong menjadi tersedia, notifikasi dikirim pemanggil setelah commit
+func (service *ProductStockServiceImplementation) PostStockMovement(tx *gorm.DB, requestId string, stockMovement modelService.StockMovement) (productStockHistory entity.ProductStockHistory, restocked bool) {
+	product, errFindProduct := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(tx, stockMovement.IdProduct)
+	exceptions.PanicIfErrorWithRollback(errFindProduct, requestId, []string{"product not found"}, service.Logger, tx)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFoundWithRollback(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger, tx)
+	}
+	if len(product.ProductVariants) > 0 && stockMovement.IdProductVariant == "" {
+		tx.Rollback()
+		exceptions.PanicIfBadRequest(errors.New("variant required"), requestId, []string{"id_product_variant is required"}, service.Logger)
+	}
+
+	stockOpname := product.Stock
+	if stockMovement.IdProductVariant != "" {
+		productVariant, errFindProductVariant := service.ProductVariantRepositoryInterface.FindProductVariantById(tx, stockMovement.IdProductVariant)
+		exceptions.PanicIfErrorWithRollback(errFindProductVariant, requestId, []string{"product variant not found"}, service.Logger, tx)
+		if productVariant.Id == "" || productVariant.IdProduct != product.Id {
+			exceptions.PanicIfRecordNotFoundWithRollback(errors.New("product variant not found"), requestId, []string{"product variant not found"}, service.Logger, tx)
+		}
+		stockOpname = productVariant.Stock
+	}
+	if stockOpname+stockMovement.Qty < 0 {
+		tx.Rollback()
+		exceptions.PanicIfBadRequest(errors.New("stock not enough"), requestId, []string{"stock of " + product.ProductName + " is not enough, current stock " + strconv.Itoa(stockOpname)}, service.Logger)
+	}
+
+	if stockMovement.IdProductVariant != "" {
+		productVariantEntity := &entity.ProductVariant{}
+		productVariantEntity.Stock = stockOpname + stockMovement.Qty
+		_, errUpdateProductVariantStock := service.ProductVariantRepositoryInterface.UpdateProductVariantStock(tx, stockMovement.IdProductVariant, *productVariantEntity)
+		exceptions.PanicIfErrorWithRollback(errUpdateProductVariantStock, requestId, []string{"update stock error"}, service.Logger, tx)
+	}
+
+	productStockHistory.IdProduct = stockMovement.IdProduct
+	productStockHistory.IdProductVariant = stockMovement.IdProductVariant
+	productStockHistory.TxDate = time.Now()
+	productStockHistory.StockOpname = stockOpname
+	if stockMovement.Qty > 0 {
+		productStockHistory.StockInQty = stockMovement.Qty
+	} else {
+		productStockHistory.StockOutQty = -stockMovement.Qty
+	}
+	productStockHistory.StockFinal = stockOpname + stockMovement.Qty
+	productStockHistory.Description = stockMovement.Description
+	productStockHistory.TxType = stockMovement.TxType
+	productStockHistory.ReasonCode = stockMovement.ReasonCode
+	productStockHistory.Supplier = stockMovement.Supplier
+	productStockHistory.Reference = stockMovement.Reference
+	productStockHistory.BatchNumber = stockMovement.BatchNumber
+	productStockHistory.CreatedBy = stockMovement.CreatedBy
+	productStockHistory.CreatedAt = time.Now()
+	_, errAddProductStockHistory := service.ProductStockHistoryRepositoryInterface.AddProductStockHistory(tx, productStockHistory)
+	exceptions.PanicIfErrorWithRollback(errAddProductStockHistory, requestId, []string{"add stock history error"}, service.Logger, tx)
+
+	productEntity := &entity.Product{}
+	productEntity.Stock = product.Stock + stockMovement.Qty
+	_, errUpdateProductStock := service.ProductRepositoryInterface.UpdateProductStock(tx, stockMovement.IdProduct, *productEntity)
+	exceptions.PanicIfErrorWithRollback(errUpdateProductStock, requestId, []string{"update stock error"}, service.Logger, tx)
+
+	// Level alert stok turun kembali saat stok bertambah agar penurunan berikutnya dialert lagi
+	stockAlertLevelAfter := stockAlertLevel(productEntity.Stock, product.ReorderPoint)
+	if stockMovement.Qty > 0 && stockAlertSeverity(stockAlertLevelAfter) < stockAlertSeverity(product.StockAlertLevel) {
+		_, errUpdateStockAlertLevel := service.ProductRepositoryInterface.UpdateProductStockAlertLevel(tx, product.Id, product.StockAlertLevel, stockAlertLevelAfter)
+		exceptions.PanicIfErrorWithRollback(errUpdateStockAlertLevel, requestId, []string{"update stock error"}, service.Logger, tx)
+	}
+
+	restocked = stockOpname <= 0 && productStockHistory.StockFinal > 0
+	return productStockHistory, restocked
+}
+
+func (service *ProductStockServiceImplementation) StockIn(requestId string, idUser string, productStockInRequest *request.ProductStockInRequest) (productStockMovementResponse response.ProductStockMovementResponse) {
+	request.ValidateProductStockInRequest(service.Validate, productStockInRequest, requestId, service.Logger)
+
+	stockMovement := modelService.StockMovement{
+		IdProduct:        productStockInRequest.IdProduct,
+		IdProductVariant: productStockInRequest.IdProductVariant,
+		Qty:              productStockInRequest.Qty,
+		TxType:           StockTxReceiving,
+		Description:      productStockInRequest.Description,
+		CreatedBy:        idUser,
+	}
+	if stockMovement.Description == "" {
+		stockMovement.Description = "Stok masuk"
+	}
+
+	tx := service.DB.Begin()
+	productStockHistory, restocked := service.PostStockMovement(tx, requestId, stockMovement)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+
+	if restocked {
+		go service.RestockSubscriptionServiceInterface.NotifyRestockSubscribers()
+	}
+	productStockMovementResponse = response.ToProductStockMovementResponse(productStockHistory)
+	return productStockMovementResponse
+}
+
+// Semua item satu penerimaan disimpan dalam satu transaksi
+func (service *ProductStockServiceImplementation) ReceiveGoods(requestId string, idUser string, goodsReceivingRequest *request.GoodsReceivingRequest) (goodsReceivingResponse response.GoodsReceivingResponse) {
+	request.ValidateProductStockRequest(service.Validate, goodsReceivingRequest, requestId, service.Logger)
+
+	supplier := strings.TrimSpace(goodsReceivingRequest.Supplier)
+	reference := strings.TrimSpace(goodsReceivingRequest.Reference)
+	receivedCount, err := service.ProductStockHistoryRepositoryInterface.CountProductStockHistoryByReference(service.DB, StockTxReceiving, supplier, reference)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if receivedCount > 0 {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("goods already received"), requestId, []string{"reference " + reference + " from " + supplier + " is already received"}, service.Logger)
+	}
+
+	description := goodsReceivingRequest.Description
+	if description == "" {
+		description = "Penerimaan barang " + reference + " dari " + supplier
+	}
+
+	// Batch dicek sebelum transaksi agar semua kesalahan input ditolak tanpa menyentuh stok
+	expiryDates := make([]time.Time, len(goodsReceivingRequest.Items))
+	for index, goodsReceivingItem := range goodsReceivingRequest.Items {
+		expiryDates[index] = service.parseBatchExpiryDate(requestId, goodsReceivingItem)
+	}
+
+	goodsReceivingResponse.Supplier = supplier
+	goodsReceivingResponse.Reference = reference
+	goodsReceivingResponse.Items = []response.ProductStockMovementResponse{}
+	restocked := false
+	tx := service.DB.Begin()
+	for index, goodsReceivingItem := range goodsReceivingRequest.Items {
+		batchNumber := strings.TrimSpace(goodsReceivingItem.BatchNumber)
+		productStockHistory, itemRestocked := service.PostStockMovement(tx, requestId, modelService.StockMovement{
+			IdProduct:        goodsReceivingItem.IdProduct,
+			IdProductVariant: goodsReceivingItem.IdProductVariant,
+			Qty:              goodsReceivingItem.Qty,
+			TxType:           StockTxReceiving,
+			Supplier:         supplier,
+			Reference:        reference,
+			BatchNumber:      batchNumber,
+			Description:      description,
+			CreatedBy:        idUser,
+		})
+		if batchNumber != "" {
+			service.receiveProductBatch(tx, requestId, productStockHistory, expiryDates[index])
+		}
+		restocked = restocked || itemRestocked
+		goodsReceivingResponse.Items = append(goodsReceivingResponse.Items, response.ToProductStockMovementResponse(productStockHistory))
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+
+	if restocked {
+		go service.RestockSubscriptionServiceInterface.NotifyRestockSubscribers()
+	}
+	return goodsReceivingResponse
+}
+
+func (service *ProductStockServiceImplementation) parseBatchExpiryDate(requestId string, goodsReceivingItem request.GoodsReceivingItemRequest) (expiryDate time.Time) {
+	batchNumber := strings.TrimSpace(goodsReceivingItem.BatchNumber)
+	if batchNumber == "" && goodsReceivingItem.ExpiryDate == "" {
+		return expiryDate
+	}
+	if batchNumber == "" || goodsReceivingItem.ExpiryDate == "" {
+		exceptions.PanicIfBadRequest(errors.New("incomplete batch"), requestId, []string{"batch_number and expiry_date must be filled together"}, service.Logger)
+	}
+	expiryDate, err := time.ParseInLocation("2006-01-02", goodsReceivingItem.ExpiryDate, time.Local)
+	exceptions.PanicIfBadRequest(err, requestId, []string{"expiry_date format must be YYYY-MM-DD"}, service.Logger)
+	if !expiryDate.After(today()) {
+		exceptions.PanicIfBadRequest(errors.New("batch already expired"), requestId, []string{"batch " + batchNumber + " is already expired"}, service.Logger)
+	}
+	return expiryDate
+}
+
+// Penerimaan ulang nomor batch yang sama menambah qty batch tersebut, tanggal kadaluarsanya harus sama
+func (service *ProductStockServiceImplementation) receiveProductBatch(tx *gorm.DB, requestId string, productStockHistory entity.ProductStockHistory, expiryDate time.Time) {
+	productBatch, err := service.ProductBatchRepositoryInterface.FindProductBatchByNumber(tx, productStockHistory.IdProduct, productStockHistory.IdProductVariant, productStockHistory.BatchNumber)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create batch error"}, service.Logger, tx)
+	if productBatch.Id != "" {
+		if !productBatch.ExpiryDate.Equal(expiryDate) {
+			tx.Rollback()
+			exceptions.PanicIfBadRequest(errors.New("batch expiry date mismatch"), requestId, []string{"batch " + productBatch.BatchNumber + " is already received with expiry date " + productBatch.ExpiryDate.Format("2006-01-02")}, service.Logger)
+		}
+		err = service.ProductBatchRepositoryInterface.AddProductBatchReceivedQty(tx, productBatch.Id, productStockHistory.StockInQty)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create batch error"}, service.Logger, tx)
+		return
+	}
+
+	productBatchEntity := &entity.ProductBatch{}
+	productBatchEntity.Id = utilities.RandomUUID()
+	productBatchEntity.IdProduct = productStockHistory.IdProduct
+	productBatchEntity.IdProductVariant = productStockHistory.IdProductVariant
+	productBatchEntity.BatchNumber = productStockHistory.BatchNumber
+	productBatchEntity.ExpiryDate = expiryDate
+	productBatchEntity.ReceivedQty = productStockHistory.StockInQty
+	productBatchEntity.RemainingQty = productStockHistory.StockInQty
+	productBatchEntity.Supplier = productStockHistory.Supplier
+	productBatchEntity.Reference = productStockHistory.Reference
+	productBatchEntity.CreatedAt = time.Now()
+	_, err = service.ProductBatchRepositoryInterface.CreateProductBatch(tx, *productBatchEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create batch error"}, service.Logger, tx)
+}
+
+func (service *ProductStockServiceImplementation) AdjustStock(requestId string, idUser string, stockAdjustmentRequest *request.StockAdjustmentRequest) (productStockMovementResponse response.ProductStockMovementResponse) {
+	request.ValidateProductStockRequest(service.Validate, stockAdjustmentRequest, requestId, service.Logger)
+
+	switch stockAdjustmentRequest.ReasonCode {
+	case "damaged", "expired", "lost":
+		if stockAdjustmentRequest.Qty > 0 {
+			exceptions.PanicIfBadRequest(errors.New("invalid adjustment qty"), requestId, []string{"qty must be negative for reason " + stockAdjustmentRequest.ReasonCode}, service.Logger)
+		}
+	case "found":
+		if stockAdjustmentRequest.Qty < 0 {
+			exceptions.PanicIfBadRequest(errors.New("invalid adjustment qty"), requestId, []string{"qty must be positive for reason found"}, service.Logger)
+		}
+	}
+
+	description := stockAdjustmentRequest.Description
+	if description == "" {
+		description = "Penyesuaian stok " + stockAdjustmentRequest.ReasonCode
+	}
+
+	var productBatch entity.ProductBatch
+	if stockAdjustmentRequest.IdProductBatch != "" {
+		var err error
+		productBatch, err = service.ProductBatchRepositoryInterface.FindProductBatchById(service.DB, stockAdjustmentRequest.IdProductBatch)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if productBatch.Id == "" || productBatch.IdProduct != stockAdjustmentRequest.IdProduct || productBatch.IdProductVariant != stockAdjustmentRequest.IdProductVariant {
+			exceptions.PanicIfRecordNotFound(errors.New("product batch not found"), requestId, []string{"product batch not found"}, service.Logger)
+		}
+	}
+
+	tx := service.DB.Begin()
+	productStockHistory, restocked := service.PostStockMovement(tx, requestId, modelService.StockMovement{
+		IdProduct:        stockAdjustmentRequest.IdProduct,
+		IdProductVariant: stockAdjustmentRequest.IdProductVariant,
+		Qty:              stockAdjustmentRequest.Qty,
+		TxType:           StockTxAdjustment,
+		ReasonCode:       stockAdjustmentRequest.ReasonCode,
+		BatchNumber:      productBatch.BatchNumber,
+		Description:      description,
+		CreatedBy:        idUser,
+	})
+	if productBatch.Id != "" {
+		updated, err := service.ProductBatchRepositoryInterface.UpdateProductBatchRemainingQty(tx, productBatch.Id, stockAdjustmentRequest.Qty)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update batch error"}, service.Logger, tx)
+		if updated == 0 {
+			tx.Rollback()
+			exceptions.PanicIfBadRequest(errors.New("batch qty not enough"), requestId, []string{"remaining qty of batch " + productBatch.BatchNumber + " is " + strconv.Itoa(productBatch.RemainingQty)}, service.Logger)
+		}
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+
+	if restocked {
+		go service.RestockSubscriptionServiceInterface.NotifyRestockSubscribers()
+	}
+	if stockAdjustmentRequest.Qty < 0 {
+		go service.StockMonitorServiceInterface.CheckProductStocks([]string{stockAdjustmentRequest.IdProduct})
+	}
+	productStockMovementResponse = response.ToProductStockMovementResponse(productStockHistory)
+	return productStockMovementResponse
+}
+
+// Stok awal diambil dari history terakhir sebelum periode. Produk yang belum punya history
+// memakai stok sebelum transaksi pertama, atau stok sekarang jika tidak ada transaksi sama sekali
+func (service *ProductStockServiceImplementation) FindStockCard(requestId string, stockCardRequest *request.StockCardRequest) (stockCardResponse response.StockCardResponse) {
+	request.ValidateProductStockRequest(service.Validate, stockCardRequest, requestId, service.Logger)
+
+	product, err := service.ProductRepositoryInterface.FindProductByIdIncludeUnpublished(service.DB, stockCardRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"product not found"}, service.Logger)
+	}
+	currentStock := product.Stock
+	if stockCardRequest.IdProductVariant != "" {
+		productVariant, err := service.ProductVariantRepositoryInterface.FindProductVariantById(service.DB, stockCardRequest.IdProductVariant)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if productVariant.Id == "" || productVariant.IdProduct != product.Id {
+			exceptions.PanicIfRecordNotFound(errors.New("product variant not found"), requestId, []string{"product variant not found"}, service.Logger)
+		}
+		currentStock = productVariant.Stock
+		stockCardResponse.VariantName = productVariant.VariantName
+	} else if len(product.ProductVariants) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("variant required"), requestId, []string{"id_product_variant is required"}, service.Logger)
+	}
+
+	dateFrom, dateTo := service.parseStockCardPeriod(requestId, stockCardRequest)
+	productStockHistories, err := service.ProductStockHistoryRepositoryInterface.FindProductStockHistories(service.DB, product.Id, stockCardRequest.IdProductVariant, dateFrom, dateTo)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	lastHistory, err := service.ProductStockHistoryRepositoryInterface.FindLastProductStockHistoryBeforeDate(service.DB, product.Id, stockCardRequest.IdProductVariant, dateFrom)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	stockCardResponse.IdProduct = product.Id
+	stockCardResponse.ProductName = product.ProductName
+	stockCardResponse.IdProductVariant = stockCardRequest.IdProductVariant
+	stockCardResponse.DateFrom = dateFrom.Format("2006-01-02")
+	stockCardResponse.DateTo = dateTo.Format("2006-01-02")
+	switch {
+	case !lastHistory.TxDate.IsZero():
+		stockCardResponse.OpeningStock = lastHistory.StockFinal
+	case len(productStockHistories) > 0:
+		stockCardResponse.OpeningStock = productStockHistories[0].StockOpname
+	default:
+		stockCardResponse.OpeningStock = currentStock
+	}
+	stockCardResponse.ClosingStock = stockCardResponse.OpeningStock
+	for _, productStockHistory := range productStockHistories {
+		stockCardResponse.TotalIn += productStockHistory.StockInQty
+		stockCardResponse.TotalOut += productStockHistory.StockOutQty
+		stockCardResponse.ClosingStock = productStockHistory.StockFinal
+	}
+	stockCardResponse.Movements = response.ToStockCardMovementResponses(productStockHistories)
+	return stockCardResponse
+}
+
+// Periode default bulan berjalan sampai hari ini
+func (service *ProductStockServiceImplementation) parseStockCardPeriod(requestId string, stockCardRequest *request.StockCardRequest) (dateFrom time.Time, dateTo time.Time) {
+	now := time.Now()
+	dateFrom = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	dateTo = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	var err error
+	if stockCardRequest.DateFrom != "" {
+		dateFrom, err = time.ParseInLocation("2006-01-02", stockCardRequest.DateFrom, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_from format must be YYYY-MM-DD"}, service.Logger)
+	}
+	if stockCardRequest.DateTo != "" {
+		dateTo, err = time.ParseInLocation("2006-01-02", stockCardRequest.DateTo, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_to format must be YYYY-MM-DD"}, service.Logger)
+	}
+
+	if dateTo.Before(dateFrom) {
+		exceptions.PanicIfBadRequest(errors.New("invalid date range"), requestId, []string{"date_to must be after date_from"}, service.Logger)
+	}
+	if dateTo.Sub(dateFrom) > stockCardMaxDays*24*time.Hour {
+		exceptions.PanicIfBadRequest(errors.New("date range too long"), requestId, []string{"stock card period can not be longer than " + strconv.Itoa(stockCardMaxDays) + " days"}, service.Logger)
+	}
+
+	dateTo = dateTo.Add(24*time.Hour - time.Second)
+	return dateFrom, dateTo
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
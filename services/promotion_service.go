@@ -0,0 +1,49 @@
+package services
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+type PromotionServiceInterface interface {
+	EvaluateCartPromotions(requestId string, cartItems []entity.Cart, now time.Time) (cartPromotions []modelService.CartPromotion)
+}
+
+type PromotionServiceImplementation struct {
+	ConfigWebserver              config.Webserver
+	DB                           *gorm.DB
+	Logger                       *logrus.Logger
+	PromotionRepositoryInterface mysql.PromotionRepositoryInterface
+}
+
+func NewPromotionService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	promotionRepositoryInterface mysql.PromotionRepositoryInterface) PromotionServiceInterface {
+	return &PromotionServiceImplementation{
+		ConfigWebserver:              configWebserver,
+		DB:                           DB,
+		Logger:                       logger,
+		PromotionRepositoryInterface: promotionRepositoryInterface,
+	}
+}
+
+func (service *PromotionServiceImplementation) EvaluateCartPromotions(requestId string, cartItems []entity.Cart, now time.Time) (cartPromotions []modelService.CartPromotion) {
+	if len(cartItems) == 0 {
+		return cartPromotions
+	}
+
+	promotions, err := service.PromotionRepositoryInterface.FindActivePromotions(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	cartPromotions = pricing.EvaluateCartPromotions(promotions, cartItems, now)
+	return cartPromotions
+}
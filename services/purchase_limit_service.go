@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+// Nama setting minimal nilai belanja per order, tidak ada setting berarti tanpa minimal
+const settingMinOrderValue = "min_order_value"
+
+type PurchaseLimitServiceInterface interface {
+	CheckCartQty(requestId string, idUser string, product entity.Product, idCart string, qty int)
+	EvaluatePurchaseLimits(requestId string, idUser string, cartItems []entity.Cart, now time.Time) (violations []string)
+	CheckUserPurchaseLimits(tx *gorm.DB, requestId string, idUser string, cartItems []entity.Cart, now time.Time)
+	FindMinOrderValue(requestId string) float64
+}
+
+type PurchaseLimitServiceImplementation struct {
+	ConfigWebserver              config.Webserver
+	DB                           *gorm.DB
+	Logger                       *logrus.Logger
+	CartRepositoryInterface      mysql.CartRepositoryInterface
+	OrderItemRepositoryInterface mysql.OrderItemRepositoryInterface
+	SettingRepositoryInterface   mysql.SettingRepositoryInterface
+	UserRepositoryInterface      mysql.UserRepositoryInterface
+}
+
+func NewPurchaseLimitService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	cartRepositoryInterface mysql.CartRepositoryInterface,
+	orderItemRepositoryInterface mysql.OrderItemRepositoryInterface,
+	settingRepositoryInterface mysql.SettingRepositoryInterface,
+	userRepositoryInterface mysql.UserRepositoryInterface) PurchaseLimitServiceInterface {
+	return &PurchaseLimitServiceImplementation{
+		ConfigWebserver:              configWebserver,
+		DB:                           DB,
+		Logger:                       logger,
+		CartRepositoryInterface:      cartRepositoryInterface,
+		OrderItemRepositoryInterface: orderItemRepositoryInterface,
+		SettingRepositoryInterface:   settingRepositoryInterface,
+		UserRepositoryInterface:      userRepositoryInterface,
+	}
+}
+
+// Qty baru satu baris keranjang ditambah qty varian lain dari produk yang sama tidak boleh melewati batas pembelian
+func (service *PurchaseLimitServiceImplementation) CheckCartQty(requestId string, idUser string, product entity.Product, idCart string, qty int) {
+	if product.MaxQtyPerOrder == 0 && product.MaxQtyPerUser == 0 {
+		return
+	}
+
+	cartItems, err := service.CartRepositoryInterface.FindCartByIdUser(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	totalQty := qty
+	for _, cartItem := range cartItems {
+		if cartItem.IdProduct == product.Id && cartItem.Id != idCart {
+			totalQty = totalQty + cartItem.Qty
+		}
+	}
+
+	violations := service.productLimitViolations(service.DB, requestId, idUser, product, totalQty, time.Now())
+	if len(violations) > 0 {
+		exceptions.PanicIfBadRequest(errors.New("purchase limit exceeded"), requestId, violations, service.Logger)
+	}
+}
+
+// Dicek ulang saat order dibuat karena batas dan riwayat order bisa berubah setelah produk masuk keranjang.
+// Minimal belanja dihitung dari harga produk sebelum potongan promo keranjang dan voucher
+func (service *PurchaseLimitServiceImplementation) EvaluatePurchaseLimits(requestId string, idUser string, cartItems []entity.Cart, now time.Time) (violations []string) {
+	var idProducts []string
+	products := make(map[string]entity.Product)
+	productQty := make(map[string]int)
+	var subTotal float64
+	for _, cartItem := range cartItems {
+		if _, ok := products[cartItem.IdProduct]; !ok {
+			idProducts = append(idProducts, cartItem.IdProduct)
+			products[cartItem.IdProduct] = cartItem.Product
+		}
+		productQty[cartItem.IdProduct] = productQty[cartItem.IdProduct] + cartItem.Qty
+		subTotal = subTotal + pricing.EvaluateCartItemPricing(cartItem, now).Price*float64(cartItem.Qty)
+	}
+
+	for _, idProduct := range idProducts {
+		violations = append(violations, service.productLimitViolations(service.DB, requestId, idUser, products[idProduct], productQty[idProduct], now)...)
+	}
+
+	if minOrderValue := service.FindMinOrderValue(requestId); len(cartItems) > 0 && subTotal < minOrderValue {
+		violations = append(violations, "Minimal belanja Rp"+formatPoint(minOrderValue)+", kurang Rp"+formatPoint(minOrderValue-subTotal))
+	}
+	return violations
+}
+
+// Batas per user dicek ulang di dalam transaksi order dengan baris user terkunci, order lain dari user yang sama
+// menunggu sampai transaksi ini selesai sehingga riwayat order yang dijumlahkan sudah termasuk order tersebut
+func (service *PurchaseLimitServiceImplementation) CheckUserPurchaseLimits(tx *gorm.DB, requestId string, idUser string, cartItems []entity.Cart, now time.Time) {
+	var idProducts []string
+	products := make(map[string]entity.Product)
+	productQty := make(map[string]int)
+	for _, cartItem := range cartItems {
+		if cartItem.Product.MaxQtyPerUser == 0 {
+			continue
+		}
+		if _, ok := products[cartItem.IdProduct]; !ok {
+			idProducts = append(idProducts, cartItem.IdProduct)
+			products[cartItem.IdProduct] = cartItem.Product
+		}
+		productQty[cartItem.IdProduct] = productQty[cartItem.IdProduct] + cartItem.Qty
+	}
+	if len(idProducts) == 0 {
+		return
+	}
+
+	_, err := service.UserRepositoryInterface.FindUserByIdForUpdate(tx, idUser)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"user not found"}, service.Logger, tx)
+
+	var violations []string
+	for _, idProduct := range idProducts {
+		violations = append(violations, service.productLimitViolations(tx, requestId, idUser, products[idProduct], productQty[idProduct], now)...)
+	}
+	if len(violations) > 0 {
+		tx.Rollback()
+		exceptions.PanicIfBadRequest(errors.New("purchase limit exceeded"), requestId, violations, service.Logger)
+	}
+}
+
+func (service *PurchaseLimitServiceImplementation) FindMinOrderValue(requestId string) float64 {
+	settings, _ := service.SettingRepositoryInterface.FindSettingsByName(service.DB, settingMinOrderValue)
+	return settings.Value
+}
+
+func (service *PurchaseLimitServiceImplementation) productLimitViolations(DB *gorm.DB, requestId string, idUser string, product entity.Product, qty int, now time.Time) (violations []string) {
+	productName := strings.TrimSpace(product.ProductName)
+	if product.MaxQtyPerOrder > 0 && qty > product.MaxQtyPerOrder {
+		violations = append(violations, "Maksimal pembelian "+productName+" adalah "+strconv.Itoa(product.MaxQtyPerOrder)+" per order")
+	}
+
+	if product.MaxQtyPerUser > 0 {
+		var dateFrom time.Time
+		period := "akun"
+		if product.MaxQtyPeriodDays > 0 {
+			dateFrom = now.AddDate(0, 0, -product.MaxQtyPeriodDays)
+			period = strconv.Itoa(product.MaxQtyPeriodDays) + " hari"
+		}
+		boughtQty, err := service.OrderItemRepositoryInterface.SumOrderItemQtyByIdUser(DB, idUser, product.Id, dateFrom)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if boughtQty+qty > product.MaxQtyPerUser {
+			remainingQty := product.MaxQtyPerUser - boughtQty
+			if remainingQty < 0 {
+				remainingQty = 0
+			}
+			violations = append(violations, "Maksimal pembelian "+productName+" adalah "+strconv.Itoa(product.MaxQtyPerUser)+" per "+period+", sisa yang bisa dibeli "+strconv.Itoa(remainingQty))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+)
+
+func TestCheckUserPurchaseLimitsLocksUserBeforeSummingOrders(t *testing.T) {
+	limitedProduct := entity.Product{Id: "limited", ProductName: "Susu", MaxQtyPerUser: 3}
+	tests := []struct {
+		name       string
+		cartItems  []entity.Cart
+		boughtQty  int
+		locked     bool
+		violations bool
+	}{
+		{
+			name:      "without per user limit",
+			cartItems: []entity.Cart{{IdProduct: "free", Qty: 10, Product: entity.Product{Id: "free"}}},
+		},
+		{
+			name:      "within limit",
+			cartItems: []entity.Cart{{IdProduct: "limited", Qty: 1, Product: limitedProduct}},
+			boughtQty: 2,
+			locked:    true,
+		},
+		{
+			name:       "limit used by concurrent order",
+			cartItems:  []entity.Cart{{IdProduct: "limited", Qty: 2, Product: limitedProduct}},
+			boughtQty:  2,
+			locked:     true,
+			violations: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			DB, mock := newMockDB(t)
+			service := &PurchaseLimitServiceImplementation{
+				DB:                           DB,
+				Logger:                       newTestLogger(),
+				OrderItemRepositoryInterface: mysql.NewOrderItemRepository(nil),
+				UserRepositoryInterface:      mysql.NewUserRepository(nil),
+			}
+
+			mock.ExpectBegin()
+			if test.locked {
+				mock.ExpectQuery("SELECT \\* FROM `users` WHERE users.id = \\? .*FOR UPDATE").
+					WithArgs("user").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user"))
+				mock.ExpectQuery("SELECT COALESCE\\(SUM\\(orders_items.qty\\), 0\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(test.boughtQty))
+			}
+			if test.violations {
+				mock.ExpectRollback()
+			}
+
+			tx := DB.Begin()
+			rejected := func() (rejected bool) {
+				defer func() {
+					rejected = recover() != nil
+				}()
+				service.CheckUserPurchaseLimits(tx, "request", "user", test.cartItems, time.Now())
+				return false
+			}()
+			if rejected != test.violations {
+				t.Errorf("rejected = %v, want %v", rejected, test.violations)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
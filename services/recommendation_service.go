@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Jenis rekomendasi yang disimpan oleh job
+const (
+	RecommendationTypeFrequentlyBoughtTogether = "frequently_bought_together"
+	RecommendationTypeCustomersAlsoBought      = "customers_also_bought"
+	RecommendationTypeBuyAgain                 = "buy_again"
+	RecommendationTypeRecommendedForYou        = "recommended_for_you"
+)
+
+const recommendationLimit = 10
+
+type RecommendationServiceInterface interface {
+	FindProductRecommendations(requestId string, idUser string, idProduct string) (productRecommendationResponse response.FindProductRecommendationResponse)
+	FindHomeFeed(requestId string, idUser string) (homeFeedResponse response.FindHomeFeedResponse)
+	RefreshRecommendations()
+}
+
+type RecommendationServiceImplementation struct {
+	ConfigWebserver                     config.Webserver
+	DB                                  *gorm.DB
+	Logger                              *logrus.Logger
+	RecommendationRepositoryInterface   mysql.RecommendationRepositoryInterface
+	ProductRepositoryInterface          mysql.ProductRepositoryInterface
+	ProductServiceInterface             ProductServiceInterface
+	ProductPriceHistoryServiceInterface ProductPriceHistoryServiceInterface
+}
+
+func NewRecommendationService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	recommendationRepositoryInterface mysql.RecommendationRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productServiceInterface ProductServiceInterface,
+	productPriceHistoryServiceInterface ProductPriceHistoryServiceInterface) RecommendationServiceInterface {
+	return &RecommendationServiceImplementation{
+		ConfigWebserver:                     configWebserver,
+		DB:                                  DB,
+		Logger:                              logger,
+		RecommendationRepositoryInterface:   recommendationRepositoryInterface,
+		ProductRepositoryInterface:          productRepositoryInterface,
+		ProductServiceInterface:             productServiceInterface,
+		ProductPriceHistoryServiceInterface: productPriceHistoryServiceInterface,
+	}
+}
+
+// Riwayat pembelian satu produk oleh satu user
+type userPurchase struct {
+	orders        int
+	lastOrderedAt time.Time
+}
+
+func (service *RecommendationServiceImplementation) FindProductRecommendations(requestId string, idUser string, idProduct string) (productRecommendationResponse response.FindProductRecommendationResponse) {
+	product, _ := service.ProductRepositoryInterface.FindProductById(service.DB, idProduct)
+	if product.Id == "" {
+		err := errors.New("product not found")
+		exceptions.PanicIfRecordNotFound(err, requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	frequentlyBoughtTogether, err := service.RecommendationRepositoryInterface.FindProductRecommendations(service.DB, product.Id, RecommendationTypeFrequentlyBoughtTogether, recommendationLimit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	customersAlsoBought, err := service.RecommendationRepositoryInterface.FindProductRecommendations(service.DB, product.Id, RecommendationTypeCustomersAlsoBought, recommendationLimit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	var frequentlyBoughtTogetherIds, customersAlsoBoughtIds []string
+	for _, productRecommendation := range frequentlyBoughtTogether {
+		frequentlyBoughtTogetherIds = append(frequentlyBoughtTogetherIds, productRecommendation.IdProductRecommended)
+	}
+	for _, productRecommendation := range customersAlsoBought {
+		customersAlsoBoughtIds = append(customersAlsoBoughtIds, productRecommendation.IdProductRecommended)
+	}
+
+	productRecommendationResponse.FrequentlyBoughtTogether = service.findProductResponsesByIds(requestId, idUser, frequentlyBoughtTogetherIds)
+	productRecommendationResponse.CustomersAlsoBought = service.findProductResponsesByIds(requestId, idUser, customersAlsoBoughtIds)
+	return productRecommendationResponse
+}
+
+// User tanpa rekomendasi personal, misalnya user baru, mendapat produk terlaris
+func (service *RecommendationServiceImplementation) FindHomeFeed(requestId string, idUser string) (homeFeedResponse response.FindHomeFeedResponse) {
+	buyAgain, err := service.RecommendationRepositoryInterface.FindUserRecommendations(service.DB, idUser, RecommendationTypeBuyAgain, recommendationLimit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	recommendedForYou, err := service.RecommendationRepositoryInterface.FindUserRecommendations(service.DB, idUser, RecommendationTypeRecommendedForYou, recommendationLimit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	var buyAgainIds, recommendedForYouIds []string
+	buyAgainProducts := make(map[string]bool)
+	for _, userRecommendation := range buyAgain {
+		buyAgainIds = append(buyAgainIds, userRecommendation.IdProduct)
+		buyAgainProducts[userRecommendation.IdProduct] = true
+	}
+	for _, userRecommendation := range recommendedForYou {
+		recommendedForYouIds = append(recommendedForYouIds, userRecommendation.IdProduct)
+	}
+
+	if len(recommendedForYouIds) == 0 {
+		productSoldQty, err := service.ProductRepositoryInterface.FindProductSoldQty(service.DB)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		bestSellers := make(map[string]float64, len(productSoldQty))
+		for idProduct, sold := range productSoldQty {
+			bestSellers[idProduct] = float64(sold)
+		}
+		recommendedForYouIds = topRecommendations(bestSellers, buyAgainProducts)
+	}
+
+	homeFeedResponse.BuyAgain = service.findProductResponsesByIds(requestId, idUser, buyAgainIds)
+	homeFeedResponse.RecommendedForYou = service.findProductResponsesByIds(requestId, idUser, recommendedForYouIds)
+	return homeFeedResponse
+}
+
+// Dijalankan berkala, menghitung ulang seluruh rekomendasi dari orders_items lalu mengganti isi tabel rekomendasi
+func (service *RecommendationServiceImplementation) RefreshRecommendations() {
+	purchasedProducts, err := service.RecommendationRepositoryInterface.FindPurchasedProducts(service.DB)
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+
+	// Produk yang sama dalam satu pesanan, misalnya beda varian, dihitung sekali
+	orderProducts := make(map[string]map[string]bool)
+	userPurchases := make(map[string]map[string]*userPurchase)
+	for _, purchasedProduct := range purchasedProducts {
+		if orderProducts[purchasedProduct.IdOrder] == nil {
+			orderProducts[purchasedProduct.IdOrder] = make(map[string]bool)
+		}
+		if orderProducts[purchasedProduct.IdOrder][purchasedProduct.IdProduct] {
+			continue
+		}
+		orderProducts[purchasedProduct.IdOrder][purchasedProduct.IdProduct] = true
+
+		if userPurchases[purchasedProduct.IdUser] == nil {
+			userPurchases[purchasedProduct.IdUser] = make(map[string]*userPurchase)
+		}
+		purchase := userPurchases[purchasedProduct.IdUser][purchasedProduct.IdProduct]
+		if purchase == nil {
+			purchase = &userPurchase{}
+			userPurchases[purchasedProduct.IdUser][purchasedProduct.IdProduct] = purchase
+		}
+		purchase.orders++
+		if purchasedProduct.OrderedAt.After(purchase.lastOrderedAt) {
+			purchase.lastOrderedAt = purchasedProduct.OrderedAt
+		}
+	}
+
+	userProducts := make(map[string]map[string]bool, len(userPurchases))
+	for idUser, purchases := range userPurchases {
+		userProducts[idUser] = make(map[string]bool, len(purchases))
+		for idProduct := range purchases {
+			userProducts[idUser][idProduct] = true
+		}
+	}
+
+	// Dibeli bersama dihitung per pesanan, pelanggan juga membeli dihitung per user
+	frequentlyBoughtTogether := countProductPairs(orderProducts)
+	customersAlsoBought := countProductPairs(userProducts)
+
+	now := time.Now()
+	var productRecommendations []entity.ProductRecommendation
+	for idProduct, scores := range frequentlyBoughtTogether {
+		for _, idProductRecommended := range topRecommendations(scores, map[string]bool{}) {
+			productRecommendations = append(productRecommendations, entity.ProductRecommendation{
+				Id:                   utilities.RandomUUID(),
+				IdProduct:            idProduct,
+				IdProductRecommended: idProductRecommended,
+				RecommendationType:   RecommendationTypeFrequentlyBoughtTogether,
+				Score:                scores[idProductRecommended],
+				CreatedAt:            now,
+			})
+		}
+	}
+	for idProduct, scores := range customersAlsoBought {
+		for _, idProductRecommended := range topRecommendations(scores, map[string]bool{}) {
+			productRecommendations = append(productRecommendations, entity.ProductRecommendation{
+				Id:                   utilities.RandomUUID(),
+				IdProduct:            idProduct,
+				IdProductRecommended: idProductRecommended,
+				RecommendationType:   RecommendationTypeCustomersAlsoBought,
+				Score:                scores[idProductRecommended],
+				CreatedAt:            now,
+			})
+		}
+	}
+
+	var userRecommendations []entity.UserRecommendation
+	for idUser, purchases := range userPurchases {
+		var idProducts []string
+		for idProduct := range purchases {
+			idProducts = append(idProducts, idProduct)
+		}
+		sort.Slice(idProducts, func(i, j int) bool {
+			if purchases[idProducts[i]].orders != purchases[idProducts[j]].orders {
+				return purchases[idProducts[i]].orders > purchases[idProducts[j]].orders
+			}
+			return purchases[idProducts[i]].lastOrderedAt.After(purchases[idProducts[j]].lastOrderedAt)
+		})
+		if len(idProducts) > recommendationLimit {
+			idProducts = idProducts[:recommendationLimit]
+		}
+		for _, idProduct := range idProducts {
+			userRecommendations = append(userRecommendations, entity.UserRecommendation{
+				Id:                 utilities.RandomUUID(),
+				IdUser:             idUser,
+				IdProduct:          idProduct,
+				RecommendationType: RecommendationTypeBuyAgain,
+				Score:              float64(purchases[idProduct].orders),
+				LastOrderedAt:      purchases[idProduct].lastOrderedAt,
+				CreatedAt:          now,
+			})
+		}
+
+		// Rekomendasi personal adalah gabungan skor pelanggan juga membeli dari semua produk yang pernah dibeli user
+		personalScores := make(map[string]float64)
+		for idProduct := range purchases {
+			for idProductRecommended, score := range customersAlsoBought[idProduct] {
+				personalScores[idProductRecommended] = personalScores[idProductRecommended] + score
+			}
+		}
+		for _, idProductRecommended := range topRecommendations(personalScores, userProducts[idUser]) {
+			userRecommendations = append(userRecommendations, entity.UserRecommendation{
+				Id:                 utilities.RandomUUID(),
+				IdUser:             idUser,
+				IdProduct:          idProductRecommended,
+				RecommendationType: RecommendationTypeRecommendedForYou,
+				Score:              personalScores[idProductRecommended],
+				CreatedAt:          now,
+			})
+		}
+	}
+
+	tx := service.DB.Begin()
+	err = service.RecommendationRepositoryInterface.DeleteAllProductRecommendations(tx)
+	if err == nil {
+		err = service.RecommendationRepositoryInterface.DeleteAllUserRecommendations(tx)
+	}
+	if err == nil && len(productRecommendations) > 0 {
+		err = service.RecommendationRepositoryInterface.CreateProductRecommendations(tx, productRecommendations)
+	}
+	if err == nil && len(userRecommendations) > 0 {
+		err = service.RecommendationRepositoryInterface.CreateUserRecommendations(tx, userRecommendations)
+	}
+	if err != nil {
+		service.Logger.Error(err)
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+}
+
+func (service *RecommendationServiceImplementation) findProductResponsesByIds(requestId string, idUser string, idProducts []string) (productResponses []response.FindProductResponse) {
+	if len(idProducts) == 0 {
+		return []response.FindProductResponse{}
+	}
+	products, err := service.ProductRepositoryInterface.FindProductsByIds(service.DB, idProducts)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	productResponses = response.ToFindProductResponsesByIds(products, idProducts)
+	service.ProductServiceInterface.MarkWishlistedProducts(requestId, idUser, productResponses)
+	service.ProductPriceHistoryServiceInterface.MarkPriceDroppedProducts(requestId, productResponses)
+	return productResponses
+}
+
+// Menghitung berapa kali setiap pasangan produk muncul dalam kelompok yang sama
+func countProductPairs(groups map[string]map[string]bool) map[string]map[string]float64 {
+	pairScores := make(map[string]map[string]float64)
+	for _, products := range groups {
+		for idProduct := range products {
+			for idProductOther := range products {
+				if idProduct == idProductOther {
+					continue
+				}
+				if pairScores[idProduct] == nil {
+					pairScores[idProduct] = make(map[string]float64)
+				}
+				pairScores[idProduct][idProductOther]++
+			}
+		}
+	}
+	return pairScores
+}
+
+// Produk dengan skor tertinggi, skor sama diurutkan berdasarkan id agar hasil job stabil
+func topRecommendations(scores map[string]float64, excludes map[string]bool) (idProducts []string) {
+	for idProduct := range scores {
+		if !excludes[idProduct] {
+			idProducts = append(idProducts, idProduct)
+		}
+	}
+	sort.Slice(idProducts, func(i, j int) bool {
+		if scores[idProducts[i]] != scores[idProducts[j]] {
+			return scores[idProducts[i]] > scores[idProducts[j]]
+		}
+		return idProducts[i] < idProducts[j]
+	})
+	if len(idProducts) > recommendationLimit {
+		idProducts = idProducts[:recommendationLimit]
+	}
+	return idProducts
+}
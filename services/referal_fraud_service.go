@@ -0,0 +1,355 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// Bobot tiap sinyal fraud referal, total skor dibandingkan dengan setting referal_fraud_threshold
+const (
+	referalFraudScoreSameDevice      = 40
+	referalFraudScoreSharedDevice    = 30
+	referalFraudScoreSameIp          = 25
+	referalFraudScoreIpVelocity      = 20
+	referalFraudScoreSamePhonePrefix = 15
+	referalFraudScorePhonePrefixFarm = 15
+	referalFraudScoreSameAddress     = 25
+	referalFraudScoreNearAddress     = 30
+	referalFraudScoreReferalVelocity = 20
+	referalFraudScoreReferalChain    = 10
+
+	referalFraudDefaultThreshold     = 50
+	referalFraudDefaultVelocityLimit = 5
+	referalFraudIpVelocityLimit      = 3
+	referalFraudPhonePrefixLength    = 8
+	referalFraudNearAddressMeter     = 100
+)
+
+var referalFraudAddressCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+type ReferalFraudServiceInterface interface {
+	ScoreRegistration(user entity.User, phone string, userReferal entity.User) (score int, reasons []string)
+	ScoreOrder(order entity.Order, user entity.User, userReferal entity.User) (score int, reasons []string)
+	IsReferalSuspicious(score int) bool
+	HoldReferalBonus(tx *gorm.DB, requestId string, referalBonusReview entity.ReferalBonusReview, score int, reasons []string)
+	FindReferalBonusReview(requestId string, findReferalBonusReviewRequest *request.FindReferalBonusReviewRequest) (referalBonusReviewResponse response.FindReferalBonusReviewResponse)
+	ApproveReferalBonusReview(requestId string, idUserAdmin string, reviewReferalBonusRequest *request.ReviewReferalBonusRequest) error
+	RejectReferalBonusReview(requestId string, idUserAdmin string, reviewReferalBonusRequest *request.ReviewReferalBonusRequest) error
+}
+
+type ReferalFraudServiceImplementation struct {
+	ConfigurationWebserver                 config.Webserver
+	DB                                     *gorm.DB
+	Validate                               *validator.Validate
+	Logger                                 *logrus.Logger
+	UserRepositoryInterface                mysql.UserRepositoryInterface
+	UserShippingAddressRepositoryInterface mysql.UserShippingAddressRepositoryInterface
+	BalancePointRepositoryInterface        mysql.BalancePointRepositoryInterface
+	BalancePointTxRepositoryInterface      mysql.BalancePointTxRepositoryInterface
+	ReferalBonusReviewRepositoryInterface  mysql.ReferalBonusReviewRepositoryInterface
+	SettingRepositoryInterface             mysql.SettingRepositoryInterface
+}
+
+func NewReferalFraudService(
+	configurationWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	userRepositoryInterface mysql.UserRepositoryInterface,
+	userShippingAddressRepositoryInterface mysql.UserShippingAddressRepositoryInterface,
+	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface,
+	balancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface,
+	referalBonusReviewRepositoryInterface mysql.ReferalBonusReviewRepositoryInterface,
+	settingRepositoryInterface mysql.SettingRepositoryInterface) ReferalFraudServiceInterface {
+	return &ReferalFraudServiceImplementation{
+		ConfigurationWebserver:                 configurationWebserver,
+		DB:                                     DB,
+		Validate:                               validate,
+		Logger:                                 logger,
+		UserRepositoryInterface:                userRepositoryInterface,
+		UserShippingAddressRepositoryInterface: userShippingAddressRepositoryInterface,
+		BalancePointRepositoryInterface:        balancePointRepositoryInterface,
+		BalancePointTxRepositoryInterface:      balancePointTxRepositoryInterface,
+		ReferalBonusReviewRepositoryInterface:  referalBonusReviewRepositoryInterface,
+		SettingRepositoryInterface:             settingRepositoryInterface,
+	}
+}
+
+func (service *ReferalFraudServiceImplementation) ScoreRegistration(user entity.User, phone string, userReferal entity.User) (score int, reasons []string) {
+	// Device yang sama dengan pemilik kode referal atau sudah dipakai akun lain
+	if user.TokenDevice != "" {
+		if user.TokenDevice == userReferal.TokenDevice {
+			score, reasons = score+referalFraudScoreSameDevice, append(reasons, "same_device_as_referal")
+		} else if total, _ := service.UserRepositoryInterface.CountUserByTokenDevice(service.DB, user.TokenDevice, user.Id); total > 0 {
+			score, reasons = score+referalFraudScoreSharedDevice, append(reasons, "device_used_by_other_account")
+		}
+	}
+
+	// Registrasi dari ip yang sama dengan pemilik kode referal atau banyak registrasi dari ip yang sama
+	if user.RegistrationIp != "" {
+		if user.RegistrationIp == userReferal.RegistrationIp {
+			score, reasons = score+referalFraudScoreSameIp, append(reasons, "same_ip_as_referal")
+		}
+		total, _ := service.UserRepositoryInterface.CountUserByRegistrationIp(service.DB, user.RegistrationIp, time.Now().Add(-24*time.Hour))
+		if total >= referalFraudIpVelocityLimit {
+			score, reasons = score+referalFraudScoreIpVelocity, append(reasons, "ip_registration_velocity")
+		}
+	}
+
+	// Nomor hp berurutan biasanya berasal dari kartu perdana yang dibeli sekaligus
+	phonePrefix := referalFraudPhonePrefix(phone)
+	if phonePrefix != "" {
+		if phonePrefix == referalFraudPhonePrefix(userReferal.FamilyMembers.Phone) {
+			score, reasons = score+referalFraudScoreSamePhonePrefix, append(reasons, "same_phone_prefix_as_referal")
+		}
+		total, _ := service.UserRepositoryInterface.CountUserByRegistrationReferalAndPhonePrefix(service.DB, userReferal.ReferalCode, phonePrefix)
+		if total >= 2 {
+			score, reasons = score+referalFraudScorePhonePrefixFarm, append(reasons, "phone_prefix_farm")
+		}
+	}
+
+	score, reasons = service.ScoreReferalVelocity(userReferal, score, reasons)
+	return score, reasons
+}
+
+func (service *ReferalFraudServiceImplementation) ScoreOrder(order entity.Order, user entity.User, userReferal entity.User) (score int, reasons []string) {
+	if user.TokenDevice != "" && user.TokenDevice == userReferal.TokenDevice {
+		score, reasons = score+referalFraudScoreSameDevice, append(reasons, "same_device_as_referal")
+	}
+
+	if user.RegistrationIp != "" && user.RegistrationIp == userReferal.RegistrationIp {
+		score, reasons = score+referalFraudScoreSameIp, append(reasons, "same_ip_as_referal")
+	}
+
+	// Alamat pengiriman sama atau berdekatan dengan alamat pemilik kode referal
+	referalAddresses, _ := service.UserShippingAddressRepositoryInterface.FindUserShippingAddressByIdUser(service.DB, userReferal.Id)
+	userAddresses, _ := service.UserShippingAddressRepositoryInterface.FindUserShippingAddressByIdUser(service.DB, user.Id)
+	orderAddress := referalFraudNormalizeAddress(order.Address)
+	sameAddress, nearAddress := false, false
+	for _, referalAddress := range referalAddresses {
+		if orderAddress != "" && orderAddress == referalFraudNormalizeAddress(referalAddress.Address) {
+			sameAddress = true
+		}
+		for _, userAddress := range userAddresses {
+			// Alamat yang belum punya koordinat tersimpan sebagai (0,0), tidak ikut dibandingkan
+			if (referalAddress.Latitude == 0 && referalAddress.Longitude == 0) || (userAddress.Latitude == 0 && userAddress.Longitude == 0) {
+				continue
+			}
+			if distanceInMeter(userAddress.Latitude, userAddress.Longitude, referalAddress.Latitude, referalAddress.Longitude) <= referalFraudNearAddressMeter {
+				nearAddress = true
+			}
+		}
+	}
+	if sameAddress {
+		score, reasons = score+referalFraudScoreSameAddress, append(reasons, "same_address_as_referal")
+	}
+	if nearAddress {
+		score, reasons = score+referalFraudScoreNearAddress, append(reasons, "near_coordinate_to_referal")
+	}
+
+	score, reasons = service.ScoreReferalVelocity(userReferal, score, reasons)
+	return score, reasons
+}
+
+// Banyak registrasi memakai kode referal yang sama dalam 24 jam, atau pemilik kode sendiri akun baru hasil referal
+func (service *ReferalFraudServiceImplementation) ScoreReferalVelocity(userReferal entity.User, score int, reasons []string) (int, []string) {
+	velocityLimit := service.FindReferalFraudSetting("referal_fraud_velocity_limit", referalFraudDefaultVelocityLimit)
+	total, _ := service.UserRepositoryInterface.CountUserByRegistrationReferalSince(service.DB, userReferal.ReferalCode, time.Now().Add(-24*time.Hour))
+	if float64(total) >= velocityLimit {
+		score, reasons = score+referalFraudScoreReferalVelocity, append(reasons, "referal_velocity")
+	}
+
+	if userReferal.RegistrationReferalCode != "" && userReferal.CreatedDate.After(time.Now().AddDate(0, 0, -7)) {
+		score, reasons = score+referalFraudScoreReferalChain, append(reasons, "new_account_referal_chain")
+	}
+	return score, reasons
+}
+
+func (service *ReferalFraudServiceImplementation) IsReferalSuspicious(score int) bool {
+	threshold := service.FindReferalFraudSetting("referal_fraud_threshold", referalFraudDefaultThreshold)
+	return float64(score) >= threshold
+}
+
+// Bonus ditahan dengan status pending sampai direview admin
+func (service *ReferalFraudServiceImplementation) HoldReferalBonus(tx *gorm.DB, requestId string, referalBonusReview entity.ReferalBonusReview, score int, reasons []string) {
+	referalBonusReview.Id = utilities.RandomUUID()
+	referalBonusReview.FraudScore = score
+	referalBonusReview.FraudReasons = strings.Join(reasons, ",")
+	referalBonusReview.Status = "pending"
+	referalBonusReview.CreatedDate = time.Now()
+
+	_, err := service.ReferalBonusReviewRepositoryInterface.CreateReferalBonusReview(tx, referalBonusReview)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"create referal bonus review error"}, service.Logger, tx)
+
+	service.Logger.WithFields(logrus.Fields{"request_id": requestId, "id_user": referalBonusReview.IdUser, "id_user_referal": referalBonusReview.IdUserReferal, "fraud_score": score}).Warn("referal bonus held for review: " + referalBonusReview.FraudReasons)
+}
+
+func (service *ReferalFraudServiceImplementation) FindReferalBonusReview(requestId string, findReferalBonusReviewRequest *request.FindReferalBonusReviewRequest) (referalBonusReviewResponse response.FindReferalBonusReviewResponse) {
+	request.ValidateFindReferalBonusReviewRequest(service.Validate, findReferalBonusReviewRequest, requestId, service.Logger)
+
+	status := findReferalBonusReviewRequest.Status
+	if status == "" {
+		status = "pending"
+	}
+	page := findReferalBonusReviewRequest.Page
+	if page == 0 {
+		page = 1
+	}
+	limit := findReferalBonusReviewRequest.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	referalBonusReviews, err := service.ReferalBonusReviewRepositoryInterface.FindReferalBonusReviewByStatus(service.DB, status, limit, (page-1)*limit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	totalData, err := service.ReferalBonusReviewRepositoryInterface.CountReferalBonusReviewByStatus(service.DB, status)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	referalBonusReviewResponse = response.ToFindReferalBonusReviewResponse(page, limit, totalData, referalBonusReviews)
+	return referalBonusReviewResponse
+}
+
+func (service *ReferalFraudServiceImplementation) ApproveReferalBonusReview(requestId string, idUserAdmin string, reviewReferalBonusRequest *request.ReviewReferalBonusRequest) error {
+	request.ValidateReviewReferalBonusRequest(service.Validate, reviewReferalBonusRequest, requestId, service.Logger)
+
+	referalBonusReview, _ := service.ReferalBonusReviewRepositoryInterface.FindReferalBonusReviewById(service.DB, reviewReferalBonusRequest.IdReview)
+	if referalBonusReview.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("review not found"), requestId, []string{"Review tidak ditemukan"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+
+	service.UpdateReferalBonusReviewStatus(tx, requestId, idUserAdmin, referalBonusReview.Id, "approved", reviewReferalBonusRequest.Note)
+
+	// Bonus yang ditahan baru ditambahkan ke point user setelah disetujui
+	userDescription, referalDescription := "Bonus Registrasi", "Bonus Referal Registrasi"
+	if referalBonusReview.Event == "order" {
+		userDescription, referalDescription = "Bonus Dari Pembelian", ""
+	}
+	if referalBonusReview.BonusUser > 0 {
+		service.AddReferalBonusPoint(tx, requestId, referalBonusReview.IdUser, referalBonusReview, "debit", referalBonusReview.BonusUser, userDescription)
+	}
+	if referalBonusReview.BonusReferal > 0 && referalBonusReview.IdUserReferal != "" {
+		service.AddReferalBonusPoint(tx, requestId, referalBonusReview.IdUserReferal, referalBonusReview, "referal", referalBonusReview.BonusReferal, referalDescription)
+	}
+
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, referalBonusReview.IdUser)
+	userReferal, _ := service.UserRepositoryInterface.FindUserById(service.DB, referalBonusReview.IdUserReferal)
+	if referalBonusReview.BonusUser > 0 {
+		go utilities.SendPushNotification(user.TokenDevice, &modelService.NotificationData{Title: "Bonus Point", Body: "Bonus point Anda sudah ditambahkan"})
+	}
+	if referalBonusReview.BonusReferal > 0 {
+		go utilities.SendPushNotification(userReferal.TokenDevice, &modelService.NotificationData{Title: "Bonus Referal", Body: "Bonus referal Anda sudah ditambahkan"})
+	}
+
+	return nil
+}
+
+func (service *ReferalFraudServiceImplementation) RejectReferalBonusReview(requestId string, idUserAdmin string, reviewReferalBonusRequest *request.ReviewReferalBonusRequest) error {
+	request.ValidateReviewReferalBonusRequest(service.Validate, reviewReferalBonusRequest, requestId, service.Logger)
+
+	referalBonusReview, _ := service.ReferalBonusReviewRepositoryInterface.FindReferalBonusReviewById(service.DB, reviewReferalBonusRequest.IdReview)
+	if referalBonusReview.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("review not found"), requestId, []string{"Review tidak ditemukan"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	service.UpdateReferalBonusReviewStatus(tx, requestId, idUserAdmin, referalBonusReview.Id, "rejected", reviewReferalBonusRequest.Note)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	return nil
+}
+
+func (service *ReferalFraudServiceImplementation) UpdateReferalBonusReviewStatus(tx *gorm.DB, requestId string, idUserAdmin string, idReferalBonusReview string, status string, note string) {
+	referalBonusReviewEntity := &entity.ReferalBonusReview{}
+	referalBonusReviewEntity.Status = status
+	referalBonusReviewEntity.ReviewedBy = idUserAdmin
+	referalBonusReviewEntity.ReviewNote = note
+	referalBonusReviewEntity.ReviewedAt = null.NewTime(time.Now(), true)
+
+	rowsAffected, err := service.ReferalBonusReviewRepositoryInterface.UpdateReferalBonusReviewStatus(tx, idReferalBonusReview, *referalBonusReviewEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"update referal bonus review error"}, service.Logger, tx)
+	if rowsAffected == 0 {
+		tx.Rollback()
+		exceptions.PanicIfBadRequest(errors.New("review already processed"), requestId, []string{"Review sudah diproses"}, service.Logger)
+	}
+}
+
+func (service *ReferalFraudServiceImplementation) AddReferalBonusPoint(tx *gorm.DB, requestId string, idUser string, referalBonusReview entity.ReferalBonusReview, txType string, nominal float64, description string) {
+	balancePoint, errFindBalancePoint := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, idUser)
+	exceptions.PanicIfErrorWithRollback(errFindBalancePoint, requestId, []string{"find balance point error"}, service.Logger, tx)
+
+	balancePointEntity := &entity.BalancePoint{}
+	balancePointEntity.BalancePoints = balancePoint.BalancePoints + nominal
+
+	_, errUpdateBalancePoint := service.BalancePointRepositoryInterface.UpdateBalancePoint(tx, balancePoint.IdUser, *balancePointEntity)
+	exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
+
+	// Add to point history
+	balancePointTxEntity := &entity.BalancePointTx{}
+	balancePointTxEntity.Id = utilities.RandomUUID()
+	balancePointTxEntity.IdBalancePoint = balancePoint.Id
+	balancePointTxEntity.NoOrder = referalBonusReview.NoOrder
+	balancePointTxEntity.IdCampaign = referalBonusReview.IdCampaign
+	balancePointTxEntity.TxType = txType
+	balancePointTxEntity.TxDate = time.Now()
+	balancePointTxEntity.TxNominal = nominal
+	balancePointTxEntity.LastPointBalance = balancePoint.BalancePoints
+	balancePointTxEntity.NewPointBalance = balancePointEntity.BalancePoints
+	balancePointTxEntity.CreatedDate = time.Now()
+	balancePointTxEntity.Description = description
+
+	_, errCreateBalancePointTx := service.BalancePointTxRepositoryInterface.CreateBalancePointTx(tx, *balancePointTxEntity)
+	exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
+}
+
+func (service *ReferalFraudServiceImplementation) FindReferalFraudSetting(settingName string, defaultValue float64) float64 {
+	settings, _ := service.SettingRepositoryInterface.FindSettingsByName(service.DB, settingName)
+	if settings.SettingsName == "" || settings.Value <= 0 {
+		return defaultValue
+	}
+	return settings.Value
+}
+
+func referalFraudPhonePrefix(phone string) string {
+	phone = strings.Replace(strings.Replace(phone, "-", "", -1), "+62", "0", -1)
+	if len(phone) <= referalFraudPhonePrefixLength {
+		return ""
+	}
+	return phone[:referalFraudPhonePrefixLength]
+}
+
+func referalFraudNormalizeAddress(address string) string {
+	return strings.TrimSpace(referalFraudAddressCleaner.ReplaceAllString(strings.ToLower(address), " "))
+}
+
+// Jarak dua koordinat dalam meter dengan rumus haversine
+func distanceInMeter(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	const earthRadius = 6371000
+	deltaLatitude := (latitude2 - latitude1) * math.Pi / 180
+	deltaLongitude := (longitude2 - longitude1) * math.Pi / 180
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(latitude1*math.Pi/180)*math.Cos(latitude2*math.Pi/180)*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+// Jumlah langganan yang dikirim notifikasinya dalam satu batch
+const restockNotificationBatchSize = 100
+
+type RestockSubscriptionServiceInterface interface {
+	FindRestockSubscriptionsByIdUser(requestId string, idUser string) (restockSubscriptionResponses []response.FindRestockSubscriptionResponse)
+	SubscribeRestock(requestId string, idUser string, restockSubscriptionRequest *request.RestockSubscriptionRequest) (restockSubscriptionResponse response.FindRestockSubscriptionResponse)
+	UnsubscribeRestock(requestId string, idUser string, restockSubscriptionRequest *request.RestockSubscriptionRequest)
+	NotifyRestockSubscribers()
+}
+
+type RestockSubscriptionServiceImplementation struct {
+	ConfigWebserver                        config.Webserver
+	ConfigWhatsapp                         config.Whatsapp
+	DB                                     *gorm.DB
+	Validate                               *validator.Validate
+	Logger                                 *logrus.Logger
+	RestockSubscriptionRepositoryInterface mysql.RestockSubscriptionRepositoryInterface
+	ProductRepositoryInterface             mysql.ProductRepositoryInterface
+	notifyMutex                            sync.Mutex
+}
+
+func NewRestockSubscriptionService(configWebserver config.Webserver,
+	configWhatsapp config.Whatsapp,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	restockSubscriptionRepositoryInterface mysql.RestockSubscriptionRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface) RestockSubscriptionServiceInterface {
+	return &RestockSubscriptionServiceImplementation{
+		ConfigWebserver:                        configWebserver,
+		ConfigWhatsapp:                         configWhatsapp,
+		DB:                                     DB,
+		Validate:                               validate,
+		Logger:                                 logger,
+		RestockSubscriptionRepositoryInterface: restockSubscriptionRepositoryInterface,
+		ProductRepositoryInterface:             productRepositoryInterface,
+	}
+}
+
+func (service *RestockSubscriptionServiceImplementation) FindRestockSubscriptionsByIdUser(requestId string, idUser string) (restockSubscriptionResponses []response.FindRestockSubscriptionResponse) {
+	restockSubscriptions, err := service.RestockSubscriptionRepositoryInterface.FindRestockSubscriptionsByIdUser(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	restockSubscriptionResponses = response.ToFindRestockSubscriptionResponses(restockSubscriptions)
+	return restockSubscriptionResponses
+}
+
+// Hanya produk atau varian yang stoknya kosong yang bisa ditunggu
+func (service *RestockSubscriptionServiceImplementation) SubscribeRestock(requestId string, idUser string, restockSubscriptionRequest *request.RestockSubscriptionRequest) (restockSubscriptionResponse response.FindRestockSubscriptionResponse) {
+	request.ValidateRestockSubscriptionRequest(service.Validate, restockSubscriptionRequest, requestId, service.Logger)
+
+	product, _ := service.ProductRepositoryInterface.FindProductById(service.DB, restockSubscriptionRequest.IdProduct)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+	productVariant := entity.ProductVariant{}
+	for _, variant := range product.ProductVariants {
+		if variant.Id == restockSubscriptionRequest.IdProductVariant {
+			productVariant = variant
+		}
+	}
+	if len(product.ProductVariants) > 0 && productVariant.Id == "" {
+		exceptions.PanicIfBadRequest(errors.New("variant required"), requestId, []string{"Mohon pilih varian produk"}, service.Logger)
+	}
+	if len(product.ProductVariants) == 0 && restockSubscriptionRequest.IdProductVariant != "" {
+		exceptions.PanicIfBadRequest(errors.New("variant not found"), requestId, []string{"Varian produk tidak ditemukan"}, service.Logger)
+	}
+
+	stock := product.Stock
+	if productVariant.Id != "" {
+		stock = productVariant.Stock
+	}
+	if stock > 0 {
+		exceptions.PanicIfBadRequest(errors.New("stock available"), requestId, []string{"Stok produk masih tersedia"}, service.Logger)
+	}
+
+	restockSubscription, err := service.RestockSubscriptionRepositoryInterface.FindRestockSubscription(service.DB, idUser, product.Id, productVariant.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if restockSubscription.Id == "" {
+		restockSubscriptionEntity := &entity.RestockSubscription{}
+		restockSubscriptionEntity.Id = utilities.RandomUUID()
+		restockSubscriptionEntity.IdUser = idUser
+		restockSubscriptionEntity.IdProduct = product.Id
+		restockSubscriptionEntity.IdProductVariant = productVariant.Id
+		restockSubscriptionEntity.CreatedAt = time.Now()
+		restockSubscription, err = service.RestockSubscriptionRepositoryInterface.CreateRestockSubscription(service.DB, *restockSubscriptionEntity)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+	}
+
+	restockSubscription.Product = product
+	restockSubscription.ProductVariant = productVariant
+	restockSubscriptionResponse = response.ToFindRestockSubscriptionResponse(restockSubscription)
+	return restockSubscriptionResponse
+}
+
+func (service *RestockSubscriptionServiceImplementation) UnsubscribeRestock(requestId string, idUser string, restockSubscriptionRequest *request.RestockSubscriptionRequest) {
+	request.ValidateRestockSubscriptionRequest(service.Validate, restockSubscriptionRequest, requestId, service.Logger)
+	err := service.RestockSubscriptionRepositoryInterface.DeleteRestockSubscription(service.DB, idUser, restockSubscriptionRequest.IdProduct, restockSubscriptionRequest.IdProductVariant)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+}
+
+// Dipanggil setelah stok masuk dan berkala. Notifikasi dikirim per batch lalu langganannya dihapus,
+// proses yang sedang berjalan tidak dijalankan dua kali agar user tidak menerima notifikasi ganda
+func (service *RestockSubscriptionServiceImplementation) NotifyRestockSubscribers() {
+	if !service.notifyMutex.TryLock() {
+		return
+	}
+	defer service.notifyMutex.Unlock()
+
+	for {
+		restockSubscriptions, err := service.RestockSubscriptionRepositoryInterface.FindRestockedSubscriptions(service.DB, restockNotificationBatchSize)
+		if err != nil {
+			service.Logger.Error(err)
+			return
+		}
+		if len(restockSubscriptions) == 0 {
+			return
+		}
+
+		var waitGroup sync.WaitGroup
+		var ids []string
+		for _, restockSubscription := range restockSubscriptions {
+			ids = append(ids, restockSubscription.Id)
+			waitGroup.Add(1)
+			go func(restockSubscription entity.RestockSubscription) {
+				defer waitGroup.Done()
+				service.sendRestockNotification(restockSubscription)
+			}(restockSubscription)
+		}
+		waitGroup.Wait()
+
+		err = service.RestockSubscriptionRepositoryInterface.DeleteRestockSubscriptionsByIds(service.DB, ids)
+		if err != nil {
+			service.Logger.Error(err)
+			return
+		}
+		if len(restockSubscriptions) < restockNotificationBatchSize {
+			return
+		}
+	}
+}
+
+// Push notifikasi ke device user, whatsapp hanya jika template restock sudah diatur
+func (service *RestockSubscriptionServiceImplementation) sendRestockNotification(restockSubscription entity.RestockSubscription) {
+	productName := restockSubscription.Product.ProductName
+	if restockSubscription.ProductVariant.VariantName != "" {
+		productName = productName + " - " + restockSubscription.ProductVariant.VariantName
+	}
+
+	if restockSubscription.User.TokenDevice != "" {
+		utilities.SendPushNotification(restockSubscription.User.TokenDevice, &modelService.NotificationData{
+			Title: "Produk Tersedia Kembali",
+			Body:  productName + " sudah tersedia kembali, yuk pesan sebelum kehabisan",
+		})
+	}
+
+	phone := restockSubscription.User.FamilyMembers.Phone
+	if service.ConfigWhatsapp.MssgRestockTemplateId != "" && phone != "" {
+		utilities.SendWhatsapp(phone, restockSubscription.User.FamilyMembers.FullName, &modelService.WhatsappBody{
+			Key:       "1",
+			Value:     "product_name",
+			ValueText: productName,
+		}, service.ConfigWhatsapp.MssgRestockTemplateId)
+	}
+}
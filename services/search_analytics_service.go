@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/search"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+const (
+	// Klik produk dihitung dari pencarian dalam sesi ini, keranjang dari klik dalam periode atribusi
+	searchSessionDuration       = 30 * time.Minute
+	searchCartAttributionPeriod = 24 * time.Hour
+	searchReportDefaultDays     = 30
+	searchReportMaxDays         = 366
+	searchReportDefaultLimit    = 20
+	searchTermMaxLength         = 255
+)
+
+type SearchAnalyticsServiceInterface interface {
+	RecordSearch(idUser string, query string, resultCount int64) (idSearchLog string)
+	RecordSearchClick(idUser string, idSearchLog string, idProduct string)
+	RecordSearchAddToCart(idUser string, idProduct string)
+	FindTopSearches(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchTermReportResponse response.SearchTermReportResponse)
+	FindZeroResultSearches(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchTermReportResponse response.SearchTermReportResponse)
+	FindSearchConversion(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchConversionReportResponse response.SearchConversionReportResponse)
+}
+
+type SearchAnalyticsServiceImplementation struct {
+	ConfigWebserver              config.Webserver
+	DB                           *gorm.DB
+	Validate                     *validator.Validate
+	Logger                       *logrus.Logger
+	SearchLogRepositoryInterface mysql.SearchLogRepositoryInterface
+}
+
+func NewSearchAnalyticsService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	searchLogRepositoryInterface mysql.SearchLogRepositoryInterface) SearchAnalyticsServiceInterface {
+	return &SearchAnalyticsServiceImplementation{
+		ConfigWebserver:              configWebserver,
+		DB:                           DB,
+		Validate:                     validate,
+		Logger:                       logger,
+		SearchLogRepositoryInterface: searchLogRepositoryInterface,
+	}
+}
+
+// Disimpan sebelum response dikirim agar klik dengan search_id tidak mendahului log pencarian. Gagal mencatat
+// hanya di-log dan search_id dikosongkan supaya pencarian tetap berhasil. Query kosong tidak dicatat
+func (service *SearchAnalyticsServiceImplementation) RecordSearch(idUser string, query string, resultCount int64) (idSearchLog string) {
+	term := normalizeSearchTerm(query)
+	if term == "" {
+		return ""
+	}
+
+	searchLogEntity := &entity.SearchLog{}
+	searchLogEntity.Id = utilities.RandomUUID()
+	searchLogEntity.IdUser = idUser
+	searchLogEntity.Term = term
+	searchLogEntity.Query = truncateSearchTerm(strings.TrimSpace(query))
+	searchLogEntity.ResultCount = resultCount
+	searchLogEntity.CreatedAt = time.Now()
+
+	_, err := service.SearchLogRepositoryInterface.CreateSearchLog(service.DB, *searchLogEntity)
+	if err != nil {
+		service.Logger.Error(err)
+		return ""
+	}
+	return searchLogEntity.Id
+}
+
+// Tanpa search_id, klik user login dihitung ke pencarian terakhirnya dalam sesi
+func (service *SearchAnalyticsServiceImplementation) RecordSearchClick(idUser string, idSearchLog string, idProduct string) {
+	if idSearchLog == "" && idUser == "" {
+		return
+	}
+	go service.createSearchLogClick(idUser, idSearchLog, idProduct, time.Now())
+}
+
+// Produk yang masuk keranjang dihitung sebagai konversi dari pencarian tempat produk tersebut terakhir diklik
+func (service *SearchAnalyticsServiceImplementation) RecordSearchAddToCart(idUser string, idProduct string) {
+	go service.updateSearchLogAddedToCart(idUser, idProduct, time.Now())
+}
+
+func (service *SearchAnalyticsServiceImplementation) FindTopSearches(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchTermReportResponse response.SearchTermReportResponse) {
+	dateFrom, dateTo, limit := service.parseSearchReportRequest(requestId, searchAnalyticsRequest)
+	searchTermStats, err := service.SearchLogRepositoryInterface.FindSearchTermStats(service.DB, dateFrom, dateTo, false, limit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	searchTermReportResponse = response.ToSearchTermReportResponse(dateFrom, dateTo, searchTermStats)
+	return searchTermReportResponse
+}
+
+// Term tanpa hasil untuk bahan penambahan produk dan sinonim
+func (service *SearchAnalyticsServiceImplementation) FindZeroResultSearches(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchTermReportResponse response.SearchTermReportResponse) {
+	dateFrom, dateTo, limit := service.parseSearchReportRequest(requestId, searchAnalyticsRequest)
+	searchTermStats, err := service.SearchLogRepositoryInterface.FindSearchTermStats(service.DB, dateFrom, dateTo, true, limit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	searchTermReportResponse = response.ToSearchTermReportResponse(dateFrom, dateTo, searchTermStats)
+	return searchTermReportResponse
+}
+
+func (service *SearchAnalyticsServiceImplementation) FindSearchConversion(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (searchConversionReportResponse response.SearchConversionReportResponse) {
+	dateFrom, dateTo, limit := service.parseSearchReportRequest(requestId, searchAnalyticsRequest)
+	searchSummary, err := service.SearchLogRepositoryInterface.FindSearchSummary(service.DB, dateFrom, dateTo)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	searchTermStats, err := service.SearchLogRepositoryInterface.FindSearchTermStats(service.DB, dateFrom, dateTo, false, limit)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	searchConversionReportResponse = response.ToSearchConversionReportResponse(dateFrom, dateTo, searchSummary, searchTermStats)
+	return searchConversionReportResponse
+}
+
+// search_id milik user lain atau pencarian di luar sesi tidak dihitung
+func (service *SearchAnalyticsServiceImplementation) createSearchLogClick(idUser string, idSearchLog string, idProduct string, clickedAt time.Time) {
+	var searchLog entity.SearchLog
+	var err error
+	if idSearchLog != "" {
+		searchLog, err = service.SearchLogRepositoryInterface.FindSearchLogById(service.DB, idSearchLog)
+	} else {
+		searchLog, err = service.SearchLogRepositoryInterface.FindLatestSearchLogByIdUser(service.DB, idUser, clickedAt.Add(-searchSessionDuration))
+	}
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+	if searchLog.Id == "" || (searchLog.IdUser != "" && searchLog.IdUser != idUser) || clickedAt.Sub(searchLog.CreatedAt) > searchSessionDuration {
+		return
+	}
+
+	searchLogClickEntity := &entity.SearchLogClick{}
+	searchLogClickEntity.Id = utilities.RandomUUID()
+	searchLogClickEntity.IdSearchLog = searchLog.Id
+	searchLogClickEntity.IdUser = idUser
+	searchLogClickEntity.IdProduct = idProduct
+	searchLogClickEntity.ClickedAt = clickedAt
+
+	tx := service.DB.Begin()
+	_, err = service.SearchLogRepositoryInterface.CreateSearchLogClick(tx, *searchLogClickEntity)
+	if err == nil {
+		err = service.SearchLogRepositoryInterface.IncrementSearchLogClickCount(tx, searchLog.Id)
+	}
+	if err != nil {
+		tx.Rollback()
+		service.Logger.Error(err)
+		return
+	}
+	if err = tx.Commit().Error; err != nil {
+		service.Logger.Error(err)
+	}
+}
+
+func (service *SearchAnalyticsServiceImplementation) updateSearchLogAddedToCart(idUser string, idProduct string, addedToCartAt time.Time) {
+	searchLogClick, err := service.SearchLogRepositoryInterface.FindLatestSearchLogClick(service.DB, idUser, idProduct, addedToCartAt.Add(-searchCartAttributionPeriod))
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+	if searchLogClick.Id == "" {
+		return
+	}
+
+	tx := service.DB.Begin()
+	err = service.SearchLogRepositoryInterface.UpdateSearchLogClickAddedToCart(tx, searchLogClick.Id, addedToCartAt)
+	if err == nil {
+		err = service.SearchLogRepositoryInterface.UpdateSearchLogAddedToCart(tx, searchLogClick.IdSearchLog)
+	}
+	if err != nil {
+		tx.Rollback()
+		service.Logger.Error(err)
+		return
+	}
+	if err = tx.Commit().Error; err != nil {
+		service.Logger.Error(err)
+	}
+}
+
+// Periode default 30 hari terakhir sampai hari ini
+func (service *SearchAnalyticsServiceImplementation) parseSearchReportRequest(requestId string, searchAnalyticsRequest *request.SearchAnalyticsRequest) (dateFrom time.Time, dateTo time.Time, limit int) {
+	request.ValidateSearchAnalyticsRequest(service.Validate, searchAnalyticsRequest, requestId, service.Logger)
+
+	dateTo = today()
+	dateFrom = dateTo.AddDate(0, 0, -(searchReportDefaultDays - 1))
+
+	var err error
+	if searchAnalyticsRequest.DateFrom != "" {
+		dateFrom, err = time.ParseInLocation("2006-01-02", searchAnalyticsRequest.DateFrom, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_from format must be YYYY-MM-DD"}, service.Logger)
+	}
+	if searchAnalyticsRequest.DateTo != "" {
+		dateTo, err = time.ParseInLocation("2006-01-02", searchAnalyticsRequest.DateTo, time.Local)
+		exceptions.PanicIfBadRequest(err, requestId, []string{"date_to format must be YYYY-MM-DD"}, service.Logger)
+	}
+
+	if dateTo.Before(dateFrom) {
+		exceptions.PanicIfBadRequest(errors.New("invalid date range"), requestId, []string{"date_to must be after date_from"}, service.Logger)
+	}
+	if dateTo.Sub(dateFrom) > searchReportMaxDays*24*time.Hour {
+		exceptions.PanicIfBadRequest(errors.New("date range too long"), requestId, []string{"search report period can not be longer than " + strconv.Itoa(searchReportMaxDays) + " days"}, service.Logger)
+	}
+
+	limit = searchAnalyticsRequest.Limit
+	if limit == 0 {
+		limit = searchReportDefaultLimit
+	}
+	dateTo = dateTo.Add(24*time.Hour - time.Second)
+	return dateFrom, dateTo, limit
+}
+
+// Term dinormalisasi dengan tokenizer pencarian agar "Susu  Bayi" dan "susu bayi!" terhitung sama,
+// query yang seluruhnya stop word tetap dicatat dalam huruf kecil
+func normalizeSearchTerm(query string) string {
+	term := strings.Join(search.Tokenize(query), " ")
+	if term == "" {
+		term = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	}
+	return truncateSearchTerm(term)
+}
+
+func truncateSearchTerm(term string) string {
+	runes := []rune(term)
+	if len(runes) > searchTermMaxLength {
+		return string(runes[:searchTermMaxLength])
+	}
+	return term
+}
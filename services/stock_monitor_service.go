@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"gorm.io/gorm"
+)
+
+// Level alert stok yang tersimpan di produk, kosong berarti stok aman
+const (
+	StockAlertLevelNormal = ""
+	StockAlertLevelLow    = "low"
+	StockAlertLevelOut    = "out"
+)
+
+// Batas panjang pesan telegram 4096 karakter, sisakan ruang untuk judul
+const telegramMaxMessageLength = 4000
+
+type StockMonitorServiceInterface interface {
+	CheckProductStocks(idProducts []string)
+	SendLowStockDigest()
+}
+
+type StockMonitorServiceImplementation struct {
+	ConfigTelegram             config.Telegram
+	DB                         *gorm.DB
+	Logger                     *logrus.Logger
+	ProductRepositoryInterface mysql.ProductRepositoryInterface
+}
+
+func NewStockMonitorService(
+	configTelegram config.Telegram,
+	DB *gorm.DB,
+	logger *logrus.Logger,
+	productRepositoryInterface mysql.ProductRepositoryInterface) StockMonitorServiceInterface {
+	return &StockMonitorServiceImplementation{
+		ConfigTelegram:             configTelegram,
+		DB:                         DB,
+		Logger:                     logger,
+		ProductRepositoryInterface: productRepositoryInterface,
+	}
+}
+
+// Dipanggil setelah commit transaksi yang mengurangi stok. Alert hanya dikirim saat level berubah menjadi
+// lebih parah, level kembali normal saat stok ditambah sehingga penurunan berikutnya dialert lagi
+func (service *StockMonitorServiceImplementation) CheckProductStocks(idProducts []string) {
+	if len(idProducts) == 0 {
+		return
+	}
+	products, err := service.ProductRepositoryInterface.FindProductsForStockMonitor(service.DB, idProducts)
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+
+	var outOfStockLines, lowStockLines []string
+	for _, product := range products {
+		level := stockAlertLevel(product.Stock, product.ReorderPoint)
+		if level == product.StockAlertLevel {
+			continue
+		}
+		updated, err := service.ProductRepositoryInterface.UpdateProductStockAlertLevel(service.DB, product.Id, product.StockAlertLevel, level)
+		if err != nil {
+			service.Logger.Error(err)
+			continue
+		}
+		if updated == 0 || stockAlertSeverity(level) < stockAlertSeverity(product.StockAlertLevel) {
+			continue
+		}
+		switch level {
+		case StockAlertLevelOut:
+			outOfStockLines = append(outOfStockLines, stockAlertLine(product))
+		case StockAlertLevelLow:
+			lowStockLines = append(lowStockLines, stockAlertLine(product))
+		}
+	}
+
+	if len(outOfStockLines) > 0 {
+		service.sendStockAlert("Stok Habis", outOfStockLines)
+	}
+	if len(lowStockLines) > 0 {
+		service.sendStockAlert("Stok Menipis", lowStockLines)
+	}
+}
+
+// Ringkasan harian semua produk yang stoknya habis atau di bawah reorder point, tidak terpengaruh throttle alert
+func (service *StockMonitorServiceImplementation) SendLowStockDigest() {
+	products, err := service.ProductRepositoryInterface.FindLowStockProducts(service.DB)
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+	if len(products) == 0 {
+		return
+	}
+
+	var outOfStockLines, lowStockLines []string
+	for _, product := range products {
+		if product.Stock <= 0 {
+			outOfStockLines = append(outOfStockLines, stockAlertLine(product))
+		} else {
+			lowStockLines = append(lowStockLines, stockAlertLine(product))
+		}
+	}
+
+	lines := []string{"Habis (" + strconv.Itoa(len(outOfStockLines)) + " produk)"}
+	lines = append(lines, outOfStockLines...)
+	lines = append(lines, "", "Menipis ("+strconv.Itoa(len(lowStockLines))+" produk)")
+	lines = append(lines, lowStockLines...)
+	service.sendStockAlert("Ringkasan Stok Harian", lines)
+}
+
+// Pesan panjang dipecah menjadi beberapa pesan telegram
+func (service *StockMonitorServiceImplementation) sendStockAlert(title string, lines []string) {
+	message := title
+	for _, line := range lines {
+		if len(message)+len(line)+1 > telegramMaxMessageLength {
+			service.sendTelegram(message)
+			message = title + " (lanjutan)"
+		}
+		message = message + "\n" + line
+	}
+	service.sendTelegram(message)
+}
+
+func (service *StockMonitorServiceImplementation) sendTelegram(message string) {
+	if service.ConfigTelegram.BotToken == "" || service.ConfigTelegram.ChatId == "" {
+		return
+	}
+	resp, err := http.PostForm("https://api.telegram.org/bot"+service.ConfigTelegram.BotToken+"/sendMessage", url.Values{
+		"chat_id": {service.ConfigTelegram.ChatId},
+		"text":    {message},
+	})
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		service.Logger.Error(errors.New("send telegram stock alert failed with status " + resp.Status))
+	}
+}
+
+// Id produk dari item order, baris potongan promo keranjang tidak punya stok
+func orderItemIdProducts(orderItems []entity.OrderItem) (idProducts []string) {
+	for _, orderItem := range orderItems {
+		if orderItem.IdPromotion == "" {
+			idProducts = append(idProducts, orderItem.IdProduct)
+		}
+	}
+	return idProducts
+}
+
+func stockAlertLevel(stock int, reorderPoint int) string {
+	switch {
+	case stock <= 0:
+		return StockAlertLevelOut
+	case stock <= reorderPoint:
+		return StockAlertLevelLow
+	}
+	return StockAlertLevelNormal
+}
+
+func stockAlertSeverity(level string) int {
+	switch level {
+	case StockAlertLevelOut:
+		return 2
+	case StockAlertLevelLow:
+		return 1
+	}
+	return 0
+}
+
+func stockAlertLine(product entity.Product) string {
+	line := "- " + strings.TrimSpace(product.ProductName)
+	if product.NoSku != "" {
+		line = line + " (" + product.NoSku + ")"
+	}
+	line = line + ": stok " + strconv.Itoa(product.Stock)
+	if product.ReorderPoint > 0 {
+		line = line + ", reorder point " + strconv.Itoa(product.ReorderPoint)
+	}
+	return line
+}
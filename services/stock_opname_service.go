@@ -0,0 +1,274 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// Status sesi stock opname
+const (
+	StockOpnameStatusOpen      = "open"
+	StockOpnameStatusPosted    = "posted"
+	StockOpnameStatusCancelled = "cancelled"
+)
+
+type StockOpnameServiceInterface interface {
+	FindStockOpnames(requestId string, stockOpnameListRequest *request.StockOpnameListRequest) (stockOpnameListResponse response.FindStockOpnameListResponse)
+	FindStockOpnameById(requestId string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse)
+	CreateStockOpname(requestId string, idUser string, stockOpnameCreateRequest *request.StockOpnameCreateRequest) (stockOpnameResponse response.StockOpnameResponse)
+	CountStockOpname(requestId string, stockOpnameCountRequest *request.StockOpnameCountRequest) (stockOpnameResponse response.StockOpnameResponse)
+	PostStockOpname(requestId string, idUser string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse)
+	CancelStockOpname(requestId string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse)
+}
+
+type StockOpnameServiceImplementation struct {
+	ConfigWebserver                     config.Webserver
+	DB                                  *gorm.DB
+	Validate                            *validator.Validate
+	Logger                              *logrus.Logger
+	StockOpnameRepositoryInterface      mysql.StockOpnameRepositoryInterface
+	ProductRepositoryInterface          mysql.ProductRepositoryInterface
+	ProductStockServiceInterface        ProductStockServiceInterface
+	RestockSubscriptionServiceInterface RestockSubscriptionServiceInterface
+	StockMonitorServiceInterface        StockMonitorServiceInterface
+	ProductSearchServiceInterface       ProductSearchServiceInterface
+}
+
+func NewStockOpnameService(
+	configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	stockOpnameRepositoryInterface mysql.StockOpnameRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	productStockServiceInterface ProductStockServiceInterface,
+	restockSubscriptionServiceInterface RestockSubscriptionServiceInterface,
+	stockMonitorServiceInterface StockMonitorServiceInterface,
+	productSearchServiceInterface ProductSearchServiceInterface) StockOpnameServiceInterface {
+	return &StockOpnameServiceImplementation{
+		ConfigWebserver:                     configWebserver,
+		DB:                                  DB,
+		Validate:                            validate,
+		Logger:                              logger,
+		StockOpnameRepositoryInterface:      stockOpnameRepositoryInterface,
+		ProductRepositoryInterface:          productRepositoryInterface,
+		ProductStockServiceInterface:        productStockServiceInterface,
+		RestockSubscriptionServiceInterface: restockSubscriptionServiceInterface,
+		StockMonitorServiceInterface:        stockMonitorServiceInterface,
+		ProductSearchServiceInterface:       productSearchServiceInterface,
+	}
+}
+
+func (service *StockOpnameServiceImplementation) FindStockOpnames(requestId string, stockOpnameListRequest *request.StockOpnameListRequest) (stockOpnameListResponse response.FindStockOpnameListResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameListRequest, requestId, service.Logger)
+
+	pagination := modelService.Pagination{Page: stockOpnameListRequest.Page, Limit: stockOpnameListRequest.Limit}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = 20
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+
+	stockOpnames, totalData, err := service.StockOpnameRepositoryInterface.FindStockOpnames(service.DB, pagination.Limit, pagination.Offset)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	stockOpnameListResponse = response.ToFindStockOpnameListResponse(stockOpnames, totalData)
+	return stockOpnameListResponse
+}
+
+func (service *StockOpnameServiceImplementation) FindStockOpnameById(requestId string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameIdRequest, requestId, service.Logger)
+	stockOpname := service.findStockOpname(requestId, stockOpnameIdRequest.Id)
+	stockOpnameResponse = response.ToStockOpnameResponse(stockOpname)
+	return stockOpnameResponse
+}
+
+// Stok sistem dicatat saat sesi dibuat, produk dengan varian dihitung per varian aktif
+func (service *StockOpnameServiceImplementation) CreateStockOpname(requestId string, idUser string, stockOpnameCreateRequest *request.StockOpnameCreateRequest) (stockOpnameResponse response.StockOpnameResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameCreateRequest, requestId, service.Logger)
+
+	openStockOpname, err := service.StockOpnameRepositoryInterface.FindOpenStockOpname(service.DB)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if openStockOpname.Id != "" {
+		exceptions.PanicIfRecordAlreadyExists(errors.New("stock opname already open"), requestId, []string{"stock opname " + openStockOpname.NoOpname + " is still open"}, service.Logger)
+	}
+
+	products, err := service.ProductRepositoryInterface.FindAdminProductsForExport(service.DB, "")
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	stockOpnameEntity := &entity.StockOpname{}
+	stockOpnameEntity.Id = utilities.RandomUUID()
+	stockOpnameEntity.NoOpname = "SO" + time.Now().Format("20060102150405")
+	stockOpnameEntity.Description = stockOpnameCreateRequest.Description
+	stockOpnameEntity.IdCategory = stockOpnameCreateRequest.IdCategory
+	stockOpnameEntity.Status = StockOpnameStatusOpen
+	stockOpnameEntity.CreatedBy = idUser
+	stockOpnameEntity.CreatedAt = time.Now()
+
+	var stockOpnameItems []entity.StockOpnameItem
+	for _, product := range products {
+		if product.ArchivedAt.Valid {
+			continue
+		}
+		if stockOpnameCreateRequest.IdCategory != 0 && product.IdCategory != stockOpnameCreateRequest.IdCategory {
+			continue
+		}
+		if len(product.ProductVariants) == 0 {
+			stockOpnameItems = append(stockOpnameItems, entity.StockOpnameItem{
+				Id:            utilities.RandomUUID(),
+				IdStockOpname: stockOpnameEntity.Id,
+				IdProduct:     product.Id,
+				SystemQty:     product.Stock,
+			})
+			continue
+		}
+		for _, productVariant := range product.ProductVariants {
+			stockOpnameItems = append(stockOpnameItems, entity.StockOpnameItem{
+				Id:               utilities.RandomUUID(),
+				IdStockOpname:    stockOpnameEntity.Id,
+				IdProduct:        product.Id,
+				IdProductVariant: productVariant.Id,
+				SystemQty:        productVariant.Stock,
+			})
+		}
+	}
+	if len(stockOpnameItems) == 0 {
+		exceptions.PanicIfBadRequest(errors.New("no product to count"), requestId, []string{"no product to count"}, service.Logger)
+	}
+
+	tx := service.DB.Begin()
+	_, err = service.StockOpnameRepositoryInterface.CreateStockOpname(tx, *stockOpnameEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error create stock opname"}, service.Logger, tx)
+	err = service.StockOpnameRepositoryInterface.CreateStockOpnameItems(tx, stockOpnameItems)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error create stock opname"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	stockOpname := service.findStockOpname(requestId, stockOpnameEntity.Id)
+	stockOpnameResponse = response.ToStockOpnameResponse(stockOpname)
+	return stockOpnameResponse
+}
+
+func (service *StockOpnameServiceImplementation) CountStockOpname(requestId string, stockOpnameCountRequest *request.StockOpnameCountRequest) (stockOpnameResponse response.StockOpnameResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameCountRequest, requestId, service.Logger)
+
+	stockOpname := service.findStockOpname(requestId, stockOpnameCountRequest.Id)
+	service.checkStockOpnameOpen(requestId, stockOpname)
+
+	stockOpnameItems := make(map[string]entity.StockOpnameItem, len(stockOpname.StockOpnameItems))
+	for _, stockOpnameItem := range stockOpname.StockOpnameItems {
+		stockOpnameItems[stockOpnameItem.Id] = stockOpnameItem
+	}
+
+	tx := service.DB.Begin()
+	for _, countItem := range stockOpnameCountRequest.Items {
+		stockOpnameItem, ok := stockOpnameItems[countItem.IdItem]
+		if !ok {
+			exceptions.PanicIfRecordNotFoundWithRollback(errors.New("stock opname item not found"), requestId, []string{"item " + countItem.IdItem + " not found in this stock opname"}, service.Logger, tx)
+		}
+		stockOpnameItem.CountedQty = null.IntFrom(int64(countItem.CountedQty))
+		stockOpnameItem.DifferenceQty = countItem.CountedQty - stockOpnameItem.SystemQty
+		stockOpnameItem.CountedAt = null.TimeFrom(time.Now())
+		_, err := service.StockOpnameRepositoryInterface.UpdateStockOpnameItemCount(tx, stockOpnameItem.Id, stockOpnameItem)
+		exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update stock opname item"}, service.Logger, tx)
+	}
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+
+	stockOpname = service.findStockOpname(requestId, stockOpname.Id)
+	stockOpnameResponse = response.ToStockOpnameResponse(stockOpname)
+	return stockOpnameResponse
+}
+
+// Hanya item yang sudah dihitung dan memiliki selisih yang diposting ke stok, semuanya dalam satu transaksi
+func (service *StockOpnameServiceImplementation) PostStockOpname(requestId string, idUser string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameIdRequest, requestId, service.Logger)
+
+	stockOpname := service.findStockOpname(requestId, stockOpnameIdRequest.Id)
+	service.checkStockOpnameOpen(requestId, stockOpname)
+
+	restocked := false
+	var decreasedIdProducts []string
+	tx := service.DB.Begin()
+	for _, stockOpnameItem := range stockOpname.StockOpnameItems {
+		if !stockOpnameItem.CountedQty.Valid || stockOpnameItem.DifferenceQty == 0 {
+			continue
+		}
+		_, itemRestocked := service.ProductStockServiceInterface.PostStockMovement(tx, requestId, modelService.StockMovement{
+			IdProduct:        stockOpnameItem.IdProduct,
+			IdProductVariant: stockOpnameItem.IdProductVariant,
+			Qty:              stockOpnameItem.DifferenceQty,
+			TxType:           StockTxOpname,
+			Reference:        stockOpname.NoOpname,
+			Description:      "Selisih stock opname " + stockOpname.NoOpname,
+			CreatedBy:        idUser,
+		})
+		restocked = restocked || itemRestocked
+		if stockOpnameItem.DifferenceQty < 0 {
+			decreasedIdProducts = append(decreasedIdProducts, stockOpnameItem.IdProduct)
+		}
+	}
+
+	stockOpnameEntity := &entity.StockOpname{}
+	stockOpnameEntity.Status = StockOpnameStatusPosted
+	stockOpnameEntity.PostedAt = null.TimeFrom(time.Now())
+	_, err := service.StockOpnameRepositoryInterface.UpdateStockOpnameStatus(tx, stockOpname.Id, *stockOpnameEntity)
+	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error update stock opname"}, service.Logger, tx)
+	commit := tx.Commit()
+	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
+	service.ProductSearchServiceInterface.InvalidateProductSearchIndex()
+
+	if restocked {
+		go service.RestockSubscriptionServiceInterface.NotifyRestockSubscribers()
+	}
+	go service.StockMonitorServiceInterface.CheckProductStocks(decreasedIdProducts)
+
+	stockOpname = service.findStockOpname(requestId, stockOpname.Id)
+	stockOpnameResponse = response.ToStockOpnameResponse(stockOpname)
+	return stockOpnameResponse
+}
+
+func (service *StockOpnameServiceImplementation) CancelStockOpname(requestId string, stockOpnameIdRequest *request.StockOpnameIdRequest) (stockOpnameResponse response.StockOpnameResponse) {
+	request.ValidateStockOpnameRequest(service.Validate, stockOpnameIdRequest, requestId, service.Logger)
+
+	stockOpname := service.findStockOpname(requestId, stockOpnameIdRequest.Id)
+	service.checkStockOpnameOpen(requestId, stockOpname)
+
+	stockOpnameEntity := &entity.StockOpname{}
+	stockOpnameEntity.Status = StockOpnameStatusCancelled
+	_, err := service.StockOpnameRepositoryInterface.UpdateStockOpnameStatus(service.DB, stockOpname.Id, *stockOpnameEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	stockOpname.Status = StockOpnameStatusCancelled
+	stockOpnameResponse = response.ToStockOpnameResponse(stockOpname)
+	return stockOpnameResponse
+}
+
+func (service *StockOpnameServiceImplementation) findStockOpname(requestId string, id string) (stockOpname entity.StockOpname) {
+	stockOpname, err := service.StockOpnameRepositoryInterface.FindStockOpnameById(service.DB, id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if stockOpname.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("stock opname not found"), requestId, []string{"stock opname not found"}, service.Logger)
+	}
+	return stockOpname
+}
+
+func (service *StockOpnameServiceImplementation) checkStockOpnameOpen(requestId string, stockOpname entity.StockOpname) {
+	if stockOpname.Status != StockOpnameStatusOpen {
+		exceptions.PanicIfBadRequest(errors.New("stock opname is not open"), requestId, []string{"stock opname is already " + stockOpname.Status}, service.Logger)
+	}
+}
@@ -26,7 +26,7 @@ import (
 )
 
 type UserServiceInterface interface {
-	CreateUser(requestId string, userRequest *request.CreateUserRequest) (userResponse response.CreateUserResponse)
+	CreateUser(requestId string, userRequest *request.CreateUserRequest, registrationIp string) (userResponse response.CreateUserResponse)
 	FindUserByReferal(requestId string, referalCode string) (userResponse response.FindUserByReferalResponse)
 	FindUserById(requestId string, id string) (userResponse response.FindUserByIdResponse)
 	UpdateUser(requestId string, idUser string, userRequest *request.UpdateUserRequest) error
@@ -39,19 +39,21 @@ type UserServiceInterface interface {
 }
 
 type UserServiceImplementation struct {
-	ConfigurationWebserver                 config.Webserver
-	DB                                     *gorm.DB
-	ConfigJwt                              config.Jwt
-	Validate                               *validator.Validate
-	Logger                                 *logrus.Logger
-	ConfigEmail                            config.Email
-	UserRepositoryInterface                mysql.UserRepositoryInterface
-	ProvinsiRepositoryInterface            mysql.ProvinsiRepositoryInterface
-	FamilyRepositoryInterface              mysql.FamilyRepositoryInterface
-	FamilyMembersRepositoryInterface       mysql.FamilyMembersRepositoryInterface
-	BalancePointRepositoryInterface        mysql.BalancePointRepositoryInterface
-	BalancePointTxRepositoryInterface      mysql.BalancePointTxRepositoryInterface
-	UserShippingAddressRepositoryInterface mysql.UserShippingAddressRepositoryInterface
+	ConfigurationWebserver                  config.Webserver
+	DB                                      *gorm.DB
+	ConfigJwt                               config.Jwt
+	Validate                                *validator.Validate
+	Logger                                  *logrus.Logger
+	ConfigEmail                             config.Email
+	UserRepositoryInterface                 mysql.UserRepositoryInterface
+	ProvinsiRepositoryInterface             mysql.ProvinsiRepositoryInterface
+	FamilyRepositoryInterface               mysql.FamilyRepositoryInterface
+	FamilyMembersRepositoryInterface        mysql.FamilyMembersRepositoryInterface
+	BalancePointRepositoryInterface         mysql.BalancePointRepositoryInterface
+	BalancePointTxRepositoryInterface       mysql.BalancePointTxRepositoryInterface
+	UserShippingAddressRepositoryInterface  mysql.UserShippingAddressRepositoryInterface
+	RegistrationCampaignRepositoryInterface mysql.RegistrationCampaignRepositoryInterface
+	ReferalFraudServiceInterface            ReferalFraudServiceInterface
 }
 
 func NewUserService(
@@ -67,21 +69,25 @@ func NewUserService(
 	familyMembersRepositoryInterface mysql.FamilyMembersRepositoryInterface,
 	balancePointRepositoryInterface mysql.BalancePointRepositoryInterface,
 	balancePointTxRepositoryInterface mysql.BalancePointTxRepositoryInterface,
-	userShippingAddressRepositoryInterface mysql.UserShippingAddressRepositoryInterface) UserServiceInterface {
+	userShippingAddressRepositoryInterface mysql.UserShippingAddressRepositoryInterface,
+	registrationCampaignRepositoryInterface mysql.RegistrationCampaignRepositoryInterface,
+	referalFraudServiceInterface ReferalFraudServiceInterface) UserServiceInterface {
 	return &UserServiceImplementation{
-		ConfigurationWebserver:                 configurationWebserver,
-		DB:                                     DB,
-		ConfigJwt:                              configJwt,
-		Validate:                               validate,
-		Logger:                                 logger,
-		ConfigEmail:                            configEmail,
-		UserRepositoryInterface:                userRepositoryInterface,
-		ProvinsiRepositoryInterface:            provinsiRepositoryInterface,
-		FamilyRepositoryInterface:              familyRepositoryInterface,
-		FamilyMembersRepositoryInterface:       familyMembersRepositoryInterface,
-		BalancePointRepositoryInterface:        balancePointRepositoryInterface,
-		BalancePointTxRepositoryInterface:      balancePointTxRepositoryInterface,
-		UserShippingAddressRepositoryInterface: userShippingAddressRepositoryInterface,
+		ConfigurationWebserver:                  configurationWebserver,
+		DB:                                      DB,
+		ConfigJwt:                               configJwt,
+		Validate:                                validate,
+		Logger:                                  logger,
+		ConfigEmail:                             configEmail,
+		UserRepositoryInterface:                 userRepositoryInterface,
+		ProvinsiRepositoryInterface:             provinsiRepositoryInterface,
+		FamilyRepositoryInterface:               familyRepositoryInterface,
+		FamilyMembersRepositoryInterface:        familyMembersRepositoryInterface,
+		BalancePointRepositoryInterface:         balancePointRepositoryInterface,
+		BalancePointTxRepositoryInterface:       balancePointTxRepositoryInterface,
+		UserShippingAddressRepositoryInterface:  userShippingAddressRepositoryInterface,
+		RegistrationCampaignRepositoryInterface: registrationCampaignRepositoryInterface,
+		ReferalFraudServiceInterface:            referalFraudServiceInterface,
 	}
 }
 
@@ -287,7 +293,7 @@ func (service *UserServiceImplementation) UpdateUser(requestId string, idUser st
 	return nil
 }
 
-func (service *UserServiceImplementation) CreateUser(requestId string, userRequest *request.CreateUserRequest) (userResponse response.CreateUserResponse) {
+func (service *UserServiceImplementation) CreateUser(requestId string, userRequest *request.CreateUserRequest, registrationIp string) (userResponse response.CreateUserResponse) {
 
 	// Validate request
 	request.ValidateCreateUserRequest(service.Validate, userRequest, requestId, service.Logger)
@@ -312,6 +318,20 @@ func (service *UserServiceImplementation) CreateUser(requestId string, userReque
 		exceptions.PanicIfRecordAlreadyExists(err, requestId, []string{"Phone sudah digunakan"}, service.Logger)
 	}
 
+	// Check referal code
+	registrationReferalCode := strings.ToUpper(strings.TrimSpace(userRequest.RegistrationReferalCode))
+	var userReferal entity.User
+	if registrationReferalCode != "" {
+		userReferal, _ = service.UserRepositoryInterface.FindUserByReferalCode(service.DB, registrationReferalCode)
+	}
+
+	// Cari campaign registrasi yang berlaku untuk kode referal
+	registrationCampaign := service.FindRegistrationCampaign(registrationReferalCode, time.Now())
+	if registrationReferalCode != "" && userReferal.Id == "" && !service.IsRegistrationCampaignCode(registrationCampaign, registrationReferalCode) {
+		err := errors.New("referal code not found")
+		exceptions.PanicIfBadRequest(err, requestId, []string{"Kode referal tidak ditemukan"}, service.Logger)
+	}
+
 	// Begin Transcation
 	tx := service.DB.Begin()
 	exceptions.PanicIfError(tx.Error, requestId, service.Logger)
@@ -348,17 +368,14 @@ func (service *UserServiceImplementation) CreateUser(requestId string, userReque
 	userEntity.Password = string(bcryptPassword)
 	userEntity.IsActive = 1
 	userEntity.VerificationDate = null.NewTime(time.Now(), true)
-	if userRequest.RegistrationReferalCode == "" {
-		// dafault kode referal jika inputan kosong
-		userEntity.RegistrationReferalCode = "0X0ROQIBA"
-	} else {
-		userEntity.RegistrationReferalCode = strings.ToUpper(userRequest.RegistrationReferalCode)
-	}
+	userEntity.RegistrationReferalCode = registrationReferalCode
 
 	userEntity.CreatedDate = time.Now()
 	userEntity.VerificationDueDate = time.Now().Add(time.Hour * 24)
 	userEntity.ReferalCode = referalCode
 	userEntity.RefreshToken = ""
+	userEntity.TokenDevice = userRequest.TokenDevice
+	userEntity.RegistrationIp = registrationIp
 
 	user, err := service.UserRepositoryInterface.CreateUser(tx, *userEntity)
 	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error insert user"}, service.Logger, tx)
@@ -371,32 +388,81 @@ func (service *UserServiceImplementation) CreateUser(requestId string, userReque
 	balancePoint, err := service.BalancePointRepositoryInterface.CreateBalancePoint(tx, *balancePointEntity)
 	exceptions.PanicIfErrorWithRollback(err, requestId, []string{"Error insert balance point"}, service.Logger, tx)
 
-	// Kode untuk bonus point registrasi referal
-	if userRequest.RegistrationReferalCode != "" {
-		// dapat bonus point jika menggunakan kode referal
-
-		balancePointEntity := &entity.BalancePoint{}
-		balancePointEntity.BalancePoints = 10000
-
-		_, errUpdateBalancePoint := service.BalancePointRepositoryInterface.UpdateBalancePoint(tx, balancePoint.IdUser, *balancePointEntity)
-		exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
-
-		// Add to point history
-		balancePointTxEntity := &entity.BalancePointTx{}
-		balancePointTxEntity.Id = utilities.RandomUUID()
-		balancePointTxEntity.IdBalancePoint = balancePoint.Id
-		balancePointTxEntity.TxType = "debit"
-		balancePointTxEntity.TxDate = time.Now()
-		balancePointTxEntity.TxNominal = balancePointEntity.BalancePoints
-		balancePointTxEntity.LastPointBalance = 0
-		balancePointTxEntity.NewPointBalance = balancePointEntity.BalancePoints
-		balancePointTxEntity.CreatedDate = time.Now()
-		balancePointTxEntity.Description = "Bonus Registrasi"
-
-		_, errCreateBalancePointTx := service.BalancePointTxRepositoryInterface.CreateBalancePointTx(tx, *balancePointTxEntity)
-		exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
+	// Bonus point registrasi dari campaign
+	if registrationCampaign.Id != "" {
+		// kuota campaign bisa habis oleh registrasi lain, maka redemption dicatat lebih dulu
+		redemption, errRedemption := service.RegistrationCampaignRepositoryInterface.AddRegistrationCampaignRedemption(tx, registrationCampaign.Id)
+		exceptions.PanicIfErrorWithRollback(errRedemption, requestId, []string{"update campaign error"}, service.Logger, tx)
+
+		// Registrasi yang terindikasi fraud referal, bonusnya ditahan sampai direview admin
+		holdBonus := false
+		if redemption > 0 && userReferal.Id != "" {
+			fraudScore, fraudReasons := service.ReferalFraudServiceInterface.ScoreRegistration(*userEntity, phoneFinal, userReferal)
+			if service.ReferalFraudServiceInterface.IsReferalSuspicious(fraudScore) {
+				holdBonus = true
+				referalBonusReviewEntity := &entity.ReferalBonusReview{}
+				referalBonusReviewEntity.IdUser = userEntity.Id
+				referalBonusReviewEntity.IdUserReferal = userReferal.Id
+				referalBonusReviewEntity.Event = "registration"
+				referalBonusReviewEntity.IdCampaign = registrationCampaign.Id
+				referalBonusReviewEntity.BonusUser = registrationCampaign.BonusNewUser
+				referalBonusReviewEntity.BonusReferal = registrationCampaign.BonusReferrer
+				service.ReferalFraudServiceInterface.HoldReferalBonus(tx, requestId, *referalBonusReviewEntity, fraudScore, fraudReasons)
+			}
+		}
+
+		if redemption > 0 && !holdBonus && registrationCampaign.BonusNewUser > 0 {
+			balancePointEntity := &entity.BalancePoint{}
+			balancePointEntity.BalancePoints = registrationCampaign.BonusNewUser
+
+			_, errUpdateBalancePoint := service.BalancePointRepositoryInterface.UpdateBalancePoint(tx, balancePoint.IdUser, *balancePointEntity)
+			exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
+
+			// Add to point history
+			balancePointTxEntity := &entity.BalancePointTx{}
+			balancePointTxEntity.Id = utilities.RandomUUID()
+			balancePointTxEntity.IdBalancePoint = balancePoint.Id
+			balancePointTxEntity.IdCampaign = registrationCampaign.Id
+			balancePointTxEntity.TxType = "debit"
+			balancePointTxEntity.TxDate = time.Now()
+			balancePointTxEntity.TxNominal = balancePointEntity.BalancePoints
+			balancePointTxEntity.LastPointBalance = 0
+			balancePointTxEntity.NewPointBalance = balancePointEntity.BalancePoints
+			balancePointTxEntity.CreatedDate = time.Now()
+			balancePointTxEntity.Description = "Bonus Registrasi"
+
+			_, errCreateBalancePointTx := service.BalancePointTxRepositoryInterface.CreateBalancePointTx(tx, *balancePointTxEntity)
+			exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
+		}
+
+		if redemption > 0 && !holdBonus && registrationCampaign.BonusReferrer > 0 && userReferal.Id != "" {
+			balancePointReferal, errFindBalancePointReferal := service.BalancePointRepositoryInterface.FindBalancePointWalletByIdUserForUpdate(tx, userReferal.Id)
+			exceptions.PanicIfErrorWithRollback(errFindBalancePointReferal, requestId, []string{"find balance point error"}, service.Logger, tx)
+
+			balancePointEntityReferal := &entity.BalancePoint{}
+			balancePointEntityReferal.BalancePoints = balancePointReferal.BalancePoints + registrationCampaign.BonusReferrer
+
+			_, errUpdateBalancePoint := service.BalancePointRepositoryInterface.UpdateBalancePoint(tx, balancePointReferal.IdUser, *balancePointEntityReferal)
+			exceptions.PanicIfErrorWithRollback(errUpdateBalancePoint, requestId, []string{"update balance point error"}, service.Logger, tx)
+
+			// Add to point history
+			balancePointTxEntityReferal := &entity.BalancePointTx{}
+			balancePointTxEntityReferal.Id = utilities.RandomUUID()
+			balancePointTxEntityReferal.IdBalancePoint = balancePointReferal.Id
+			balancePointTxEntityReferal.IdCampaign = registrationCampaign.Id
+			balancePointTxEntityReferal.TxType = "referal"
+			balancePointTxEntityReferal.TxDate = time.Now()
+			balancePointTxEntityReferal.TxNominal = registrationCampaign.BonusReferrer
+			balancePointTxEntityReferal.LastPointBalance = balancePointReferal.BalancePoints
+			balancePointTxEntityReferal.NewPointBalance = balancePointEntityReferal.BalancePoints
+			balancePointTxEntityReferal.CreatedDate = time.Now()
+			balancePointTxEntityReferal.Description = "Bonus Referal Registrasi"
+
+			_, errCreateBalancePointTx := service.BalancePointTxRepositoryInterface.CreateBalancePointTx(tx, *balancePointTxEntityReferal)
+			exceptions.PanicIfErrorWithRollback(errCreateBalancePointTx, requestId, []string{"create balance point tx error"}, service.Logger, tx)
+		}
 	}
-	// end of promo registration code
+	// end of registration campaign
 
 	commit := tx.Commit()
 	exceptions.PanicIfError(commit.Error, requestId, service.Logger)
@@ -435,6 +501,62 @@ func (service *UserServiceImplementation) GenerateReferalCode(fullName string) s
 	return referalCode
 }
 
+// Campaign pertama yang aktif dan berlaku untuk kode referal, kosong jika tidak ada
+func (service *UserServiceImplementation) FindRegistrationCampaign(referalCode string, date time.Time) (registrationCampaign entity.RegistrationCampaign) {
+	if referalCode == "" {
+		return registrationCampaign
+	}
+
+	registrationCampaigns, _ := service.RegistrationCampaignRepositoryInterface.FindActiveRegistrationCampaigns(service.DB, date)
+	for _, campaign := range registrationCampaigns {
+		if campaign.MaxRedemption > 0 && campaign.TotalRedemption >= campaign.MaxRedemption {
+			continue
+		}
+
+		referalCodes := service.SplitCampaignCodes(campaign.ReferalCodes)
+		referalCodePrefixes := service.SplitCampaignCodes(campaign.ReferalCodePrefixes)
+
+		// campaign tanpa daftar kode berlaku untuk semua kode referal
+		if len(referalCodes) == 0 && len(referalCodePrefixes) == 0 {
+			return campaign
+		}
+
+		for _, code := range referalCodes {
+			if code == referalCode {
+				return campaign
+			}
+		}
+
+		for _, prefix := range referalCodePrefixes {
+			if strings.HasPrefix(referalCode, prefix) {
+				return campaign
+			}
+		}
+	}
+
+	return registrationCampaign
+}
+
+// Kode yang terdaftar langsung di campaign boleh dipakai walaupun bukan kode referal user
+func (service *UserServiceImplementation) IsRegistrationCampaignCode(registrationCampaign entity.RegistrationCampaign, referalCode string) bool {
+	for _, code := range service.SplitCampaignCodes(registrationCampaign.ReferalCodes) {
+		if code == referalCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (service *UserServiceImplementation) SplitCampaignCodes(codes string) (campaignCodes []string) {
+	for _, code := range strings.Split(codes, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code != "" {
+			campaignCodes = append(campaignCodes, code)
+		}
+	}
+	return campaignCodes
+}
+
 func (service *UserServiceImplementation) FindUserByReferal(requestId string, referal string) (userResponse response.FindUserByReferalResponse) {
 	user, err := service.UserRepositoryInterface.FindUserByReferal(service.DB, referal)
 	exceptions.PanicIfRecordNotFound(err, requestId, []string{"Data Not Found"}, service.Logger)
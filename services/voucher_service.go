@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// Jenis voucher
+const (
+	voucherTypePercentage   = "percentage"
+	voucherTypeFixed        = "fixed"
+	voucherTypeFreeShipping = "free_shipping"
+)
+
+// Jenis rule voucher, rule kategori dan brand membatasi produk yang mendapat potongan
+const (
+	voucherRuleCategory = "category"
+	voucherRuleBrand    = "brand"
+	voucherRuleLevel    = "level"
+)
+
+// Status pemakaian voucher
+const (
+	voucherUsageUsed     = "used"
+	voucherUsageReleased = "released"
+)
+
+type VoucherServiceInterface interface {
+	ValidateVoucher(requestId string, idUser string, voucherValidateRequest *request.VoucherValidateRequest) (voucherValidateResponse response.VoucherValidateResponse)
+	EvaluateVoucher(requestId string, user entity.User, cartItems []entity.Cart, voucherCode string, shippingCost float64, now time.Time) (voucherRedemption modelService.VoucherRedemption)
+	RedeemVoucher(tx *gorm.DB, requestId string, order entity.Order)
+	ReleaseVoucher(tx *gorm.DB, requestId string, order entity.Order)
+}
+
+type VoucherServiceImplementation struct {
+	ConfigWebserver                 config.Webserver
+	DB                              *gorm.DB
+	Validate                        *validator.Validate
+	Logger                          *logrus.Logger
+	VoucherRepositoryInterface      mysql.VoucherRepositoryInterface
+	VoucherUsageRepositoryInterface mysql.VoucherUsageRepositoryInterface
+	CartRepositoryInterface         mysql.CartRepositoryInterface
+	UserRepositoryInterface         mysql.UserRepositoryInterface
+	SettingRepositoryInterface      mysql.SettingRepositoryInterface
+	PromotionServiceInterface       PromotionServiceInterface
+	FlashSaleServiceInterface       FlashSaleServiceInterface
+}
+
+func NewVoucherService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	voucherRepositoryInterface mysql.VoucherRepositoryInterface,
+	voucherUsageRepositoryInterface mysql.VoucherUsageRepositoryInterface,
+	cartRepositoryInterface mysql.CartRepositoryInterface,
+	userRepositoryInterface mysql.UserRepositoryInterface,
+	settingRepositoryInterface mysql.SettingRepositoryInterface,
+	promotionServiceInterface PromotionServiceInterface,
+	flashSaleServiceInterface FlashSaleServiceInterface) VoucherServiceInterface {
+	return &VoucherServiceImplementation{
+		ConfigWebserver:                 configWebserver,
+		DB:                              DB,
+		Validate:                        validate,
+		Logger:                          logger,
+		VoucherRepositoryInterface:      voucherRepositoryInterface,
+		VoucherUsageRepositoryInterface: voucherUsageRepositoryInterface,
+		CartRepositoryInterface:         cartRepositoryInterface,
+		UserRepositoryInterface:         userRepositoryInterface,
+		SettingRepositoryInterface:      settingRepositoryInterface,
+		PromotionServiceInterface:       promotionServiceInterface,
+		FlashSaleServiceInterface:       flashSaleServiceInterface,
+	}
+}
+
+func (service *VoucherServiceImplementation) ValidateVoucher(requestId string, idUser string, voucherValidateRequest *request.VoucherValidateRequest) (voucherValidateResponse response.VoucherValidateResponse) {
+	request.ValidateVoucherValidateRequest(service.Validate, voucherValidateRequest, requestId, service.Logger)
+
+	user, _ := service.UserRepositoryInterface.FindUserById(service.DB, idUser)
+	if user.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("user not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	cartItems, err := service.CartRepositoryInterface.FindCartByIdUser(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	cartItems = service.FlashSaleServiceInterface.ApplyFlashSales(requestId, idUser, cartItems, time.Now())
+
+	// Tanpa ongkos kirim dari client, pakai ongkos kirim yang sama dengan keranjang
+	shippingCost := voucherValidateRequest.ShippingCost
+	if shippingCost == 0 {
+		settingShippingCost, err := service.SettingRepositoryInterface.FindSettingShippingCost(service.DB)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		shippingCost = settingShippingCost.Value
+	}
+
+	voucherRedemption := service.EvaluateVoucher(requestId, user, cartItems, voucherValidateRequest.VoucherCode, shippingCost, time.Now())
+	voucherValidateResponse = response.ToVoucherValidateResponse(voucherRedemption)
+	return voucherValidateResponse
+}
+
+func (service *VoucherServiceImplementation) EvaluateVoucher(requestId string, user entity.User, cartItems []entity.Cart, voucherCode string, shippingCost float64, now time.Time) (voucherRedemption modelService.VoucherRedemption) {
+	voucherRedemption.VoucherCode = strings.TrimSpace(voucherCode)
+	voucherRedemption.ShippingCost = shippingCost
+
+	voucher, err := service.VoucherRepositoryInterface.FindVoucherByCode(service.DB, voucherRedemption.VoucherCode)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if voucher.Id == "" {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Voucher tidak ditemukan")
+		return voucherRedemption
+	}
+	voucherRedemption.IdVoucher = voucher.Id
+	voucherRedemption.VoucherCode = voucher.VoucherCode
+	voucherRedemption.VoucherName = voucher.VoucherName
+	voucherRedemption.VoucherType = voucher.VoucherType
+
+	eligibleCategories := make(map[string]bool)
+	eligibleBrands := make(map[string]bool)
+	eligibleLevels := make(map[string]bool)
+	for _, voucherRule := range voucher.VoucherRules {
+		switch voucherRule.RuleType {
+		case voucherRuleCategory:
+			eligibleCategories[voucherRule.RefId] = true
+		case voucherRuleBrand:
+			eligibleBrands[voucherRule.RefId] = true
+		case voucherRuleLevel:
+			eligibleLevels[voucherRule.RefId] = true
+		}
+	}
+
+	// Voucher dihitung dari total setelah potongan promo keranjang
+	lineDiscounts := pricing.CartPromotionDiscounts(service.PromotionServiceInterface.EvaluateCartPromotions(requestId, cartItems, now))
+	for _, cartItem := range cartItems {
+		price := pricing.EvaluateCartItemPricing(cartItem, now).Price
+		totalPrice := price*float64(cartItem.Qty) - lineDiscounts[cartItem.Id]
+		voucherRedemption.Subtotal = voucherRedemption.Subtotal + totalPrice
+		if len(eligibleCategories) > 0 && !eligibleCategories[strconv.Itoa(cartItem.Product.IdCategory)] {
+			continue
+		}
+		if len(eligibleBrands) > 0 && !eligibleBrands[cartItem.Product.IdBrand] {
+			continue
+		}
+		voucherRedemption.EligibleTotal = voucherRedemption.EligibleTotal + totalPrice
+	}
+
+	if !voucher.StartDate.IsZero() && now.Before(voucher.StartDate) {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Voucher belum berlaku")
+	}
+	if endAt := pricing.ValidUntil(voucher.EndDate); !endAt.IsZero() && !now.Before(endAt) {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Voucher sudah berakhir")
+	}
+	if len(eligibleLevels) > 0 && !eligibleLevels[strconv.Itoa(user.IdLevelMember)] {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Voucher tidak berlaku untuk level member anda")
+	}
+	if voucher.UsageLimit > 0 && voucher.UsageCount >= voucher.UsageLimit {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Kuota voucher sudah habis")
+	}
+	if voucher.UsageLimitPerUser > 0 {
+		usageCount, err := service.VoucherUsageRepositoryInterface.CountVoucherUsageByIdUser(service.DB, voucher.Id, user.Id)
+		exceptions.PanicIfError(err, requestId, service.Logger)
+		if usageCount >= int64(voucher.UsageLimitPerUser) {
+			voucherRedemption.Violations = append(voucherRedemption.Violations, "Batas pemakaian voucher sudah tercapai")
+		}
+	}
+	if voucherRedemption.EligibleTotal == 0 {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Tidak ada produk di keranjang yang berlaku untuk voucher")
+	} else if voucherRedemption.EligibleTotal < voucher.MinSpend {
+		voucherRedemption.Violations = append(voucherRedemption.Violations, "Minimal belanja "+formatPoint(voucher.MinSpend)+" untuk memakai voucher")
+	}
+
+	voucherRedemption.Discount = voucherDiscount(voucher, voucherRedemption.EligibleTotal, shippingCost)
+	return voucherRedemption
+}
+
+// Mencatat pemakaian voucher order di dalam transaksi pembuatan order
+func (service *VoucherServiceImplementation) RedeemVoucher(tx *gorm.DB, requestId string, order entity.Order) {
+	rowsAffected, errIncreaseUsage := service.VoucherRepositoryInterface.IncreaseVoucherUsageCount(tx, order.IdVoucher)
+	exceptions.PanicIfErrorWithRollback(errIncreaseUsage, requestId, []string{"update voucher error"}, service.Logger, tx)
+	if rowsAffected == 0 {
+		exceptions.PanicIfErrorWithRollback(errors.New("voucher quota exceeded"), requestId, []string{"Kuota voucher sudah habis"}, service.Logger, tx)
+	}
+
+	// Dihitung setelah baris voucher terkunci agar order bersamaan dari user yang sama tetap terhitung
+	voucher, errFindVoucher := service.VoucherRepositoryInterface.FindVoucherByCode(tx, order.VoucherCode)
+	exceptions.PanicIfErrorWithRollback(errFindVoucher, requestId, []string{"voucher not found"}, service.Logger, tx)
+	if voucher.UsageLimitPerUser > 0 {
+		usageCount, errCountUsage := service.VoucherUsageRepositoryInterface.CountVoucherUsageByIdUser(tx, order.IdVoucher, order.IdUser)
+		exceptions.PanicIfErrorWithRollback(errCountUsage, requestId, []string{"count voucher usage error"}, service.Logger, tx)
+		if usageCount >= int64(voucher.UsageLimitPerUser) {
+			exceptions.PanicIfErrorWithRollback(errors.New("voucher user limit exceeded"), requestId, []string{"Batas pemakaian voucher sudah tercapai"}, service.Logger, tx)
+		}
+	}
+
+	voucherUsageEntity := &entity.VoucherUsage{}
+	voucherUsageEntity.Id = utilities.RandomUUID()
+	voucherUsageEntity.IdVoucher = order.IdVoucher
+	voucherUsageEntity.IdUser = order.IdUser
+	voucherUsageEntity.IdOrder = order.Id
+	voucherUsageEntity.NumberOrder = order.NumberOrder
+	voucherUsageEntity.VoucherDiscount = order.VoucherDiscount
+	voucherUsageEntity.Status = voucherUsageUsed
+	voucherUsageEntity.UsedAt = time.Now()
+
+	_, errCreateUsage := service.VoucherUsageRepositoryInterface.CreateVoucherUsage(tx, *voucherUsageEntity)
+	exceptions.PanicIfErrorWithRollback(errCreateUsage, requestId, []string{"create voucher usage error"}, service.Logger, tx)
+}
+
+// Mengembalikan kuota voucher dari order yang dibatalkan atau kedaluwarsa
+func (service *VoucherServiceImplementation) ReleaseVoucher(tx *gorm.DB, requestId string, order entity.Order) {
+	if order.IdVoucher == "" {
+		return
+	}
+
+	voucherUsage, errFindUsage := service.VoucherUsageRepositoryInterface.FindVoucherUsageByIdOrder(tx, order.Id)
+	exceptions.PanicIfErrorWithRollback(errFindUsage, requestId, []string{"voucher usage not found"}, service.Logger, tx)
+	if voucherUsage.Id == "" {
+		return
+	}
+
+	voucherUsageEntity := &entity.VoucherUsage{}
+	voucherUsageEntity.Status = voucherUsageReleased
+	voucherUsageEntity.ReleasedAt = null.NewTime(time.Now(), true)
+
+	_, errUpdateUsage := service.VoucherUsageRepositoryInterface.UpdateVoucherUsageStatus(tx, voucherUsage.Id, *voucherUsageEntity)
+	exceptions.PanicIfErrorWithRollback(errUpdateUsage, requestId, []string{"update voucher usage error"}, service.Logger, tx)
+
+	errDecreaseUsage := service.VoucherRepositoryInterface.DecreaseVoucherUsageCount(tx, voucherUsage.IdVoucher)
+	exceptions.PanicIfErrorWithRollback(errDecreaseUsage, requestId, []string{"update voucher error"}, service.Logger, tx)
+}
+
+// Potongan persen dan nominal dihitung dari total produk yang berlaku, gratis ongkir dari ongkos kirim
+func voucherDiscount(voucher entity.Voucher, eligibleTotal float64, shippingCost float64) (discount float64) {
+	switch voucher.VoucherType {
+	case voucherTypePercentage:
+		discount = math.Floor(eligibleTotal * voucher.Value / 100)
+	case voucherTypeFixed:
+		discount = math.Min(voucher.Value, eligibleTotal)
+	case voucherTypeFreeShipping:
+		discount = shippingCost
+		if voucher.Value > 0 {
+			discount = math.Min(discount, voucher.Value)
+		}
+	}
+	if voucher.MaxDiscount > 0 {
+		discount = math.Min(discount, voucher.MaxDiscount)
+	}
+	return discount
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/config"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/exceptions"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/entity"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/request"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/models/http/response"
+	modelService "github.com/tensuqiuwulu/be-service-teman-bunda/models/service"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/pricing"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/repository/mysql"
+	"github.com/tensuqiuwulu/be-service-teman-bunda/utilities"
+	"gorm.io/gorm"
+)
+
+type WishlistServiceInterface interface {
+	FindWishlistByIdUser(requestId string, idUser string) (wishlistResponses []response.FindWishlistResponse)
+	AddProductToWishlist(requestId string, idUser string, wishlistRequest *request.WishlistRequest) (wishlistResponse response.FindWishlistResponse)
+	DeleteProductInWishlist(requestId string, idUser string, wishlistRequest *request.WishlistRequest)
+	MoveWishlistToCart(requestId string, idUser string, wishlistRequest *request.WishlistRequest) (addProductToCartResponse response.AddProductToCartResponse)
+	NotifyWishlistPriceChanges()
+}
+
+type WishlistServiceImplementation struct {
+	ConfigWebserver             config.Webserver
+	DB                          *gorm.DB
+	Validate                    *validator.Validate
+	Logger                      *logrus.Logger
+	WishlistRepositoryInterface mysql.WishlistRepositoryInterface
+	ProductRepositoryInterface  mysql.ProductRepositoryInterface
+	CartServiceInterface        CartServiceInterface
+}
+
+func NewWishlistService(configWebserver config.Webserver,
+	DB *gorm.DB,
+	validate *validator.Validate,
+	logger *logrus.Logger,
+	wishlistRepositoryInterface mysql.WishlistRepositoryInterface,
+	productRepositoryInterface mysql.ProductRepositoryInterface,
+	cartServiceInterface CartServiceInterface) WishlistServiceInterface {
+	return &WishlistServiceImplementation{
+		ConfigWebserver:             configWebserver,
+		DB:                          DB,
+		Validate:                    validate,
+		Logger:                      logger,
+		WishlistRepositoryInterface: wishlistRepositoryInterface,
+		ProductRepositoryInterface:  productRepositoryInterface,
+		CartServiceInterface:        cartServiceInterface,
+	}
+}
+
+func (service *WishlistServiceImplementation) FindWishlistByIdUser(requestId string, idUser string) (wishlistResponses []response.FindWishlistResponse) {
+	wishlists, err := service.WishlistRepositoryInterface.FindWishlistByIdUser(service.DB, idUser)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	wishlistResponses = response.ToFindWishlistResponses(wishlists)
+	return wishlistResponses
+}
+
+func (service *WishlistServiceImplementation) AddProductToWishlist(requestId string, idUser string, wishlistRequest *request.WishlistRequest) (wishlistResponse response.FindWishlistResponse) {
+	request.ValidateWishlistRequest(service.Validate, wishlistRequest, requestId, service.Logger)
+
+	product, _ := service.ProductRepositoryInterface.FindProductById(service.DB, wishlistRequest.IdProduct)
+	if product.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("product not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	// Produk yang sudah ada di wishlist tidak ditambahkan lagi
+	wishlistExist, err := service.WishlistRepositoryInterface.FindWishlistByIdUserAndIdProduct(service.DB, idUser, product.Id)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if wishlistExist.Id != "" {
+		wishlistExist.Product = product
+		wishlistResponse = response.ToFindWishlistResponse(wishlistExist)
+		return wishlistResponse
+	}
+
+	productPricing := pricing.EvaluateProductPricing(product, entity.ProductVariant{}, time.Now())
+
+	wishlistEntity := &entity.Wishlist{}
+	wishlistEntity.Id = utilities.RandomUUID()
+	wishlistEntity.IdUser = idUser
+	wishlistEntity.IdProduct = product.Id
+	wishlistEntity.ProductName = product.ProductName
+	wishlistEntity.Price = productPricing.Price
+	wishlistEntity.PictureUrl = product.PictureUrl
+	wishlistEntity.Thumbnail = product.Thumbnail
+	wishlistEntity.NotifiedPrice = productPricing.Price
+	if productPricing.OnPromo {
+		wishlistEntity.NotifiedPromo = 1
+	}
+	wishlistEntity.CreatedAt = time.Now()
+
+	wishlist, err := service.WishlistRepositoryInterface.CreateWishlist(service.DB, *wishlistEntity)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+
+	wishlist.Product = product
+	wishlistResponse = response.ToFindWishlistResponse(wishlist)
+	return wishlistResponse
+}
+
+func (service *WishlistServiceImplementation) DeleteProductInWishlist(requestId string, idUser string, wishlistRequest *request.WishlistRequest) {
+	request.ValidateWishlistRequest(service.Validate, wishlistRequest, requestId, service.Logger)
+	err := service.WishlistRepositoryInterface.DeleteWishlist(service.DB, idUser, wishlistRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+}
+
+func (service *WishlistServiceImplementation) MoveWishlistToCart(requestId string, idUser string, wishlistRequest *request.WishlistRequest) (addProductToCartResponse response.AddProductToCartResponse) {
+	request.ValidateWishlistRequest(service.Validate, wishlistRequest, requestId, service.Logger)
+
+	wishlist, err := service.WishlistRepositoryInterface.FindWishlistByIdUserAndIdProduct(service.DB, idUser, wishlistRequest.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	if wishlist.Id == "" {
+		exceptions.PanicIfRecordNotFound(errors.New("wishlist not found"), requestId, []string{"Not Found"}, service.Logger)
+	}
+
+	qty := wishlistRequest.Qty
+	if qty == 0 {
+		qty = 1
+	}
+
+	// Stok dan varian dicek oleh keranjang, wishlist dihapus setelah berhasil masuk keranjang
+	addProductToCartResponse = service.CartServiceInterface.AddProductToCart(requestId, idUser, &request.AddProductToCartRequest{
+		IdProduct:        wishlist.IdProduct,
+		IdProductVariant: wishlistRequest.IdProductVariant,
+		Qty:              qty,
+	})
+
+	err = service.WishlistRepositoryInterface.DeleteWishlist(service.DB, idUser, wishlist.IdProduct)
+	exceptions.PanicIfError(err, requestId, service.Logger)
+	return addProductToCartResponse
+}
+
+// Dijalankan berkala, mengirim notifikasi jika produk wishlist mulai promo atau harganya turun sejak notifikasi terakhir
+func (service *WishlistServiceImplementation) NotifyWishlistPriceChanges() {
+	wishlists, err := service.WishlistRepositoryInterface.FindAllWishlistWithUser(service.DB)
+	if err != nil {
+		service.Logger.Error(err)
+		return
+	}
+
+	now := time.Now()
+	for _, wishlist := range wishlists {
+		if wishlist.Product.Id == "" || wishlist.Product.Published != "1" {
+			continue
+		}
+
+		productPricing := pricing.EvaluateProductPricing(wishlist.Product, entity.ProductVariant{}, now)
+		notifiedPromo := 0
+		if productPricing.OnPromo {
+			notifiedPromo = 1
+		}
+		if productPricing.Price == wishlist.NotifiedPrice && notifiedPromo == wishlist.NotifiedPromo {
+			continue
+		}
+
+		var notification *modelService.NotificationData
+		if productPricing.OnPromo && wishlist.NotifiedPromo == 0 {
+			notification = &modelService.NotificationData{Title: "Produk Wishlist Sedang Promo", Body: wishlist.Product.ProductName + " sekarang Rp " + formatPoint(productPricing.Price)}
+		} else if productPricing.Price < wishlist.NotifiedPrice {
+			notification = &modelService.NotificationData{Title: "Harga Produk Wishlist Turun", Body: wishlist.Product.ProductName + " sekarang Rp " + formatPoint(productPricing.Price)}
+		}
+
+		wishlistEntity := &entity.Wishlist{}
+		wishlistEntity.NotifiedPrice = productPricing.Price
+		wishlistEntity.NotifiedPromo = notifiedPromo
+		_, err := service.WishlistRepositoryInterface.UpdateWishlistNotification(service.DB, wishlist.Id, *wishlistEntity)
+		if err != nil {
+			service.Logger.Error(err)
+			continue
+		}
+
+		if notification != nil && wishlist.User.TokenDevice != "" {
+			go utilities.SendPushNotification(wishlist.User.TokenDevice, notification)
+		}
+	}
+}
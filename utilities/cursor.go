@@ -0,0 +1,29 @@
+package utilities
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Cursor halaman berisi sort dan offset yang di-encode base64 url
+func EncodeCursor(sort string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(sort + "|" + strconv.Itoa(offset)))
+}
+
+func DecodeCursor(cursor string) (sort string, offset int, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, err
+	}
+	parts := strings.SplitN(string(decoded), "|", 2)
+	if len(parts) != 2 {
+		return "", 0, errors.New("invalid cursor")
+	}
+	offset, err = strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return "", 0, errors.New("invalid cursor")
+	}
+	return parts[0], offset, nil
+}
@@ -0,0 +1,48 @@
+package utilities
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		sort   string
+		offset int
+	}{
+		{"", 0},
+		{"price_asc", 20},
+		{"bestselling", 100000},
+	}
+	for _, test := range tests {
+		sort, offset, err := DecodeCursor(EncodeCursor(test.sort, test.offset))
+		if err != nil || sort != test.sort || offset != test.offset {
+			t.Errorf("round trip (%q, %d) = (%q, %d, %v)", test.sort, test.offset, sort, offset, err)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsMalformedInput(t *testing.T) {
+	encode := func(text string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(text))
+	}
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("price_asc|2"))},
+		{"without separator", encode("price_asc")},
+		{"offset not a number", encode("price_asc|abc")},
+		{"negative offset", encode("price_asc|-20")},
+		{"empty offset", encode("price_asc|")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := DecodeCursor(test.cursor); err == nil {
+				t.Errorf("DecodeCursor(%q) accepted malformed cursor", test.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package utilities
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 9
+	pdfLineHeight   = 12
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// Membuat dokumen pdf A4 sederhana berisi baris teks dengan font monospace,
+// cukup untuk laporan berbentuk tabel tanpa dependency tambahan
+func GenerateTextPdf(title string, lines []string) []byte {
+	var pages [][]string
+	allLines := append([]string{title, ""}, lines...)
+	for start := 0; start < len(allLines); start += pdfLinesPerPage {
+		end := start + pdfLinesPerPage
+		if end > len(allLines) {
+			end = len(allLines)
+		}
+		pages = append(pages, allLines[start:end])
+	}
+
+	var objects []string
+	// 1: catalog, 2: pages, 3: font, selanjutnya pasangan page dan content
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+i*2))
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+
+	for i, pageLines := range pages {
+		var content bytes.Buffer
+		content.WriteString(fmt.Sprintf("BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin))
+		for _, line := range pageLines {
+			content.WriteString("(" + escapePdfText(line) + ") Tj T*\n")
+		}
+		content.WriteString("ET")
+
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 5+i*2))
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		pdf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, object))
+	}
+
+	xref := pdf.Len()
+	pdf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, offset := range offsets {
+		pdf.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	pdf.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref))
+
+	return pdf.Bytes()
+}
+
+func escapePdfText(text string) string {
+	var output strings.Builder
+	for _, char := range text {
+		switch {
+		case char == '\\' || char == '(' || char == ')':
+			output.WriteRune('\\')
+			output.WriteRune(char)
+		case char < 32 || char > 126:
+			// font standar pdf hanya aman untuk karakter ascii
+			output.WriteRune('?')
+		default:
+			output.WriteRune(char)
+		}
+	}
+	return output.String()
+}
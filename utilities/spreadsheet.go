@@ -0,0 +1,287 @@
+package utilities
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Batas ukuran isi file di dalam xlsx agar file zip kecil tidak bisa memakan memori besar
+const xlsxMaxPartSize = 50 * 1024 * 1024
+
+var ErrUnsupportedSpreadsheet = errors.New("spreadsheet must be a csv or xlsx file")
+
+// Membaca baris dari file csv atau xlsx (sheet pertama) sesuai ekstensi nama file.
+// Setiap baris hasil sesuai nomor baris di file, baris kosong di xlsx tetap ada sebagai baris kosong
+func ReadSpreadsheet(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return readCsv(data)
+	case ".xlsx":
+		return readXlsx(data)
+	}
+	return nil, ErrUnsupportedSpreadsheet
+}
+
+// Excel dengan regional Indonesia menyimpan csv dengan pemisah titik koma
+func readCsv(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	firstLine := data
+	if index := bytes.IndexByte(data, '\n'); index >= 0 {
+		firstLine = data[:index]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	return reader.ReadAll()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Id string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxStringItem struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxStringItem `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref          string         `xml:"r,attr"`
+			Type         string         `xml:"t,attr"`
+			Value        string         `xml:"v"`
+			InlineString xlsxStringItem `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func (stringItem xlsxStringItem) String() string {
+	if len(stringItem.Runs) == 0 {
+		return stringItem.Text
+	}
+	var text strings.Builder
+	for _, run := range stringItem.Runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+func readXlsx(data []byte) ([][]string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrUnsupportedSpreadsheet
+	}
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXlsxPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, ErrUnsupportedSpreadsheet
+	}
+	var relationships xlsxRelationships
+	if err := decodeXlsxPart(files, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+	sheetPath := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.Id == workbook.Sheets[0].Id {
+			sheetPath = relationship.Target
+		}
+	}
+	if sheetPath == "" {
+		return nil, ErrUnsupportedSpreadsheet
+	}
+	if strings.HasPrefix(sheetPath, "/") {
+		sheetPath = strings.TrimPrefix(sheetPath, "/")
+	} else {
+		sheetPath = path.Join("xl", sheetPath)
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXlsxPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	var worksheet xlsxWorksheet
+	if err := decodeXlsxPart(files, sheetPath, &worksheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, sheetRow := range worksheet.Rows {
+		rowNumber := sheetRow.Number
+		if rowNumber <= len(rows) {
+			rowNumber = len(rows) + 1
+		}
+		for len(rows) < rowNumber-1 {
+			rows = append(rows, []string{})
+		}
+
+		var row []string
+		for _, cell := range sheetRow.Cells {
+			column := xlsxColumnIndex(cell.Ref)
+			if column < len(row) {
+				column = len(row)
+			}
+			for len(row) < column {
+				row = append(row, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, ErrUnsupportedSpreadsheet
+				}
+				value = sharedStrings.Items[index].String()
+			case "inlineStr":
+				value = cell.InlineString.String()
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decodeXlsxPart(files map[string]*zip.File, name string, target interface{}) error {
+	file, ok := files[name]
+	if !ok {
+		return ErrUnsupportedSpreadsheet
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	limitReader := &io.LimitedReader{R: reader, N: xlsxMaxPartSize + 1}
+	if err := xml.NewDecoder(limitReader).Decode(target); err != nil {
+		return ErrUnsupportedSpreadsheet
+	}
+	if limitReader.N <= 0 {
+		return errors.New("spreadsheet is too large")
+	}
+	return nil
+}
+
+// Kolom dari referensi sel, contoh AB12 menjadi 27. Sel tanpa referensi mengikuti sel sebelumnya
+func xlsxColumnIndex(ref string) int {
+	column := 0
+	for _, char := range ref {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		column = column*26 + int(char-'A'+1)
+	}
+	return column - 1
+}
+
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func GenerateCsv(rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Membuat file xlsx satu sheet, semua sel ditulis sebagai teks agar SKU seperti 0012 tidak berubah
+func GenerateXlsx(sheetName string, rows [][]string) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for rowIndex, row := range rows {
+		rowNumber := strconv.Itoa(rowIndex + 1)
+		sheet.WriteString(`<row r="` + rowNumber + `">`)
+		for columnIndex, value := range row {
+			sheet.WriteString(`<c r="` + xlsxColumnName(columnIndex) + rowNumber + `" t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(&sheet, []byte(value))
+			sheet.WriteString(`</t></is></c>`)
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var escapedSheetName bytes.Buffer
+	xml.EscapeText(&escapedSheetName, []byte(sheetName))
+
+	parts := []struct {
+		Name    string
+		Content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + escapedSheetName.String() + `" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for _, part := range parts {
+		writer, err := zipWriter.Create(part.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(part.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}